@@ -19,6 +19,12 @@ const (
 	StorageProviderMinio  StorageProvider = "minio"
 	StorageProviderCeph   StorageProvider = "ceph"
 	StorageProviderOracle StorageProvider = "oracle"
+
+	// BackupConditionStorageVerified reflects the result of the storage checks done before the backup is started
+	BackupConditionStorageVerified = "StorageVerified"
+
+	// BackupStateFailed is the state of a failed backup. The backup states are the ones reported by Icarus
+	BackupStateFailed = "FAILED"
 )
 
 type CassandraBackupSpec struct {
@@ -100,6 +106,16 @@ type CassandraBackupStatus struct {
 	Errors []BackupError `json:"errors,omitempty"`
 	// A value from 0 to 100 indicating the progress of the backup as a percentage
 	Progress int `json:"progress,omitempty"`
+	// Conditions of the backup. The StorageVerified condition shows if the storage location is accessible and writable
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// The pod which Icarus sidecar coordinates the backup
+	Coordinator string `json:"coordinator,omitempty"`
+	// ID of the Icarus backup operation. Used to track the backup on all nodes if the coordinator is lost
+	OperationID string `json:"operationID,omitempty"`
+	// Time the backup was started. Set once the backup is completed
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Time the backup was completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 type BackupError struct {
@@ -174,7 +190,7 @@ func ValidateStorageSecret(logger *zap.SugaredLogger, secret *v1.Secret, storage
 		}
 
 		if len(secret.Data["awsaccesskeyid"]) == 0 {
-			logger.Info(fmt.Sprintf("'awssecretaccesskey' key for secret %s is not set, "+
+			logger.Info(fmt.Sprintf("'awsaccesskeyid' key for secret %s is not set, "+
 				"will try to use AWS compatible env vars to obtain credentials", secret.Name))
 		}
 
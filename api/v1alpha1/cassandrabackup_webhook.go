@@ -17,14 +17,20 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/ibm/cassandra-operator/controllers/util"
 
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -36,13 +42,24 @@ func (cb *CassandraBackup) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		Complete()
 }
 
+var (
+	// bucket naming rules shared by S3 and S3 compatible storages, GCP and Azure containers
+	bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.\-_]{1,61}[a-z0-9]$`)
+	cqlNameRegexp    = regexp.MustCompile(`^[a-zA-Z0-9_]{1,48}$`)
+)
+
 var _ webhook.Validator = &CassandraBackup{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (cb *CassandraBackup) ValidateCreate() error {
 	webhookLogger.Debugf("Validating webhook has been called on create request for backup: %s", cb.Name)
 
-	return kerrors.NewAggregate(validateBackupCreateUpdate(cb))
+	verrors := validateBackupCreateUpdate(cb)
+	verrors = append(verrors, validateClusterReference(cb.Namespace, cb.Spec.CassandraCluster, splitDCs(cb.Spec.DC))...)
+	verrors = append(verrors, validateSecretReference(cb.Namespace, cb.Spec.SecretName)...)
+	verrors = append(verrors, validateMedusaBackup(cb)...)
+
+	return kerrors.NewAggregate(verrors)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -54,7 +71,14 @@ func (cb *CassandraBackup) ValidateUpdate(old runtime.Object) error {
 		return fmt.Errorf("old casandra cluster object: (%s) is not of type CassandraBackup", cbOld.Name)
 	}
 
-	return kerrors.NewAggregate(validateBackupCreateUpdate(cb))
+	verrors := validateBackupCreateUpdate(cb)
+	// a failed backup is retried with the changed spec
+	if operationStarted(cbOld.Status.State, BackupStateFailed) && !cmp.Equal(cbOld.Spec, cb.Spec) {
+		verrors = append(verrors, fmt.Errorf(".spec can't be changed once the backup has started (state %s). "+
+			"Recreate the CassandraBackup to start a new backup", cbOld.Status.State))
+	}
+
+	return kerrors.NewAggregate(verrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -64,6 +88,14 @@ func (cb *CassandraBackup) ValidateDelete() error {
 }
 
 func validateBackupCreateUpdate(cb *CassandraBackup) (verrors []error) {
+	if len(cb.Spec.CassandraCluster) == 0 {
+		verrors = append(verrors, errors.New(".spec.cassandraCluster should be set"))
+	}
+
+	if len(cb.Spec.SecretName) == 0 {
+		verrors = append(verrors, errors.New(".spec.secretName should be set"))
+	}
+
 	if err := validateStorageLocation(cb.Spec.StorageLocation); err != nil {
 		verrors = append(verrors, err)
 	}
@@ -72,6 +104,56 @@ func validateBackupCreateUpdate(cb *CassandraBackup) (verrors []error) {
 		verrors = append(verrors, err)
 	}
 
+	if err := validateBandwidth(cb.Spec.Bandwidth); err != nil {
+		verrors = append(verrors, err)
+	}
+
+	if _, err := parseEntities(cb.Spec.Entities); err != nil {
+		verrors = append(verrors, fmt.Errorf(".spec.entities is invalid: %s", err.Error()))
+	}
+
+	return verrors
+}
+
+// validateMedusaBackup rejects the options Medusa doesn't support if the cluster uses the Medusa backup engine.
+// Medusa backs up all keyspaces of all DCs to the storage location configured for the cluster.
+// Skipped if the webhook client is not set or if the cluster doesn't exist.
+func validateMedusaBackup(cb *CassandraBackup) (verrors []error) {
+	if webhookClient == nil || len(cb.Spec.CassandraCluster) == 0 {
+		return nil
+	}
+
+	cc := &CassandraCluster{}
+	if err := webhookClient.Get(context.Background(), types.NamespacedName{Name: cb.Spec.CassandraCluster, Namespace: cb.Namespace}, cc); err != nil {
+		// reported by validateClusterReference
+		return nil
+	}
+
+	if cc.Spec.BackupEngine != BackupEngineMedusa || cc.Spec.Medusa == nil {
+		return nil
+	}
+
+	if cb.Spec.StorageLocation != cc.Spec.Medusa.StorageLocation {
+		verrors = append(verrors, fmt.Errorf(".spec.storageLocation should be %q: Medusa uploads the backups of CassandraCluster %s/%s to .spec.medusa.storageLocation",
+			cc.Spec.Medusa.StorageLocation, cc.Namespace, cc.Name))
+	}
+
+	unsupported := []struct {
+		field string
+		set   bool
+	}{
+		{field: ".spec.entities", set: len(cb.Spec.Entities) != 0},
+		{field: ".spec.dc", set: len(cb.Spec.DC) != 0},
+		{field: ".spec.bandwidth", set: cb.Spec.Bandwidth != nil},
+		{field: ".spec.duration", set: len(cb.Spec.Duration) != 0},
+		{field: ".spec.retry", set: cb.Spec.Retry != Retry{}},
+	}
+	for _, option := range unsupported {
+		if option.set {
+			verrors = append(verrors, fmt.Errorf("%s is not supported: CassandraCluster %s/%s uses the %q backup engine", option.field, cc.Namespace, cc.Name, BackupEngineMedusa))
+		}
+	}
+
 	return verrors
 }
 
@@ -83,14 +165,14 @@ func validateDuration(durationStr string) error {
 	allowedDurationUnits := []string{"days", "hours", "microseconds", "milliseconds", "minutes", "nanoseconds", "seconds"}
 	duration := strings.Split(durationStr, " ")
 	validationErr := fmt.Errorf(
-		"duration should be in format \"amount unit\", where amount is an integer value and unit is one of the following values: %v",
+		"duration should be in format \"amount unit\", where amount is a positive integer value and unit is one of the following values: %v",
 		allowedDurationUnits,
 	)
 	if len(duration) != 2 {
 		return validationErr
 	}
 
-	if _, err := strconv.ParseInt(duration[0], 10, 64); err != nil {
+	if amount, err := strconv.ParseInt(duration[0], 10, 64); err != nil || amount <= 0 {
 		return validationErr
 	}
 
@@ -101,6 +183,23 @@ func validateDuration(durationStr string) error {
 	return nil
 }
 
+func validateBandwidth(bandwidth *DataRate) error {
+	if bandwidth == nil {
+		return nil
+	}
+
+	allowedUnits := []string{"BPS", "KBPS", "MBPS", "GBPS"}
+	if !util.Contains(allowedUnits, bandwidth.Unit) {
+		return fmt.Errorf(".spec.bandwidth.unit %q is invalid. Should be one of the following: %v", bandwidth.Unit, allowedUnits)
+	}
+
+	if bandwidth.Value < 1 {
+		return errors.New(".spec.bandwidth.value should be greater than 0")
+	}
+
+	return nil
+}
+
 func validateStorageLocation(location string) error {
 	index := 0
 	if index = strings.Index(location, "://"); index < 0 {
@@ -120,5 +219,124 @@ func validateStorageLocation(location string) error {
 		return fmt.Errorf("protocol %s is not supported. Should be one of the following: %v", requestedProtocol, supportedProtocols)
 	}
 
+	bucket := strings.SplitN(location[index+3:], "/", 2)[0]
+	return ValidateBucketName(StorageProvider(requestedProtocol), bucket)
+}
+
+// ValidateBucketName checks the bucket name against the naming rules of the storage provider
+func ValidateBucketName(provider StorageProvider, bucket string) error {
+	if !bucketNameRegexp.MatchString(bucket) {
+		return fmt.Errorf("bucket name %q is invalid for storage provider %s. "+
+			"It should be 3-63 characters long and contain only lowercase letters, numbers, dots, hyphens and underscores", bucket, provider)
+	}
+
+	if provider == StorageProviderAzure && strings.ContainsAny(bucket, "._") {
+		return fmt.Errorf("container name %q is invalid for storage provider %s. "+
+			"It should contain only lowercase letters, numbers and hyphens", bucket, provider)
+	}
+
+	return nil
+}
+
+// parseEntities parses the entities in the Icarus format: either keyspaces 'ks1,ks2' or tables 'ks1.t1,ks2.t2'.
+// Returns the parsed entities, each split into keyspace and optional table.
+func parseEntities(entities string) ([][]string, error) {
+	if len(strings.TrimSpace(entities)) == 0 {
+		return nil, nil
+	}
+
+	var parsed [][]string
+	tables := false
+	for i, entity := range strings.Split(entities, ",") {
+		entity = strings.TrimSpace(entity)
+		names := strings.Split(entity, ".")
+		if len(names) > 2 {
+			return nil, fmt.Errorf("%q should be in format 'keyspace' or 'keyspace.table'", entity)
+		}
+
+		for _, name := range names {
+			if !cqlNameRegexp.MatchString(name) {
+				return nil, fmt.Errorf("%q is not a valid keyspace or table name", name)
+			}
+		}
+
+		if i == 0 {
+			tables = len(names) == 2
+		} else if tables != (len(names) == 2) {
+			return nil, errors.New("keyspaces and tables can't be used together. Use either 'ks1,ks2' or 'ks1.t1,ks2.t2'")
+		}
+
+		parsed = append(parsed, names)
+	}
+
+	return parsed, nil
+}
+
+// operationStarted returns true if the backup or restore has been started and not failed
+func operationStarted(state, failedState string) bool {
+	return len(state) != 0 && state != failedState
+}
+
+func splitDCs(dcs string) []string {
+	var list []string
+	for _, dc := range strings.Split(dcs, ",") {
+		if dc = strings.TrimSpace(dc); len(dc) != 0 {
+			list = append(list, dc)
+		}
+	}
+
+	return list
+}
+
+// validateClusterReference checks that the referenced cluster exists and has the given DCs.
+// Skipped if the webhook client is not set.
+func validateClusterReference(namespace, clusterName string, dcs []string) (verrors []error) {
+	if webhookClient == nil || len(clusterName) == 0 {
+		return nil
+	}
+
+	cc := &CassandraCluster{}
+	err := webhookClient.Get(context.Background(), types.NamespacedName{Name: clusterName, Namespace: namespace}, cc)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return []error{fmt.Errorf("CassandraCluster %s/%s not found", namespace, clusterName)}
+		}
+
+		webhookLogger.Warnf("Can't get CassandraCluster %s/%s, skipping the DC validation: %s", namespace, clusterName, err.Error())
+		return nil
+	}
+
+	for _, dcName := range dcs {
+		found := false
+		for _, dc := range cc.Spec.DCs {
+			if dc.Name == dcName {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			verrors = append(verrors, fmt.Errorf("DC %q not found in CassandraCluster %s/%s", dcName, namespace, clusterName))
+		}
+	}
+
+	return verrors
+}
+
+// validateSecretReference checks that the storage credentials secret exists. Skipped if the webhook client is not set.
+func validateSecretReference(namespace, secretName string) []error {
+	if webhookClient == nil || len(secretName) == 0 {
+		return nil
+	}
+
+	err := webhookClient.Get(context.Background(), types.NamespacedName{Name: secretName, Namespace: namespace}, &v1.Secret{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return []error{fmt.Errorf("storage credentials secret %s/%s not found", namespace, secretName)}
+		}
+
+		webhookLogger.Warnf("Can't get secret %s/%s, skipping the validation: %s", namespace, secretName, err.Error())
+	}
+
 	return nil
 }
@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CatalogConditionSynced shows if the last scan of the storage location succeeded
+	CatalogConditionSynced = "Synced"
+)
+
+type CassandraBackupCatalogSpec struct {
+	// example: s3://myBucket/backups
+	// location where the backups are stored. Backups of all clusters stored in the location are discovered.
+	// A value of the storageLocation property has to have exact format which is 'protocol://bucket-name/path'
+	// protocol is either 's3', 'minio', 'ceph' or 'oracle'.
+	// +kubebuilder:validation:MinLength:=1
+	StorageLocation string `json:"storageLocation"`
+	// Name of the secret from which credentials used for the communication to cloud storage providers are read.
+	// +kubebuilder:validation:MinLength:=1
+	SecretName string `json:"secretName"`
+	// Relevant for S3-like buckets only. If true, communication is done via HTTP instead of HTTPS. Defaults to false.
+	Insecure bool `json:"insecure,omitempty"`
+	// How often the storage location is scanned for backups. Defaults to 600 seconds
+	// +kubebuilder:validation:Minimum=60
+	RefreshIntervalSeconds int64 `json:"refreshIntervalSeconds,omitempty"`
+}
+
+type CassandraBackupCatalogStatus struct {
+	// Backups found in the storage location
+	Backups []CatalogBackup `json:"backups,omitempty"`
+	// Time of the last successful scan of the storage location
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Conditions of the catalog. The Synced condition shows if the last scan of the storage location succeeded
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type CatalogBackup struct {
+	// Name of the backed up cluster
+	Cluster string `json:"cluster"`
+	// Snapshot tag of the backup. Used as .spec.snapshotTag in a CassandraRestore
+	SnapshotTag string `json:"snapshotTag"`
+	// Schema version of the backup. Used as .spec.schemaVersion in a CassandraRestore
+	SchemaVersion string `json:"schemaVersion"`
+	// Backed up DCs with the number of nodes in each of them
+	DCs []CatalogDC `json:"dcs,omitempty"`
+	// Total number of backed up nodes
+	Nodes int32 `json:"nodes"`
+	// Size of the backed up SSTables in bytes as recorded in the backup manifests
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+	// Time the backup was taken, based on the most recent manifest of the backup
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+type CatalogDC struct {
+	Name  string `json:"name"`
+	Nodes int32  `json:"nodes"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// CassandraBackupCatalog is the Schema for the CassandraBackupCatalogs API
+type CassandraBackupCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CassandraBackupCatalogSpec   `json:"spec"`
+	Status CassandraBackupCatalogStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CassandraBackupCatalogList contains a list of CassandraBackupCatalog
+type CassandraBackupCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CassandraBackupCatalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CassandraBackupCatalog{}, &CassandraBackupCatalogList{})
+}
+
+func (in *CassandraBackupCatalog) StorageProvider() StorageProvider {
+	return storageProvider(in.Spec.StorageLocation)
+}
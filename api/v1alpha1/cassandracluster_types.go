@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/gogo/protobuf/proto"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -27,6 +28,7 @@ const (
 	CassandraClusterDC        = "cassandra-cluster-dc"
 	CassandraClusterChecksum  = "cassandra-cluster-checksum"
 	CassandraClusterSeed      = "cassandra-cluster-seed"
+	CassandraReaperInstance   = "cassandra-reaper-instance"
 
 	CassandraClusterComponentProber    = "prober"
 	CassandraClusterComponentReaper    = "reaper"
@@ -61,6 +63,7 @@ const (
 	ThriftPort      = 9160
 	InstaclustrPort = 9500
 	IcarusPort      = 4567
+	MedusaPort      = 50051
 
 	ReaperReplicasNumber     = 1
 	reaperRepairIntensityMin = 0.1
@@ -68,12 +71,47 @@ const (
 
 	InternodeEncryptionNone = "none"
 
+	// the TLS secrets are mounted at the paths set in the generated cassandra.yaml
+	CassandraServerTLSDir = "/etc/cassandra-server-tls"
+	CassandraClientTLSDir = "/etc/cassandra-client-tls"
+	// the cassandra container replays the commitlogs downloaded into that directory on start
+	CommitLogRestoreDir = "/var/lib/cassandra/commitlog-restore"
+
+	BackupEngineIcarus = "icarus"
+	BackupEngineMedusa = "medusa"
+
+	RepairEngineReaper  = "reaper"
+	RepairEngineBuiltin = "builtin"
+
+	RestoreFromStateRestoring = "Restoring"
+	RestoreFromStateCompleted = "Completed"
+
+	RepairCoverageStateRepaired      = "Repaired"
+	RepairCoverageStateWarning       = "Warning"
+	RepairCoverageStateOverdue       = "Overdue"
+	RepairCoverageStateNeverRepaired = "NeverRepaired"
+
+	IncrementalRepairMigrationFullRepair      = "FullRepair"
+	IncrementalRepairMigrationMarkingRepaired = "MarkingRepaired"
+	IncrementalRepairMigrationCompleted       = "Completed"
+
 	HMS       = "15:04:05"
 	ISOFormat = "2006-01-02T" + HMS // YYYY-MM-DDThh:mm:ss format (reaper API dates do not include timezone)
 )
 
 var CassandraOperatorPodLabels = map[string]string{CassandraOperatorInstance: CassandraOperatorInstanceName}
 
+var DefaultCipherSuites = []string{
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
+	"TLS_ECDH_RSA_WITH_AES_128_CBC_SHA",
+	"TLS_ECDH_ECDSA_WITH_AES_256_CBC_SHA",
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+	"TLS_RSA_WITH_AES_128_CBC_SHA",
+	"TLS_RSA_WITH_AES_256_CBC_SHA",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA",
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
+}
+
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 // Run "make" to regenerate code after modifying this file.
 
@@ -94,9 +132,27 @@ type CassandraClusterSpec struct {
 	Ingress              Ingress         `json:"ingress,omitempty"`
 	ExternalRegions      ExternalRegions `json:"externalRegions,omitempty"`
 	Icarus               Icarus          `json:"icarus,omitempty"`
-	Prober               Prober          `json:"prober,omitempty"`
-	Reaper               *Reaper         `json:"reaper,omitempty"`
-	HostPort             HostPort        `json:"hostPort,omitempty"`
+	// The sidecar used by CassandraBackup and CassandraRestore. Defaults to `icarus`.
+	// Medusa restores whole DCs in place, point-in-time recovery and bootstrapping from a backup require Icarus
+	// +kubebuilder:validation:Enum:=icarus;medusa
+	BackupEngine string `json:"backupEngine,omitempty"`
+	// (Optional) Configuration of the Medusa sidecar. Used if backupEngine is `medusa`
+	// +optional
+	Medusa *Medusa `json:"medusa,omitempty"`
+	Prober Prober  `json:"prober,omitempty"`
+	Reaper *Reaper `json:"reaper,omitempty"`
+	// (Optional) Name of a CassandraReaper in the same namespace that repairs the cluster. If set, no Reaper is deployed
+	// for the cluster, it is added to the shared Reaper instead. The repair schedules are still taken from `reaper.repairSchedules`
+	// +optional
+	ReaperRef string `json:"reaperRef,omitempty"`
+	// The engine that runs the repairs from `reaper.repairSchedules`. Defaults to `reaper`.
+	// `builtin` doesn't deploy Reaper, the operator repairs token subranges of the nodes through JMX
+	// +kubebuilder:validation:Enum:=reaper;builtin
+	RepairEngine string `json:"repairEngine,omitempty"`
+	// (Optional) Configuration of the builtin repair engine. Used if repairEngine is `builtin`
+	// +optional
+	BuiltinRepair BuiltinRepair `json:"builtinRepair,omitempty"`
+	HostPort      HostPort      `json:"hostPort,omitempty"`
 	// Authentication is always enabled and by default is set to `internal`. Available options: `internal`, `local_files`.
 	// +kubebuilder:validation:Enum:=local_files;internal
 	JMXAuth    string     `json:"jmxAuth,omitempty"`
@@ -105,6 +161,34 @@ type CassandraClusterSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Policies for C* cluster"
 	// +optional
 	NetworkPolicies NetworkPolicies `json:"networkPolicies,omitempty"`
+	// (Optional) Bootstrap the cluster from a backup. Can be set only on cluster creation.
+	// SSTables and schema are downloaded into each node before Cassandra starts
+	// +optional
+	RestoreFrom *RestoreFrom `json:"restoreFrom,omitempty"`
+	// (Optional) Take a local snapshot on every node before running CQL ConfigMap scripts, changing keyspaces replication,
+	// decommissioning DCs and upgrading Cassandra. The operator waits for the snapshot to be taken before proceeding
+	// +optional
+	SafetySnapshots *SafetySnapshots `json:"safetySnapshots,omitempty"`
+}
+
+const (
+	SafetySnapshotFailurePolicyFail   = "Fail"
+	SafetySnapshotFailurePolicyIgnore = "Ignore"
+)
+
+// SafetySnapshots configures the snapshots taken before risky operations.
+// A CassandraSnapshot is created for each operation, its name is used as the snapshot tag
+type SafetySnapshots struct {
+	// Number of seconds the snapshots are kept on the nodes. Defaults to 86400 (1 day)
+	// +kubebuilder:validation:Minimum=60
+	RetentionSeconds int64 `json:"retentionSeconds,omitempty"`
+	// Number of seconds to wait for a snapshot to be taken, e.g. for all nodes to become ready. Defaults to 1800 (30 minutes)
+	// +kubebuilder:validation:Minimum=60
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
+	// What to do if a snapshot fails. `Fail` (default) holds the operation back until the failed CassandraSnapshot is deleted,
+	// which makes the operator take the snapshot again. `Ignore` proceeds with the operation without the snapshot
+	// +kubebuilder:validation:Enum:=Fail;Ignore
+	FailurePolicy string `json:"failurePolicy,omitempty"`
 }
 
 type ExternalRegions struct {
@@ -151,6 +235,10 @@ type Reaper struct {
 	SegmentCountPerNode int32 `json:"segmentCountPerNode,omitempty"`
 	// +kubebuilder:validation:Minimum=1
 	MaxParallelRepairs int32 `json:"maxParallelRepairs,omitempty"`
+	// A warning is raised when the time since the last complete repair of a table exceeds this percentage of its gc_grace_seconds
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	GCGraceWarningPercent int32 `json:"gcGraceWarningPercent,omitempty"`
 }
 
 type HostPort struct {
@@ -226,6 +314,120 @@ type NodeTLSSecret struct {
 	GenerateKeystorePassword string `json:"generateKeystorePassword,omitempty"`
 }
 
+// SetDefaults sets the defaults of the server encryption options that aren't set
+func (in *ServerEncryption) SetDefaults() {
+	if in.InternodeEncryption == "" {
+		in.InternodeEncryption = InternodeEncryptionNone
+	}
+
+	if in.RequireClientAuth == nil {
+		in.RequireClientAuth = proto.Bool(true)
+	}
+
+	if in.Protocol == "" {
+		in.Protocol = "TLS"
+	}
+
+	if in.Algorithm == "" {
+		in.Algorithm = "SunX509"
+	}
+
+	if in.StoreType == "" {
+		in.StoreType = "PKCS12"
+	}
+
+	if len(in.CipherSuites) == 0 {
+		in.CipherSuites = DefaultCipherSuites
+	}
+
+	in.CATLSSecret.SetDefaults()
+	in.NodeTLSSecret.SetDefaults()
+}
+
+// SetDefaults sets the defaults of the client encryption options that aren't set
+func (in *ClientEncryption) SetDefaults() {
+	if in.RequireClientAuth == nil {
+		in.RequireClientAuth = proto.Bool(true)
+	}
+
+	if in.Protocol == "" {
+		in.Protocol = "TLS"
+	}
+
+	if in.Algorithm == "" {
+		in.Algorithm = "SunX509"
+	}
+
+	if in.StoreType == "" {
+		in.StoreType = "PKCS12"
+	}
+
+	if len(in.CipherSuites) == 0 {
+		in.CipherSuites = DefaultCipherSuites
+	}
+
+	in.CATLSSecret.SetDefaults()
+	in.NodeTLSSecret.SetDefaults()
+}
+
+// SetDefaults sets the default secret keys of the CA
+func (in *CATLSSecret) SetDefaults() {
+	if in.FileKey == "" {
+		in.FileKey = "ca.key"
+	}
+
+	if in.CrtFileKey == "" {
+		in.CrtFileKey = "ca.crt"
+	}
+}
+
+// SetDefaults sets the default secret keys of the node certificates and keystores
+func (in *NodeTLSSecret) SetDefaults() {
+	if in.FileKey == "" {
+		in.FileKey = "tls.key"
+	}
+
+	if in.CrtFileKey == "" {
+		in.CrtFileKey = "tls.crt"
+	}
+
+	if in.CACrtFileKey == "" {
+		in.CACrtFileKey = "ca.crt"
+	}
+
+	if in.KeystoreFileKey == "" {
+		in.KeystoreFileKey = "keystore.p12"
+	}
+
+	if in.KeystorePasswordKey == "" {
+		in.KeystorePasswordKey = "keystore.password"
+	}
+
+	if in.TruststoreFileKey == "" {
+		in.TruststoreFileKey = "truststore.p12"
+	}
+
+	if in.TruststorePasswordKey == "" {
+		in.TruststorePasswordKey = "truststore.password"
+	}
+
+	if in.GenerateKeystorePassword == "" {
+		in.GenerateKeystorePassword = "cassandra"
+	}
+}
+
+type BuiltinRepair struct {
+	// Number of token subranges repaired at the same time in the cluster
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentSegments int32 `json:"maxConcurrentSegments,omitempty"`
+	// Number of token subranges the token ranges of each node are split into
+	// +kubebuilder:validation:Minimum=1
+	SegmentCountPerNode int32 `json:"segmentCountPerNode,omitempty"`
+	// Time after which the repair of a token subrange is considered failed and retried
+	// +kubebuilder:validation:Minimum=1
+	SegmentTimeoutMins int32 `json:"segmentTimeoutMins,omitempty"`
+}
+
 type RepairSchedules struct {
 	Enabled bool             `json:"enabled,omitempty"`
 	Repairs []RepairSchedule `json:"repairs,omitempty"`
@@ -247,6 +449,26 @@ type RepairSchedule struct {
 	// +kubebuilder:validation:Minimum:=1
 	// +kubebuilder:validation:Maximum:=4
 	RepairThreadCount int32 `json:"repairThreadCount,omitempty" url:"repairThreadCount,omitempty"`
+	// Cron expression in UTC the repair runs are started on, e.g. `0 1 * * SAT`. Can't be used together with
+	// scheduleDaysBetween and scheduleTriggerTime. The runs must be a whole number of days apart as Reaper repeats schedules in days.
+	Cron string `json:"cron,omitempty" url:"-"`
+	// The repair schedule is paused outside of the allowed windows. Not restricted if empty.
+	AllowedWindows []RepairWindow `json:"allowedWindows,omitempty" url:"-"`
+	// The repair schedule is paused during the blackout windows
+	BlackoutWindows []RepairWindow `json:"blackoutWindows,omitempty" url:"-"`
+}
+
+// RepairWindow is a time window in UTC that repeats on the given days of the week
+type RepairWindow struct {
+	// Days of the week the window starts on: MON, TUE, WED, THU, FRI, SAT or SUN. Every day if empty.
+	Days []string `json:"days,omitempty"`
+	// Start time in HH:MM format
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// End time in HH:MM format. The window ends on the next day if the end is before the start
+	// and lasts the whole day if the end equals the start.
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
 }
 
 type AutoScheduling struct {
@@ -287,6 +509,33 @@ type Cassandra struct {
 	Sysctls                       map[string]string `json:"sysctls,omitempty"`
 	Monitoring                    Monitoring        `json:"monitoring,omitempty"`
 	ConfigOverrides               string            `json:"configOverrides,omitempty"`
+	// Ships sealed commitlog segments to a storage location. Required to restore a cluster to a point in time
+	CommitLogArchiving *CommitLogArchiving `json:"commitLogArchiving,omitempty"`
+}
+
+// CommitLogArchiving configures the shipping of sealed commitlog segments.
+// The segments of a node are uploaded to '<storageLocation>/<cluster name>/<dc name>/<pod name>'
+type CommitLogArchiving struct {
+	// example: gcp://myBucket
+	// location the commitlog segments are shipped to. Usually the same location the backups are uploaded to.
+	// A value of the storageLocation property has to have exact format which is 'protocol://bucket-name
+	// protocol is either 'gcp', 's3', 'azure', 'minio', 'ceph' or 'oracle'.
+	// +kubebuilder:validation:MinLength:=1
+	StorageLocation string `json:"storageLocation"`
+	// Name of the secret from which credentials used for the communication to cloud storage providers are read.
+	// +kubebuilder:validation:MinLength:=1
+	SecretName string `json:"secretName"`
+	// how often the archived segments are shipped, in seconds. Defaults to 60
+	// +kubebuilder:validation:Minimum=1
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+	// number of threads used for upload, when not set, it defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	ConcurrentConnections int64 `json:"concurrentConnections,omitempty"`
+	// Relevant during upload to S3-like bucket only. If true, communication is done via HTTP instead of HTTPS. Defaults to false.
+	Insecure bool `json:"insecure,omitempty"`
+	// Do not check the existence of a bucket.
+	// Some storage providers (e.g. S3) requires a special permissions to be able to list buckets or query their existence which might not be allowed.
+	SkipBucketVerification bool `json:"skipBucketVerification,omitempty"`
 }
 
 type Persistence struct {
@@ -305,11 +554,32 @@ type Icarus struct {
 	Resources       v1.ResourceRequirements `json:"resources,omitempty"`
 }
 
-type Prober struct {
+type Medusa struct {
 	Image string `json:"image,omitempty"`
 	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
 	ImagePullPolicy v1.PullPolicy           `json:"imagePullPolicy,omitempty"`
 	Resources       v1.ResourceRequirements `json:"resources,omitempty"`
+	// The bucket the backups are uploaded to, e.g. s3://bucket-name/prefix. The cluster name is used as the prefix if not set.
+	// All providers except azure are supported
+	// +kubebuilder:validation:MinLength:=1
+	StorageLocation string `json:"storageLocation"`
+	// The secret with the storage credentials. Uses the same keys as the CassandraBackup secret
+	// +kubebuilder:validation:MinLength:=1
+	SecretName string `json:"secretName"`
+}
+
+func (in *Medusa) StorageProvider() StorageProvider {
+	return storageProvider(in.StorageLocation)
+}
+
+type Prober struct {
+	// Number of prober replicas. The state is persisted in a ConfigMap and written by the elected leader.
+	// +kubebuilder:validation:Minimum=1
+	Replicas int32  `json:"replicas,omitempty"`
+	Image    string `json:"image,omitempty"`
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	ImagePullPolicy v1.PullPolicy           `json:"imagePullPolicy,omitempty"`
+	Resources       v1.ResourceRequirements `json:"resources,omitempty"`
 	// +kubebuilder:validation:Enum:=info;debug;trace
 	LogLevel string `json:"logLevel,omitempty"`
 	// +kubebuilder:validation:Enum:=console;json
@@ -363,10 +633,135 @@ type SystemKeyspaceDC struct {
 	RF int32 `json:"rf"`
 }
 
+// RestoreFrom defines the backup a new cluster is bootstrapped from.
+// Nodes are mapped to the backed up nodes by DC name and pod ordinal using the topology file uploaded with the backup.
+type RestoreFrom struct {
+	// example: gcp://myBucket
+	// location the backup was uploaded to.
+	// A value of the storageLocation property has to have exact format which is 'protocol://bucket-name
+	// protocol is either 'gcp', 's3', 'azure', 'minio', 'ceph' or 'oracle'.
+	// +kubebuilder:validation:MinLength:=1
+	StorageLocation string `json:"storageLocation"`
+	// Name of the cluster the backup was taken from. Defaults to the name of the CassandraCluster
+	SourceCluster string `json:"sourceCluster,omitempty"`
+	// Name of the snapshot tag to restore
+	// +kubebuilder:validation:MinLength:=1
+	SnapshotTag string `json:"snapshotTag"`
+	// version of schema we want to restore from.
+	// If schema version is not specified, we expect that there will be one and only one backup taken with respective snapshot name.
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+	// Name of the secret from which credentials used for the communication to cloud storage providers are read.
+	// +kubebuilder:validation:MinLength:=1
+	SecretName string `json:"secretName"`
+	// number of threads used for download, there might be at most so many downloading threads at any given time,
+	// when not set, it defaults to 10
+	// +kubebuilder:validation:Minimum=1
+	ConcurrentConnections int64 `json:"concurrentConnections,omitempty"`
+	// Relevant during download from S3-like bucket only. If true, communication is done via HTTP instead of HTTPS. Defaults to false.
+	Insecure bool `json:"insecure,omitempty"`
+	// Do not check the existence of a bucket.
+	// Some storage providers (e.g. S3) requires a special permissions to be able to list buckets or query their existence which might not be allowed.
+	SkipBucketVerification bool `json:"skipBucketVerification,omitempty"`
+}
+
 // CassandraClusterStatus defines the observed state of CassandraCluster
 type CassandraClusterStatus struct {
 	MaintenanceState []Maintenance `json:"maintenanceState,omitempty"`
 	Ready            bool          `json:"ready,omitempty"`
+	// State of the cluster bootstrap from a backup. Set only if `restoreFrom` is used
+	RestoreFromState string `json:"restoreFromState,omitempty"`
+	// State of the repair schedules from `.spec.reaper.repairSchedules` in Reaper
+	RepairSchedules []RepairScheduleStatus `json:"repairSchedules,omitempty"`
+	// Set while the repairs are paused by the operator for a disruptive operation
+	RepairsPause *RepairsPause `json:"repairsPause,omitempty"`
+	// IDs of the repair schedules paused by the operator outside of their repair windows or while the repairs are paused.
+	// Only these schedules are resumed by the operator, the schedules paused by the user stay paused.
+	PausedRepairSchedules []string `json:"pausedRepairSchedules,omitempty"`
+	// Summary per keyspace of the last complete repair of the tables compared to their gc_grace_seconds
+	RepairCoverage []KeyspaceRepairCoverage `json:"repairCoverage,omitempty"`
+	// Migrations of the keyspaces with incremental repair schedules from full repairs
+	IncrementalRepairMigrations []IncrementalRepairMigration `json:"incrementalRepairMigrations,omitempty"`
+	// Medusa restore applied by the nodes of the restored DCs when they start. Set once the restore is prepared on the nodes
+	MedusaRestore *MedusaRestore `json:"medusaRestore,omitempty"`
+}
+
+type MedusaRestore struct {
+	// Name of the CassandraRestore
+	Restore    string   `json:"restore"`
+	RestoreKey string   `json:"restoreKey"`
+	BackupName string   `json:"backupName"`
+	DCs        []string `json:"dcs"`
+}
+
+// IncrementalRepairMigration is the migration of a keyspace to incremental repairs.
+// The incremental repair schedules of the keyspace are created once the migration is completed.
+type IncrementalRepairMigration struct {
+	Keyspace string `json:"keyspace"`
+	// FullRepair while the keyspace is fully repaired, MarkingRepaired while the SSTables of the keyspace
+	// are marked as repaired node by node, or Completed
+	Phase string `json:"phase"`
+	// ID of the full repair run
+	RepairRunID string `json:"repairRunID,omitempty"`
+	// Pods with the SSTables of the keyspace marked as repaired
+	MarkedPods []string `json:"markedPods,omitempty"`
+	// Pod being restarted to mark its SSTables as repaired
+	CurrentPod string `json:"currentPod,omitempty"`
+	// UID of the pod before the restart
+	CurrentPodUID  string       `json:"currentPodUID,omitempty"`
+	StartTime      metav1.Time  `json:"startTime"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+type KeyspaceRepairCoverage struct {
+	Keyspace string `json:"keyspace"`
+	// Number of the keyspace's tables with a non-zero gc_grace_seconds
+	Tables int32 `json:"tables"`
+	// Worst state of the tables: Overdue, Warning, NeverRepaired or Repaired. A table is in the Warning state if it gets
+	// close to its gc_grace_seconds without a repair, Overdue if it's exceeded and NeverRepaired if no complete repair
+	// of the table is found
+	State string `json:"state"`
+	// Number of tables in the Warning state
+	Warning int32 `json:"warning,omitempty"`
+	// Number of tables in the Overdue state
+	Overdue int32 `json:"overdue,omitempty"`
+	// Number of tables in the NeverRepaired state
+	NeverRepaired int32 `json:"neverRepaired,omitempty"`
+	// End time of the oldest last complete repair of the tables. Not set if none of the tables has been repaired.
+	OldestCompleteRepair *metav1.Time `json:"oldestCompleteRepair,omitempty"`
+}
+
+type RepairsPause struct {
+	// The operation in progress: scaling, rolling restart, maintenance or restore
+	Reason string      `json:"reason"`
+	Since  metav1.Time `json:"since"`
+	// IDs of the repair runs paused by the operator. They are resumed once the operation is finished.
+	RepairRunIDs []string `json:"repairRunIDs,omitempty"`
+}
+
+type RepairScheduleStatus struct {
+	Keyspace string   `json:"keyspace"`
+	Tables   []string `json:"tables,omitempty"`
+	// ID of the repair schedule in Reaper. Not set if the schedule is not created yet.
+	ID string `json:"id,omitempty"`
+	// ACTIVE or PAUSED
+	State string `json:"state,omitempty"`
+	// Time the next repair run is started
+	NextActivation *metav1.Time `json:"nextActivation,omitempty"`
+	// The latest repair run started by the schedule
+	LastRun *ScheduledRepairRun `json:"lastRun,omitempty"`
+}
+
+type ScheduledRepairRun struct {
+	// ID of the repair run in Reaper
+	ID string `json:"id"`
+	// State of the repair run as reported by Reaper
+	State     string       `json:"state,omitempty"`
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	EndTime   *metav1.Time `json:"endTime,omitempty"`
+	// Duration of the repair run as reported by Reaper
+	Duration string `json:"duration,omitempty"`
+	// Percentage of the segments that have been repaired
+	RepairedPercent int32 `json:"repairedPercent"`
 }
 
 // +kubebuilder:object:root=true
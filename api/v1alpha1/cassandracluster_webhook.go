@@ -17,27 +17,48 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/ibm/cassandra-operator/controllers/cron"
 	"github.com/ibm/cassandra-operator/controllers/util"
 
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/yaml"
 )
 
 var webhookLogger = zap.NewNop().Sugar()
 
+var imageMajorVersionRegexp = regexp.MustCompile(`^v?(\d+)\.\d+`)
+
+// webhookClient is used to validate the references to other resources. The checks are skipped if it's not set.
+var webhookClient client.Reader
+
 func SetWebhookLogger(l *zap.SugaredLogger) {
 	webhookLogger = l
 }
 
+// webhookSchemaReader returns the tables of the cluster by keyspace. Used to validate the rename targets of restores, skipped if not set.
+var webhookSchemaReader func(ctx context.Context, cc *CassandraCluster) (map[string][]string, error)
+
+func SetWebhookClient(c client.Reader) {
+	webhookClient = c
+}
+
+func SetWebhookSchemaReader(schemaReader func(ctx context.Context, cc *CassandraCluster) (map[string][]string, error)) {
+	webhookSchemaReader = schemaReader
+}
+
 func (cc *CassandraCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(cc).
@@ -94,6 +115,14 @@ func validateClusterCreateUpdate(cc *CassandraCluster, ccOld *CassandraCluster)
 		}
 	}
 
+	if err = validateRepairEngine(cc); err != nil {
+		errors = append(errors, err...)
+	}
+
+	if err = validateReaperRef(cc); err != nil {
+		errors = append(errors, err...)
+	}
+
 	if err = validateProber(cc); err != nil {
 		errors = append(errors, err...)
 	}
@@ -106,10 +135,24 @@ func validateClusterCreateUpdate(cc *CassandraCluster, ccOld *CassandraCluster)
 		errors = append(errors, err...)
 	}
 
+	if cc.Spec.RestoreFrom != nil {
+		if err = validateRestoreFrom(cc); err != nil {
+			errors = append(errors, err...)
+		}
+	}
+
+	if err = validateBackupEngine(cc); err != nil {
+		errors = append(errors, err...)
+	}
+
 	return
 }
 
 func validateImmutableFields(cc *CassandraCluster, ccOld *CassandraCluster) (errors []error) {
+	if ccOld != nil && !cmp.Equal(ccOld.Spec.RestoreFrom, cc.Spec.RestoreFrom) {
+		errors = append(errors, fmt.Errorf("restoreFrom can be set only on cluster creation; you need to recreate your cluster to restore it from a backup"))
+	}
+
 	if ccOld != nil && ccOld.Spec.Cassandra != nil && cc.Spec.Cassandra != nil {
 		if ccOld.Spec.Cassandra.Persistence.Enabled != cc.Spec.Cassandra.Persistence.Enabled {
 			errors = append(errors, fmt.Errorf("once the persistence is set, you can't change it; you need to recreate your cluster to apply new value"))
@@ -180,6 +223,12 @@ func validateCassandra(cc *CassandraCluster) (errors []error) {
 		}
 	}
 
+	if cc.Spec.Cassandra.CommitLogArchiving != nil {
+		if err := validateStorageLocation(cc.Spec.Cassandra.CommitLogArchiving.StorageLocation); err != nil {
+			errors = append(errors, fmt.Errorf("cassandra.commitLogArchiving.storageLocation is invalid: %s", err.Error()))
+		}
+	}
+
 	return
 }
 
@@ -218,10 +267,48 @@ func validateReaper(cc *CassandraCluster) (errors []error) {
 				}
 			}
 
-			_, err := time.Parse(ISOFormat, repair.ScheduleTriggerTime)
+			if len(repair.Cron) > 0 {
+				errors = append(errors, validateRepairCron(repair, cc.Spec.RepairEngine)...)
+			} else {
+				_, err := time.Parse(ISOFormat, repair.ScheduleTriggerTime)
 
-			if err != nil {
-				errors = append(errors, fmt.Errorf("reaper repair schedule `%s` has invalid format, should be `2000-01-31T00:00:00`", repair.ScheduleTriggerTime))
+				if err != nil {
+					errors = append(errors, fmt.Errorf("reaper repair schedule `%s` has invalid format, should be `2000-01-31T00:00:00`", repair.ScheduleTriggerTime))
+				}
+			}
+
+			for _, window := range append(append([]RepairWindow{}, repair.AllowedWindows...), repair.BlackoutWindows...) {
+				if _, err := cron.ParseWindow(window.Days, window.Start, window.End); err != nil {
+					errors = append(errors, fmt.Errorf("repair window of keyspace '%s' is invalid: %s", repair.Keyspace, err.Error()))
+				}
+			}
+		}
+	}
+
+	return
+}
+
+func validateRepairEngine(cc *CassandraCluster) (errors []error) {
+	if cc.Spec.RepairEngine != RepairEngineBuiltin {
+		return
+	}
+
+	if len(cc.Spec.ExternalRegions.Managed) > 0 || len(cc.Spec.ExternalRegions.Unmanaged) > 0 {
+		errors = append(errors, fmt.Errorf("the builtin repair engine can't be used with external regions"))
+	}
+
+	// the repair status is read with getParentRepairStatus which Cassandra 3.x doesn't have. The default image
+	// or images without a version tag are checked by the operator once the nodes are up.
+	if cc.Spec.Cassandra != nil {
+		if major, found := imageMajorVersion(cc.Spec.Cassandra.Image); found && major < 4 {
+			errors = append(errors, fmt.Errorf("the builtin repair engine requires Cassandra 4.0 or newer, image %s runs Cassandra %d", cc.Spec.Cassandra.Image, major))
+		}
+	}
+
+	if cc.Spec.Reaper != nil {
+		for _, repair := range cc.Spec.Reaper.RepairSchedules.Repairs {
+			if len(repair.Nodes) > 0 {
+				errors = append(errors, fmt.Errorf("the builtin repair engine doesn't support repairs of specific nodes for keyspace '%s'", repair.Keyspace))
 			}
 		}
 	}
@@ -229,6 +316,53 @@ func validateReaper(cc *CassandraCluster) (errors []error) {
 	return
 }
 
+// imageMajorVersion returns the major version from the image tag, e.g. 3 for cassandra:3.11.13
+func imageMajorVersion(image string) (int, bool) {
+	tag := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.Index(tag, "@"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	i := strings.LastIndex(tag, ":")
+	if i < 0 {
+		return 0, false
+	}
+
+	match := imageMajorVersionRegexp.FindStringSubmatch(tag[i+1:])
+	if match == nil {
+		return 0, false
+	}
+
+	major, err := strconv.Atoi(match[1])
+	return major, err == nil
+}
+
+func validateReaperRef(cc *CassandraCluster) (errors []error) {
+	if len(cc.Spec.ReaperRef) == 0 {
+		return
+	}
+
+	if cc.Spec.RepairEngine == RepairEngineBuiltin {
+		errors = append(errors, fmt.Errorf("reaperRef can't be used with the builtin repair engine"))
+	}
+
+	// a single Reaper instance reaches the nodes of all DCs through the pod network
+	if cc.Spec.HostPort.Enabled {
+		errors = append(errors, fmt.Errorf("reaperRef can't be used with hostPort"))
+	}
+
+	if len(cc.Spec.ExternalRegions.Managed) > 0 || len(cc.Spec.ExternalRegions.Unmanaged) > 0 {
+		errors = append(errors, fmt.Errorf("reaperRef can't be used with external regions"))
+	}
+
+	// the auto scheduling settings are global to a Reaper instance
+	if cc.Spec.Reaper != nil && cc.Spec.Reaper.AutoScheduling.Enabled {
+		errors = append(errors, fmt.Errorf("reaperRef can't be used with reaper.autoScheduling"))
+	}
+
+	return
+}
+
 func validateProber(cc *CassandraCluster) (errors []error) {
 	if cc.Spec.Prober.ServiceMonitor.ScrapeInterval != "" {
 		if _, err := time.ParseDuration(cc.Spec.Prober.ServiceMonitor.ScrapeInterval); err != nil {
@@ -279,6 +413,28 @@ func getSystemKeyspaceDCByName(dcs []SystemKeyspaceDC, dcName string) *SystemKey
 	return nil
 }
 
+func validateRepairCron(repair RepairSchedule, repairEngine string) (errors []error) {
+	if len(repair.ScheduleTriggerTime) > 0 || repair.ScheduleDaysBetween > 0 {
+		errors = append(errors, fmt.Errorf("cron can't be used together with scheduleTriggerTime and scheduleDaysBetween for keyspace '%s'", repair.Keyspace))
+	}
+
+	schedule, err := cron.Parse(repair.Cron)
+	if err != nil {
+		return append(errors, fmt.Errorf("cron of the repair schedule for keyspace '%s' is invalid: %s", repair.Keyspace, err.Error()))
+	}
+
+	// the builtin repair engine isn't limited to schedules repeating in days
+	if repairEngine == RepairEngineBuiltin {
+		return errors
+	}
+
+	if _, err = cron.DaysBetween(schedule, time.Now()); err != nil {
+		errors = append(errors, fmt.Errorf("cron `%s` of the repair schedule for keyspace '%s' is not supported by Reaper: %s", repair.Cron, repair.Keyspace, err.Error()))
+	}
+
+	return errors
+}
+
 func checkRepairIntensity(intensity string) error {
 	repairInt, err := strconv.ParseFloat(intensity, 32)
 	if err != nil {
@@ -319,3 +475,33 @@ func validateNetworkPolicies(cc *CassandraCluster) (errors []error) {
 
 	return errors
 }
+
+func validateRestoreFrom(cc *CassandraCluster) (errors []error) {
+	if err := validateStorageLocation(cc.Spec.RestoreFrom.StorageLocation); err != nil {
+		errors = append(errors, fmt.Errorf("restoreFrom.storageLocation is invalid: %s", err.Error()))
+	}
+
+	return
+}
+
+func validateBackupEngine(cc *CassandraCluster) (errors []error) {
+	if cc.Spec.BackupEngine != BackupEngineMedusa {
+		return nil
+	}
+
+	if cc.Spec.Medusa == nil {
+		return append(errors, fmt.Errorf("medusa should be configured if backupEngine is %q", BackupEngineMedusa))
+	}
+
+	if err := validateStorageLocation(cc.Spec.Medusa.StorageLocation); err != nil {
+		errors = append(errors, fmt.Errorf("medusa.storageLocation is invalid: %s", err.Error()))
+	} else if cc.Spec.Medusa.StorageProvider() == StorageProviderAzure {
+		errors = append(errors, fmt.Errorf("medusa.storageLocation is invalid: %s storage is not supported by Medusa", StorageProviderAzure))
+	}
+
+	if cc.Spec.RestoreFrom != nil {
+		errors = append(errors, fmt.Errorf("restoreFrom requires the %q backup engine", BackupEngineIcarus))
+	}
+
+	return
+}
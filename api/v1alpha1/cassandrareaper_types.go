@@ -0,0 +1,67 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CassandraReaperSpec defines a Reaper instance shared by the CassandraClusters that reference it in `.spec.reaperRef`
+type CassandraReaperSpec struct {
+	// CassandraCluster that stores the state of Reaper in its `.spec.reaper.keyspace` keyspace.
+	// It doesn't have to be one of the clusters repaired by the Reaper instance
+	// +kubebuilder:validation:MinLength:=1
+	StorageCassandraCluster string `json:"storageCassandraCluster"`
+	Image                   string `json:"image,omitempty"`
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	ImagePullPolicy     v1.PullPolicy           `json:"imagePullPolicy,omitempty"`
+	ImagePullSecretName string                  `json:"imagePullSecretName,omitempty"`
+	Tolerations         []v1.Toleration         `json:"tolerations,omitempty"`
+	NodeSelector        map[string]string       `json:"nodeSelector,omitempty"`
+	Resources           v1.ResourceRequirements `json:"resources,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	HangingRepairTimeoutMins               int32  `json:"hangingRepairTimeoutMins,omitempty"`
+	RepairIntensity                        string `json:"repairIntensity,omitempty"` // value between 0.0 and 1.0, but must never be 0.0.
+	RepairManagerSchedulingIntervalSeconds int32  `json:"repairManagerSchedulingIntervalSeconds,omitempty"`
+	BlacklistTWCS                          bool   `json:"blacklistTWCS,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	RepairRunThreads int32 `json:"repairRunThreads,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	SegmentCountPerNode int32 `json:"segmentCountPerNode,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	MaxParallelRepairs int32 `json:"maxParallelRepairs,omitempty"`
+}
+
+type CassandraReaperStatus struct {
+	// Whether the Reaper deployment is ready
+	Ready bool `json:"ready,omitempty"`
+	// CassandraClusters whose JMX credentials are loaded by the running Reaper. The clusters are added to Reaper once listed here
+	Clusters []string `json:"clusters,omitempty"`
+	// Checksums of the JMX credentials of the clusters registered in Reaper, by cluster name.
+	// Reaper gets the credentials of a cluster again through its API when they change
+	CredentialsChecksums map[string]string `json:"credentialsChecksums,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// CassandraReaper is the Schema for the CassandraReapers API
+type CassandraReaper struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CassandraReaperSpec   `json:"spec"`
+	Status CassandraReaperStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CassandraReaperList contains a list of CassandraReaper
+type CassandraReaperList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CassandraReaper `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CassandraReaper{}, &CassandraReaperList{})
+}
@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CassandraRepairSpec defines the repair run. The fields match the parameters of the Reaper `POST /repair_run` API.
+type CassandraRepairSpec struct {
+	// CassandraCluster that is being repaired
+	// +kubebuilder:validation:MinLength:=1
+	CassandraCluster string `json:"cassandraCluster" url:"-"`
+	// Keyspace to repair
+	// +kubebuilder:validation:MinLength:=1
+	Keyspace string `json:"keyspace" url:"keyspace"`
+	// Tables to repair. If empty, all tables of the keyspace are repaired.
+	Tables []string `json:"tables,omitempty" url:"tables,comma,omitempty"`
+	// Tables that should not be repaired. Cannot be used together with tables.
+	BlacklistedTables []string `json:"blacklistedTables,omitempty" url:"blacklistedTables,comma,omitempty"`
+	// Datacenters to repair. Ignored if nodes are set. If empty, all datacenters are repaired.
+	Datacenters []string `json:"datacenters,omitempty" url:"datacenters,comma,omitempty"`
+	// Nodes whose token ranges are repaired. If empty, all nodes are repaired.
+	Nodes []string `json:"nodes,omitempty" url:"nodes,comma,omitempty"`
+	// Number of segments per node the token ranges are split into
+	// +kubebuilder:validation:Minimum:=1
+	SegmentCountPerNode int32 `json:"segmentCountPerNode,omitempty" url:"segmentCountPerNode,omitempty"`
+	// +kubebuilder:validation:Enum:=SEQUENTIAL;PARALLEL;DATACENTER_AWARE
+	RepairParallelism string `json:"repairParallelism,omitempty" url:"repairParallelism,omitempty"`
+	// Value between 0.0 and 1.0, but must never be 0.0. Defines the share of the time the repair is running.
+	Intensity         string `json:"intensity,omitempty" url:"intensity,omitempty"`
+	IncrementalRepair bool   `json:"incrementalRepair,omitempty" url:"incrementalRepair,omitempty"`
+	// Defaulted to .spec.reaper.repairThreadCount of the CassandraCluster
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=4
+	RepairThreadCount int32 `json:"repairThreadCount,omitempty" url:"repairThreadCount,omitempty"`
+}
+
+type CassandraRepairStatus struct {
+	// ID of the Reaper repair run
+	RunID string `json:"runID,omitempty"`
+	// State of the repair run as reported by Reaper
+	State string `json:"state,omitempty"`
+	// Number of segments that have been repaired
+	SegmentsRepaired int32 `json:"segmentsRepaired,omitempty"`
+	// Number of segments the token ranges are split into
+	TotalSegments int32 `json:"totalSegments,omitempty"`
+	// Time the repair run was started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Time the repair run has finished
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// The last event of the repair run reported by Reaper. Shows the cause if the repair run has failed.
+	LastEvent string `json:"lastEvent,omitempty"`
+	// The error returned by Reaper if the repair run can't be created
+	Error string `json:"error,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// CassandraRepair is the Schema for the CassandraRepairs API
+type CassandraRepair struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CassandraRepairSpec   `json:"spec"`
+	Status CassandraRepairStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CassandraRepairList contains a list of CassandraRepair
+type CassandraRepairList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CassandraRepair `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CassandraRepair{}, &CassandraRepairList{})
+}
@@ -2,9 +2,19 @@ package v1alpha1
 
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+const (
+	RestoreConditionVerified = "Verified"
+
+	// RestoreStateFailed is the state of a failed restore. The restore states are the ones reported by Icarus
+	RestoreStateFailed = "FAILED"
+)
+
 type CassandraRestoreSpec struct {
 	CassandraCluster string `json:"cassandraCluster"`
 	CassandraBackup  string `json:"cassandraBackup,omitempty"`
+	// Name of the CassandraBackupCatalog the backup is picked from. The snapshot tag and schema version are validated
+	// against the backups found by the catalog. The storage location and the secret are taken from the catalog if not set.
+	CassandraBackupCatalog string `json:"cassandraBackupCatalog,omitempty"`
 	// example: gcp://myBucket
 	// location of SSTables
 	// A value of the storageLocation property has to have exact format which is 'protocol://bucket-name
@@ -68,6 +78,47 @@ type CassandraRestoreSpec struct {
 	// There might be cases when we want to restore a table for which its CQL schema has not changed,
 	// but it has changed for other table / keyspace but a schema for that node has changed by doing that.
 	ExactSchemaVersion bool `json:"exactSchemaVersion,omitempty"`
+	// Name of the cluster the backup was taken from. Defaults to the cluster of the CassandraBackup or to the restored cluster
+	SourceCluster string `json:"sourceCluster,omitempty"`
+	// Maps DCs of the backed up cluster to DCs of the restored cluster. Required if the DC names of the clusters differ.
+	// If the number of nodes of a source DC differs from the target DC, the SSTables are streamed into the target DC
+	// by a loader job in the style of sstableloader instead of being copied to the nodes with matching host IDs.
+	TopologyMapping []DCMapping `json:"topologyMapping,omitempty"`
+	// Point in time the cluster is restored to. After the snapshot is restored, the commitlog segments archived
+	// by the source cluster in the storage location are replayed up to that moment.
+	// Cassandra pods are restarted to replay the commitlogs. The restored cluster must have
+	// .spec.cassandra.commitLogArchiving configured. Can't be used with loader based restores.
+	// example: 2021-09-01T12:30:00Z
+	PointInTime *metav1.Time `json:"pointInTime,omitempty"`
+	// Verification of the restored data. If set, the restored tables are checked against the backup manifests
+	// after the restore is completed and the result is reflected in the Verified condition.
+	// Backup manifests can be read only from S3 compatible storage providers.
+	Verification *RestoreVerification `json:"verification,omitempty"`
+}
+
+type RestoreVerification struct {
+	// Consistency level used for the sample reads of the restored tables. Defaults to LOCAL_QUORUM
+	// +kubebuilder:validation:Enum=ONE;TWO;THREE;QUORUM;ALL;LOCAL_QUORUM;EACH_QUORUM;LOCAL_ONE
+	ConsistencyLevel string `json:"consistencyLevel,omitempty"`
+	// Run a repair of the restored keyspaces through Reaper after the checks have passed
+	Repair bool `json:"repair,omitempty"`
+	// Minimum size of the live data of a restored table in a DC as a percentage of the size of the table in the backup.
+	// Compactions after the restore may remove overwritten and deleted data. Defaults to 50
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MinDataPercent int32 `json:"minDataPercent,omitempty"`
+}
+
+type DCMapping struct {
+	// Name of the DC in the backed up cluster
+	// +kubebuilder:validation:MinLength:=1
+	Source string `json:"source"`
+	// Name of the DC in the restored cluster
+	// +kubebuilder:validation:MinLength:=1
+	Target string `json:"target"`
+	// Number of nodes in the source DC. Defaults to the number of nodes in the target DC
+	// +kubebuilder:validation:Minimum=1
+	SourceNodes int32 `json:"sourceNodes,omitempty"`
 }
 
 type RestoreImport struct {
@@ -84,6 +135,29 @@ type CassandraRestoreStatus struct {
 	State    string         `json:"state,omitempty"`
 	Progress int            `json:"progress,omitempty"`
 	Errors   []RestoreError `json:"errors,omitempty"`
+	// The pod which Icarus sidecar coordinates the restore
+	Coordinator string `json:"coordinator,omitempty"`
+	// IDs of the Icarus restore operations, one per restored DC. Used to track the restore on all nodes if the coordinator is lost
+	OperationIDs []string `json:"operationIDs,omitempty"`
+	// Restored tables compared with the backup manifests by the verification
+	VerifiedTables []VerifiedTable    `json:"verifiedTables,omitempty"`
+	Conditions     []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type VerifiedTable struct {
+	Keyspace string `json:"keyspace"`
+	Table    string `json:"table"`
+	DC       string `json:"dc"`
+	// Number of SSTables of the table in the backup manifests of the DC
+	BackupSSTables int64 `json:"backupSSTables"`
+	// Number of live SSTables of the restored table on the DC nodes
+	LiveSSTables int64 `json:"liveSSTables"`
+	// Size of the table in the backup manifests of the DC
+	BackupSizeBytes int64 `json:"backupSizeBytes"`
+	// Size of the live SSTables of the restored table on the DC nodes
+	LiveSizeBytes int64 `json:"liveSizeBytes"`
+	// Estimated number of partitions of the restored table on the DC nodes
+	EstimatedPartitions int64 `json:"estimatedPartitions"`
 }
 
 type RestoreError struct {
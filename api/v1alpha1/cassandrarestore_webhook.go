@@ -17,13 +17,21 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/ibm/cassandra-operator/controllers/util"
 )
 
 func (cr *CassandraRestore) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -38,19 +46,36 @@ var _ webhook.Validator = &CassandraRestore{}
 func (cr *CassandraRestore) ValidateCreate() error {
 	webhookLogger.Debugf("Validating webhook has been called on create request for restore: %s", cr.Name)
 
-	return kerrors.NewAggregate(validateRestoreCreateUpdate(cr))
+	verrors := validateRestoreCreateUpdate(cr)
+	dcs := splitDCs(cr.Spec.DC)
+	for _, dcMapping := range cr.Spec.TopologyMapping {
+		dcs = append(dcs, dcMapping.Target)
+	}
+	verrors = append(verrors, validateClusterReference(cr.Namespace, cr.Spec.CassandraCluster, dcs)...)
+	verrors = append(verrors, validateSecretReference(cr.Namespace, cr.Spec.SecretName)...)
+	verrors = append(verrors, validateRenameTargets(cr.Namespace, cr.Spec.CassandraCluster, cr.Spec.Rename)...)
+	verrors = append(verrors, validatePointInTimeEngine(cr)...)
+
+	return kerrors.NewAggregate(verrors)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (cr *CassandraRestore) ValidateUpdate(old runtime.Object) error {
 	webhookLogger.Debugf("Validating webhook has been called on update request for restore: %s", cr.Name)
 
-	cbOld, ok := old.(*CassandraRestore)
+	crOld, ok := old.(*CassandraRestore)
 	if !ok {
-		return fmt.Errorf("old cassandra cluster object: (%s) is not of type CassandraRestore", cbOld.Name)
+		return fmt.Errorf("old cassandra cluster object: (%s) is not of type CassandraRestore", crOld.Name)
+	}
+
+	verrors := validateRestoreCreateUpdate(cr)
+	// a failed restore is retried with the changed spec
+	if operationStarted(crOld.Status.State, RestoreStateFailed) && !cmp.Equal(crOld.Spec, cr.Spec) {
+		verrors = append(verrors, fmt.Errorf(".spec can't be changed once the restore has started (state %s). "+
+			"Recreate the CassandraRestore to start a new restore", crOld.Status.State))
 	}
 
-	return kerrors.NewAggregate(validateRestoreCreateUpdate(cr))
+	return kerrors.NewAggregate(verrors)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -60,15 +85,153 @@ func (cr *CassandraRestore) ValidateDelete() error {
 }
 
 func validateRestoreCreateUpdate(cr *CassandraRestore) (verrors []error) {
-	if len(cr.Spec.CassandraBackup) == 0 {
+	if len(cr.Spec.CassandraCluster) == 0 {
+		verrors = append(verrors, errors.New(".spec.cassandraCluster should be set"))
+	}
+
+	if len(cr.Spec.CassandraBackup) != 0 && len(cr.Spec.CassandraBackupCatalog) != 0 {
+		verrors = append(verrors, errors.New(".spec.cassandraBackup and .spec.cassandraBackupCatalog can't be set at the same time"))
+	}
+
+	if len(cr.Spec.CassandraBackupCatalog) != 0 {
+		if len(cr.Spec.SnapshotTag) == 0 {
+			verrors = append(verrors, errors.New(".spec.snapshotTag should be set if .spec.cassandraBackupCatalog is set"))
+		}
+	} else if len(cr.Spec.CassandraBackup) == 0 {
 		if len(cr.Spec.StorageLocation) == 0 || len(cr.Spec.SnapshotTag) == 0 || len(cr.Spec.SecretName) == 0 {
-			verrors = append(verrors, errors.New(".spec.storageLocation, .spec.snapshotTag and .spec.secretName should be set if .spec.cassandraBackup is not set"))
-		} else {
-			if err := validateStorageLocation(cr.Spec.StorageLocation); err != nil {
-				verrors = append(verrors, err)
+			verrors = append(verrors, errors.New(".spec.storageLocation, .spec.snapshotTag and .spec.secretName should be set if .spec.cassandraBackup or .spec.cassandraBackupCatalog is not set"))
+		}
+	}
+
+	if len(cr.Spec.StorageLocation) != 0 {
+		if err := validateStorageLocation(cr.Spec.StorageLocation); err != nil {
+			verrors = append(verrors, err)
+		}
+	}
+
+	if cr.Spec.PointInTime != nil && cr.Spec.PointInTime.After(time.Now()) {
+		verrors = append(verrors, errors.New(".spec.pointInTime can't be in the future"))
+	}
+
+	entities, err := parseEntities(cr.Spec.Entities)
+	if err != nil {
+		verrors = append(verrors, fmt.Errorf(".spec.entities is invalid: %s", err.Error()))
+	}
+
+	if len(cr.Spec.Rename) != 0 && err == nil {
+		verrors = append(verrors, validateRename(cr.Spec.Rename, entities)...)
+	}
+
+	return verrors
+}
+
+// validateRename checks that the renamed tables are restored and that the target tables are not restored themselves,
+// as Icarus truncates the target tables before the data of the source tables is imported into them
+func validateRename(rename map[string]string, entities [][]string) (verrors []error) {
+	restored := func(keyspace, table string) bool {
+		for _, entity := range entities {
+			if entity[0] == keyspace && (len(entity) == 1 || entity[1] == table) {
+				return true
 			}
 		}
+		return false
+	}
+
+	targets := make(map[string]string)
+	for _, source := range sortedKeys(rename) {
+		target := rename[source]
+		sourceNames := strings.Split(source, ".")
+		targetNames := strings.Split(target, ".")
+		if len(sourceNames) != 2 || len(targetNames) != 2 || !cqlNameRegexp.MatchString(sourceNames[0]) || !cqlNameRegexp.MatchString(sourceNames[1]) ||
+			!cqlNameRegexp.MatchString(targetNames[0]) || !cqlNameRegexp.MatchString(targetNames[1]) {
+			verrors = append(verrors, fmt.Errorf(".spec.rename is invalid: %q: %q should map a table to a table in format 'keyspace.table'", source, target))
+			continue
+		}
+
+		if source == target {
+			verrors = append(verrors, fmt.Errorf(".spec.rename is invalid: table %s is renamed to itself", source))
+		}
+
+		if len(entities) == 0 {
+			verrors = append(verrors, fmt.Errorf(".spec.rename is invalid: .spec.entities should list the renamed table %s", source))
+		} else if !restored(sourceNames[0], sourceNames[1]) {
+			verrors = append(verrors, fmt.Errorf(".spec.rename is invalid: the renamed table %s is not restored. It should be listed in .spec.entities", source))
+		}
+
+		if restored(targetNames[0], targetNames[1]) {
+			verrors = append(verrors, fmt.Errorf(".spec.rename is invalid: the target table %s is restored itself", target))
+		}
+
+		if otherSource, ok := targets[target]; ok {
+			verrors = append(verrors, fmt.Errorf(".spec.rename is invalid: tables %s and %s are renamed to the same table %s", otherSource, source, target))
+		}
+		targets[target] = source
 	}
 
 	return verrors
 }
+
+// validatePointInTimeEngine checks that the cluster uses Icarus if a point-in-time restore is requested, as Medusa doesn't archive the commitlogs.
+// Skipped if the webhook client is not set or if the cluster doesn't exist.
+func validatePointInTimeEngine(cr *CassandraRestore) []error {
+	if webhookClient == nil || cr.Spec.PointInTime == nil || len(cr.Spec.CassandraCluster) == 0 {
+		return nil
+	}
+
+	cc := &CassandraCluster{}
+	if err := webhookClient.Get(context.Background(), types.NamespacedName{Name: cr.Spec.CassandraCluster, Namespace: cr.Namespace}, cc); err != nil {
+		// reported by validateClusterReference
+		return nil
+	}
+
+	if cc.Spec.BackupEngine == BackupEngineMedusa {
+		return []error{fmt.Errorf(".spec.pointInTime is not supported: CassandraCluster %s/%s uses the %q backup engine", cr.Namespace, cr.Spec.CassandraCluster, BackupEngineMedusa)}
+	}
+
+	return nil
+}
+
+// validateRenameTargets checks that the target keyspaces and tables exist in the schema of the cluster, as Icarus imports the data into existing tables.
+// Skipped if the webhook client or the schema reader is not set, or if the cluster doesn't exist.
+func validateRenameTargets(namespace, clusterName string, rename map[string]string) (verrors []error) {
+	if webhookClient == nil || webhookSchemaReader == nil || len(clusterName) == 0 || len(rename) == 0 {
+		return nil
+	}
+
+	cc := &CassandraCluster{}
+	if err := webhookClient.Get(context.Background(), types.NamespacedName{Name: clusterName, Namespace: namespace}, cc); err != nil {
+		// reported by validateClusterReference
+		return nil
+	}
+
+	tables, err := webhookSchemaReader(context.Background(), cc)
+	if err != nil {
+		return []error{fmt.Errorf(".spec.rename can't be validated: failed to read the schema of CassandraCluster %s/%s: %s", namespace, clusterName, err.Error())}
+	}
+
+	for _, source := range sortedKeys(rename) {
+		targetNames := strings.Split(rename[source], ".")
+		if len(targetNames) != 2 {
+			// reported by validateRename
+			continue
+		}
+
+		keyspaceTables, found := tables[targetNames[0]]
+		if !found {
+			verrors = append(verrors, fmt.Errorf(".spec.rename is invalid: the target keyspace %s of table %s doesn't exist", targetNames[0], source))
+		} else if !util.Contains(keyspaceTables, targetNames[1]) {
+			verrors = append(verrors, fmt.Errorf(".spec.rename is invalid: the target table %s of table %s doesn't exist", rename[source], source))
+		}
+	}
+
+	return verrors
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
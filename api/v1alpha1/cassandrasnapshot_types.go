@@ -0,0 +1,88 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SnapshotStateRunning   = "RUNNING"
+	SnapshotStateCompleted = "COMPLETED"
+	SnapshotStateCleared   = "CLEARED"
+	SnapshotStateFailed    = "FAILED"
+
+	// SnapshotConditionDiskPressure shows if snapshots take more space on the data volume of a node than allowed by .spec.diskUsageWarningPercent
+	SnapshotConditionDiskPressure = "DiskPressure"
+)
+
+type CassandraSnapshotSpec struct {
+	// CassandraCluster that is being snapshotted
+	// +kubebuilder:validation:MinLength:=1
+	CassandraCluster string `json:"cassandraCluster"`
+	// Tag name that identifies the snapshot on all nodes. Defaulted to the name of the CassandraSnapshot.
+	SnapshotTag string `json:"snapshotTag,omitempty"`
+	// Keyspaces to snapshot. If empty, all keyspaces are snapshotted.
+	Keyspaces []string `json:"keyspaces,omitempty"`
+	// Number of seconds the snapshot is kept on the nodes after it has been taken. Once expired, the snapshot is cleared.
+	// If not set, the snapshot is kept until the CassandraSnapshot is deleted.
+	// +kubebuilder:validation:Minimum=60
+	RetentionSeconds int64 `json:"retentionSeconds,omitempty"`
+	// A warning is emitted if the snapshots on a node take more than the given percentage of its data volume. Defaults to 50.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	DiskUsageWarningPercent int32 `json:"diskUsageWarningPercent,omitempty"`
+	// Number of seconds to wait for the snapshot to be taken on all nodes. The snapshot fails if it isn't taken in time.
+	// If not set, the operator keeps trying until the snapshot is taken.
+	// +kubebuilder:validation:Minimum=60
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
+}
+
+type CassandraSnapshotStatus struct {
+	// The current state of the snapshot
+	State string `json:"state,omitempty"`
+	// Time the snapshot was taken on all nodes
+	SnapshotTime *metav1.Time `json:"snapshotTime,omitempty"`
+	// Time the snapshot is cleared from the nodes according to .spec.retentionSeconds
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+	// Snapshot state and disk usage of each node
+	Nodes []SnapshotNode `json:"nodes,omitempty"`
+	// Conditions of the snapshot. The DiskPressure condition shows if snapshots are filling the data volumes
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type SnapshotNode struct {
+	// Name of the pod
+	Pod string `json:"pod"`
+	// DC of the pod
+	DC string `json:"dc"`
+	// Time the snapshot was taken on the node
+	SnapshotTime *metav1.Time `json:"snapshotTime,omitempty"`
+	// Disk space in bytes used by all snapshots on the node, including the ones not managed by this CassandraSnapshot
+	SnapshotsSizeBytes int64 `json:"snapshotsSizeBytes,omitempty"`
+	// Disk space used by all snapshots on the node as a percentage of its data volume. Not set if persistence is disabled
+	DataVolumeUsagePercent *int32 `json:"dataVolumeUsagePercent,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// CassandraSnapshot is the Schema for the CassandraSnapshots API
+type CassandraSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CassandraSnapshotSpec   `json:"spec"`
+	Status CassandraSnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CassandraSnapshotList contains a list of CassandraSnapshot
+type CassandraSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CassandraSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CassandraSnapshot{}, &CassandraSnapshotList{})
+}
@@ -22,8 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -62,6 +62,21 @@ func (in *BackupError) DeepCopy() *BackupError {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuiltinRepair) DeepCopyInto(out *BuiltinRepair) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuiltinRepair.
+func (in *BuiltinRepair) DeepCopy() *BuiltinRepair {
+	if in == nil {
+		return nil
+	}
+	out := new(BuiltinRepair)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CATLSSecret) DeepCopyInto(out *CATLSSecret) {
 	*out = *in
@@ -105,6 +120,11 @@ func (in *Cassandra) DeepCopyInto(out *Cassandra) {
 		}
 	}
 	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	if in.CommitLogArchiving != nil {
+		in, out := &in.CommitLogArchiving, &out.CommitLogArchiving
+		*out = new(CommitLogArchiving)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cassandra.
@@ -144,6 +164,113 @@ func (in *CassandraBackup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupCatalog) DeepCopyInto(out *CassandraBackupCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupCatalog.
+func (in *CassandraBackupCatalog) DeepCopy() *CassandraBackupCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraBackupCatalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupCatalogList) DeepCopyInto(out *CassandraBackupCatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CassandraBackupCatalog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupCatalogList.
+func (in *CassandraBackupCatalogList) DeepCopy() *CassandraBackupCatalogList {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupCatalogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraBackupCatalogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupCatalogSpec) DeepCopyInto(out *CassandraBackupCatalogSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupCatalogSpec.
+func (in *CassandraBackupCatalogSpec) DeepCopy() *CassandraBackupCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraBackupCatalogStatus) DeepCopyInto(out *CassandraBackupCatalogStatus) {
+	*out = *in
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]CatalogBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupCatalogStatus.
+func (in *CassandraBackupCatalogStatus) DeepCopy() *CassandraBackupCatalogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraBackupCatalogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CassandraBackupList) DeepCopyInto(out *CassandraBackupList) {
 	*out = *in
@@ -205,6 +332,21 @@ func (in *CassandraBackupStatus) DeepCopyInto(out *CassandraBackupStatus) {
 		*out = make([]BackupError, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraBackupStatus.
@@ -307,15 +449,31 @@ func (in *CassandraClusterSpec) DeepCopyInto(out *CassandraClusterSpec) {
 	in.Ingress.DeepCopyInto(&out.Ingress)
 	in.ExternalRegions.DeepCopyInto(&out.ExternalRegions)
 	in.Icarus.DeepCopyInto(&out.Icarus)
+	if in.Medusa != nil {
+		in, out := &in.Medusa, &out.Medusa
+		*out = new(Medusa)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Prober.DeepCopyInto(&out.Prober)
 	if in.Reaper != nil {
 		in, out := &in.Reaper, &out.Reaper
 		*out = new(Reaper)
 		(*in).DeepCopyInto(*out)
 	}
+	out.BuiltinRepair = in.BuiltinRepair
 	in.HostPort.DeepCopyInto(&out.HostPort)
 	in.Encryption.DeepCopyInto(&out.Encryption)
 	in.NetworkPolicies.DeepCopyInto(&out.NetworkPolicies)
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(RestoreFrom)
+		**out = **in
+	}
+	if in.SafetySnapshots != nil {
+		in, out := &in.SafetySnapshots, &out.SafetySnapshots
+		*out = new(SafetySnapshots)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraClusterSpec.
@@ -338,6 +496,42 @@ func (in *CassandraClusterStatus) DeepCopyInto(out *CassandraClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RepairSchedules != nil {
+		in, out := &in.RepairSchedules, &out.RepairSchedules
+		*out = make([]RepairScheduleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RepairsPause != nil {
+		in, out := &in.RepairsPause, &out.RepairsPause
+		*out = new(RepairsPause)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PausedRepairSchedules != nil {
+		in, out := &in.PausedRepairSchedules, &out.PausedRepairSchedules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RepairCoverage != nil {
+		in, out := &in.RepairCoverage, &out.RepairCoverage
+		*out = make([]KeyspaceRepairCoverage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IncrementalRepairMigrations != nil {
+		in, out := &in.IncrementalRepairMigrations, &out.IncrementalRepairMigrations
+		*out = make([]IncrementalRepairMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MedusaRestore != nil {
+		in, out := &in.MedusaRestore, &out.MedusaRestore
+		*out = new(MedusaRestore)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraClusterStatus.
@@ -350,6 +544,239 @@ func (in *CassandraClusterStatus) DeepCopy() *CassandraClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraReaper) DeepCopyInto(out *CassandraReaper) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraReaper.
+func (in *CassandraReaper) DeepCopy() *CassandraReaper {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraReaper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraReaper) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraReaperList) DeepCopyInto(out *CassandraReaperList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CassandraReaper, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraReaperList.
+func (in *CassandraReaperList) DeepCopy() *CassandraReaperList {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraReaperList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraReaperList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraReaperSpec) DeepCopyInto(out *CassandraReaperSpec) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraReaperSpec.
+func (in *CassandraReaperSpec) DeepCopy() *CassandraReaperSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraReaperSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraReaperStatus) DeepCopyInto(out *CassandraReaperStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialsChecksums != nil {
+		in, out := &in.CredentialsChecksums, &out.CredentialsChecksums
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraReaperStatus.
+func (in *CassandraReaperStatus) DeepCopy() *CassandraReaperStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraReaperStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraRepair) DeepCopyInto(out *CassandraRepair) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRepair.
+func (in *CassandraRepair) DeepCopy() *CassandraRepair {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraRepair)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraRepair) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraRepairList) DeepCopyInto(out *CassandraRepairList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CassandraRepair, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRepairList.
+func (in *CassandraRepairList) DeepCopy() *CassandraRepairList {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraRepairList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraRepairList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraRepairSpec) DeepCopyInto(out *CassandraRepairSpec) {
+	*out = *in
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlacklistedTables != nil {
+		in, out := &in.BlacklistedTables, &out.BlacklistedTables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Datacenters != nil {
+		in, out := &in.Datacenters, &out.Datacenters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRepairSpec.
+func (in *CassandraRepairSpec) DeepCopy() *CassandraRepairSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraRepairSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraRepairStatus) DeepCopyInto(out *CassandraRepairStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRepairStatus.
+func (in *CassandraRepairStatus) DeepCopy() *CassandraRepairStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraRepairStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CassandraRestore) DeepCopyInto(out *CassandraRestore) {
 	*out = *in
@@ -374,81 +801,264 @@ func (in *CassandraRestore) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
-	return nil
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraRestoreList) DeepCopyInto(out *CassandraRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CassandraRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRestoreList.
+func (in *CassandraRestoreList) DeepCopy() *CassandraRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraRestoreSpec) DeepCopyInto(out *CassandraRestoreSpec) {
+	*out = *in
+	out.Import = in.Import
+	out.Retry = in.Retry
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TopologyMapping != nil {
+		in, out := &in.TopologyMapping, &out.TopologyMapping
+		*out = make([]DCMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.PointInTime != nil {
+		in, out := &in.PointInTime, &out.PointInTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(RestoreVerification)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRestoreSpec.
+func (in *CassandraRestoreSpec) DeepCopy() *CassandraRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraRestoreStatus) DeepCopyInto(out *CassandraRestoreStatus) {
+	*out = *in
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]RestoreError, len(*in))
+		copy(*out, *in)
+	}
+	if in.OperationIDs != nil {
+		in, out := &in.OperationIDs, &out.OperationIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VerifiedTables != nil {
+		in, out := &in.VerifiedTables, &out.VerifiedTables
+		*out = make([]VerifiedTable, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRestoreStatus.
+func (in *CassandraRestoreStatus) DeepCopy() *CassandraRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraSnapshot) DeepCopyInto(out *CassandraSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraSnapshot.
+func (in *CassandraSnapshot) DeepCopy() *CassandraSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraSnapshotList) DeepCopyInto(out *CassandraSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CassandraSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraSnapshotList.
+func (in *CassandraSnapshotList) DeepCopy() *CassandraSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CassandraSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CassandraSnapshotSpec) DeepCopyInto(out *CassandraSnapshotSpec) {
+	*out = *in
+	if in.Keyspaces != nil {
+		in, out := &in.Keyspaces, &out.Keyspaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraSnapshotSpec.
+func (in *CassandraSnapshotSpec) DeepCopy() *CassandraSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CassandraSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CassandraRestoreList) DeepCopyInto(out *CassandraRestoreList) {
+func (in *CassandraSnapshotStatus) DeepCopyInto(out *CassandraSnapshotStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]CassandraRestore, len(*in))
+	if in.SnapshotTime != nil {
+		in, out := &in.SnapshotTime, &out.SnapshotTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]SnapshotNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRestoreList.
-func (in *CassandraRestoreList) DeepCopy() *CassandraRestoreList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraSnapshotStatus.
+func (in *CassandraSnapshotStatus) DeepCopy() *CassandraSnapshotStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CassandraRestoreList)
+	out := new(CassandraSnapshotStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CassandraRestoreList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CassandraRestoreSpec) DeepCopyInto(out *CassandraRestoreSpec) {
+func (in *CatalogBackup) DeepCopyInto(out *CatalogBackup) {
 	*out = *in
-	out.Import = in.Import
-	out.Retry = in.Retry
-	if in.Rename != nil {
-		in, out := &in.Rename, &out.Rename
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.DCs != nil {
+		in, out := &in.DCs, &out.DCs
+		*out = make([]CatalogDC, len(*in))
+		copy(*out, *in)
 	}
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRestoreSpec.
-func (in *CassandraRestoreSpec) DeepCopy() *CassandraRestoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogBackup.
+func (in *CatalogBackup) DeepCopy() *CatalogBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(CassandraRestoreSpec)
+	out := new(CatalogBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CassandraRestoreStatus) DeepCopyInto(out *CassandraRestoreStatus) {
+func (in *CatalogDC) DeepCopyInto(out *CatalogDC) {
 	*out = *in
-	if in.Errors != nil {
-		in, out := &in.Errors, &out.Errors
-		*out = make([]RestoreError, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CassandraRestoreStatus.
-func (in *CassandraRestoreStatus) DeepCopy() *CassandraRestoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogDC.
+func (in *CatalogDC) DeepCopy() *CatalogDC {
 	if in == nil {
 		return nil
 	}
-	out := new(CassandraRestoreStatus)
+	out := new(CatalogDC)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -480,6 +1090,21 @@ func (in *ClientEncryption) DeepCopy() *ClientEncryption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommitLogArchiving) DeepCopyInto(out *CommitLogArchiving) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitLogArchiving.
+func (in *CommitLogArchiving) DeepCopy() *CommitLogArchiving {
+	if in == nil {
+		return nil
+	}
+	out := new(CommitLogArchiving)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DC) DeepCopyInto(out *DC) {
 	*out = *in
@@ -490,12 +1115,12 @@ func (in *DC) DeepCopyInto(out *DC) {
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -512,6 +1137,21 @@ func (in *DC) DeepCopy() *DC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DCMapping) DeepCopyInto(out *DCMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DCMapping.
+func (in *DCMapping) DeepCopy() *DCMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(DCMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataRate) DeepCopyInto(out *DataRate) {
 	*out = *in
@@ -607,6 +1247,31 @@ func (in *Icarus) DeepCopy() *Icarus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IncrementalRepairMigration) DeepCopyInto(out *IncrementalRepairMigration) {
+	*out = *in
+	if in.MarkedPods != nil {
+		in, out := &in.MarkedPods, &out.MarkedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncrementalRepairMigration.
+func (in *IncrementalRepairMigration) DeepCopy() *IncrementalRepairMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(IncrementalRepairMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ingress) DeepCopyInto(out *Ingress) {
 	*out = *in
@@ -650,6 +1315,25 @@ func (in *Jolokia) DeepCopy() *Jolokia {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceRepairCoverage) DeepCopyInto(out *KeyspaceRepairCoverage) {
+	*out = *in
+	if in.OldestCompleteRepair != nil {
+		in, out := &in.OldestCompleteRepair, &out.OldestCompleteRepair
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceRepairCoverage.
+func (in *KeyspaceRepairCoverage) DeepCopy() *KeyspaceRepairCoverage {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceRepairCoverage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Maintenance) DeepCopyInto(out *Maintenance) {
 	*out = *in
@@ -685,6 +1369,42 @@ func (in *ManagedRegion) DeepCopy() *ManagedRegion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Medusa) DeepCopyInto(out *Medusa) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Medusa.
+func (in *Medusa) DeepCopy() *Medusa {
+	if in == nil {
+		return nil
+	}
+	out := new(Medusa)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MedusaRestore) DeepCopyInto(out *MedusaRestore) {
+	*out = *in
+	if in.DCs != nil {
+		in, out := &in.DCs, &out.DCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MedusaRestore.
+func (in *MedusaRestore) DeepCopy() *MedusaRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(MedusaRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Monitoring) DeepCopyInto(out *Monitoring) {
 	*out = *in
@@ -752,12 +1472,12 @@ func (in *NetworkPolicyRule) DeepCopyInto(out *NetworkPolicyRule) {
 	*out = *in
 	if in.PodSelector != nil {
 		in, out := &in.PodSelector, &out.PodSelector
-		*out = new(metav1.LabelSelector)
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.NamespaceSelector != nil {
 		in, out := &in.NamespaceSelector, &out.NamespaceSelector
-		*out = new(metav1.LabelSelector)
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Ports != nil {
@@ -831,7 +1551,7 @@ func (in *Prober) DeepCopyInto(out *Prober) {
 	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -845,7 +1565,7 @@ func (in *Prober) DeepCopyInto(out *Prober) {
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -865,7 +1585,7 @@ func (in *Reaper) DeepCopyInto(out *Reaper) {
 	*out = *in
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -916,6 +1636,20 @@ func (in *RepairSchedule) DeepCopyInto(out *RepairSchedule) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedWindows != nil {
+		in, out := &in.AllowedWindows, &out.AllowedWindows
+		*out = make([]RepairWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlackoutWindows != nil {
+		in, out := &in.BlackoutWindows, &out.BlackoutWindows
+		*out = make([]RepairWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepairSchedule.
@@ -928,6 +1662,35 @@ func (in *RepairSchedule) DeepCopy() *RepairSchedule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepairScheduleStatus) DeepCopyInto(out *RepairScheduleStatus) {
+	*out = *in
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextActivation != nil {
+		in, out := &in.NextActivation, &out.NextActivation
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRun != nil {
+		in, out := &in.LastRun, &out.LastRun
+		*out = new(ScheduledRepairRun)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepairScheduleStatus.
+func (in *RepairScheduleStatus) DeepCopy() *RepairScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RepairScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepairSchedules) DeepCopyInto(out *RepairSchedules) {
 	*out = *in
@@ -950,6 +1713,47 @@ func (in *RepairSchedules) DeepCopy() *RepairSchedules {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepairWindow) DeepCopyInto(out *RepairWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepairWindow.
+func (in *RepairWindow) DeepCopy() *RepairWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(RepairWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepairsPause) DeepCopyInto(out *RepairsPause) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	if in.RepairRunIDs != nil {
+		in, out := &in.RepairRunIDs, &out.RepairRunIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepairsPause.
+func (in *RepairsPause) DeepCopy() *RepairsPause {
+	if in == nil {
+		return nil
+	}
+	out := new(RepairsPause)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreError) DeepCopyInto(out *RestoreError) {
 	*out = *in
@@ -965,6 +1769,21 @@ func (in *RestoreError) DeepCopy() *RestoreError {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreFrom) DeepCopyInto(out *RestoreFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreFrom.
+func (in *RestoreFrom) DeepCopy() *RestoreFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreImport) DeepCopyInto(out *RestoreImport) {
 	*out = *in
@@ -980,6 +1799,21 @@ func (in *RestoreImport) DeepCopy() *RestoreImport {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerification) DeepCopyInto(out *RestoreVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerification.
+func (in *RestoreVerification) DeepCopy() *RestoreVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Retry) DeepCopyInto(out *Retry) {
 	*out = *in
@@ -995,6 +1829,44 @@ func (in *Retry) DeepCopy() *Retry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SafetySnapshots) DeepCopyInto(out *SafetySnapshots) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SafetySnapshots.
+func (in *SafetySnapshots) DeepCopy() *SafetySnapshots {
+	if in == nil {
+		return nil
+	}
+	out := new(SafetySnapshots)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledRepairRun) DeepCopyInto(out *ScheduledRepairRun) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledRepairRun.
+func (in *ScheduledRepairRun) DeepCopy() *ScheduledRepairRun {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledRepairRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerEncryption) DeepCopyInto(out *ServerEncryption) {
 	*out = *in
@@ -1044,6 +1916,30 @@ func (in *ServiceMonitor) DeepCopy() *ServiceMonitor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotNode) DeepCopyInto(out *SnapshotNode) {
+	*out = *in
+	if in.SnapshotTime != nil {
+		in, out := &in.SnapshotTime, &out.SnapshotTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DataVolumeUsagePercent != nil {
+		in, out := &in.DataVolumeUsagePercent, &out.DataVolumeUsagePercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotNode.
+func (in *SnapshotNode) DeepCopy() *SnapshotNode {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemKeyspaceDC) DeepCopyInto(out *SystemKeyspaceDC) {
 	*out = *in
@@ -1108,3 +2004,18 @@ func (in *UnmanagedRegion) DeepCopy() *UnmanagedRegion {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerifiedTable) DeepCopyInto(out *VerifiedTable) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerifiedTable.
+func (in *VerifiedTable) DeepCopy() *VerifiedTable {
+	if in == nil {
+		return nil
+	}
+	out := new(VerifiedTable)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,146 @@
+package backupengine
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+// Engine is the backup sidecar the backup and restore controllers talk to.
+// The requests and operations are the Icarus ones as Icarus was the first supported engine.
+type Engine interface {
+	Backup(ctx context.Context, req icarus.BackupRequest) (icarus.Backup, error)
+	Backups(ctx context.Context) ([]icarus.Backup, error)
+	Restore(ctx context.Context, req icarus.RestoreRequest) error
+	Restores(ctx context.Context) ([]icarus.Restore, error)
+}
+
+// Clients creates the clients of the supported engines
+type Clients struct {
+	Icarus func(coordinatorPodURL string) icarus.Icarus
+	Medusa func(addr string) medusa.Medusa
+}
+
+// New returns the engine configured for the cluster. Icarus sends the requests to the coordinator which distributes
+// them to the other nodes, Medusa gets a request on every ready node.
+func New(cc *v1alpha1.CassandraCluster, clients Clients, coordinator v1.Pod, readyPods []v1.Pod) Engine {
+	if cc.Spec.BackupEngine == v1alpha1.BackupEngineMedusa {
+		nodes := make([]medusaNode, 0, len(readyPods))
+		for _, pod := range readyPods {
+			nodes = append(nodes, medusaNode{
+				dc:     pod.Labels[v1alpha1.CassandraClusterDC],
+				client: clients.Medusa(medusa.PodAddress(cc, pod)),
+			})
+		}
+		return newMedusaEngine(nodes)
+	}
+
+	return clients.Icarus(icarus.PodURL(cc, coordinator))
+}
+
+// Name returns the name of the engine used by the cluster
+func Name(cc *v1alpha1.CassandraCluster) string {
+	if cc.Spec.BackupEngine == v1alpha1.BackupEngineMedusa {
+		return "Medusa"
+	}
+
+	return "Icarus"
+}
+
+// AllNodesReady returns true if the backup sidecars of all nodes of the DCs are ready. All DCs are checked if none are given.
+// Medusa backs up and restores only the nodes that get the request, so its requests wait for all nodes.
+func AllNodesReady(cc *v1alpha1.CassandraCluster, readyPods []v1.Pod, dcs ...string) bool {
+	for _, dc := range cc.Spec.DCs {
+		if len(dcs) != 0 && !util.Contains(dcs, dc.Name) {
+			continue
+		}
+
+		var replicas, ready int32
+		if dc.Replicas != nil {
+			replicas = *dc.Replicas
+		}
+		for _, pod := range readyPods {
+			if pod.Labels[v1alpha1.CassandraClusterDC] == dc.Name {
+				ready++
+			}
+		}
+
+		if ready < replicas {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ReadyPods returns the Cassandra pods of the cluster which have a ready backup sidecar, sorted by name
+func ReadyPods(ctx context.Context, c ctrlclient.Client, cc *v1alpha1.CassandraCluster) ([]v1.Pod, error) {
+	podList := &v1.PodList{}
+	err := c.List(ctx, podList, ctrlclient.InNamespace(cc.Namespace), ctrlclient.MatchingLabels(labels.Cassandra(cc)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list Cassandra pods")
+	}
+
+	containerName := icarus.ContainerName
+	if cc.Spec.BackupEngine == v1alpha1.BackupEngineMedusa {
+		containerName = medusa.ContainerName
+	}
+
+	var readyPods []v1.Pod
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil || len(pod.Labels[v1alpha1.CassandraClusterDC]) == 0 {
+			continue
+		}
+
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name == containerName && containerStatus.Ready {
+				readyPods = append(readyPods, pod)
+				break
+			}
+		}
+	}
+
+	sort.Slice(readyPods, func(i, j int) bool {
+		return readyPods[i].Name < readyPods[j].Name
+	})
+
+	return readyPods, nil
+}
+
+// NodeIPs returns the addresses JMX of the pods is available on.
+// The broadcast addresses are preferred over the pod IPs as that's what Cassandra advertises in the JMX stubs
+func NodeIPs(ctx context.Context, c ctrlclient.Client, cc *v1alpha1.CassandraCluster, pods []v1.Pod) (map[string]string, error) {
+	ips := make(map[string]string, len(pods))
+	for _, pod := range pods {
+		ips[pod.Name] = pod.Status.PodIP
+	}
+
+	podIPs := &v1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Name: names.PodIPsConfigMap(cc.Name), Namespace: cc.Namespace}, podIPs)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ips, nil
+		}
+		return nil, errors.Wrap(err, "can't get pod IPs configmap")
+	}
+
+	for podName := range ips {
+		if broadcastIP := podIPs.Data[podName]; len(broadcastIP) != 0 {
+			ips[podName] = broadcastIP
+		}
+	}
+
+	return ips, nil
+}
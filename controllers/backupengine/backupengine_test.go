@@ -0,0 +1,134 @@
+package backupengine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/medusa/fake"
+)
+
+func TestMedusaEngine(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := fake.NewServer()
+	defer server.Close()
+
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec:       v1alpha1.CassandraClusterSpec{BackupEngine: v1alpha1.BackupEngineMedusa},
+	}
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-cassandra-dc1-0", Labels: map[string]string{v1alpha1.CassandraClusterDC: "dc1"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-cassandra-dc1-1", Labels: map[string]string{v1alpha1.CassandraClusterDC: "dc1"}}},
+	}
+
+	var addrs []string
+	engine := backupengine.New(cc, backupengine.Clients{
+		Icarus: func(string) icarus.Icarus {
+			t.Fatal("the icarus client should not be used")
+			return nil
+		},
+		Medusa: func(addr string) medusa.Medusa {
+			addrs = append(addrs, addr)
+			return medusa.New(server.Addr())
+		},
+	}, pods[0], pods)
+	g.Expect(addrs).To(Equal([]string{
+		"test-cluster-cassandra-dc1-0.test-cluster-cassandra-dc1.default.svc.cluster.local:50051",
+		"test-cluster-cassandra-dc1-1.test-cluster-cassandra-dc1.default.svc.cluster.local:50051",
+	}))
+	g.Expect(backupengine.Name(cc)).To(Equal("Medusa"))
+
+	backup, err := engine.Backup(ctx, icarus.BackupRequest{SnapshotTag: "snapshot-1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backup.ID).To(Equal("snapshot-1"))
+	g.Expect(backup.State).To(Equal(icarus.StateRunning))
+	g.Expect(server.Requests("AsyncBackup")).To(Equal(2))
+
+	backups, err := engine.Backups(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups).To(HaveLen(1))
+	g.Expect(backups[0].ID).To(Equal("snapshot-1"))
+	g.Expect(backups[0].State).To(Equal(icarus.StateRunning))
+	g.Expect(backups[0].Progress).To(BeZero())
+
+	server.SetBackupStatus("snapshot-1", medusa.StatusType_SUCCESS)
+	backups, err = engine.Backups(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups[0].State).To(Equal(icarus.StateCompleted))
+	g.Expect(backups[0].Progress).To(BeEquivalentTo(1))
+	g.Expect(backups[0].CompletionTime).ToNot(BeEmpty())
+
+	server.AddBackup(&medusa.BackupSummary{BackupName: "snapshot-0", TotalNodes: 3, FinishedNodes: 1, Status: medusa.StatusType_FAILED})
+	backups, err = engine.Backups(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups).To(HaveLen(2))
+	g.Expect(backups[1].State).To(Equal(icarus.StateFailed))
+	g.Expect(backups[1].Errors).To(HaveLen(1))
+	g.Expect(backups[1].Errors[0].Message).To(Equal("backup finished on 1 of 3 nodes"))
+
+	g.Expect(engine.Restore(ctx, icarus.RestoreRequest{SnapshotTag: "snapshot-1"})).ToNot(Succeed(), "the restore key is required")
+	g.Expect(engine.Restore(ctx, icarus.RestoreRequest{SnapshotTag: "snapshot-1", DC: "dc2", RestoreKey: "restore-key"})).ToNot(Succeed())
+	g.Expect(engine.Restore(ctx, icarus.RestoreRequest{SnapshotTag: "snapshot-1", DC: "dc1", RestoreKey: "restore-key"})).To(Succeed())
+	g.Expect(server.PreparedRestores()).To(HaveLen(2))
+	for _, req := range server.PreparedRestores() {
+		g.Expect(req.BackupName).To(Equal("snapshot-1"))
+		g.Expect(req.Datacenter).To(Equal("dc1"))
+		g.Expect(req.RestoreKey).To(Equal("restore-key"))
+	}
+
+	restores, err := engine.Restores(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restores).To(BeEmpty())
+}
+
+func TestAllNodesReady(t *testing.T) {
+	g := NewWithT(t)
+
+	cc := &v1alpha1.CassandraCluster{
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{{Name: "dc1", Replicas: proto.Int32(2)}, {Name: "dc2", Replicas: proto.Int32(1)}},
+		},
+	}
+	dc1Pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1alpha1.CassandraClusterDC: "dc1"}}}
+	dc2Pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1alpha1.CassandraClusterDC: "dc2"}}}
+
+	g.Expect(backupengine.AllNodesReady(cc, []v1.Pod{dc1Pod, dc1Pod, dc2Pod})).To(BeTrue())
+	g.Expect(backupengine.AllNodesReady(cc, []v1.Pod{dc1Pod, dc2Pod})).To(BeFalse())
+	g.Expect(backupengine.AllNodesReady(cc, []v1.Pod{dc1Pod, dc2Pod}, "dc2")).To(BeTrue())
+	g.Expect(backupengine.AllNodesReady(cc, []v1.Pod{dc1Pod, dc1Pod}, "dc1", "dc2")).To(BeFalse())
+}
+
+func TestMedusaEngineAlreadyStarted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := fake.NewServer()
+	defer server.Close()
+
+	cc := &v1alpha1.CassandraCluster{Spec: v1alpha1.CassandraClusterSpec{BackupEngine: v1alpha1.BackupEngineMedusa}}
+	clients := backupengine.Clients{Medusa: func(string) medusa.Medusa { return medusa.New(server.Addr()) }}
+	engine := backupengine.New(cc, clients, v1.Pod{}, []v1.Pod{{}})
+
+	server.Errors["AsyncBackup"] = status.Error(codes.AlreadyExists, "already exists")
+	_, err := engine.Backup(ctx, icarus.BackupRequest{SnapshotTag: "snapshot-1"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	server.Errors["AsyncBackup"] = status.Error(codes.Internal, "no space left on device")
+	_, err = engine.Backup(ctx, icarus.BackupRequest{SnapshotTag: "snapshot-1"})
+	g.Expect(err).To(HaveOccurred())
+
+	engine = backupengine.New(cc, clients, v1.Pod{}, nil)
+	_, err = engine.Backup(ctx, icarus.BackupRequest{SnapshotTag: "snapshot-1"})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,145 @@
+package backupengine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+// medusaEngine maps the backup requests to Medusa gRPC calls. Medusa backs up a single node per request,
+// so the request is sent to every node. The backup name is the snapshot tag and is used as the operation ID.
+type medusaEngine struct {
+	nodes []medusaNode
+}
+
+type medusaNode struct {
+	dc     string
+	client medusa.Medusa
+}
+
+func newMedusaEngine(nodes []medusaNode) Engine {
+	return &medusaEngine{nodes: nodes}
+}
+
+func (e *medusaEngine) Backup(ctx context.Context, req icarus.BackupRequest) (icarus.Backup, error) {
+	if len(e.nodes) == 0 {
+		return icarus.Backup{}, errors.New("no Medusa sidecar to send the backup request to")
+	}
+
+	for _, node := range e.nodes {
+		err := node.client.AsyncBackup(ctx, req.SnapshotTag, medusa.BackupModeDifferential)
+		if err != nil && !medusa.IsAlreadyExists(err) { // the node has already started the backup
+			return icarus.Backup{}, errors.Wrap(err, "backup request failed")
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	return icarus.Backup{
+		ID:            req.SnapshotTag,
+		Type:          "backup",
+		SnapshotTag:   req.SnapshotTag,
+		State:         icarus.StateRunning,
+		GlobalRequest: true,
+		CreationTime:  now,
+		StartTime:     now,
+	}, nil
+}
+
+// Backups returns the backups found in the storage. Every node lists the backups of the whole cluster,
+// the next node is asked only if the request fails.
+func (e *medusaEngine) Backups(ctx context.Context) ([]icarus.Backup, error) {
+	err := errors.New("no Medusa sidecar to get the backups from")
+	for _, node := range e.nodes {
+		var summaries []*medusa.BackupSummary
+		summaries, err = node.client.GetBackups(ctx)
+		if err != nil {
+			continue
+		}
+
+		backups := make([]icarus.Backup, 0, len(summaries))
+		for _, summary := range summaries {
+			backups = append(backups, medusaBackup(summary))
+		}
+		return backups, nil
+	}
+
+	return nil, err
+}
+
+// Restore prepares the restore of the backup on the nodes of the restored DCs. Medusa applies the restore when the nodes
+// are restarted with the restore key, the nodes of a DC should be restarted only once the restore is prepared on all of them.
+func (e *medusaEngine) Restore(ctx context.Context, req icarus.RestoreRequest) error {
+	if len(req.RestoreKey) == 0 {
+		return errors.New("the restore key is required to prepare a Medusa restore")
+	}
+
+	prepared := 0
+	for _, node := range e.nodes {
+		if len(req.DC) != 0 && !util.Contains(strings.Split(req.DC, ","), node.dc) {
+			continue
+		}
+
+		if err := node.client.PrepareRestore(ctx, req.SnapshotTag, node.dc, req.RestoreKey); err != nil {
+			return errors.Wrapf(err, "failed to prepare the restore of backup %s", req.SnapshotTag)
+		}
+		prepared++
+	}
+
+	if prepared == 0 {
+		return errors.Errorf("no Medusa sidecar in DC %q to prepare the restore on", req.DC)
+	}
+
+	return nil
+}
+
+// Restores returns no operations as Medusa doesn't track the restores: they are applied by the nodes on start.
+// The progress of a restore is the rollout of the restarted nodes.
+func (e *medusaEngine) Restores(ctx context.Context) ([]icarus.Restore, error) {
+	return nil, nil
+}
+
+func medusaBackup(summary *medusa.BackupSummary) icarus.Backup {
+	backup := icarus.Backup{
+		ID:            summary.BackupName,
+		Type:          "backup",
+		SnapshotTag:   summary.BackupName,
+		GlobalRequest: true,
+		CreationTime:  unixTime(summary.StartTime),
+		StartTime:     unixTime(summary.StartTime),
+		State:         icarus.StateRunning,
+	}
+
+	if summary.TotalNodes > 0 {
+		backup.Progress = float64(summary.FinishedNodes) / float64(summary.TotalNodes)
+	}
+
+	switch summary.Status {
+	case medusa.StatusType_SUCCESS:
+		backup.State = icarus.StateCompleted
+		backup.Progress = 1
+		backup.CompletionTime = unixTime(summary.FinishTime)
+	case medusa.StatusType_FAILED:
+		backup.State = icarus.StateFailed
+		backup.Errors = []icarus.Error{{
+			Source:  "medusa",
+			Message: fmt.Sprintf("backup finished on %d of %d nodes", summary.FinishedNodes, summary.TotalNodes),
+		}}
+	}
+
+	return backup
+}
+
+func unixTime(seconds int64) string {
+	if seconds == 0 {
+		return ""
+	}
+
+	return time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,298 @@
+package backupengine
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+)
+
+// Poller polls the backup and restore operations of the clusters that have active CassandraBackups or
+// CassandraRestores. The operation lists are cached per cluster, so the controllers don't request them from the
+// sidecar on every reconcile. The watching objects are reconciled through the reconcile channels
+// when the operations change or the engine can't be polled.
+type Poller struct {
+	sync.Mutex
+	clusters      map[types.UID]*clusterOperations
+	log           *zap.SugaredLogger
+	backupEvents  chan event.GenericEvent
+	restoreEvents chan event.GenericEvent
+	interval      time.Duration
+	maxInterval   time.Duration
+}
+
+type clusterOperations struct {
+	name            types.NamespacedName
+	engine          Engine
+	backups         []icarus.Backup
+	restores        []icarus.Restore
+	backupsPolled   bool
+	restoresPolled  bool
+	backupWatchers  map[types.NamespacedName]client.Object
+	restoreWatchers map[types.NamespacedName]client.Object
+	interval        time.Duration
+	nextPoll        time.Time
+	polling         bool
+}
+
+// NewPoller creates a poller that polls every interval while the operations change
+// and backs off up to maxInterval while they don't
+func NewPoller(backupEvents, restoreEvents chan event.GenericEvent, interval, maxInterval time.Duration, logr *zap.SugaredLogger) *Poller {
+	return &Poller{
+		clusters:      make(map[types.UID]*clusterOperations),
+		log:           logr,
+		backupEvents:  backupEvents,
+		restoreEvents: restoreEvents,
+		interval:      interval,
+		maxInterval:   maxInterval,
+	}
+}
+
+// Backups returns the backups of the cluster and registers the CassandraBackup to be reconciled when they change.
+// The engine is used for the next polls, so the latest chosen coordinator is polled.
+func (p *Poller) Backups(ctx context.Context, cc *v1alpha1.CassandraCluster, engine Engine, cb *v1alpha1.CassandraBackup) ([]icarus.Backup, error) {
+	p.Lock()
+	operations := p.watch(cc, engine)
+	p.unwatch(client.ObjectKeyFromObject(cb), cc.UID, false)
+	operations.backupWatchers[client.ObjectKeyFromObject(cb)] = cb
+	if operations.backupsPolled {
+		backups := copyBackups(operations.backups)
+		p.Unlock()
+		return backups, nil
+	}
+	p.Unlock()
+
+	backups, err := engine.Backups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	operations.backups, operations.backupsPolled = copyBackups(backups), true
+	return backups, nil
+}
+
+// Restores returns the restores of the cluster and registers the CassandraRestore to be reconciled when they change
+func (p *Poller) Restores(ctx context.Context, cc *v1alpha1.CassandraCluster, engine Engine, cr *v1alpha1.CassandraRestore) ([]icarus.Restore, error) {
+	p.Lock()
+	operations := p.watch(cc, engine)
+	p.unwatch(client.ObjectKeyFromObject(cr), cc.UID, true)
+	operations.restoreWatchers[client.ObjectKeyFromObject(cr)] = cr
+	if operations.restoresPolled {
+		restores := copyRestores(operations.restores)
+		p.Unlock()
+		return restores, nil
+	}
+	p.Unlock()
+
+	restores, err := engine.Restores(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	operations.restores, operations.restoresPolled = copyRestores(restores), true
+	return restores, nil
+}
+
+// Refresh drops the cached operations of the cluster and resets the backoff. Used after a new request is sent.
+func (p *Poller) Refresh(cc *v1alpha1.CassandraCluster) {
+	p.Lock()
+	defer p.Unlock()
+
+	operations, ok := p.clusters[cc.UID]
+	if !ok {
+		return
+	}
+
+	operations.backupsPolled, operations.restoresPolled = false, false
+	operations.interval = p.interval
+	operations.nextPoll = time.Now().Add(p.interval)
+}
+
+// ForgetBackup stops reconciling the CassandraBackup on operation changes. The cluster is not polled anymore
+// once nothing watches it.
+func (p *Poller) ForgetBackup(key types.NamespacedName) {
+	p.Lock()
+	defer p.Unlock()
+	p.unwatch(key, "", false)
+}
+
+// ForgetRestore stops reconciling the CassandraRestore on operation changes
+func (p *Poller) ForgetRestore(key types.NamespacedName) {
+	p.Lock()
+	defer p.Unlock()
+	p.unwatch(key, "", true)
+}
+
+// Start polls the watched clusters until the context is done. Implements manager.Runnable.
+func (p *Poller) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			p.Lock()
+			for _, operations := range p.clusters {
+				if operations.polling || now.Before(operations.nextPoll) {
+					continue
+				}
+				operations.polling = true
+				go p.poll(ctx, operations)
+			}
+			p.Unlock()
+		}
+	}
+}
+
+func (p *Poller) watch(cc *v1alpha1.CassandraCluster, engine Engine) *clusterOperations {
+	operations, ok := p.clusters[cc.UID]
+	if !ok {
+		operations = &clusterOperations{
+			name:            types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace},
+			backupWatchers:  make(map[types.NamespacedName]client.Object),
+			restoreWatchers: make(map[types.NamespacedName]client.Object),
+			interval:        p.interval,
+			nextPoll:        time.Now().Add(p.interval),
+		}
+		p.clusters[cc.UID] = operations
+	}
+
+	operations.engine = engine
+	return operations
+}
+
+// unwatch removes the object from the watchers of all clusters except the given one
+func (p *Poller) unwatch(key types.NamespacedName, except types.UID, restore bool) {
+	for uid, operations := range p.clusters {
+		if uid == except {
+			continue
+		}
+
+		if restore {
+			delete(operations.restoreWatchers, key)
+			if len(operations.restoreWatchers) == 0 {
+				operations.restores, operations.restoresPolled = nil, false
+			}
+		} else {
+			delete(operations.backupWatchers, key)
+			if len(operations.backupWatchers) == 0 {
+				operations.backups, operations.backupsPolled = nil, false
+			}
+		}
+
+		if len(operations.backupWatchers) == 0 && len(operations.restoreWatchers) == 0 {
+			delete(p.clusters, uid)
+		}
+	}
+}
+
+func (p *Poller) poll(ctx context.Context, operations *clusterOperations) {
+	p.Lock()
+	engine := operations.engine
+	pollBackups := len(operations.backupWatchers) != 0
+	pollRestores := len(operations.restoreWatchers) != 0
+	p.Unlock()
+
+	var (
+		backups  []icarus.Backup
+		restores []icarus.Restore
+		err      error
+	)
+	if pollBackups {
+		backups, err = engine.Backups(ctx)
+	}
+	if pollRestores && err == nil {
+		restores, err = engine.Restores(ctx)
+	}
+
+	p.Lock()
+	operations.polling = false
+	var notify []client.Object
+	var running bool
+	if err != nil {
+		// the coordinator may be gone, the reconcile chooses a new one and gets the operations itself
+		p.log.Warnf("Failed to poll the backup operations of cluster %s: %s", operations.name, err.Error())
+		operations.backupsPolled, operations.restoresPolled = false, false
+		notify = append(watchers(operations.backupWatchers), watchers(operations.restoreWatchers)...)
+	} else {
+		if pollBackups {
+			if !operations.backupsPolled || !reflect.DeepEqual(operations.backups, backups) {
+				notify = append(notify, watchers(operations.backupWatchers)...)
+			}
+			operations.backups, operations.backupsPolled = copyBackups(backups), true
+			for _, backup := range backups {
+				running = running || inProgress(backup.State)
+			}
+		}
+		if pollRestores {
+			if !operations.restoresPolled || !reflect.DeepEqual(operations.restores, restores) {
+				notify = append(notify, watchers(operations.restoreWatchers)...)
+			}
+			operations.restores, operations.restoresPolled = copyRestores(restores), true
+			for _, restore := range restores {
+				running = running || inProgress(restore.State)
+			}
+		}
+	}
+
+	switch {
+	case len(notify) != 0:
+		operations.interval = p.interval
+	case running: // the operations progress, but slower than polled
+		operations.interval *= 2
+	default: // nothing to wait for until a new request is sent
+		operations.interval = p.maxInterval
+	}
+	if operations.interval > p.maxInterval {
+		operations.interval = p.maxInterval
+	}
+	operations.nextPoll = time.Now().Add(operations.interval)
+	p.Unlock()
+
+	for _, obj := range notify {
+		ch := p.backupEvents
+		if _, ok := obj.(*v1alpha1.CassandraRestore); ok {
+			ch = p.restoreEvents
+		}
+
+		select {
+		case ch <- event.GenericEvent{Object: obj}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func watchers(objects map[types.NamespacedName]client.Object) []client.Object {
+	list := make([]client.Object, 0, len(objects))
+	for _, obj := range objects {
+		list = append(list, obj)
+	}
+	return list
+}
+
+func inProgress(state string) bool {
+	return state == icarus.StatePending || state == icarus.StateRunning
+}
+
+// the clients may reuse the returned slices, so the cache keeps its own copy to detect changes
+func copyBackups(backups []icarus.Backup) []icarus.Backup {
+	return append([]icarus.Backup(nil), backups...)
+}
+
+func copyRestores(restores []icarus.Restore) []icarus.Restore {
+	return append([]icarus.Restore(nil), restores...)
+}
@@ -0,0 +1,94 @@
+package backupengine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/icarus/fake"
+)
+
+func TestPoller(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := fake.NewServer()
+	defer server.Close()
+
+	backupEvents := make(chan event.GenericEvent)
+	restoreEvents := make(chan event.GenericEvent)
+	poller := backupengine.NewPoller(backupEvents, restoreEvents, 10*time.Millisecond, 40*time.Millisecond, zap.NewNop().Sugar())
+	go func() {
+		_ = poller.Start(ctx)
+	}()
+
+	cc := &v1alpha1.CassandraCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default", UID: "uid-1"}}
+	cb := &v1alpha1.CassandraBackup{ObjectMeta: metav1.ObjectMeta{Name: "test-backup", Namespace: "default"}}
+	engine := icarus.New(server.URL)
+
+	backups, err := poller.Backups(ctx, cc, engine, cb)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups).To(BeEmpty())
+	g.Consistently(backupEvents, 100*time.Millisecond).ShouldNot(Receive(), "no changes, no reconciles")
+
+	backup, err := engine.Backup(ctx, icarus.BackupRequest{Type: "backup", SnapshotTag: "test-backup", GlobalRequest: true})
+	g.Expect(err).ToNot(HaveOccurred())
+	poller.Refresh(cc)
+
+	var e event.GenericEvent
+	g.Eventually(backupEvents, time.Second).Should(Receive(&e))
+	g.Expect(e.Object.GetName()).To(Equal("test-backup"))
+	backups, err = poller.Backups(ctx, cc, engine, cb)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups).To(HaveLen(1))
+	g.Expect(backups[0].State).To(Equal(icarus.StateRunning))
+
+	server.SetBackupState(backup.ID, icarus.StateCompleted)
+	g.Eventually(backupEvents, time.Second).Should(Receive())
+	backups, err = poller.Backups(ctx, cc, engine, cb)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups[0].State).To(Equal(icarus.StateCompleted))
+
+	poller.ForgetBackup(types.NamespacedName{Name: "test-backup", Namespace: "default"})
+	server.SetBackupState(backup.ID, icarus.StateFailed)
+	g.Consistently(backupEvents, 100*time.Millisecond).ShouldNot(Receive(), "the backup is not watched anymore")
+	g.Expect(restoreEvents).ToNot(Receive())
+}
+
+func TestPollerError(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := fake.NewServer()
+
+	backupEvents := make(chan event.GenericEvent)
+	restoreEvents := make(chan event.GenericEvent)
+	poller := backupengine.NewPoller(backupEvents, restoreEvents, 10*time.Millisecond, 40*time.Millisecond, zap.NewNop().Sugar())
+	go func() {
+		_ = poller.Start(ctx)
+	}()
+
+	cc := &v1alpha1.CassandraCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default", UID: "uid-1"}}
+	cr := &v1alpha1.CassandraRestore{ObjectMeta: metav1.ObjectMeta{Name: "test-restore", Namespace: "default"}}
+	engine := icarus.New(server.URL)
+
+	_, err := poller.Restores(ctx, cc, engine, cr)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	server.Close() // the coordinator is gone, the restore is reconciled to choose a new one
+	var e event.GenericEvent
+	g.Eventually(restoreEvents, time.Second).Should(Receive(&e))
+	g.Expect(e.Object.GetName()).To(Equal("test-restore"))
+
+	_, err = poller.Restores(ctx, cc, engine, cr)
+	g.Expect(err).To(HaveOccurred(), "the operations are requested again after a failed poll")
+}
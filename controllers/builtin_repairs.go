@@ -0,0 +1,474 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/builtinrepair"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/cron"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+const (
+	builtinRepairStateKey       = "state.json"
+	builtinRepairScheduledCause = "scheduled run"
+)
+
+// repairRunner starts one-off repairs of a keyspace
+type repairRunner interface {
+	RunRepair(ctx context.Context, keyspace, cause string) error
+}
+
+// builtinRepairRunner adds one-off repair runs to the builtin repairs state. The runs are started by reconcileBuiltinRepairs.
+type builtinRepairRunner struct {
+	r  *CassandraClusterReconciler
+	cc *dbv1alpha1.CassandraCluster
+}
+
+func builtinRepairEnabled(cc *dbv1alpha1.CassandraCluster) bool {
+	return cc.Spec.RepairEngine == dbv1alpha1.RepairEngineBuiltin
+}
+
+func (b *builtinRepairRunner) RunRepair(ctx context.Context, keyspace, cause string) error {
+	cm, state, err := b.r.builtinRepairState(ctx, b.cc)
+	if err != nil {
+		return err
+	}
+
+	if _, found := state.ActiveRun(keyspace, ""); found {
+		b.r.Log.Infof("Repair run of keyspace %s is already in progress", keyspace)
+		return nil
+	}
+
+	state.AddRun(builtinrepair.Run{
+		Cause:       cause,
+		Keyspace:    keyspace,
+		Parallelism: b.cc.Spec.Reaper.RepairParallelism,
+		ThreadCount: b.cc.Spec.Reaper.RepairThreadCount,
+		Incremental: b.cc.Spec.Reaper.IncrementalRepair,
+	}, time.Now())
+
+	return b.r.saveBuiltinRepairState(ctx, cm, state)
+}
+
+// reconcileBuiltinRepairs runs the repair schedules and the one-off repairs of the cluster through JMX without Reaper.
+// The running segments are checked by the segment watcher between the reconciles.
+func (r *CassandraClusterReconciler) reconcileBuiltinRepairs(ctx context.Context, cc *dbv1alpha1.CassandraCluster, cqlClient cql.CqlClient, podList *v1.PodList, nodeList *v1.NodeList) error {
+	cm, state, err := r.builtinRepairState(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	keyspaces, err := cqlClient.GetKeyspacesInfo()
+	if err != nil {
+		return errors.Wrap(err, "can't get keyspace info")
+	}
+
+	now := time.Now()
+	if len(cm.Data[builtinRepairStateKey]) == 0 {
+		// system_auth keyspace has been modified to bootstrap the cluster before the repairs could run
+		r.Log.Info("Starting a repair for system_auth keyspace")
+		state.AddRun(builtinrepair.Run{
+			Cause:       repairCauseBuiltinRepairInit,
+			Keyspace:    keyspaceSystemAuth,
+			Parallelism: cc.Spec.Reaper.RepairParallelism,
+			ThreadCount: cc.Spec.Reaper.RepairThreadCount,
+		}, now)
+	}
+
+	if err = r.reconcileIncrementalRepairMigrations(ctx, cc, &builtinMigrationRepairs{cc: cc, state: state, now: now}); err != nil {
+		return errors.Wrap(err, "Failed to reconcile incremental repair migrations")
+	}
+
+	r.reconcileBuiltinRepairSchedules(cc, state, now)
+
+	previousStates := make(map[string]string, len(state.Runs))
+	for i, run := range state.Runs {
+		previousStates[run.ID] = run.State
+		if run.State == reaper.RepairStateNotStarted {
+			state.Runs[i].CoordinatorDC = coordinatorDC(cc, keyspaces, run)
+		}
+	}
+
+	if state.Active() {
+		if err = r.processBuiltinRepairs(ctx, cc, state, podList, nodeList, now); err != nil {
+			return err
+		}
+	} else {
+		r.BuiltinRepairs.Forget(types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace})
+	}
+
+	for _, run := range state.Runs {
+		if run.State == previousStates[run.ID] {
+			continue
+		}
+
+		switch run.State {
+		case reaper.RepairStateDone:
+			r.Events.Normal(cc, events.EventRepairRunCompleted, fmt.Sprintf("Repair run %s of keyspace %s completed", run.ID, run.Keyspace))
+		case reaper.RepairStateError:
+			r.Events.Warning(cc, events.EventRepairRunFailed, fmt.Sprintf("Repair run %s of keyspace %s failed: %s", run.ID, run.Keyspace, run.LastError))
+		}
+	}
+
+	state.Prune()
+	if err = r.saveBuiltinRepairState(ctx, cm, state); err != nil {
+		return err
+	}
+
+	repairSchedules, repairRuns := builtinReaperRepairs(cc, state, now)
+	cc.Status.RepairSchedules = nil
+	if cc.Spec.Reaper.RepairSchedules.Enabled && len(cc.Spec.Reaper.RepairSchedules.Repairs) > 0 {
+		cc.Status.RepairSchedules = repairSchedulesStatus(cc.Spec.Reaper.RepairSchedules.Repairs, repairSchedules, repairRuns)
+	}
+
+	if err = r.reconcileRepairCoverage(cc, cqlClient, repairRuns); err != nil {
+		return errors.Wrap(err, "Failed to reconcile repair coverage")
+	}
+
+	return nil
+}
+
+// reconcileBuiltinRepairSchedules syncs the schedules with the spec and starts the repair runs of the due schedules
+func (r *CassandraClusterReconciler) reconcileBuiltinRepairSchedules(cc *dbv1alpha1.CassandraCluster, state *builtinrepair.State, now time.Time) {
+	var desiredRepairs []dbv1alpha1.RepairSchedule
+	if cc.Spec.Reaper.RepairSchedules.Enabled {
+		desiredRepairs = cc.Spec.Reaper.RepairSchedules.Repairs
+	}
+
+	schedules := make([]builtinrepair.Schedule, 0, len(desiredRepairs))
+	for _, repair := range desiredRepairs {
+		if incrementalRepairMigrationPending(cc, repair) {
+			r.Log.Debugf("Keyspace %s is not migrated to incremental repairs yet, not scheduling its repairs", repair.Keyspace)
+			continue
+		}
+
+		schedule := builtinrepair.Schedule{
+			ID:          builtinRepairScheduleID(repair),
+			Keyspace:    repair.Keyspace,
+			Tables:      repair.Tables,
+			Incremental: repair.IncrementalRepair,
+			Spec:        util.Sha1(fmt.Sprintf("%s/%s/%d", repair.Cron, repair.ScheduleTriggerTime, repair.ScheduleDaysBetween)),
+		}
+
+		for _, existingSchedule := range state.Schedules {
+			if existingSchedule.ID == schedule.ID && existingSchedule.Spec == schedule.Spec {
+				schedule.NextActivation = existingSchedule.NextActivation
+			}
+		}
+
+		if schedule.NextActivation.IsZero() {
+			activation, err := r.firstActivation(repair, now)
+			if err != nil {
+				r.Log.Warnf("Invalid schedule of the repair schedule for keyspace %s: %s", repair.Keyspace, err.Error())
+				continue
+			}
+			r.Log.Infof("Scheduling repairs of keyspace %s starting at %s", repair.Keyspace, activation.Format(time.RFC3339))
+			schedule.NextActivation = activation
+		}
+
+		if !now.Before(schedule.NextActivation) && repairAllowed(repair, now) && cc.Status.RepairsPause == nil {
+			if run, found := state.ActiveRun(repair.Keyspace, schedule.ID); found {
+				r.Log.Infof("Previous repair run %s of keyspace %s is still in progress, skipping the scheduled run", run.ID, repair.Keyspace)
+			} else {
+				run = state.AddRun(builtinrepair.Run{
+					ScheduleID:  schedule.ID,
+					Cause:       builtinRepairScheduledCause,
+					Keyspace:    repair.Keyspace,
+					Tables:      repair.Tables,
+					Datacenters: repair.Datacenters,
+					Incremental: repair.IncrementalRepair,
+					Parallelism: repair.RepairParallelism,
+					ThreadCount: repair.RepairThreadCount,
+				}, now)
+				r.Log.Infof("Created scheduled repair run %s of keyspace %s", run.ID, repair.Keyspace)
+			}
+
+			schedule.NextActivation = nextActivation(repair, schedule.NextActivation, now)
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	state.Schedules = schedules
+}
+
+func (r *CassandraClusterReconciler) processBuiltinRepairs(ctx context.Context, cc *dbv1alpha1.CassandraCluster, state *builtinrepair.State, podList *v1.PodList, nodeList *v1.NodeList, now time.Time) error {
+	broadcastAddresses, err := getBroadcastAddresses(cc, podList.Items, nodeList.Items)
+	if err != nil {
+		return err
+	}
+
+	nodeDCs := make(map[string]string, len(podList.Items))
+	var readyNodes []string
+	for _, pod := range podList.Items {
+		nodeDCs[broadcastAddresses[pod.Name]] = pod.Labels[dbv1alpha1.CassandraClusterDC]
+		if podReady(pod) {
+			readyNodes = append(readyNodes, broadcastAddresses[pod.Name])
+		}
+	}
+
+	if len(readyNodes) == 0 {
+		return errors.New("no ready nodes to run the repairs")
+	}
+	sort.Strings(readyNodes)
+
+	adminSecret, err := r.adminRoleSecret(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	roleName, rolePassword, err := extractCredentials(adminSecret)
+	if err != nil {
+		return err
+	}
+
+	nctl := r.NodectlClient(names.JolokiaURL(cc).String(), roleName, rolePassword, r.Log)
+	// the segments are checked with getParentRepairStatus which is available since Cassandra 4.0
+	major, minor, patch, err := nctl.Version(ctx, readyNodes[0])
+	if err != nil {
+		return errors.Wrap(err, "can't get the Cassandra version")
+	}
+
+	if major < 4 {
+		errMsg := fmt.Sprintf("The builtin repair engine requires Cassandra 4.0 or newer, the cluster runs Cassandra %d.%d.%d. The repairs are not run", major, minor, patch)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cc, events.EventRepairEngineUnsupported, errMsg)
+		return nil
+	}
+
+	ring, err := nctl.TokenRing(ctx, readyNodes[0])
+	if err != nil {
+		return errors.Wrap(err, "can't get the token ring")
+	}
+
+	runner := builtinrepair.NewRunner(nctl, builtinrepair.Config{
+		MaxConcurrentSegments: int(cc.Spec.BuiltinRepair.MaxConcurrentSegments),
+		SegmentCountPerNode:   int(cc.Spec.BuiltinRepair.SegmentCountPerNode),
+		SegmentTimeout:        time.Duration(cc.Spec.BuiltinRepair.SegmentTimeoutMins) * time.Minute,
+	}, r.Log)
+
+	allowed := func(run builtinrepair.Run) bool {
+		if cc.Status.RepairsPause != nil {
+			return false
+		}
+
+		for _, repair := range cc.Spec.Reaper.RepairSchedules.Repairs {
+			if builtinRepairScheduleID(repair) == run.ScheduleID {
+				return repairAllowed(repair, now)
+			}
+		}
+
+		return true
+	}
+
+	if err = runner.Process(ctx, state, ring, nodeDCs, allowed, now); err != nil {
+		return err
+	}
+
+	r.BuiltinRepairs.Watch(cc, nctl, state)
+	return nil
+}
+
+// coordinatorDC returns the datacenter which nodes coordinate the segments of the repair run.
+// The nodes must be replicas of the keyspace, so the first datacenter the keyspace is replicated to is used.
+func coordinatorDC(cc *dbv1alpha1.CassandraCluster, keyspaces []cql.Keyspace, run builtinrepair.Run) string {
+	if len(run.Datacenters) > 0 {
+		return run.Datacenters[0]
+	}
+
+	keyspace, found := getKeyspaceByName(keyspaces, run.Keyspace)
+	if !found || !strings.HasSuffix(keyspace.Replication["class"], "NetworkTopologyStrategy") {
+		return ""
+	}
+
+	for _, dc := range cc.Spec.DCs {
+		if rf, err := strconv.Atoi(keyspace.Replication[dc.Name]); err == nil && rf > 0 {
+			return dc.Name
+		}
+	}
+
+	return ""
+}
+
+func builtinRepairScheduleID(repair dbv1alpha1.RepairSchedule) string {
+	tables := append([]string(nil), repair.Tables...)
+	sort.Strings(tables)
+	return util.Sha1(repair.Keyspace + "/" + strings.Join(tables, ","))[:16]
+}
+
+// firstActivation returns the time the first repair run of the schedule is started
+func (r *CassandraClusterReconciler) firstActivation(repair dbv1alpha1.RepairSchedule, now time.Time) (time.Time, error) {
+	if len(repair.Cron) > 0 {
+		schedule, err := cron.Parse(repair.Cron)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return schedule.Next(now), nil
+	}
+
+	if len(repair.ScheduleTriggerTime) == 0 {
+		return now, nil
+	}
+
+	if err := rescheduleTimestamp(&repair); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.ParseInLocation(dbv1alpha1.ISOFormat, repair.ScheduleTriggerTime, time.UTC)
+}
+
+// nextActivation returns the first activation of the schedule after now
+func nextActivation(repair dbv1alpha1.RepairSchedule, lastActivation, now time.Time) time.Time {
+	if len(repair.Cron) > 0 {
+		if schedule, err := cron.Parse(repair.Cron); err == nil {
+			return schedule.Next(now)
+		}
+	}
+
+	daysBetween := int(repair.ScheduleDaysBetween)
+	if daysBetween < 1 {
+		daysBetween = 1
+	}
+
+	next := lastActivation
+	for !next.After(now) {
+		next = next.AddDate(0, 0, daysBetween)
+	}
+
+	return next
+}
+
+// builtinReaperRepairs represents the builtin repair schedules and runs the same way as Reaper does,
+// so that they are reported in the cluster status and tracked in the repair coverage the same way
+func builtinReaperRepairs(cc *dbv1alpha1.CassandraCluster, state *builtinrepair.State, now time.Time) ([]reaper.RepairSchedule, []reaper.RepairRun) {
+	repairSchedules := make([]reaper.RepairSchedule, 0, len(state.Schedules))
+	for _, schedule := range state.Schedules {
+		scheduleState := reaper.RepairScheduleStatePaused
+		for _, repair := range cc.Spec.Reaper.RepairSchedules.Repairs {
+			if builtinRepairScheduleID(repair) == schedule.ID && repairAllowed(repair, now) && cc.Status.RepairsPause == nil {
+				scheduleState = reaper.RepairScheduleStateActive
+			}
+		}
+
+		repairSchedules = append(repairSchedules, reaper.RepairSchedule{
+			ID:             schedule.ID,
+			Owner:          reaper.OwnerCassandraOperator,
+			State:          scheduleState,
+			KeyspaceName:   schedule.Keyspace,
+			Tables:         schedule.Tables,
+			NextActivation: schedule.NextActivation.UTC().Format(time.RFC3339),
+		})
+	}
+
+	repairRuns := make([]reaper.RepairRun, 0, len(state.Runs))
+	for _, run := range state.Runs {
+		cause := run.Cause
+		if len(run.ScheduleID) > 0 {
+			cause = reaper.ScheduledRunCause(run.ScheduleID)
+		}
+
+		repairRun := reaper.RepairRun{
+			ID:                run.ID,
+			State:             run.State,
+			ClusterName:       cc.Name,
+			KeyspaceName:      run.Keyspace,
+			ColumnFamilies:    run.Tables,
+			Owner:             reaper.OwnerCassandraOperator,
+			Cause:             cause,
+			IncrementalRepair: run.Incremental,
+			RepairParallelism: run.Parallelism,
+			TotalSegments:     int32(run.TotalSegments),
+			SegmentsRepaired:  int32(run.Repaired),
+			LastEvent:         run.LastError,
+			Datacenters:       run.Datacenters,
+			RepairThreadCount: run.ThreadCount,
+			CreationTime:      run.CreationTime.UTC().Format(time.RFC3339),
+		}
+
+		if run.StartTime != nil {
+			repairRun.StartTime = run.StartTime.UTC().Format(time.RFC3339)
+			endTime := now
+			if run.EndTime != nil {
+				repairRun.EndTime = run.EndTime.UTC().Format(time.RFC3339)
+				endTime = *run.EndTime
+			}
+			repairRun.Duration = endTime.Sub(*run.StartTime).Truncate(time.Second).String()
+		}
+
+		repairRuns = append(repairRuns, repairRun)
+	}
+
+	return repairSchedules, repairRuns
+}
+
+func (r *CassandraClusterReconciler) builtinRepairState(ctx context.Context, cc *dbv1alpha1.CassandraCluster) (*v1.ConfigMap, *builtinrepair.State, error) {
+	cm := &v1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: names.BuiltinRepairConfigMap(cc.Name), Namespace: cc.Namespace}, cm)
+	if err != nil && apierrors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      names.BuiltinRepairConfigMap(cc.Name),
+				Namespace: cc.Namespace,
+			},
+		}
+
+		if err = controllerutil.SetControllerReference(cc, cm, r.Scheme); err != nil {
+			return nil, nil, errors.Wrap(err, "can't set controller reference")
+		}
+
+		r.Log.Info("Creating builtin repair state configmap")
+		if err = r.Create(ctx, cm); err != nil {
+			return nil, nil, errors.Wrap(err, "can't create builtin repair state configmap")
+		}
+	} else if err != nil {
+		return nil, nil, errors.Wrap(err, "can't get builtin repair state configmap")
+	}
+
+	state := &builtinrepair.State{}
+	if data := cm.Data[builtinRepairStateKey]; len(data) > 0 {
+		if err = json.Unmarshal([]byte(data), state); err != nil {
+			return nil, nil, errors.Wrap(err, "can't unmarshal builtin repair state")
+		}
+	}
+
+	return cm, state, nil
+}
+
+func (r *CassandraClusterReconciler) saveBuiltinRepairState(ctx context.Context, cm *v1.ConfigMap, state *builtinrepair.State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrap(err, "can't marshal builtin repair state")
+	}
+
+	if cm.Data[builtinRepairStateKey] == string(data) {
+		return nil
+	}
+
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[builtinRepairStateKey] = string(data)
+	if err = r.Update(ctx, cm); err != nil {
+		return errors.Wrap(err, "can't update builtin repair state configmap")
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/builtinrepair"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	. "github.com/onsi/gomega"
+)
+
+func TestReconcileBuiltinRepairSchedules(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	reconciler := createBasicMockedReconciler()
+	now := time.Date(2021, 9, 20, 12, 0, 0, 0, time.UTC) // Monday
+	cc := &v1alpha1.CassandraCluster{
+		Spec: v1alpha1.CassandraClusterSpec{
+			RepairEngine: v1alpha1.RepairEngineBuiltin,
+			Reaper: &v1alpha1.Reaper{
+				RepairSchedules: v1alpha1.RepairSchedules{
+					Enabled: true,
+					Repairs: []v1alpha1.RepairSchedule{
+						{Keyspace: "ks1", Cron: "0 1 * * *", RepairParallelism: "PARALLEL"},
+						{Keyspace: "ks2", Tables: []string{"t1"}, ScheduleTriggerTime: "2021-09-20T10:00:00", ScheduleDaysBetween: 7},
+					},
+				},
+			},
+		},
+	}
+
+	state := &builtinrepair.State{}
+	reconciler.reconcileBuiltinRepairSchedules(cc, state, now)
+	asserts.Expect(state.Schedules).To(HaveLen(2))
+	asserts.Expect(state.Schedules[0].NextActivation).To(Equal(time.Date(2021, 9, 21, 1, 0, 0, 0, time.UTC)))
+	asserts.Expect(state.Schedules[1].Keyspace).To(Equal("ks2"))
+	asserts.Expect(state.Schedules[1].NextActivation.After(now)).To(BeTrue())
+	asserts.Expect(state.Runs).To(BeEmpty())
+
+	// the schedule is due
+	now = time.Date(2021, 9, 21, 1, 0, 0, 0, time.UTC)
+	reconciler.reconcileBuiltinRepairSchedules(cc, state, now)
+	asserts.Expect(state.Runs).To(HaveLen(1))
+	asserts.Expect(state.Runs[0].Keyspace).To(Equal("ks1"))
+	asserts.Expect(state.Runs[0].ScheduleID).To(Equal(state.Schedules[0].ID))
+	asserts.Expect(state.Runs[0].Parallelism).To(Equal("PARALLEL"))
+	asserts.Expect(state.Runs[0].State).To(Equal(reaper.RepairStateNotStarted))
+	asserts.Expect(state.Schedules[0].NextActivation).To(Equal(time.Date(2021, 9, 22, 1, 0, 0, 0, time.UTC)))
+
+	// the previous run is still in progress
+	now = time.Date(2021, 9, 22, 1, 0, 0, 0, time.UTC)
+	reconciler.reconcileBuiltinRepairSchedules(cc, state, now)
+	asserts.Expect(state.Runs).To(HaveLen(1))
+	asserts.Expect(state.Schedules[0].NextActivation).To(Equal(time.Date(2021, 9, 23, 1, 0, 0, 0, time.UTC)))
+
+	// no runs are started outside of the allowed windows
+	state.Runs[0].State = reaper.RepairStateDone
+	cc.Spec.Reaper.RepairSchedules.Repairs[0].AllowedWindows = []v1alpha1.RepairWindow{{Start: "22:00", End: "23:00"}}
+	now = time.Date(2021, 9, 23, 1, 0, 0, 0, time.UTC)
+	reconciler.reconcileBuiltinRepairSchedules(cc, state, now)
+	asserts.Expect(state.Runs).To(HaveLen(1))
+	asserts.Expect(state.Schedules[0].NextActivation).To(Equal(now))
+
+	// the schedule is recomputed if changed
+	cc.Spec.Reaper.RepairSchedules.Repairs[0].Cron = "0 22 * * *"
+	reconciler.reconcileBuiltinRepairSchedules(cc, state, now)
+	asserts.Expect(state.Schedules[0].NextActivation).To(Equal(time.Date(2021, 9, 23, 22, 0, 0, 0, time.UTC)))
+
+	// removed schedules
+	cc.Spec.Reaper.RepairSchedules.Enabled = false
+	reconciler.reconcileBuiltinRepairSchedules(cc, state, now)
+	asserts.Expect(state.Schedules).To(BeEmpty())
+}
+
+func TestNextActivation(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	now := time.Date(2021, 9, 20, 12, 0, 0, 0, time.UTC)
+	lastActivation := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	asserts.Expect(nextActivation(v1alpha1.RepairSchedule{ScheduleDaysBetween: 7}, lastActivation, now)).To(Equal(time.Date(2021, 9, 22, 10, 0, 0, 0, time.UTC)))
+	asserts.Expect(nextActivation(v1alpha1.RepairSchedule{Cron: "30 */6 * * *"}, lastActivation, now)).To(Equal(time.Date(2021, 9, 20, 12, 30, 0, 0, time.UTC)))
+}
+
+func TestCoordinatorDC(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{{Name: "dc1"}, {Name: "dc2"}},
+		},
+	}
+	keyspaces := []cql.Keyspace{
+		{Name: "simple", Replication: map[string]string{"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "3"}},
+		{Name: "dc2only", Replication: map[string]string{"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc1": "0", "dc2": "3"}},
+		{Name: "all", Replication: map[string]string{"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc1": "3", "dc2": "3"}},
+	}
+
+	asserts.Expect(coordinatorDC(cc, keyspaces, builtinrepair.Run{Keyspace: "simple"})).To(Equal(""))
+	asserts.Expect(coordinatorDC(cc, keyspaces, builtinrepair.Run{Keyspace: "dc2only"})).To(Equal("dc2"))
+	asserts.Expect(coordinatorDC(cc, keyspaces, builtinrepair.Run{Keyspace: "all"})).To(Equal("dc1"))
+	asserts.Expect(coordinatorDC(cc, keyspaces, builtinrepair.Run{Keyspace: "all", Datacenters: []string{"dc2"}})).To(Equal("dc2"))
+	asserts.Expect(coordinatorDC(cc, keyspaces, builtinrepair.Run{Keyspace: "unknown"})).To(Equal(""))
+}
+
+func TestBuiltinReaperRepairs(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	now := time.Date(2021, 9, 20, 12, 0, 0, 0, time.UTC)
+	repair := v1alpha1.RepairSchedule{Keyspace: "ks", Tables: []string{"t1"}}
+	cc := &v1alpha1.CassandraCluster{
+		Spec: v1alpha1.CassandraClusterSpec{
+			Reaper: &v1alpha1.Reaper{
+				RepairSchedules: v1alpha1.RepairSchedules{Enabled: true, Repairs: []v1alpha1.RepairSchedule{repair}},
+			},
+		},
+	}
+
+	startTime := now.Add(-time.Hour)
+	endTime := now.Add(-time.Minute)
+	state := &builtinrepair.State{
+		Schedules: []builtinrepair.Schedule{{ID: builtinRepairScheduleID(repair), Keyspace: "ks", Tables: []string{"t1"}, NextActivation: now.Add(time.Hour)}},
+		Runs: []builtinrepair.Run{
+			{ID: "1", ScheduleID: builtinRepairScheduleID(repair), Keyspace: "ks", Tables: []string{"t1"}, State: reaper.RepairStateDone,
+				CreationTime: startTime, StartTime: &startTime, EndTime: &endTime, TotalSegments: 4, Repaired: 4},
+		},
+	}
+
+	repairSchedules, repairRuns := builtinReaperRepairs(cc, state, now)
+	statuses := repairSchedulesStatus(cc.Spec.Reaper.RepairSchedules.Repairs, repairSchedules, repairRuns)
+	asserts.Expect(statuses).To(HaveLen(1))
+	asserts.Expect(statuses[0].State).To(Equal(reaper.RepairScheduleStateActive))
+	asserts.Expect(statuses[0].NextActivation.Time.Equal(now.Add(time.Hour))).To(BeTrue())
+	asserts.Expect(statuses[0].LastRun).ToNot(BeNil())
+	asserts.Expect(statuses[0].LastRun.ID).To(Equal("1"))
+	asserts.Expect(statuses[0].LastRun.Duration).To(Equal("59m0s"))
+	asserts.Expect(statuses[0].LastRun.RepairedPercent).To(Equal(int32(100)))
+
+	// paused while a disruptive operation is in progress
+	cc.Status.RepairsPause = &v1alpha1.RepairsPause{Reason: repairsPauseReasonScaling}
+	repairSchedules, _ = builtinReaperRepairs(cc, state, now)
+	asserts.Expect(repairSchedules[0].State).To(Equal(reaper.RepairScheduleStatePaused))
+}
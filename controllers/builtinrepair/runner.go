@@ -0,0 +1,241 @@
+package builtinrepair
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+)
+
+// a segment is retried until it fails this many times, after that the repair run fails
+const maxSegmentAttempts = 3
+
+type Config struct {
+	MaxConcurrentSegments int
+	SegmentCountPerNode   int
+	SegmentTimeout        time.Duration
+}
+
+// Runner repairs the segments of the repair runs through JMX
+type Runner struct {
+	nodectl nodectl.Nodectl
+	cfg     Config
+	log     *zap.SugaredLogger
+}
+
+func NewRunner(nctl nodectl.Nodectl, cfg Config, logr *zap.SugaredLogger) *Runner {
+	return &Runner{
+		nodectl: nctl,
+		cfg:     cfg,
+		log:     logr,
+	}
+}
+
+// Process checks the running segments, retries the failed ones and starts the pending segments of the runs allowed to
+// progress, so that at most MaxConcurrentSegments segments and one segment per coordinator node are repaired at a time.
+// The ring maps the tokens to the addresses of their nodes, the nodeDCs map the addresses to their datacenters.
+func (r *Runner) Process(ctx context.Context, state *State, ring, nodeDCs map[string]string, allowed func(run Run) bool, now time.Time) error {
+	ringChecksum := RingChecksum(ring)
+	busyNodes := make(map[string]bool)
+	running := 0
+
+	for i := range state.Runs {
+		run := &state.Runs[i]
+		if run.State != reaper.RepairStateRunning {
+			continue
+		}
+
+		if run.Ring != ringChecksum {
+			r.log.Infof("Token ring changed, restarting the segments of repair run %s of keyspace %s", run.ID, run.Keyspace)
+			run.State = reaper.RepairStateNotStarted
+			run.Running = nil
+			continue
+		}
+
+		r.checkRunningSegments(ctx, run, now)
+		for _, segment := range run.Running {
+			busyNodes[segment.Node] = true
+			running++
+		}
+
+		if run.State == reaper.RepairStateRunning && len(run.Running) == 0 && len(run.Pending) == 0 {
+			r.log.Infof("Repair run %s of keyspace %s is done", run.ID, run.Keyspace)
+			run.State = reaper.RepairStateDone
+			run.EndTime = timePtr(now)
+		}
+	}
+
+	segmentsByDC := make(map[string][]Segment)
+	for i := range state.Runs {
+		run := &state.Runs[i]
+		if run.Finished() || !allowed(*run) {
+			continue
+		}
+
+		segments, found := segmentsByDC[run.CoordinatorDC]
+		if !found {
+			var err error
+			segments, err = Segments(ring, nodeDCs, run.CoordinatorDC, r.cfg.SegmentCountPerNode)
+			if err != nil {
+				return err
+			}
+			segmentsByDC[run.CoordinatorDC] = segments
+		}
+
+		if run.State == reaper.RepairStateNotStarted {
+			r.log.Infof("Starting repair run %s of keyspace %s with %d segments", run.ID, run.Keyspace, len(segments))
+			run.State = reaper.RepairStateRunning
+			if run.StartTime == nil {
+				run.StartTime = timePtr(now)
+			}
+			run.Ring = ringChecksum
+			run.TotalSegments = len(segments)
+			run.Repaired = 0
+			run.Attempts = nil
+			run.Pending = make([]int, len(segments))
+			for j := range segments {
+				run.Pending[j] = j
+			}
+		}
+
+		pending := make([]int, 0, len(run.Pending))
+		for _, index := range run.Pending {
+			segment := segments[index]
+			if running >= r.cfg.MaxConcurrentSegments || busyNodes[segment.Node] || run.Finished() {
+				pending = append(pending, index)
+				continue
+			}
+
+			command, err := r.nodectl.RepairAsync(ctx, segment.Node, run.Keyspace, repairOptions(*run, segment))
+			if err != nil {
+				r.log.Warnf("Failed to start segment %d of repair run %s on node %s: %s", index, run.ID, segment.Node, err.Error())
+				if r.segmentFailed(run, index, err.Error(), now) {
+					pending = append(pending, index)
+				}
+				continue
+			}
+
+			if command == 0 { // nothing to repair
+				run.Repaired++
+				continue
+			}
+
+			r.log.Debugf("Started segment %d of repair run %s on node %s, command %d", index, run.ID, segment.Node, command)
+			run.Running = append(run.Running, RunningSegment{Index: index, Node: segment.Node, Command: command, StartTime: now})
+			busyNodes[segment.Node] = true
+			running++
+		}
+		run.Pending = pending
+
+		if run.State == reaper.RepairStateRunning && len(run.Running) == 0 && len(run.Pending) == 0 {
+			r.log.Infof("Repair run %s of keyspace %s is done", run.ID, run.Keyspace)
+			run.State = reaper.RepairStateDone
+			run.EndTime = timePtr(now)
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) checkRunningSegments(ctx context.Context, run *Run, now time.Time) {
+	stillRunning := make([]RunningSegment, 0, len(run.Running))
+	for _, segment := range run.Running {
+		if run.Finished() {
+			break
+		}
+
+		status, messages, err := r.nodectl.RepairStatus(ctx, segment.Node, segment.Command)
+		timedOut := now.Sub(segment.StartTime) > r.cfg.SegmentTimeout
+		switch {
+		case err != nil && !timedOut: // the node may be temporarily unavailable
+			r.log.Warnf("Failed to get the status of segment %d of repair run %s on node %s: %s", segment.Index, run.ID, segment.Node, err.Error())
+			stillRunning = append(stillRunning, segment)
+		case err == nil && status == nodectl.RepairStatusCompleted:
+			run.Repaired++
+		case err == nil && status == nodectl.RepairStatusInProgress && !timedOut:
+			stillRunning = append(stillRunning, segment)
+		default:
+			reason := fmt.Sprintf("repair command %d on node %s %s", segment.Command, segment.Node, strings.ToLower(status))
+			if timedOut {
+				reason = fmt.Sprintf("repair command %d on node %s timed out after %s", segment.Command, segment.Node, r.cfg.SegmentTimeout)
+			} else if len(messages) > 0 {
+				reason += ": " + strings.Join(messages, "; ")
+			}
+			r.log.Warnf("Segment %d of repair run %s failed: %s", segment.Index, run.ID, reason)
+			if r.segmentFailed(run, segment.Index, reason, now) {
+				run.Pending = append(run.Pending, segment.Index)
+			}
+		}
+	}
+
+	if run.Finished() {
+		stillRunning = nil
+	}
+	run.Running = stillRunning
+}
+
+// segmentFailed records the failed attempt and returns true if the segment should be retried.
+// The run fails if the segment has failed too many times.
+func (r *Runner) segmentFailed(run *Run, index int, reason string, now time.Time) bool {
+	if run.Attempts == nil {
+		run.Attempts = make(map[int]int)
+	}
+	run.Attempts[index]++
+	run.LastError = reason
+
+	if run.Attempts[index] < maxSegmentAttempts {
+		return true
+	}
+
+	r.log.Warnf("Repair run %s of keyspace %s failed, segment %d failed %d times", run.ID, run.Keyspace, index, run.Attempts[index])
+	run.State = reaper.RepairStateError
+	run.EndTime = timePtr(now)
+	run.Pending = nil
+	return false
+}
+
+func repairOptions(run Run, segment Segment) map[string]string {
+	options := map[string]string{
+		"ranges":       segment.RangesOption(),
+		"primaryRange": "false",
+		"incremental":  strconv.FormatBool(run.Incremental),
+		"parallelism":  parallelism(run.Parallelism),
+		"trace":        "false",
+	}
+
+	if run.ThreadCount > 0 {
+		options["jobThreads"] = strconv.Itoa(int(run.ThreadCount))
+	}
+
+	if len(run.Tables) > 0 {
+		options["columnFamilies"] = strings.Join(run.Tables, ",")
+	}
+
+	if len(run.Datacenters) > 0 {
+		options["dataCenters"] = strings.Join(run.Datacenters, ",")
+	}
+
+	return options
+}
+
+// parallelism converts the parallelism of the Reaper API to the one of the repair options
+func parallelism(repairParallelism string) string {
+	switch strings.ToUpper(repairParallelism) {
+	case "SEQUENTIAL":
+		return "sequential"
+	case "PARALLEL":
+		return "parallel"
+	default:
+		return "dc_parallel"
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,174 @@
+package builtinrepair
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/ibm/cassandra-operator/controllers/mocks"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+)
+
+func TestRunnerProcess(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	mCtrl := gomock.NewController(t)
+	defer mCtrl.Finish()
+
+	nctl := mocks.NewMockNodectl(mCtrl)
+	runner := NewRunner(nctl, Config{MaxConcurrentSegments: 1, SegmentCountPerNode: 1, SegmentTimeout: time.Minute}, zap.NewNop().Sugar())
+	ring := map[string]string{"-100": "10.0.0.1", "100": "10.0.0.2"}
+	allowAll := func(run Run) bool { return true }
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	state := &State{}
+	state.AddRun(Run{Keyspace: "ks", Tables: []string{"t1"}, Parallelism: "PARALLEL"}, now)
+
+	// one segment at a time
+	nctl.EXPECT().RepairAsync(gomock.Any(), "10.0.0.1", "ks", map[string]string{
+		"ranges":         "100:-100",
+		"primaryRange":   "false",
+		"incremental":    "false",
+		"parallelism":    "parallel",
+		"trace":          "false",
+		"columnFamilies": "t1",
+	}).Return(1, nil)
+	asserts.Expect(runner.Process(context.Background(), state, ring, nil, allowAll, now)).To(Succeed())
+	run := state.Runs[0]
+	asserts.Expect(run.State).To(Equal(reaper.RepairStateRunning))
+	asserts.Expect(run.TotalSegments).To(Equal(2))
+	asserts.Expect(run.Pending).To(Equal([]int{1}))
+	asserts.Expect(run.Running).To(Equal([]RunningSegment{{Index: 0, Node: "10.0.0.1", Command: 1, StartTime: now}}))
+
+	// the segment is still running
+	now = now.Add(10 * time.Second)
+	nctl.EXPECT().RepairStatus(gomock.Any(), "10.0.0.1", 1).Return(nodectl.RepairStatusInProgress, nil, nil)
+	asserts.Expect(runner.Process(context.Background(), state, ring, nil, allowAll, now)).To(Succeed())
+	asserts.Expect(state.Runs[0].Running).To(HaveLen(1))
+
+	// the segment fails and is retried after the next one
+	nctl.EXPECT().RepairStatus(gomock.Any(), "10.0.0.1", 1).Return(nodectl.RepairStatusFailed, []string{"streaming error"}, nil)
+	nctl.EXPECT().RepairAsync(gomock.Any(), "10.0.0.2", "ks", gomock.Any()).Return(2, nil)
+	asserts.Expect(runner.Process(context.Background(), state, ring, nil, allowAll, now)).To(Succeed())
+	run = state.Runs[0]
+	asserts.Expect(run.Attempts).To(Equal(map[int]int{0: 1}))
+	asserts.Expect(run.LastError).To(ContainSubstring("streaming error"))
+	asserts.Expect(run.Pending).To(Equal([]int{0}))
+	asserts.Expect(run.Running).To(Equal([]RunningSegment{{Index: 1, Node: "10.0.0.2", Command: 2, StartTime: now}}))
+
+	// the runs don't progress if not allowed, but the running segments are checked
+	nctl.EXPECT().RepairStatus(gomock.Any(), "10.0.0.2", 2).Return(nodectl.RepairStatusCompleted, nil, nil)
+	asserts.Expect(runner.Process(context.Background(), state, ring, nil, func(run Run) bool { return false }, now)).To(Succeed())
+	run = state.Runs[0]
+	asserts.Expect(run.Repaired).To(Equal(1))
+	asserts.Expect(run.Running).To(BeEmpty())
+	asserts.Expect(run.Pending).To(Equal([]int{0}))
+
+	// the retried segment is repaired
+	nctl.EXPECT().RepairAsync(gomock.Any(), "10.0.0.1", "ks", gomock.Any()).Return(3, nil)
+	asserts.Expect(runner.Process(context.Background(), state, ring, nil, allowAll, now)).To(Succeed())
+	now = now.Add(10 * time.Second)
+	nctl.EXPECT().RepairStatus(gomock.Any(), "10.0.0.1", 3).Return(nodectl.RepairStatusCompleted, nil, nil)
+	asserts.Expect(runner.Process(context.Background(), state, ring, nil, allowAll, now)).To(Succeed())
+	run = state.Runs[0]
+	asserts.Expect(run.State).To(Equal(reaper.RepairStateDone))
+	asserts.Expect(run.Repaired).To(Equal(2))
+	asserts.Expect(*run.EndTime).To(Equal(now))
+	asserts.Expect(state.Active()).To(BeFalse())
+}
+
+func TestRunnerProcessFailures(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	mCtrl := gomock.NewController(t)
+	defer mCtrl.Finish()
+
+	nctl := mocks.NewMockNodectl(mCtrl)
+	runner := NewRunner(nctl, Config{MaxConcurrentSegments: 2, SegmentCountPerNode: 1, SegmentTimeout: time.Minute}, zap.NewNop().Sugar())
+	ring := map[string]string{"-100": "10.0.0.1"}
+	allowAll := func(run Run) bool { return true }
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	state := &State{}
+	state.AddRun(Run{Keyspace: "ks"}, now)
+
+	nctl.EXPECT().RepairAsync(gomock.Any(), "10.0.0.1", "ks", gomock.Any()).Return(1, nil)
+	asserts.Expect(runner.Process(context.Background(), state, ring, nil, allowAll, now)).To(Succeed())
+
+	// the status is unavailable for a while
+	nctl.EXPECT().RepairStatus(gomock.Any(), "10.0.0.1", 1).Return("", nil, errors.New("connection refused"))
+	asserts.Expect(runner.Process(context.Background(), state, ring, nil, allowAll, now)).To(Succeed())
+	asserts.Expect(state.Runs[0].Running).To(HaveLen(1))
+
+	// the segment times out
+	now = now.Add(2 * time.Minute)
+	nctl.EXPECT().RepairStatus(gomock.Any(), "10.0.0.1", 1).Return(nodectl.RepairStatusInProgress, nil, nil)
+	nctl.EXPECT().RepairAsync(gomock.Any(), "10.0.0.1", "ks", gomock.Any()).Return(0, errors.New("connection refused"))
+	asserts.Expect(runner.Process(context.Background(), state, ring, nil, allowAll, now)).To(Succeed())
+	run := state.Runs[0]
+	asserts.Expect(run.Attempts).To(Equal(map[int]int{0: 2}))
+	asserts.Expect(run.State).To(Equal(reaper.RepairStateRunning))
+
+	// the node doesn't know the repair command anymore, the run fails after too many attempts
+	nctl.EXPECT().RepairAsync(gomock.Any(), "10.0.0.1", "ks", gomock.Any()).Return(0, errors.New("connection refused"))
+	asserts.Expect(runner.Process(context.Background(), state, ring, nil, allowAll, now)).To(Succeed())
+	run = state.Runs[0]
+	asserts.Expect(run.State).To(Equal(reaper.RepairStateError))
+	asserts.Expect(run.Pending).To(BeEmpty())
+	asserts.Expect(run.LastError).To(Equal("connection refused"))
+}
+
+func TestRunnerProcessRingChange(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	mCtrl := gomock.NewController(t)
+	defer mCtrl.Finish()
+
+	nctl := mocks.NewMockNodectl(mCtrl)
+	runner := NewRunner(nctl, Config{MaxConcurrentSegments: 1, SegmentCountPerNode: 1, SegmentTimeout: time.Minute}, zap.NewNop().Sugar())
+	allowAll := func(run Run) bool { return true }
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	state := &State{}
+	state.AddRun(Run{Keyspace: "ks"}, now)
+
+	nctl.EXPECT().RepairAsync(gomock.Any(), "10.0.0.1", "ks", gomock.Any()).Return(1, nil)
+	asserts.Expect(runner.Process(context.Background(), state, map[string]string{"-100": "10.0.0.1", "100": "10.0.0.2"}, nil, allowAll, now)).To(Succeed())
+
+	// the segments are recomputed for the new ring
+	nctl.EXPECT().RepairAsync(gomock.Any(), "10.0.0.1", "ks", gomock.Any()).Return(2, nil)
+	asserts.Expect(runner.Process(context.Background(), state, map[string]string{"-100": "10.0.0.1", "0": "10.0.0.3", "100": "10.0.0.2"}, nil, allowAll, now)).To(Succeed())
+	run := state.Runs[0]
+	asserts.Expect(run.TotalSegments).To(Equal(3))
+	asserts.Expect(run.Pending).To(Equal([]int{1, 2}))
+	asserts.Expect(run.Running).To(Equal([]RunningSegment{{Index: 0, Node: "10.0.0.1", Command: 2, StartTime: now}}))
+}
+
+func TestStatePrune(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	done := func(run Run, minutes int) Run {
+		run.State = reaper.RepairStateDone
+		run.CreationTime = now.Add(time.Duration(minutes) * time.Minute)
+		run.EndTime = timePtr(run.CreationTime.Add(time.Minute))
+		return run
+	}
+
+	state := &State{Runs: []Run{
+		done(Run{ID: "1", ScheduleID: "s1", Keyspace: "ks"}, 0),
+		done(Run{ID: "2", ScheduleID: "s1", Keyspace: "ks"}, 10),
+		{ID: "3", ScheduleID: "s1", Keyspace: "ks", State: reaper.RepairStateError, CreationTime: now.Add(20 * time.Minute)},
+		done(Run{ID: "4", Cause: "keyspaces-init", Keyspace: "system_auth"}, 30),
+		{ID: "5", ScheduleID: "s1", Keyspace: "ks", State: reaper.RepairStateRunning, CreationTime: now.Add(40 * time.Minute)},
+	}}
+
+	state.Prune()
+	ids := make([]string, 0, len(state.Runs))
+	for _, run := range state.Runs {
+		ids = append(ids, run.ID)
+	}
+	asserts.Expect(ids).To(Equal([]string{"2", "3", "4", "5"}))
+}
@@ -0,0 +1,214 @@
+package builtinrepair
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// Murmur3Partitioner tokens are in range [-2^63, 2^63-1]
+	murmur3Min  = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 63))
+	murmur3Size = new(big.Int).Lsh(big.NewInt(1), 64)
+	// RandomPartitioner tokens are in range [0, 2^127]
+	randomMin  = big.NewInt(0)
+	randomSize = new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+)
+
+// TokenRange is a range of tokens (Start, End]. The range wraps around the ring if End <= Start.
+type TokenRange struct {
+	Start string
+	End   string
+}
+
+// Segment is a set of token ranges repaired in one repair session coordinated by the node
+type Segment struct {
+	Node   string
+	Ranges []TokenRange
+}
+
+// RangesOption returns the ranges in the format of the `ranges` repair option
+func (s Segment) RangesOption() string {
+	ranges := make([]string, 0, len(s.Ranges))
+	for _, r := range s.Ranges {
+		ranges = append(ranges, r.Start+":"+r.End)
+	}
+
+	return strings.Join(ranges, ",")
+}
+
+type ringToken struct {
+	value *big.Int
+	node  string
+}
+
+// Segments splits the token ring into segments, so that each coordinator node repairs countPerNode segments.
+// The coordinator of a range is the first node clockwise in the datacenter from the end of the range,
+// which is a replica of the range in that datacenter. If the datacenter is empty, the owner of the range coordinates it.
+// The segments are ordered so that consecutive segments are coordinated by different nodes.
+func Segments(ring map[string]string, nodeDCs map[string]string, dc string, countPerNode int) ([]Segment, error) {
+	tokens, err := sortedTokens(ring)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.New("the token ring is empty")
+	}
+
+	ringMin, ringSize := murmur3Min, murmur3Size
+	if tokens[len(tokens)-1].value.Cmp(new(big.Int).Add(murmur3Min, murmur3Size)) >= 0 {
+		ringMin, ringSize = randomMin, randomSize
+	}
+
+	nodeRanges := make(map[string][]TokenRange)
+	for i, token := range tokens {
+		prev := tokens[(i-1+len(tokens))%len(tokens)]
+		coordinator, found := coordinatorOf(tokens, i, nodeDCs, dc)
+		if !found {
+			return nil, errors.Errorf("no nodes of datacenter %s found in the token ring", dc)
+		}
+
+		nodeRanges[coordinator] = append(nodeRanges[coordinator], TokenRange{Start: prev.value.String(), End: token.value.String()})
+	}
+
+	nodes := make([]string, 0, len(nodeRanges))
+	for node := range nodeRanges {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	if countPerNode < 1 {
+		countPerNode = 1
+	}
+
+	nodeSegments := make([][]Segment, 0, len(nodes))
+	for _, node := range nodes {
+		nodeSegments = append(nodeSegments, splitNodeRanges(node, nodeRanges[node], countPerNode, ringMin, ringSize))
+	}
+
+	segments := make([]Segment, 0, len(nodes)*countPerNode)
+	for i := 0; i < countPerNode; i++ {
+		for _, s := range nodeSegments {
+			if i < len(s) {
+				segments = append(segments, s[i])
+			}
+		}
+	}
+
+	return segments, nil
+}
+
+// RingChecksum returns a checksum of the token ring, used to find out if the segments of a repair run are still valid
+func RingChecksum(ring map[string]string) string {
+	tokens := make([]string, 0, len(ring))
+	for token, node := range ring {
+		tokens = append(tokens, token+"="+node)
+	}
+	sort.Strings(tokens)
+
+	h := fnv.New64a()
+	h.Write([]byte(strings.Join(tokens, ",")))
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+func sortedTokens(ring map[string]string) ([]ringToken, error) {
+	tokens := make([]ringToken, 0, len(ring))
+	for token, node := range ring {
+		value, ok := new(big.Int).SetString(token, 10)
+		if !ok {
+			return nil, errors.Errorf("invalid token %q", token)
+		}
+		tokens = append(tokens, ringToken{value: value, node: node})
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].value.Cmp(tokens[j].value) < 0
+	})
+
+	return tokens, nil
+}
+
+func coordinatorOf(tokens []ringToken, i int, nodeDCs map[string]string, dc string) (string, bool) {
+	if len(dc) == 0 {
+		return tokens[i].node, true
+	}
+
+	for j := 0; j < len(tokens); j++ {
+		token := tokens[(i+j)%len(tokens)]
+		if nodeDCs[token.node] == dc {
+			return token.node, true
+		}
+	}
+
+	return "", false
+}
+
+// splitNodeRanges splits the ranges of the node into subranges if there are fewer ranges than segments
+// and groups them into count segments
+func splitNodeRanges(node string, ranges []TokenRange, count int, ringMin, ringSize *big.Int) []Segment {
+	parts := (count + len(ranges) - 1) / len(ranges)
+
+	subranges := make([]TokenRange, 0, len(ranges)*parts)
+	for _, r := range ranges {
+		subranges = append(subranges, splitRange(r, parts, ringMin, ringSize)...)
+	}
+
+	if count > len(subranges) {
+		count = len(subranges)
+	}
+
+	segments := make([]Segment, 0, count)
+	for i := 0; i < count; i++ {
+		segments = append(segments, Segment{
+			Node:   node,
+			Ranges: subranges[i*len(subranges)/count : (i+1)*len(subranges)/count],
+		})
+	}
+
+	return segments
+}
+
+func splitRange(r TokenRange, parts int, ringMin, ringSize *big.Int) []TokenRange {
+	if parts <= 1 {
+		return []TokenRange{r}
+	}
+
+	start, _ := new(big.Int).SetString(r.Start, 10)
+	end, _ := new(big.Int).SetString(r.End, 10)
+
+	width := new(big.Int).Sub(end, start)
+	width.Mod(width, ringSize)
+	if width.Sign() == 0 { // a single token owns the whole ring
+		width.Set(ringSize)
+	}
+
+	if width.Cmp(big.NewInt(int64(parts))) < 0 {
+		parts = int(width.Int64())
+	}
+
+	ringMax := new(big.Int).Add(ringMin, ringSize)
+	boundaries := make([]string, 0, parts+1)
+	boundaries = append(boundaries, r.Start)
+	for i := 1; i < parts; i++ {
+		offset := new(big.Int).Mul(width, big.NewInt(int64(i)))
+		offset.Quo(offset, big.NewInt(int64(parts)))
+		boundary := offset.Add(offset, start)
+		if boundary.Cmp(ringMax) >= 0 {
+			boundary.Sub(boundary, ringSize)
+		}
+		boundaries = append(boundaries, boundary.String())
+	}
+	boundaries = append(boundaries, r.End)
+
+	subranges := make([]TokenRange, 0, parts)
+	for i := 0; i < parts; i++ {
+		subranges = append(subranges, TokenRange{Start: boundaries[i], End: boundaries[i+1]})
+	}
+
+	return subranges
+}
@@ -0,0 +1,91 @@
+package builtinrepair
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSegments(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	ring := map[string]string{
+		"-6000000000000000000": "10.0.0.1",
+		"-1000000000000000000": "10.0.0.2",
+		"4000000000000000000":  "10.0.0.3",
+	}
+
+	segments, err := Segments(ring, nil, "", 1)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(segments).To(Equal([]Segment{
+		{Node: "10.0.0.1", Ranges: []TokenRange{{Start: "4000000000000000000", End: "-6000000000000000000"}}},
+		{Node: "10.0.0.2", Ranges: []TokenRange{{Start: "-6000000000000000000", End: "-1000000000000000000"}}},
+		{Node: "10.0.0.3", Ranges: []TokenRange{{Start: "-1000000000000000000", End: "4000000000000000000"}}},
+	}))
+
+	// the wrapping range is split around the end of the ring
+	segments, err = Segments(ring, nil, "", 2)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(segments).To(HaveLen(6))
+	asserts.Expect(segments[0]).To(Equal(Segment{Node: "10.0.0.1", Ranges: []TokenRange{{Start: "4000000000000000000", End: "8223372036854775808"}}}))
+	asserts.Expect(segments[1].Node).To(Equal("10.0.0.2"))
+	asserts.Expect(segments[3]).To(Equal(Segment{Node: "10.0.0.1", Ranges: []TokenRange{{Start: "8223372036854775808", End: "-6000000000000000000"}}}))
+	asserts.Expect(segments[3].RangesOption()).To(Equal("8223372036854775808:-6000000000000000000"))
+}
+
+func TestSegmentsGroupRanges(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	ring := map[string]string{
+		"-300": "10.0.0.1",
+		"-200": "10.0.0.2",
+		"-100": "10.0.0.1",
+		"100":  "10.0.0.2",
+		"200":  "10.0.0.1",
+		"300":  "10.0.0.2",
+	}
+
+	segments, err := Segments(ring, nil, "", 2)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(segments).To(Equal([]Segment{
+		{Node: "10.0.0.1", Ranges: []TokenRange{{Start: "300", End: "-300"}}},
+		{Node: "10.0.0.2", Ranges: []TokenRange{{Start: "-300", End: "-200"}}},
+		{Node: "10.0.0.1", Ranges: []TokenRange{{Start: "-200", End: "-100"}, {Start: "100", End: "200"}}},
+		{Node: "10.0.0.2", Ranges: []TokenRange{{Start: "-100", End: "100"}, {Start: "200", End: "300"}}},
+	}))
+	asserts.Expect(segments[2].RangesOption()).To(Equal("-200:-100,100:200"))
+}
+
+func TestSegmentsCoordinatorDC(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	ring := map[string]string{
+		"-200": "10.0.0.1",
+		"-100": "10.0.1.1",
+		"100":  "10.0.0.2",
+		"200":  "10.0.1.2",
+	}
+	nodeDCs := map[string]string{
+		"10.0.0.1": "dc1",
+		"10.0.0.2": "dc1",
+		"10.0.1.1": "dc2",
+		"10.0.1.2": "dc2",
+	}
+
+	segments, err := Segments(ring, nodeDCs, "dc2", 1)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(segments).To(Equal([]Segment{
+		{Node: "10.0.1.1", Ranges: []TokenRange{{Start: "200", End: "-200"}, {Start: "-200", End: "-100"}}},
+		{Node: "10.0.1.2", Ranges: []TokenRange{{Start: "-100", End: "100"}, {Start: "100", End: "200"}}},
+	}))
+
+	_, err = Segments(ring, nodeDCs, "dc3", 1)
+	asserts.Expect(err).To(HaveOccurred())
+
+	_, err = Segments(map[string]string{}, nil, "", 1)
+	asserts.Expect(err).To(HaveOccurred())
+}
+
+func TestRingChecksum(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	ring := map[string]string{"-100": "10.0.0.1", "100": "10.0.0.2"}
+	asserts.Expect(RingChecksum(ring)).To(Equal(RingChecksum(map[string]string{"100": "10.0.0.2", "-100": "10.0.0.1"})))
+	asserts.Expect(RingChecksum(ring)).ToNot(Equal(RingChecksum(map[string]string{"-100": "10.0.0.1", "100": "10.0.0.3"})))
+}
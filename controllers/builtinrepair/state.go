@@ -0,0 +1,163 @@
+package builtinrepair
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+)
+
+// State is the progress of the builtin repairs. It's persisted between reconciles,
+// so that the repair runs are resumed rather than restarted after an operator restart.
+type State struct {
+	Schedules []Schedule `json:"schedules,omitempty"`
+	Runs      []Run      `json:"runs,omitempty"`
+}
+
+// Schedule is the state of a repair schedule from the CassandraCluster spec
+type Schedule struct {
+	ID       string   `json:"id"`
+	Keyspace string   `json:"keyspace"`
+	Tables   []string `json:"tables,omitempty"`
+	// Whether the runs of the schedule are incremental repairs
+	Incremental bool `json:"incremental,omitempty"`
+	// Checksum of the schedule spec the next activation has been computed from
+	Spec           string    `json:"spec"`
+	NextActivation time.Time `json:"nextActivation"`
+}
+
+// Run is a repair of a keyspace split into token range segments
+type Run struct {
+	ID          string   `json:"id"`
+	ScheduleID  string   `json:"scheduleId,omitempty"`
+	Cause       string   `json:"cause"`
+	Keyspace    string   `json:"keyspace"`
+	Tables      []string `json:"tables,omitempty"`
+	Datacenters []string `json:"datacenters,omitempty"`
+	// Datacenter of the nodes coordinating the segments
+	CoordinatorDC string `json:"coordinatorDC,omitempty"`
+	Incremental   bool   `json:"incremental,omitempty"`
+	Parallelism   string `json:"parallelism,omitempty"`
+	ThreadCount   int32  `json:"threadCount,omitempty"`
+	// NOT_STARTED, RUNNING, DONE or ERROR
+	State        string     `json:"state"`
+	CreationTime time.Time  `json:"creationTime"`
+	StartTime    *time.Time `json:"startTime,omitempty"`
+	EndTime      *time.Time `json:"endTime,omitempty"`
+	// Checksum of the token ring the segments have been computed from
+	Ring          string           `json:"ring,omitempty"`
+	TotalSegments int              `json:"totalSegments,omitempty"`
+	Repaired      int              `json:"repaired,omitempty"`
+	Pending       []int            `json:"pending,omitempty"`
+	Running       []RunningSegment `json:"running,omitempty"`
+	Attempts      map[int]int      `json:"attempts,omitempty"`
+	LastError     string           `json:"lastError,omitempty"`
+}
+
+// RunningSegment is a segment being repaired by a repair command on the coordinator node
+type RunningSegment struct {
+	Index     int       `json:"index"`
+	Node      string    `json:"node"`
+	Command   int       `json:"command"`
+	StartTime time.Time `json:"startTime"`
+}
+
+// Finished returns true if the run is done or has failed
+func (r Run) Finished() bool {
+	return r.State == reaper.RepairStateDone || r.State == reaper.RepairStateError
+}
+
+// AddRun adds a not started repair run and returns it
+func (s *State) AddRun(run Run, now time.Time) Run {
+	id := now.UnixNano()
+	for s.runIndex(fmt.Sprintf("%x", id)) >= 0 {
+		id++
+	}
+
+	run.ID = fmt.Sprintf("%x", id)
+	run.State = reaper.RepairStateNotStarted
+	run.CreationTime = now
+	s.Runs = append(s.Runs, run)
+	return run
+}
+
+// ActiveRun returns the not finished run of the keyspace started by the schedule. Any not finished run of the keyspace
+// is returned if the schedule ID is empty.
+func (s *State) ActiveRun(keyspace, scheduleID string) (Run, bool) {
+	for _, run := range s.Runs {
+		if !run.Finished() && run.Keyspace == keyspace && (len(scheduleID) == 0 || run.ScheduleID == scheduleID) {
+			return run, true
+		}
+	}
+
+	return Run{}, false
+}
+
+// Active returns true if any repair run is not finished
+func (s *State) Active() bool {
+	for _, run := range s.Runs {
+		if !run.Finished() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Prune removes the finished runs that are neither the last run of a schedule nor the last successful run
+// of a keyspace, tables and datacenters combination, which is needed to track the repair coverage
+func (s *State) Prune() {
+	keep := make(map[string]bool)
+	lastRuns := make(map[string]Run)
+	lastDoneRuns := make(map[string]Run)
+	for _, run := range s.Runs {
+		if !run.Finished() {
+			keep[run.ID] = true
+			continue
+		}
+
+		key := run.ScheduleID + "/" + run.Cause
+		if last, found := lastRuns[key]; !found || run.CreationTime.After(last.CreationTime) {
+			lastRuns[key] = run
+		}
+
+		if run.State != reaper.RepairStateDone {
+			continue
+		}
+
+		key = fmt.Sprintf("%s/%v/%v", run.Keyspace, run.Tables, run.Datacenters)
+		if last, found := lastDoneRuns[key]; !found || run.EndTime.After(*last.EndTime) {
+			lastDoneRuns[key] = run
+		}
+	}
+
+	for _, run := range lastRuns {
+		keep[run.ID] = true
+	}
+	for _, run := range lastDoneRuns {
+		keep[run.ID] = true
+	}
+
+	runs := make([]Run, 0, len(keep))
+	for _, run := range s.Runs {
+		if keep[run.ID] {
+			runs = append(runs, run)
+		}
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].CreationTime.Before(runs[j].CreationTime)
+	})
+	s.Runs = runs
+}
+
+func (s *State) runIndex(id string) int {
+	for i, run := range s.Runs {
+		if run.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
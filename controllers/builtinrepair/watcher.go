@@ -0,0 +1,133 @@
+package builtinrepair
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+)
+
+// SegmentCheckInterval is how often the running segments are checked. They are checked more often than the clusters
+// are reconciled, so that the next segments are started soon after the previous ones are finished.
+const SegmentCheckInterval = 10 * time.Second
+
+// SegmentWatcher checks the status of the running segments between the reconciles of the clusters. A cluster is
+// reconciled through the reconcile channel once one of its segments is finished, so only the status of the segments
+// is requested while they are running instead of reconciling the whole cluster.
+type SegmentWatcher struct {
+	sync.Mutex
+	clusters map[types.NamespacedName]*watchedSegments
+	events   chan event.GenericEvent
+	interval time.Duration
+	log      *zap.SugaredLogger
+}
+
+type watchedSegments struct {
+	cluster  client.Object
+	nodectl  nodectl.Nodectl
+	segments []RunningSegment
+	polling  bool
+}
+
+func NewSegmentWatcher(events chan event.GenericEvent, interval time.Duration, logr *zap.SugaredLogger) *SegmentWatcher {
+	return &SegmentWatcher{
+		clusters: make(map[types.NamespacedName]*watchedSegments),
+		events:   events,
+		interval: interval,
+		log:      logr,
+	}
+}
+
+// Watch replaces the watched segments of the cluster with the running segments of the state.
+// The cluster is not watched anymore if no segment is running.
+func (w *SegmentWatcher) Watch(cluster client.Object, nctl nodectl.Nodectl, state *State) {
+	var segments []RunningSegment
+	for _, run := range state.Runs {
+		segments = append(segments, run.Running...)
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	key := client.ObjectKeyFromObject(cluster)
+	if len(segments) == 0 {
+		delete(w.clusters, key)
+		return
+	}
+
+	w.clusters[key] = &watchedSegments{
+		cluster:  cluster.DeepCopyObject().(client.Object),
+		nodectl:  nctl,
+		segments: segments,
+	}
+}
+
+// Forget stops watching the segments of the cluster
+func (w *SegmentWatcher) Forget(key types.NamespacedName) {
+	w.Lock()
+	defer w.Unlock()
+	delete(w.clusters, key)
+}
+
+// Start checks the watched segments until the context is done. Implements manager.Runnable.
+func (w *SegmentWatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.Lock()
+			for key, watched := range w.clusters {
+				if watched.polling {
+					continue
+				}
+				watched.polling = true
+				go w.poll(ctx, key, watched)
+			}
+			w.Unlock()
+		}
+	}
+}
+
+func (w *SegmentWatcher) poll(ctx context.Context, key types.NamespacedName, watched *watchedSegments) {
+	finished := false
+	for _, segment := range watched.segments {
+		status, _, err := watched.nodectl.RepairStatus(ctx, segment.Node, segment.Command)
+		if err != nil {
+			// the reconcile retries the segment if it times out
+			w.log.Debugf("Failed to check repair command %d on node %s of cluster %s: %s", segment.Command, segment.Node, key, err.Error())
+			continue
+		}
+
+		if status != nodectl.RepairStatusInProgress {
+			finished = true
+			break
+		}
+	}
+
+	w.Lock()
+	watched.polling = false
+	// the segments may have been replaced by a reconcile in the meantime
+	notify := finished && w.clusters[key] == watched
+	if notify {
+		delete(w.clusters, key)
+	}
+	w.Unlock()
+
+	if !notify {
+		return
+	}
+
+	select {
+	case w.events <- event.GenericEvent{Object: watched.cluster}:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,58 @@
+package builtinrepair
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/mocks"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+)
+
+func TestSegmentWatcher(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	mCtrl := gomock.NewController(t)
+	defer mCtrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nctl := mocks.NewMockNodectl(mCtrl)
+	events := make(chan event.GenericEvent)
+	watcher := NewSegmentWatcher(events, 10*time.Millisecond, zap.NewNop().Sugar())
+	go func() {
+		_ = watcher.Start(ctx)
+	}()
+
+	cc := &v1alpha1.CassandraCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "ns"}}
+	state := &State{Runs: []Run{{ID: "1", State: reaper.RepairStateRunning, Running: []RunningSegment{{Index: 0, Node: "10.0.0.1", Command: 1}}}}}
+
+	gomock.InOrder(
+		nctl.EXPECT().RepairStatus(gomock.Any(), "10.0.0.1", 1).Return(nodectl.RepairStatusInProgress, nil, nil),
+		nctl.EXPECT().RepairStatus(gomock.Any(), "10.0.0.1", 1).Return(nodectl.RepairStatusCompleted, nil, nil),
+	)
+	watcher.Watch(cc, nctl, state)
+
+	var e event.GenericEvent
+	asserts.Eventually(events).Should(Receive(&e))
+	asserts.Expect(types.NamespacedName{Name: e.Object.GetName(), Namespace: e.Object.GetNamespace()}).To(Equal(types.NamespacedName{Name: "cluster", Namespace: "ns"}))
+
+	watcher.Lock()
+	asserts.Expect(watcher.clusters).To(BeEmpty(), "the cluster is not watched until the next reconcile")
+	watcher.Unlock()
+
+	// no running segments
+	watcher.Watch(cc, nctl, &State{Runs: []Run{{ID: "1", State: reaper.RepairStateDone}}})
+	watcher.Lock()
+	asserts.Expect(watcher.clusters).To(BeEmpty())
+	watcher.Unlock()
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func commitLogDir(cc *dbv1alpha1.CassandraCluster) string {
+	if cc.Spec.Cassandra.Persistence.Enabled && cc.Spec.Cassandra.Persistence.CommitLogVolume {
+		return cassandraCommitLogDir
+	}
+
+	return "/var/lib/cassandra/commitlog"
+}
+
+// commitLogArchiveDir is on the same volume as the commitlogs since segments are archived using hard links
+func commitLogArchiveDir(cc *dbv1alpha1.CassandraCluster) string {
+	return commitLogDir(cc) + "/archive"
+}
+
+func commitLogShipperContainer(cc *dbv1alpha1.CassandraCluster, dc dbv1alpha1.DC) v1.Container {
+	archiving := cc.Spec.Cassandra.CommitLogArchiving
+	storageLocation := strings.TrimSuffix(archiving.StorageLocation, "/")
+	backupArgs := []string{
+		"java -jar icarus.jar esop commitlog-backup",
+		fmt.Sprintf("--storage-location=%s/%s/%s/${POD_NAME}", storageLocation, cc.Name, dc.Name),
+		"--cl-archive=${archive_dir}",
+		"--cassandra-dir=/var/lib/cassandra",
+		"--k8s-namespace=" + cc.Namespace,
+		"--k8s-secret-name=" + archiving.SecretName,
+		fmt.Sprintf("--concurrent-connections=%d", archiving.ConcurrentConnections),
+	}
+
+	if archiving.Insecure {
+		backupArgs = append(backupArgs, "--insecure")
+	}
+
+	if archiving.SkipBucketVerification {
+		backupArgs = append(backupArgs, "--skip-bucket-verification")
+	}
+
+	args := []string{
+		fmt.Sprintf(`function sigterm_handler {
+  echo -en "\nReceived SIGTERM; Exiting\n"
+  exit 0
+}
+
+trap sigterm_handler SIGTERM
+
+archive_dir=%s
+mkdir -p ${archive_dir}
+
+while true; do
+  sleep %d & wait $!
+  segments=$(ls -A ${archive_dir})
+  if [[ -z "${segments}" ]]; then
+    continue
+  fi
+
+  echo shipping $(echo "${segments}" | wc -l) commitlog segments
+  if %s; then
+    (cd ${archive_dir} && rm -f ${segments})
+  else
+    echo failed to ship commitlog segments, retrying in %d seconds
+  fi
+done`, commitLogArchiveDir(cc), archiving.IntervalSeconds, strings.Join(backupArgs, " "), archiving.IntervalSeconds),
+	}
+
+	container := v1.Container{
+		Name:            "commitlog-shipper",
+		Image:           cc.Spec.Icarus.Image,
+		ImagePullPolicy: cc.Spec.Icarus.ImagePullPolicy,
+		Env: []v1.EnvVar{
+			{
+				Name: "POD_NAME",
+				ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"},
+				},
+			},
+		},
+		Command: []string{
+			"bash",
+			"-c",
+		},
+		Args: args,
+		VolumeMounts: []v1.VolumeMount{
+			cassandraDataVolumeMount(),
+		},
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: v1.TerminationMessageReadFile,
+	}
+
+	if cc.Spec.Cassandra.Persistence.Enabled && cc.Spec.Cassandra.Persistence.CommitLogVolume {
+		container.VolumeMounts = append(container.VolumeMounts, commitLogVolumeMount())
+	}
+
+	return container
+}
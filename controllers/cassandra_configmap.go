@@ -120,6 +120,12 @@ func (r *CassandraClusterReconciler) reconcileCassandraConfigMap(ctx context.Con
 		restartChecksum["jvm.options"] = data["jvm.options"] //to restart cassandra pods on change
 	}
 
+	if cc.Spec.Cassandra.CommitLogArchiving != nil {
+		// sealed segments are hard linked into the archive directory from which the commitlog shipper uploads them
+		data["commitlog_archiving.properties"] = fmt.Sprintf("archive_command=/bin/ln %%path %s/%%name\n", commitLogArchiveDir(cc))
+		restartChecksum["commitlog_archiving.properties"] = data["commitlog_archiving.properties"] //to restart cassandra pods on change
+	}
+
 	desiredCM.Data = data
 
 	if err := controllerutil.SetControllerReference(cc, desiredCM, r.Scheme); err != nil {
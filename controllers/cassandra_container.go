@@ -226,6 +226,25 @@ else
 fi`,
 	)
 
+	if cc.Spec.RestoreFrom != nil {
+		// the tokens of the restored node are written by the restore init container
+		args = append(args, fmt.Sprintf(`if [[ -f "%[1]s/cassandra.yaml" ]]; then
+  echo using tokens of the node restored from backup
+  sed -i '/^initial_token:/d;/^auto_bootstrap:/d' $CASSANDRA_CONF/cassandra.yaml
+  grep -E '^(initial_token|auto_bootstrap):' %[1]s/cassandra.yaml >> $CASSANDRA_CONF/cassandra.yaml
+fi`, restoreFromBackupDir))
+	}
+
+	if cc.Spec.Cassandra.CommitLogArchiving != nil {
+		// commitlogs downloaded by a point-in-time restore are replayed once on the next start
+		args = append(args, fmt.Sprintf(`mkdir -p %[1]s
+if [[ -f "%[2]s/commitlog_archiving.properties" ]]; then
+  echo replaying commitlogs restored from backup
+  grep -E '^restore_' %[2]s/commitlog_archiving.properties >> $CASSANDRA_CONF/commitlog_archiving.properties
+  mv %[2]s/commitlog_archiving.properties %[2]s/commitlog_archiving.properties.replayed
+fi`, commitLogArchiveDir(cc), dbv1alpha1.CommitLogRestoreDir))
+	}
+
 	if cc.Spec.Encryption.Client.Enabled {
 		args = append(args,
 			"mkdir -p /home/cassandra/.cassandra/",
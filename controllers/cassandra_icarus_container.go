@@ -2,12 +2,13 @@ package controllers
 
 import (
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
 	v1 "k8s.io/api/core/v1"
 )
 
 func icarusContainer(cc *dbv1alpha1.CassandraCluster) v1.Container {
 	container := v1.Container{
-		Name:            "icarus",
+		Name:            icarus.ContainerName,
 		Image:           cc.Spec.Icarus.Image,
 		ImagePullPolicy: cc.Spec.Icarus.ImagePullPolicy,
 		Args:            []string{"--jmx-credentials=/etc/cassandra-auth-config/icarus-jmx", "--jmx-client-auth=true"},
@@ -81,6 +81,108 @@ done
 	}
 }
 
+func restoreFromBackupContainer(cc *dbv1alpha1.CassandraCluster) v1.Container {
+	restoreArgs := []string{
+		"java -jar icarus.jar esop restore",
+		"--storage-location=${RESTORE_STORAGE_LOCATION}",
+		"--snapshot-tag=" + cc.Spec.RestoreFrom.SnapshotTag,
+		"--k8s-namespace=" + cc.Namespace,
+		"--k8s-secret-name=" + cc.Spec.RestoreFrom.SecretName,
+		"--restoration-strategy-type=IN_PLACE",
+		"--restore-system-keyspace",
+		"--restore-into-new-cluster",
+		"--resolve-host-id-from-topology",
+		"--update-cassandra-yaml",
+		"--cassandra-dir=/var/lib/cassandra",
+		"--cassandra-config-directory=" + restoreFromBackupDir,
+		fmt.Sprintf("--concurrent-connections=%d", cc.Spec.RestoreFrom.ConcurrentConnections),
+	}
+
+	if len(cc.Spec.RestoreFrom.SchemaVersion) != 0 {
+		restoreArgs = append(restoreArgs, "--schema-version="+cc.Spec.RestoreFrom.SchemaVersion)
+	}
+
+	if cc.Spec.RestoreFrom.Insecure {
+		restoreArgs = append(restoreArgs, "--insecure")
+	}
+
+	if cc.Spec.RestoreFrom.SkipBucketVerification {
+		restoreArgs = append(restoreArgs, "--skip-bucket-verification")
+	}
+
+	args := []string{
+		`function sigterm_handler {
+  echo -en "\nReceived SIGTERM; Exiting\n"
+  exit 0
+}
+
+trap sigterm_handler SIGTERM
+
+config_path=/etc/pods-config/${POD_NAME}_${POD_UID}.sh
+COUNT=1
+until [ -f "$config_path" ]; do
+  echo Waiting for the operator to mount pod config $config_path. Attempt $(( COUNT++ ))...
+  sleep 10
+done
+
+source $config_path
+
+if [[ "$RESTORE_FROM_BACKUP" != "true" ]]; then
+  echo not restoring from backup since the cluster bootstrap is finished
+  exit 0
+fi
+
+restore_dir=` + restoreFromBackupDir + `
+if [ -f "${restore_dir}/completed" ]; then
+  echo not restoring from backup since the node is already restored
+  exit 0
+fi
+
+if [ ! -d "${restore_dir}" ] && [ -d "/var/lib/cassandra/data" ] && [ -n "$(ls -A /var/lib/cassandra/data)" ]; then
+  echo not restoring from backup since the storage directory is not empty
+  exit 0
+fi
+
+mkdir -p ${restore_dir}
+cp /etc/cassandra-configmaps/cassandra.yaml ${restore_dir}/cassandra.yaml
+echo restoring node from ${RESTORE_STORAGE_LOCATION}
+` + strings.Join(restoreArgs, " \\\n  ") + ` && touch ${restore_dir}/completed`,
+	}
+
+	return v1.Container{
+		Name:            "restore-from-backup",
+		Image:           cc.Spec.Icarus.Image,
+		ImagePullPolicy: cc.Spec.Icarus.ImagePullPolicy,
+		Resources:       cc.Spec.Icarus.Resources,
+		VolumeMounts: []v1.VolumeMount{
+			cassandraDataVolumeMount(),
+			cassandraDCConfigVolumeMount(),
+			podsConfigVolumeMount(),
+		},
+		Env: []v1.EnvVar{
+			{
+				Name: "POD_NAME",
+				ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"},
+				},
+			},
+			{
+				Name: "POD_UID",
+				ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.uid"},
+				},
+			},
+		},
+		Command: []string{
+			"bash",
+			"-c",
+		},
+		Args:                     args,
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: v1.TerminationMessageReadFile,
+	}
+}
+
 func privilegedInitContainer(cc *dbv1alpha1.CassandraCluster) v1.Container {
 	memory := resource.MustParse("200Mi")
 	cpu := resource.MustParse("0.5")
@@ -156,16 +258,56 @@ func maintenanceContainer(cc *dbv1alpha1.CassandraCluster) v1.Container {
 			"-c",
 		},
 		Args: []string{
-			fmt.Sprintf("while [[ -f %s/${HOSTNAME} ]]; do sleep 10; done", maintenanceDir),
+			markRepairedScript(cc) + fmt.Sprintf("while [[ -f %s/${HOSTNAME} ]]; do sleep 10; done", maintenanceDir),
 		},
 		TerminationMessagePath:   "/dev/termination-log",
 		TerminationMessagePolicy: v1.TerminationMessageReadFile,
 	}
 }
 
+// markRepairedScript marks the SSTables of the keyspaces migrated to incremental repairs as repaired.
+// The SSTables can be marked as repaired only while Cassandra is stopped.
+// The script is rendered only for clusters with incremental repairs, so that the pods of other clusters are not restarted.
+func markRepairedScript(cc *dbv1alpha1.CassandraCluster) string {
+	if len(incrementalRepairKeyspaces(cc)) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`if [[ -f %[1]s/${HOSTNAME}%[2]s ]]; then
+  for keyspace in $(cat %[1]s/${HOSTNAME}%[2]s); do
+    find /var/lib/cassandra/data/${keyspace} -name '*-Data.db' > /tmp/sstables
+    if [[ -s /tmp/sstables ]]; then
+      $CASSANDRA_HOME/tools/bin/sstablerepairedset --really-set --is-repaired -f /tmp/sstables || exit 1
+    fi
+  done
+fi
+`, maintenanceDir, markRepairedKeySuffix)
+}
+
 func maintenanceVolumeMount() v1.VolumeMount {
 	return v1.VolumeMount{
 		Name:      "maintenance-config",
 		MountPath: maintenanceDir,
 	}
 }
+
+// insertContainerBefore replaces the container with the same name or inserts it before the container named `before`.
+// The container is appended if there's no container named `before`.
+func insertContainerBefore(containers []v1.Container, container v1.Container, before string) []v1.Container {
+	for i := range containers {
+		if containers[i].Name == container.Name {
+			containers[i] = container
+			return containers
+		}
+	}
+
+	for i := range containers {
+		if containers[i].Name == before {
+			containers = append(containers[:i+1], containers[i:]...)
+			containers[i] = container
+			return containers
+		}
+	}
+
+	return append(containers, container)
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+func TestInsertContainerBefore(t *testing.T) {
+	g := NewGomegaWithT(t)
+	containers := []v1.Container{{Name: "maintenance-mode"}, {Name: "init"}}
+
+	containers = insertContainerBefore(containers, v1.Container{Name: "restore-from-backup"}, "init")
+	g.Expect(containers).To(Equal([]v1.Container{{Name: "maintenance-mode"}, {Name: "restore-from-backup"}, {Name: "init"}}))
+
+	containers = insertContainerBefore(containers, v1.Container{Name: "restore-from-backup", Image: "image"}, "init")
+	g.Expect(containers).To(Equal([]v1.Container{{Name: "maintenance-mode"}, {Name: "restore-from-backup", Image: "image"}, {Name: "init"}}))
+
+	containers = insertContainerBefore(containers, v1.Container{Name: "sidecar"}, "missing")
+	g.Expect(containers).To(HaveLen(4))
+	g.Expect(containers[3].Name).To(Equal("sidecar"))
+}
+
+func TestMaintenanceContainerArgs(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		Spec: v1alpha1.CassandraClusterSpec{
+			Cassandra: &v1alpha1.Cassandra{},
+			Reaper: &v1alpha1.Reaper{
+				RepairSchedules: v1alpha1.RepairSchedules{
+					Enabled: true,
+					Repairs: []v1alpha1.RepairSchedule{{Keyspace: "full_keyspace"}},
+				},
+			},
+		},
+	}
+
+	// the pod template of clusters without incremental repairs doesn't change
+	g.Expect(maintenanceContainer(cc).Args).To(Equal([]string{"while [[ -f /etc/maintenance/${HOSTNAME} ]]; do sleep 10; done"}))
+
+	cc.Spec.Reaper.RepairSchedules.Repairs[0].IncrementalRepair = true
+	args := maintenanceContainer(cc).Args
+	g.Expect(args).To(HaveLen(1))
+	g.Expect(args[0]).To(ContainSubstring("sstablerepairedset --really-set --is-repaired"))
+	g.Expect(args[0]).To(HaveSuffix("while [[ -f /etc/maintenance/${HOSTNAME} ]]; do sleep 10; done"))
+}
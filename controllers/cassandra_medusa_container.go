@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	v1 "k8s.io/api/core/v1"
+)
+
+func medusaContainer(cc *dbv1alpha1.CassandraCluster) v1.Container {
+	container := v1.Container{
+		Name:                     medusa.ContainerName,
+		Image:                    cc.Spec.Medusa.Image,
+		ImagePullPolicy:          cc.Spec.Medusa.ImagePullPolicy,
+		Resources:                cc.Spec.Medusa.Resources,
+		Env:                      medusaEnv(cc, "GRPC"),
+		VolumeMounts:             medusaVolumeMounts(),
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: v1.TerminationMessageReadFile,
+	}
+
+	medusaPort := v1.ContainerPort{
+		Name:          "medusa",
+		ContainerPort: dbv1alpha1.MedusaPort,
+		Protocol:      v1.ProtocolTCP,
+		HostPort:      0,
+	}
+
+	if cc.Spec.HostPort.Enabled {
+		medusaPort.HostPort = dbv1alpha1.MedusaPort
+	}
+
+	container.Ports = append(container.Ports, medusaPort)
+
+	return container
+}
+
+// medusaRestoreContainer applies the prepared restore before Cassandra starts.
+// Medusa keeps track of the applied restore keys and skips the restore on the later restarts.
+func medusaRestoreContainer(cc *dbv1alpha1.CassandraCluster, restore *dbv1alpha1.MedusaRestore) v1.Container {
+	return v1.Container{
+		Name:            medusa.RestoreContainerName,
+		Image:           cc.Spec.Medusa.Image,
+		ImagePullPolicy: cc.Spec.Medusa.ImagePullPolicy,
+		Resources:       cc.Spec.Medusa.Resources,
+		Env: append(medusaEnv(cc, "RESTORE"),
+			v1.EnvVar{Name: medusa.RestoreKeyEnvVar, Value: restore.RestoreKey},
+			v1.EnvVar{Name: medusa.BackupNameEnvVar, Value: restore.BackupName},
+		),
+		VolumeMounts:             medusaVolumeMounts(),
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: v1.TerminationMessageReadFile,
+	}
+}
+
+func medusaEnv(cc *dbv1alpha1.CassandraCluster, mode string) []v1.EnvVar {
+	env := []v1.EnvVar{
+		{
+			Name:  "MEDUSA_MODE",
+			Value: mode,
+		},
+		secretEnvVar("CQL_USERNAME", names.AdminAuthConfigSecret(cc.Name), dbv1alpha1.CassandraOperatorAdminRole, false),
+		secretEnvVar("CQL_PASSWORD", names.AdminAuthConfigSecret(cc.Name), dbv1alpha1.CassandraOperatorAdminPassword, false),
+	}
+
+	if cc.Spec.Medusa.StorageProvider() != dbv1alpha1.StorageProviderGCP {
+		// not set keys fall back to the instance credentials
+		env = append(env,
+			secretEnvVar("AWS_ACCESS_KEY_ID", cc.Spec.Medusa.SecretName, "awsaccesskeyid", true),
+			secretEnvVar("AWS_SECRET_ACCESS_KEY", cc.Spec.Medusa.SecretName, "awssecretaccesskey", true),
+		)
+	}
+
+	return env
+}
+
+func medusaVolumeMounts() []v1.VolumeMount {
+	return []v1.VolumeMount{
+		cassandraDataVolumeMount(),
+		cassandraDCConfigVolumeMount(),
+		medusaConfigVolumeMount(),
+		medusaSecretsVolumeMount(),
+	}
+}
+
+func secretEnvVar(name, secretName, key string, optional bool) v1.EnvVar {
+	envVar := v1.EnvVar{
+		Name: name,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+
+	if optional {
+		envVar.ValueFrom.SecretKeyRef.Optional = &optional
+	}
+
+	return envVar
+}
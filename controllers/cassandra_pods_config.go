@@ -104,6 +104,12 @@ func (r *CassandraClusterReconciler) podsConfigMapData(ctx context.Context, cc *
 		pauseInit, pauseReason := pausePodInit(pod, nextDCToInit, currentRegionPaused, seedNodesReady, nextNonSeedPodName)
 		cmData[entryName] += fmt.Sprintln("export PAUSE_INIT=" + fmt.Sprint(pauseInit))
 		cmData[entryName] += fmt.Sprintf("export PAUSE_REASON=\"%s\"\n", pauseReason)
+
+		if cc.Spec.RestoreFrom != nil {
+			restoreFromBackup := cc.Status.RestoreFromState != v1alpha1.RestoreFromStateCompleted
+			cmData[entryName] += fmt.Sprintln("export RESTORE_FROM_BACKUP=" + fmt.Sprint(restoreFromBackup))
+			cmData[entryName] += fmt.Sprintln("export RESTORE_STORAGE_LOCATION=" + restoreStorageLocation(cc, pod))
+		}
 	}
 
 	return cmData, nil
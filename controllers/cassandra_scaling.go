@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"k8s.io/apimachinery/pkg/types"
@@ -60,6 +61,10 @@ func (r *CassandraClusterReconciler) reconcileCassandraScaling(ctx context.Conte
 			continue
 		}
 
+		if err = r.ensureRepairsPaused(ctx, cc, repairsPauseReasonScaling); err != nil {
+			return true, err
+		}
+
 		if oldReplicas < newReplicas { // scale up
 			sts.Spec.Replicas = &newReplicas
 			err = r.Update(ctx, &sts)
@@ -90,6 +95,10 @@ func (r *CassandraClusterReconciler) reconcileCassandraScaling(ctx context.Conte
 		return false, nil
 	}
 
+	if err = r.ensureRepairsPaused(ctx, cc, repairsPauseReasonScaling); err != nil {
+		return true, err
+	}
+
 	err = r.handleDCsDecommission(ctx, cc, stsToDecommissionNames, stsToDecommission, allDCs, adminRoleSecret, broadcastAddresses, podList)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to decommission DCs")
@@ -132,6 +141,11 @@ func (r *CassandraClusterReconciler) handleDCsDecommission(ctx context.Context,
 		}
 	}
 
+	err = r.ensureSafetySnapshot(ctx, cc, safetySnapshotDecommission, strings.Join(stsNames, ","), nil)
+	if err != nil {
+		return err
+	}
+
 	// prepare system keyspaces to not replicate to DCs being decommissioned
 	for _, systemKeyspace := range keyspacesToReconcile {
 		// system_auth should be reconciled only after scaledown is finished, otherwise will get quorum errors on JMX requests
@@ -203,7 +217,7 @@ func (r *CassandraClusterReconciler) handlePodDecommission(ctx context.Context,
 	if err != nil {
 		r.Log.Warn("can't extract secret data")
 	}
-	nctl := r.NodectlClient(jolokiaURL(cc).String(), roleName, rolePassword, r.Log)
+	nctl := r.NodectlClient(names.JolokiaURL(cc).String(), roleName, rolePassword, r.Log)
 
 	broadcastIP := broadcastAddresses[decommissionPod.Name]
 	r.Log.Debugf("checking operation mode for node %s", decommissionPod.Name)
@@ -69,6 +69,22 @@ func (r *CassandraClusterReconciler) reconcileDCStatefulSet(ctx context.Context,
 		// scaling is handled by the scaling logic
 		desiredSts.Spec.Replicas = actualSts.Spec.Replicas
 		if !compare.EqualStatefulSet(desiredSts, actualSts) {
+			restartedSts := actualSts.DeepCopy()
+			restartedSts.Spec.Template = desiredSts.Spec.Template
+			if !compare.EqualStatefulSet(restartedSts, actualSts) {
+				if err = r.ensureRepairsPaused(ctx, cc, repairsPauseReasonRollingRestart); err != nil {
+					return err
+				}
+			}
+
+			desiredImage := cassandraContainerImage(desiredSts)
+			if actualImage := cassandraContainerImage(actualSts); len(actualImage) != 0 && actualImage != desiredImage {
+				err = r.ensureSafetySnapshot(ctx, cc, safetySnapshotUpgrade, desiredImage, nil)
+				if err != nil {
+					return err
+				}
+			}
+
 			r.Log.Info("Updating cassandra statefulset")
 			r.Log.Debug(compare.DiffStatefulSet(actualSts, desiredSts))
 			actualSts.Spec = desiredSts.Spec
@@ -154,6 +170,25 @@ func cassandraStatefulSet(cc *dbv1alpha1.CassandraCluster, dc dbv1alpha1.DC, res
 		}
 	}
 
+	if cc.Spec.RestoreFrom != nil {
+		// nodes download the backup in parallel, so the restore runs before the init container pauses the pod
+		desiredSts.Spec.Template.Spec.InitContainers = insertContainerBefore(desiredSts.Spec.Template.Spec.InitContainers, restoreFromBackupContainer(cc), initContainer(cc).Name)
+	}
+
+	if medusaEnabled(cc) {
+		// Medusa replaces Icarus as the backup sidecar
+		desiredSts.Spec.Template.Spec.Containers[1] = medusaContainer(cc)
+		desiredSts.Spec.Template.Spec.Volumes = append(desiredSts.Spec.Template.Spec.Volumes, medusaConfigVolume(cc), medusaSecretsVolume(cc))
+		if restore := cc.Status.MedusaRestore; restore != nil && util.Contains(restore.DCs, dc.Name) {
+			// the init container is kept after the restore, so the statefulset isn't updated again
+			desiredSts.Spec.Template.Spec.InitContainers = append(desiredSts.Spec.Template.Spec.InitContainers, medusaRestoreContainer(cc, restore))
+		}
+	}
+
+	if cc.Spec.Cassandra.CommitLogArchiving != nil {
+		desiredSts.Spec.Template.Spec.Containers = append(desiredSts.Spec.Template.Spec.Containers, commitLogShipperContainer(cc, dc))
+	}
+
 	if cc.Spec.Cassandra.Persistence.Enabled {
 		desiredSts.Spec.VolumeClaimTemplates = cassandraVolumeClaims(cc)
 	} else {
@@ -380,3 +415,13 @@ func tlsJVMArgs(cc *dbv1alpha1.CassandraCluster, clientTLSSecret *v1.Secret) str
 		cassandraClientTLSDir, cc.Spec.Encryption.Client.NodeTLSSecret.KeystoreFileKey, strings.TrimRight(string(clientTLSSecret.Data[cc.Spec.Encryption.Client.NodeTLSSecret.KeystorePasswordKey]), "\r\n"),
 		cassandraClientTLSDir, cc.Spec.Encryption.Client.NodeTLSSecret.TruststoreFileKey, strings.TrimRight(string(clientTLSSecret.Data[cc.Spec.Encryption.Client.NodeTLSSecret.TruststorePasswordKey]), "\r\n"))
 }
+
+func cassandraContainerImage(sts *appsv1.StatefulSet) string {
+	for _, container := range sts.Spec.Template.Spec.Containers {
+		if container.Name == "cassandra" {
+			return container.Image
+		}
+	}
+
+	return ""
+}
@@ -68,7 +68,7 @@ func TestValidateTLSFields(t *testing.T) {
 		tClient := fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(k8sResources...).Build()
 		tc.cc.Spec.Encryption.Server = tc.params["cluster-node-tls-secret"].(v1alpha1.ServerEncryption)
 		reconciler.Client = tClient
-		reconciler.defaultNodeTLSKeys(&tc.cc.Spec.Encryption.Server.NodeTLSSecret)
+		tc.cc.Spec.Encryption.Server.NodeTLSSecret.SetDefaults()
 		err := reconciler.validateTLSFields(tc.cc, nodeTLSSecret, serverNode)
 		asserts.Expect(err).To(tc.errorMatcher)
 	})
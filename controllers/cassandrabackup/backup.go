@@ -2,40 +2,79 @@ package cassandrabackup
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/events"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	v1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (r *CassandraBackupReconciler) reconcileBackup(ctx context.Context, ic icarus.Icarus, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster) (ctrl.Result, error) {
-	existingBackups, err := ic.Backups(ctx)
+func (r *CassandraBackupReconciler) reconcileBackup(ctx context.Context, engine backupengine.Engine, cb *v1alpha1.CassandraBackup,
+	cc *v1alpha1.CassandraCluster, coordinator string, readyPods []v1.Pod, storageCredentials *v1.Secret) (ctrl.Result, error) {
+	if err := r.reconcileCoordinator(ctx, cb, coordinator); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	existingBackups, err := r.Poller.Backups(ctx, cc, engine, cb)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	icarusBackup, relatedIcarusBackupFound := r.findRelatedBackup(cb, existingBackups)
+	if len(cb.Status.OperationID) != 0 && (!relatedIcarusBackupFound || icarusBackup.ID != cb.Status.OperationID) {
+		// the coordinator has changed or lost the global request after a restart
+		icarusBackup, relatedIcarusBackupFound = r.findBackupOnNodes(ctx, cc, readyPods, cb.Status.OperationID)
+		if !relatedIcarusBackupFound && cb.Status.State != icarus.StateFailed {
+			errMsg := fmt.Sprintf("Backup operation %s of backup %s/%s not found on any node. Sending a new backup request", cb.Status.OperationID, cb.Namespace, cb.Name)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cb, events.EventIcarusOperationLost, errMsg)
+		}
+	}
 
 	if cb.Status.State == icarus.StateFailed {
+		// a failed backup is retried only on a configuration change, which triggers the reconcile itself
+		r.Poller.ForgetBackup(client.ObjectKeyFromObject(cb))
 		if !relatedIcarusBackupFound {
 			r.Log.Infof("Backup %s/%s has failed and no respecting backup record found in icarus. "+
 				"Recreate the CassandraBackup resource to start a new backup attempt", cb.Namespace, cb.Name)
 			return ctrl.Result{}, err
 		}
 
-		return ctrl.Result{}, r.reconcileFailedBackup(ctx, ic, icarusBackup, cc, cb)
+		return ctrl.Result{}, r.reconcileFailedBackup(ctx, engine, icarusBackup, cc, cb)
 	}
 
 	// create if not found or found, but it's a new backup with the same tag (e.g. incremental backup)
 	if !relatedIcarusBackupFound || (relatedIcarusBackupFound && len(cb.Status.State) == 0) {
-		icarusBackup, err = ic.Backup(ctx, createBackupRequest(cc, cb))
+		storageVerified, err := r.verifyStorage(ctx, cb, cc, storageCredentials)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if !storageVerified {
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+
+		if cc.Spec.BackupEngine == v1alpha1.BackupEngineMedusa && !backupengine.AllNodesReady(cc, readyPods) {
+			// Medusa backs up only the nodes that get the request
+			errMsg := fmt.Sprintf("Not all Medusa sidecars of cluster %q are ready. Not starting backup, trying again in %s", cc.Name, r.Cfg.RetryDelay)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cb, events.EventMedusaNodesNotReady, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+
+		icarusBackup, err = engine.Backup(ctx, createBackupRequest(cc, cb))
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 
 		r.Log.Debugf("Backup request sent")
+		r.Poller.Refresh(cc)
 	}
 
 	err = r.reconcileStatus(ctx, cb, icarusBackup)
@@ -43,7 +82,8 @@ func (r *CassandraBackupReconciler) reconcileBackup(ctx context.Context, ic icar
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	// the poller triggers the reconcile when the operation changes
+	return ctrl.Result{}, nil
 }
 
 func (r *CassandraBackupReconciler) findRelatedBackup(cb *v1alpha1.CassandraBackup, icarusBackups []icarus.Backup) (icarus.Backup, bool) {
@@ -102,17 +142,24 @@ func (r *CassandraBackupReconciler) findRelatedBackup(cb *v1alpha1.CassandraBack
 	return existingBackup, true
 }
 
-func (r *CassandraBackupReconciler) reconcileFailedBackup(ctx context.Context, ic icarus.Icarus, existingBackup icarus.Backup,
+func (r *CassandraBackupReconciler) reconcileFailedBackup(ctx context.Context, engine backupengine.Engine, existingBackup icarus.Backup,
 	cc *v1alpha1.CassandraCluster, cb *v1alpha1.CassandraBackup) error {
+	if cc.Spec.BackupEngine == v1alpha1.BackupEngineMedusa {
+		// Medusa doesn't keep the request configuration, so a config change can't be detected
+		r.Log.Infof("Backup %s/%s has failed. Recreate the CassandraBackup resource to start a new backup attempt", cb.Namespace, cb.Name)
+		return nil
+	}
+
 	newBackupRequest := createBackupRequest(cc, cb)
 	if r.backupConfigChanged(existingBackup, newBackupRequest) {
 		r.Log.Info("Detected a configuration change for backup %s/%s, sending a new backup request", cb.Namespace, cb.Name)
-		icarusBackup, err := ic.Backup(ctx, newBackupRequest)
+		icarusBackup, err := engine.Backup(ctx, newBackupRequest)
 		if err != nil {
 			return err
 		}
+		r.Poller.Refresh(cc)
 
-		cb.Status = v1alpha1.CassandraBackupStatus{} // reset status since we're restarting backup in Icarus
+		cb.Status = v1alpha1.CassandraBackupStatus{Coordinator: cb.Status.Coordinator} // reset status since we're restarting backup in Icarus
 		err = r.reconcileStatus(ctx, cb, icarusBackup)
 		if err != nil {
 			return err
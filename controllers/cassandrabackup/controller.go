@@ -10,10 +10,13 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
 	"github.com/ibm/cassandra-operator/controllers/config"
 	"github.com/ibm/cassandra-operator/controllers/events"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
-	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/metrics"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,18 +25,24 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // CassandraBackupReconciler reconciles a CassandraCluster object
 type CassandraBackupReconciler struct {
 	client.Client
-	Log          *zap.SugaredLogger
-	Scheme       *runtime.Scheme
-	Cfg          config.Config
-	Events       *events.EventRecorder
-	IcarusClient func(coordinatorPodURL string) icarus.Icarus
+	Log           *zap.SugaredLogger
+	Scheme        *runtime.Scheme
+	Cfg           config.Config
+	Events        *events.EventRecorder
+	IcarusClient  func(coordinatorPodURL string) icarus.Icarus
+	MedusaClient  func(addr string) medusa.Medusa
+	StorageClient func(location storage.Location, secret *v1.Secret, insecure bool) (storage.StorageClient, error)
+	Poller        *backupengine.Poller
 }
 
 // +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackups,verbs=get;list;watch;create;update;patch;delete
@@ -44,13 +53,19 @@ func (r *CassandraBackupReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	err := r.Get(ctx, req.NamespacedName, cb)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
+			r.Poller.ForgetBackup(req.NamespacedName)
+			metrics.ForgetBackup(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
+	// restores the metrics of existing resources after an operator restart
+	metrics.UpdateBackupMetrics(cb)
+
 	if cb.Status.State == icarus.StateCompleted {
 		r.Log.Debugf("Backup %v is compeleted", cb.Name)
+		r.Poller.ForgetBackup(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
@@ -92,14 +107,22 @@ func (r *CassandraBackupReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
-	dcName := cc.Spec.DCs[0].Name
-	svc := names.DC(cc.Name, dcName)
-	//always use the same pod as the coordinator as only that pod has the global request info
-	coordinatorPodURL := fmt.Sprintf("http://%s-0.%s.%s.svc.cluster.local:%d", svc, svc, cc.Namespace, v1alpha1.IcarusPort)
+	readyPods, err := backupengine.ReadyPods(ctx, r.Client, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	coordinator, found := icarus.SelectCoordinator(readyPods, cb.Status.Coordinator)
+	if !found {
+		errMsg := fmt.Sprintf("No ready %s sidecar found in cluster %q to coordinate the backup. Trying again in %s", backupengine.Name(cc), cc.Name, r.Cfg.RetryDelay)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cb, events.EventIcarusCoordinatorUnavailable, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
 
-	ic := r.IcarusClient(coordinatorPodURL)
+	engine := r.backupEngine(cc, coordinator, readyPods)
 
-	res, err := r.reconcileBackup(ctx, ic, cb, cc)
+	res, err := r.reconcileBackup(ctx, engine, cb, cc, coordinator.Name, readyPods, storageCredentials)
 	if err != nil {
 		if statusErr, ok := errors.Cause(err).(*kerrors.StatusError); ok && statusErr.ErrStatus.Reason == metav1.StatusReasonConflict {
 			r.Log.Info("Conflict occurred. Retrying...", zap.Error(err))
@@ -113,10 +136,15 @@ func (r *CassandraBackupReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	return res, nil
 }
 
-func SetupCassandraBackupReconciler(r reconcile.Reconciler, mgr manager.Manager) error {
+func (r *CassandraBackupReconciler) backupEngine(cc *v1alpha1.CassandraCluster, coordinator v1.Pod, readyPods []v1.Pod) backupengine.Engine {
+	return backupengine.New(cc, backupengine.Clients{Icarus: r.IcarusClient, Medusa: r.MedusaClient}, coordinator, readyPods)
+}
+
+func SetupCassandraBackupReconciler(r reconcile.Reconciler, mgr manager.Manager, reconcileChan chan event.GenericEvent) error {
 	builder := ctrl.NewControllerManagedBy(mgr).
 		Named("cassandrabackup").
-		For(&v1alpha1.CassandraBackup{})
+		For(&v1alpha1.CassandraBackup{}).
+		Watches(&source.Channel{Source: reconcileChan}, &handler.EnqueueRequestForObject{})
 
 	return builder.Complete(r)
 }
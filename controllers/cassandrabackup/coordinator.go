@@ -0,0 +1,73 @@
+package cassandrabackup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	v1 "k8s.io/api/core/v1"
+)
+
+// reconcileCoordinator persists the coordinator in the status so that the same pod is used while it's ready
+func (r *CassandraBackupReconciler) reconcileCoordinator(ctx context.Context, cb *v1alpha1.CassandraBackup, coordinator string) error {
+	if cb.Status.Coordinator == coordinator {
+		return nil
+	}
+
+	if len(cb.Status.Coordinator) != 0 {
+		msg := fmt.Sprintf("Icarus coordinator of backup %s/%s changed from pod %s to %s", cb.Namespace, cb.Name, cb.Status.Coordinator, coordinator)
+		r.Log.Info(msg)
+		r.Events.Normal(cb, events.EventIcarusCoordinatorChanged, msg)
+	}
+
+	cb.Status.Coordinator = coordinator
+	return r.Status().Update(ctx, cb)
+}
+
+// findBackupOnNodes looks for the backup operation on all nodes. Used if the coordinator has changed
+// or has been restarted and doesn't have the global request anymore.
+func (r *CassandraBackupReconciler) findBackupOnNodes(ctx context.Context, cc *v1alpha1.CassandraCluster, readyPods []v1.Pod, operationID string) (icarus.Backup, bool) {
+	var nodeBackups []icarus.Backup
+	for _, pod := range readyPods {
+		backups, err := r.backupEngine(cc, pod, []v1.Pod{pod}).Backups(ctx)
+		if err != nil {
+			r.Log.Warnf("Failed to get backups from %s of pod %s: %s", backupengine.Name(cc), pod.Name, err.Error())
+			continue
+		}
+
+		for _, backup := range backups {
+			if backup.ID == operationID {
+				nodeBackups = append(nodeBackups, backup)
+			}
+		}
+	}
+
+	if len(nodeBackups) == 0 {
+		return icarus.Backup{}, false
+	}
+
+	return mergeNodeBackups(nodeBackups), true
+}
+
+// mergeNodeBackups combines the backups of the same operation from different nodes into one
+func mergeNodeBackups(nodeBackups []icarus.Backup) icarus.Backup {
+	merged := nodeBackups[0]
+	merged.State = icarus.StateCompleted
+	merged.Progress = 0
+	merged.Errors = nil
+	for _, nodeBackup := range nodeBackups {
+		merged.Progress += nodeBackup.Progress / float64(len(nodeBackups))
+		merged.Errors = append(merged.Errors, nodeBackup.Errors...)
+		switch {
+		case nodeBackup.State == icarus.StateFailed || merged.State == icarus.StateFailed:
+			merged.State = icarus.StateFailed
+		case nodeBackup.State != icarus.StateCompleted:
+			merged.State = nodeBackup.State
+		}
+	}
+
+	return merged
+}
@@ -2,16 +2,24 @@ package cassandrabackup
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func (r *CassandraBackupReconciler) reconcileStatus(ctx context.Context, cb *v1alpha1.CassandraBackup, relatedIcarusBackup icarus.Backup) error {
+	metrics.ObserveBackup(cb, relatedIcarusBackup)
+
 	backupStatus := cb.DeepCopy()
 	//found, update state
 	backupStatus.Status.Progress = int(relatedIcarusBackup.Progress * 100)
+	if len(relatedIcarusBackup.ID) != 0 {
+		backupStatus.Status.OperationID = relatedIcarusBackup.ID
+	}
 	if cb.Status.State != relatedIcarusBackup.State {
 		backupStatus.Status.State = relatedIcarusBackup.State
 		if relatedIcarusBackup.State == icarus.StateFailed {
@@ -25,6 +33,14 @@ func (r *CassandraBackupReconciler) reconcileStatus(ctx context.Context, cb *v1a
 
 			backupStatus.Status.Errors = backupErrors
 		}
+
+		if relatedIcarusBackup.State == icarus.StateCompleted {
+			completionTime := metav1.NewTime(parseTime(relatedIcarusBackup.CompletionTime, time.Now()))
+			backupStatus.Status.CompletionTime = &completionTime
+			if startTime, err := time.Parse(time.RFC3339, relatedIcarusBackup.StartTime); err == nil {
+				backupStatus.Status.StartTime = &metav1.Time{Time: startTime}
+			}
+		}
 	}
 
 	if !cmp.Equal(cb.Status, backupStatus.Status) {
@@ -36,5 +52,15 @@ func (r *CassandraBackupReconciler) reconcileStatus(ctx context.Context, cb *v1a
 		}
 	}
 
+	metrics.UpdateBackupMetrics(backupStatus)
 	return nil
 }
+
+func parseTime(value string, defaultTime time.Time) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return defaultTime
+	}
+
+	return parsed
+}
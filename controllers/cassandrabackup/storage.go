@@ -0,0 +1,97 @@
+package cassandrabackup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const storageVerificationTimeout = 30 * time.Second
+
+var errMedusaStorage = errors.New("the backups are uploaded by Medusa to .spec.medusa.storageLocation of the CassandraCluster")
+
+// verifyStorage checks that the storage location can be used before the backup request is sent to Icarus.
+// The result is reflected in the StorageVerified condition. Returns false if the backup should not be started.
+func (r *CassandraBackupReconciler) verifyStorage(ctx context.Context, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster, storageCredentials *v1.Secret) (bool, error) {
+	condition := metav1.Condition{
+		Type:               v1alpha1.BackupConditionStorageVerified,
+		Status:             metav1.ConditionTrue,
+		Reason:             storage.ReasonVerified,
+		Message:            "Storage location is accessible and writable",
+		ObservedGeneration: cb.Generation,
+	}
+
+	err := r.checkStorage(ctx, cb, cc, storageCredentials)
+	verificationErr := &storage.VerificationError{}
+	switch {
+	case err == nil:
+	case errors.Is(err, storage.ErrUnsupportedProvider):
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = storage.ReasonNotVerified
+		condition.Message = fmt.Sprintf("Storage location can't be verified: %s %s. "+
+			"The backup is started without checking the bucket and the credentials", err.Error(), cb.StorageProvider())
+	case errors.Is(err, storage.ErrNoCredentials), errors.Is(err, errMedusaStorage):
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = storage.ReasonNotVerified
+		condition.Message = fmt.Sprintf("Storage location can't be verified: %s", err.Error())
+	case errors.As(err, &verificationErr):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = verificationErr.Reason
+		condition.Message = verificationErr.Message
+		if verificationErr.Reason == storage.ReasonBucketNotFound && cb.Spec.CreateMissingBucket {
+			condition.Status = metav1.ConditionUnknown
+			condition.Message = "Bucket doesn't exist and will be created by Icarus"
+		}
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = storage.ReasonStorageUnreachable
+		condition.Message = err.Error()
+	}
+
+	if condition.Status == metav1.ConditionFalse {
+		errMsg := fmt.Sprintf("Storage verification failed for backup %s/%s: %s", cb.Namespace, cb.Name, condition.Message)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cb, events.EventStorageVerificationFailed, errMsg)
+	}
+
+	existingCondition := meta.FindStatusCondition(cb.Status.Conditions, condition.Type)
+	if existingCondition == nil || existingCondition.Status != condition.Status || existingCondition.Reason != condition.Reason ||
+		existingCondition.Message != condition.Message || existingCondition.ObservedGeneration != condition.ObservedGeneration {
+		meta.SetStatusCondition(&cb.Status.Conditions, condition)
+		if err = r.Status().Update(ctx, cb); err != nil {
+			return false, errors.Wrap(err, "failed to update storage verification condition")
+		}
+	}
+
+	return condition.Status != metav1.ConditionFalse, nil
+}
+
+func (r *CassandraBackupReconciler) checkStorage(ctx context.Context, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster, storageCredentials *v1.Secret) error {
+	if cc.Spec.BackupEngine == v1alpha1.BackupEngineMedusa {
+		return errMedusaStorage
+	}
+
+	location, err := storage.ParseLocation(cb.Spec.StorageLocation)
+	if err != nil {
+		return &storage.VerificationError{Reason: storage.ReasonInvalidStorageLocation, Message: err.Error()}
+	}
+
+	storageClient, err := r.StorageClient(location, storageCredentials, cb.Spec.Insecure)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, storageVerificationTimeout)
+	defer cancel()
+
+	objectKey := fmt.Sprintf("%s/.cassandra-operator-verification-%s", cb.Spec.CassandraCluster, cb.Name)
+	return storage.Verify(ctx, storageClient, location, objectKey, cb.Spec.SkipBucketVerification)
+}
@@ -0,0 +1,138 @@
+package cassandrabackupcatalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// scanBackups finds the backups in the storage location by their manifests. The size of backups
+// found in a previous scan is reused to avoid downloading their manifests again.
+func scanBackups(ctx context.Context, client storage.StorageClient, location storage.Location, knownBackups []v1alpha1.CatalogBackup) ([]v1alpha1.CatalogBackup, error) {
+	prefix := ""
+	if len(location.Path) != 0 {
+		prefix = location.Path + "/"
+	}
+
+	objects, err := client.ListObjects(ctx, prefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list objects in bucket %s", location.Bucket)
+	}
+
+	manifests := make(map[string][]icarus.ManifestKey)
+	for _, object := range objects {
+		key, ok := icarus.ParseManifestKey(strings.TrimPrefix(object.Key, prefix))
+		if !ok {
+			continue
+		}
+
+		key.Key = object.Key
+		id := backupID(key.Cluster, key.SnapshotTag, key.SchemaVersion)
+		manifests[id] = append(manifests[id], key)
+	}
+
+	backups := make([]v1alpha1.CatalogBackup, 0, len(manifests))
+	for _, backupManifests := range manifests {
+		backup := catalogBackup(backupManifests)
+		if knownBackup, found := findBackup(knownBackups, backup); found && knownBackup.Nodes == backup.Nodes && knownBackup.Timestamp.Equal(&backup.Timestamp) {
+			backup.SizeBytes = knownBackup.SizeBytes
+		} else {
+			for _, key := range backupManifests {
+				size, err := manifestSize(ctx, client, key.Key)
+				if err != nil {
+					return nil, err
+				}
+				backup.SizeBytes += size
+			}
+		}
+
+		backups = append(backups, backup)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].Timestamp.Equal(&backups[j].Timestamp) {
+			return backups[j].Timestamp.Before(&backups[i].Timestamp) // most recent first
+		}
+		return backupID(backups[i].Cluster, backups[i].SnapshotTag, backups[i].SchemaVersion) <
+			backupID(backups[j].Cluster, backups[j].SnapshotTag, backups[j].SchemaVersion)
+	})
+
+	return backups, nil
+}
+
+// catalogBackup combines the manifests of all nodes of a backup
+func catalogBackup(manifests []icarus.ManifestKey) v1alpha1.CatalogBackup {
+	backup := v1alpha1.CatalogBackup{
+		Cluster:       manifests[0].Cluster,
+		SnapshotTag:   manifests[0].SnapshotTag,
+		SchemaVersion: manifests[0].SchemaVersion,
+	}
+
+	nodes := make(map[string]map[string]bool)
+	for _, key := range manifests {
+		if nodes[key.DC] == nil {
+			nodes[key.DC] = make(map[string]bool)
+		}
+		nodes[key.DC][key.Node] = true
+
+		if key.Timestamp.After(backup.Timestamp.Time) {
+			backup.Timestamp = metav1.NewTime(key.Timestamp)
+		}
+	}
+
+	for dc, dcNodes := range nodes {
+		backup.DCs = append(backup.DCs, v1alpha1.CatalogDC{Name: dc, Nodes: int32(len(dcNodes))})
+		backup.Nodes += int32(len(dcNodes))
+	}
+
+	sort.Slice(backup.DCs, func(i, j int) bool {
+		return backup.DCs[i].Name < backup.DCs[j].Name
+	})
+
+	return backup
+}
+
+func manifestSize(ctx context.Context, client storage.StorageClient, key string) (int64, error) {
+	data, err := client.GetObject(ctx, key)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get manifest %s", key)
+	}
+
+	m := &icarus.Manifest{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return 0, errors.Wrapf(err, "failed to parse manifest %s", key)
+	}
+
+	var size int64
+	for _, keyspace := range m.Snapshot.Keyspaces {
+		for _, table := range keyspace.Tables {
+			for _, entry := range table.Entries {
+				size += entry.Size
+			}
+		}
+	}
+
+	return size, nil
+}
+
+func findBackup(backups []v1alpha1.CatalogBackup, backup v1alpha1.CatalogBackup) (v1alpha1.CatalogBackup, bool) {
+	for _, existingBackup := range backups {
+		if existingBackup.Cluster == backup.Cluster && existingBackup.SnapshotTag == backup.SnapshotTag && existingBackup.SchemaVersion == backup.SchemaVersion {
+			return existingBackup, true
+		}
+	}
+
+	return v1alpha1.CatalogBackup{}, false
+}
+
+func backupID(cluster, snapshotTag, schemaVersion string) string {
+	return fmt.Sprintf("%s/%s-%s", cluster, snapshotTag, schemaVersion)
+}
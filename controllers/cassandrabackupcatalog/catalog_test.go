@@ -0,0 +1,81 @@
+package cassandrabackupcatalog
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const schemaVersion = "8d2a4c2e-2b4f-3b1a-9c5e-4a7f3e2d1c0b"
+
+type fakeStorage struct {
+	objects map[string][]byte
+	gets    int
+}
+
+func (f *fakeStorage) BucketExists(ctx context.Context) (bool, error) { return true, nil }
+
+func (f *fakeStorage) PutObject(ctx context.Context, key string, data []byte) error { return nil }
+
+func (f *fakeStorage) DeleteObject(ctx context.Context, key string) error { return nil }
+
+func (f *fakeStorage) GetObject(ctx context.Context, key string) ([]byte, error) {
+	f.gets++
+	return f.objects[key], nil
+}
+
+func (f *fakeStorage) ListObjects(ctx context.Context, prefix string) ([]storage.Object, error) {
+	var objects []storage.Object
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, storage.Object{Key: key})
+		}
+	}
+	return objects, nil
+}
+
+func TestScanBackups(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeStorage{objects: map[string][]byte{
+		"prod/c1/dc1/n1/manifests/daily-" + schemaVersion + "-1630497600000.json":  []byte(`{"snapshot":{"keyspaces":{"ks":{"tables":{"t1":{"entries":[{"size":10}]},"t2":{"entries":[{"size":5}]}}}}}}`),
+		"prod/c1/dc1/n2/manifests/daily-" + schemaVersion + "-1630497605000.json":  []byte(`{"snapshot":{"keyspaces":{"ks":{"tables":{"t1":{"entries":[{"size":7}]}}}}}}`),
+		"prod/c1/dc2/n3/manifests/daily-" + schemaVersion + "-1630497601000.json":  []byte(`{"snapshot":{"keyspaces":{}}}`),
+		"prod/c1/dc1/n1/manifests/weekly-" + schemaVersion + "-1630411200000.json": []byte(`{"snapshot":{"keyspaces":{"ks":{"tables":{"t1":{"entries":[{"size":1}]}}}}}}`),
+		"prod/c1/dc1/n1/data/ks/t1/md-1-big-Data.db":                               []byte("data"),
+	}}
+	location := storage.Location{Provider: v1alpha1.StorageProviderS3, Bucket: "bucket", Path: "prod"}
+
+	backups, err := scanBackups(context.Background(), client, location, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups).To(Equal([]v1alpha1.CatalogBackup{
+		{
+			Cluster:       "c1",
+			SnapshotTag:   "daily",
+			SchemaVersion: schemaVersion,
+			DCs:           []v1alpha1.CatalogDC{{Name: "dc1", Nodes: 2}, {Name: "dc2", Nodes: 1}},
+			Nodes:         3,
+			SizeBytes:     22,
+			Timestamp:     metav1.NewTime(time.Date(2021, 9, 1, 12, 0, 5, 0, time.UTC)),
+		},
+		{
+			Cluster:       "c1",
+			SnapshotTag:   "weekly",
+			SchemaVersion: schemaVersion,
+			DCs:           []v1alpha1.CatalogDC{{Name: "dc1", Nodes: 1}},
+			Nodes:         1,
+			SizeBytes:     1,
+			Timestamp:     metav1.NewTime(time.Date(2021, 8, 31, 12, 0, 0, 0, time.UTC)),
+		},
+	}))
+	g.Expect(client.gets).To(Equal(4))
+
+	_, err = scanBackups(context.Background(), client, location, backups)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(client.gets).To(Equal(4), "manifests of known backups should not be downloaded again")
+}
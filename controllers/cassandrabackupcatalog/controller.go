@@ -0,0 +1,173 @@
+package cassandrabackupcatalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultRefreshInterval = 600 * time.Second
+	scanTimeout            = 5 * time.Minute
+
+	ReasonSynced                           = "Synced"
+	ReasonStorageCredentialsSecretNotFound = "StorageCredentialsSecretNotFound"
+	ReasonStorageCredentialsSecretInvalid  = "StorageCredentialsSecretInvalid"
+)
+
+// CassandraBackupCatalogReconciler reconciles a CassandraBackupCatalog object
+type CassandraBackupCatalogReconciler struct {
+	client.Client
+	Log           *zap.SugaredLogger
+	Scheme        *runtime.Scheme
+	Cfg           config.Config
+	Events        *events.EventRecorder
+	StorageClient func(location storage.Location, secret *v1.Secret, insecure bool) (storage.StorageClient, error)
+}
+
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackupcatalogs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrabackupcatalogs/status,verbs=get;update;patch
+
+func (r *CassandraBackupCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	catalog := &v1alpha1.CassandraBackupCatalog{}
+	err := r.Get(ctx, req.NamespacedName, catalog)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	res, err := r.reconcileCatalog(ctx, catalog)
+	if err != nil {
+		if statusErr, ok := errors.Cause(err).(*kerrors.StatusError); ok && statusErr.ErrStatus.Reason == metav1.StatusReasonConflict {
+			r.Log.Info("Conflict occurred. Retrying...", zap.Error(err))
+			return ctrl.Result{Requeue: true}, nil //retry but do not treat conflicts as errors
+		}
+
+		r.Log.Errorf("%+v", err)
+		return ctrl.Result{}, err
+	}
+
+	return res, nil
+}
+
+func (r *CassandraBackupCatalogReconciler) reconcileCatalog(ctx context.Context, catalog *v1alpha1.CassandraBackupCatalog) (ctrl.Result, error) {
+	storageCredentials := &v1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: catalog.Spec.SecretName, Namespace: catalog.Namespace}, storageCredentials)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			errMsg := fmt.Sprintf("Storage credentials secret %q not found", catalog.Spec.SecretName)
+			return r.syncFailed(ctx, catalog, ReasonStorageCredentialsSecretNotFound, events.EventStorageCredentialsSecretNotFound, errMsg)
+		}
+		return ctrl.Result{}, err
+	}
+
+	err = v1alpha1.ValidateStorageSecret(r.Log, storageCredentials, catalog.StorageProvider())
+	if err != nil {
+		errMsg := fmt.Sprintf("Storage credentials secret %q is invalid: %s", catalog.Spec.SecretName, err.Error())
+		return r.syncFailed(ctx, catalog, ReasonStorageCredentialsSecretInvalid, events.EventStorageCredentialsSecretInvalid, errMsg)
+	}
+
+	location, err := storage.ParseLocation(catalog.Spec.StorageLocation)
+	if err != nil {
+		return r.syncFailed(ctx, catalog, storage.ReasonInvalidStorageLocation, events.EventBackupCatalogSyncFailed, err.Error())
+	}
+
+	storageClient, err := r.StorageClient(location, storageCredentials, catalog.Spec.Insecure)
+	if err != nil {
+		errMsg := fmt.Sprintf("Can't scan storage location %s: %s", catalog.Spec.StorageLocation, err.Error())
+		return r.syncFailed(ctx, catalog, storage.ReasonNotVerified, events.EventBackupCatalogSyncFailed, errMsg)
+	}
+
+	scanCtx, cancel := context.WithTimeout(ctx, scanTimeout)
+	defer cancel()
+	backups, err := scanBackups(scanCtx, storageClient, location, catalog.Status.Backups)
+	if err != nil {
+		reason := storage.ReasonStorageUnreachable
+		if errors.Is(err, storage.ErrAccessDenied) {
+			reason = storage.ReasonInvalidCredentials
+		}
+		return r.syncFailed(ctx, catalog, reason, events.EventBackupCatalogSyncFailed, err.Error())
+	}
+
+	r.Log.Debugf("Found %d backups in storage location %s of catalog %s/%s", len(backups), catalog.Spec.StorageLocation, catalog.Namespace, catalog.Name)
+	now := metav1.Now()
+	catalog.Status.Backups = backups
+	catalog.Status.LastSyncTime = &now
+	meta.SetStatusCondition(&catalog.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.CatalogConditionSynced,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonSynced,
+		Message:            fmt.Sprintf("Found %d backups", len(backups)),
+		ObservedGeneration: catalog.Generation,
+	})
+	if err = r.Status().Update(ctx, catalog); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: refreshInterval(catalog)}, nil
+}
+
+// syncFailed reflects the failure in the Synced condition. The backups found by the last successful scan are kept.
+func (r *CassandraBackupCatalogReconciler) syncFailed(ctx context.Context, catalog *v1alpha1.CassandraBackupCatalog, reason string, eventReason events.EventReason, errMsg string) (ctrl.Result, error) {
+	errMsg = fmt.Sprintf("Failed to sync backup catalog %s/%s: %s", catalog.Namespace, catalog.Name, errMsg)
+	r.Log.Warn(errMsg)
+	r.Events.Warning(catalog, eventReason, errMsg)
+
+	condition := metav1.Condition{
+		Type:               v1alpha1.CatalogConditionSynced,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            errMsg,
+		ObservedGeneration: catalog.Generation,
+	}
+
+	existingCondition := meta.FindStatusCondition(catalog.Status.Conditions, condition.Type)
+	if existingCondition == nil || existingCondition.Status != condition.Status || existingCondition.Reason != condition.Reason ||
+		existingCondition.Message != condition.Message || existingCondition.ObservedGeneration != condition.ObservedGeneration {
+		meta.SetStatusCondition(&catalog.Status.Conditions, condition)
+		if err := r.Status().Update(ctx, catalog); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+}
+
+func refreshInterval(catalog *v1alpha1.CassandraBackupCatalog) time.Duration {
+	if catalog.Spec.RefreshIntervalSeconds == 0 {
+		return defaultRefreshInterval
+	}
+
+	return time.Duration(catalog.Spec.RefreshIntervalSeconds) * time.Second
+}
+
+func SetupCassandraBackupCatalogReconciler(r reconcile.Reconciler, mgr manager.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		Named("cassandrabackupcatalog").
+		For(&v1alpha1.CassandraBackupCatalog{})
+
+	return builder.Complete(r)
+}
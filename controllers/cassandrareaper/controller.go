@@ -0,0 +1,361 @@
+package cassandrareaper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/util"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// clustersResyncInterval defines how often the JMX credentials of the clusters and their registration in Reaper are checked.
+// The admin credentials of a cluster can change without a change of the CassandraCluster
+const clustersResyncInterval = time.Minute
+
+// CassandraReaperReconciler reconciles a CassandraReaper object
+type CassandraReaperReconciler struct {
+	client.Client
+	Log          *zap.SugaredLogger
+	Scheme       *runtime.Scheme
+	Cfg          config.Config
+	Events       *events.EventRecorder
+	ReaperClient func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient
+	CqlClient    func(cluster *gocql.ClusterConfig) (cql.CqlClient, error)
+}
+
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrareapers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrareapers/status,verbs=get;update;patch
+
+func (r *CassandraReaperReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cr := &v1alpha1.CassandraReaper{}
+	err := r.Get(ctx, req.NamespacedName, cr)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	res, err := r.reconcileReaper(ctx, cr)
+	if err != nil {
+		if statusErr, ok := errors.Cause(err).(*kerrors.StatusError); ok && statusErr.ErrStatus.Reason == metav1.StatusReasonConflict {
+			r.Log.Info("Conflict occurred. Retrying...", zap.Error(err))
+			return ctrl.Result{Requeue: true}, nil //retry but do not treat conflicts as errors
+		}
+
+		r.Log.Errorf("%+v", err)
+		return ctrl.Result{}, err
+	}
+
+	return res, nil
+}
+
+func (r *CassandraReaperReconciler) reconcileReaper(ctx context.Context, cr *v1alpha1.CassandraReaper) (ctrl.Result, error) {
+	r.defaultReaper(cr)
+
+	storageCluster := &v1alpha1.CassandraCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: cr.Spec.StorageCassandraCluster, Namespace: cr.Namespace}, storageCluster)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			errMsg := fmt.Sprintf("Storage cluster %q of CassandraReaper %s not found", cr.Spec.StorageCassandraCluster, cr.Name)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventCassandraClusterNotFound, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+		return ctrl.Result{}, errors.Wrap(err, "failed to get storage cluster")
+	}
+
+	if storageCluster.Spec.Encryption.Client.Enabled {
+		errMsg := fmt.Sprintf("Storage cluster %q of CassandraReaper %s has client encryption enabled, which is not supported", storageCluster.Name, cr.Name)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventReaperStorageUnsupported, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	clusters, err := r.referencingClusters(ctx, cr)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	credentials, err := r.jmxCredentials(ctx, clusters)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = r.reconcileShiroConfigMap(ctx, cr); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile shiro configmap")
+	}
+
+	if err = r.reconcileJMXCredentialsSecret(ctx, cr, credentials); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile JMX credentials secret")
+	}
+
+	storageSecret := &v1.Secret{}
+	err = r.Get(ctx, types.NamespacedName{Name: names.ActiveAdminSecret(storageCluster.Name), Namespace: cr.Namespace}, storageSecret)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			r.Log.Infof("Waiting for the admin role of storage cluster %s to be created. Trying again in %s...", storageCluster.Name, r.Cfg.RetryDelay)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+		return ctrl.Result{}, errors.Wrap(err, "can't get admin role secret of the storage cluster")
+	}
+	storageCredentialsChecksum := util.Sha1(fmt.Sprintf("%v", storageSecret.Data))
+
+	cqlClient, err := r.storageCqlClient(storageCluster, storageSecret)
+	if err != nil {
+		r.Log.Warnf("Can't connect to storage cluster %s: %s. Trying again in %s...", storageCluster.Name, err.Error(), r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+	err = r.reconcileStorageKeyspace(storageCluster, cqlClient)
+	cqlClient.CloseSession()
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile reaper keyspace on the storage cluster")
+	}
+
+	if err = r.reconcileDeployment(ctx, cr, storageCluster, storageCredentialsChecksum); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile reaper deployment")
+	}
+
+	if err = r.reconcileService(ctx, cr); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile reaper service")
+	}
+
+	ready, err := r.deploymentReady(ctx, cr)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	status := v1alpha1.CassandraReaperStatus{Ready: ready}
+	if ready {
+		reaperClusters, err := r.ReaperClient(serviceURL(cr), "", 0).Clusters(ctx)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "can't get list of clusters from reaper")
+		}
+
+		if err = r.removeUnreferencedClusters(ctx, cr, clusters, reaperClusters); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		status.CredentialsChecksums, err = r.reloadJMXCredentials(ctx, cr, clusters, credentials, reaperClusters)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		status.Clusters = credentials.clusterNames()
+	}
+
+	if !cmpStatus(cr.Status, status) {
+		cr.Status = status
+		if err = r.Status().Update(ctx, cr); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to update status")
+		}
+	}
+
+	if !ready {
+		r.Log.Infof("Reaper of CassandraReaper %s is not ready. Trying again in %s...", cr.Name, r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: clustersResyncInterval}, nil
+}
+
+func (r *CassandraReaperReconciler) defaultReaper(cr *v1alpha1.CassandraReaper) {
+	if cr.Spec.Image == "" {
+		cr.Spec.Image = r.Cfg.DefaultReaperImage
+	}
+
+	if cr.Spec.ImagePullPolicy == "" {
+		cr.Spec.ImagePullPolicy = v1.PullIfNotPresent
+	}
+
+	if cr.Spec.RepairIntensity == "" {
+		cr.Spec.RepairIntensity = "1.0"
+	}
+}
+
+// referencingClusters returns the clusters that reference the CassandraReaper in `.spec.reaperRef` sorted by name
+func (r *CassandraReaperReconciler) referencingClusters(ctx context.Context, cr *v1alpha1.CassandraReaper) ([]v1alpha1.CassandraCluster, error) {
+	ccList := &v1alpha1.CassandraClusterList{}
+	if err := r.List(ctx, ccList, client.InNamespace(cr.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list cassandra clusters")
+	}
+
+	var clusters []v1alpha1.CassandraCluster
+	for _, cc := range ccList.Items {
+		if cc.Spec.ReaperRef == cr.Name && cc.DeletionTimestamp == nil {
+			clusters = append(clusters, cc)
+		}
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	return clusters, nil
+}
+
+// removeUnreferencedClusters removes the clusters from Reaper that don't reference the CassandraReaper anymore
+func (r *CassandraReaperReconciler) removeUnreferencedClusters(ctx context.Context, cr *v1alpha1.CassandraReaper, clusters []v1alpha1.CassandraCluster, reaperClusters []string) error {
+	reaperURL := serviceURL(cr)
+	referenced := make(map[string]bool, len(clusters))
+	for _, cc := range clusters {
+		referenced[cc.Name] = true
+	}
+
+	for _, clusterName := range reaperClusters {
+		if referenced[clusterName] {
+			continue
+		}
+
+		r.Log.Infof("Cluster %s doesn't reference CassandraReaper %s anymore. Removing it from reaper", clusterName, cr.Name)
+		if err := r.ReaperClient(reaperURL, clusterName, 0).DeleteCluster(ctx); err != nil {
+			return errors.Wrapf(err, "can't delete cluster %s from reaper", clusterName)
+		}
+	}
+
+	return nil
+}
+
+// reloadJMXCredentials updates the JMX credentials of the clusters registered in Reaper whose credentials changed and
+// returns the checksums of the credentials of the registered clusters. Reaper reads the credentials Secret on startup only.
+// The clusters not registered yet are added by the CassandraCluster controller with their credentials.
+func (r *CassandraReaperReconciler) reloadJMXCredentials(ctx context.Context, cr *v1alpha1.CassandraReaper,
+	clusters []v1alpha1.CassandraCluster, credentials jmxCredentials, reaperClusters []string) (map[string]string, error) {
+	clustersByName := make(map[string]v1alpha1.CassandraCluster, len(clusters))
+	for _, cc := range clusters {
+		clustersByName[cc.Name] = cc
+	}
+
+	checksums := make(map[string]string, len(credentials))
+	for _, credential := range credentials {
+		cc, found := clustersByName[credential.clusterName]
+		if !found || !util.Contains(reaperClusters, credential.clusterName) {
+			continue
+		}
+
+		checksum := util.Sha1(credential.username + ":" + credential.password)
+		if cr.Status.CredentialsChecksums[credential.clusterName] != checksum {
+			r.Log.Infof("Loading the JMX credentials of cluster %s into CassandraReaper %s", credential.clusterName, cr.Name)
+			err := r.ReaperClient(serviceURL(cr), credential.clusterName, 0).AddClusterWithCredentials(ctx, seedHost(&cc), credential.username, credential.password)
+			if err != nil {
+				return nil, errors.Wrapf(err, "can't update JMX credentials of cluster %s in reaper", credential.clusterName)
+			}
+		}
+		checksums[credential.clusterName] = checksum
+	}
+
+	if len(checksums) == 0 {
+		return nil, nil
+	}
+
+	return checksums, nil
+}
+
+func (r *CassandraReaperReconciler) deploymentReady(ctx context.Context, cr *v1alpha1.CassandraReaper) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: names.SharedReaperDeployment(cr.Name), Namespace: cr.Namespace}, deployment)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get reaper deployment")
+	}
+
+	// the pods of the previous revision may still be running with the old storage credentials
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.Replicas == v1alpha1.ReaperReplicasNumber &&
+		deployment.Status.UpdatedReplicas == v1alpha1.ReaperReplicasNumber &&
+		deployment.Status.ReadyReplicas == v1alpha1.ReaperReplicasNumber, nil
+}
+
+func cmpStatus(a, b v1alpha1.CassandraReaperStatus) bool {
+	if a.Ready != b.Ready || len(a.Clusters) != len(b.Clusters) || len(a.CredentialsChecksums) != len(b.CredentialsChecksums) {
+		return false
+	}
+
+	for i := range a.Clusters {
+		if a.Clusters[i] != b.Clusters[i] {
+			return false
+		}
+	}
+
+	for clusterName, checksum := range a.CredentialsChecksums {
+		if b.CredentialsChecksums[clusterName] != checksum {
+			return false
+		}
+	}
+
+	return true
+}
+
+// seedHost returns the first node of the cluster, the same seed the CassandraCluster controller registers the cluster with
+func seedHost(cc *v1alpha1.CassandraCluster) string {
+	dcService := names.DC(cc.Name, cc.Spec.DCs[0].Name)
+	return fmt.Sprintf("%s-0.%s.%s.svc.cluster.local", dcService, dcService, cc.Namespace)
+}
+
+func serviceURL(cr *v1alpha1.CassandraReaper) *url.URL {
+	reaperURL, _ := url.Parse(fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", names.SharedReaperService(cr.Name), cr.Namespace, v1alpha1.ReaperAppPort))
+	return reaperURL
+}
+
+func SetupCassandraReaperReconciler(r reconcile.Reconciler, mgr manager.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		Named("cassandrareaper").
+		For(&v1alpha1.CassandraReaper{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&v1.Service{}).
+		Owns(&v1.Secret{}).
+		Owns(&v1.ConfigMap{}).
+		Watches(&source.Kind{Type: &v1alpha1.CassandraCluster{}}, handler.Funcs{
+			CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+				enqueueReaperRef(e.Object, q)
+			},
+			UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+				// the previous CassandraReaper removes the cluster once it doesn't reference it anymore
+				enqueueReaperRef(e.ObjectOld, q)
+				enqueueReaperRef(e.ObjectNew, q)
+			},
+			DeleteFunc: func(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+				enqueueReaperRef(e.Object, q)
+			},
+			GenericFunc: func(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+				enqueueReaperRef(e.Object, q)
+			},
+		})
+
+	return builder.Complete(r)
+}
+
+// enqueueReaperRef reconciles the CassandraReaper referenced by the CassandraCluster
+func enqueueReaperRef(object client.Object, q workqueue.RateLimitingInterface) {
+	cc, ok := object.(*v1alpha1.CassandraCluster)
+	if !ok || len(cc.Spec.ReaperRef) == 0 {
+		return
+	}
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: cc.Spec.ReaperRef, Namespace: cc.Namespace}})
+}
@@ -0,0 +1,67 @@
+package cassandrareaper
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/mocks"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+func TestReloadJMXCredentials(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	mCtrl := gomock.NewController(t)
+	defer mCtrl.Finish()
+
+	reaperClient := mocks.NewMockReaperClient(mCtrl)
+	var clusterNames []string
+	r := &CassandraReaperReconciler{
+		Log: zap.NewNop().Sugar(),
+		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
+			clusterNames = append(clusterNames, clusterName)
+			return reaperClient
+		},
+	}
+
+	cr := &v1alpha1.CassandraReaper{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "ns"},
+		Status: v1alpha1.CassandraReaperStatus{
+			CredentialsChecksums: map[string]string{
+				"cluster1": util.Sha1("admin1:pass1"),
+				"cluster2": util.Sha1("admin2:old-pass"),
+			},
+		},
+	}
+	clusters := []v1alpha1.CassandraCluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "ns"}, Spec: v1alpha1.CassandraClusterSpec{DCs: []v1alpha1.DC{{Name: "dc1"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster2", Namespace: "ns"}, Spec: v1alpha1.CassandraClusterSpec{DCs: []v1alpha1.DC{{Name: "dc1"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster3", Namespace: "ns"}, Spec: v1alpha1.CassandraClusterSpec{DCs: []v1alpha1.DC{{Name: "dc1"}}}},
+	}
+	credentials := jmxCredentials{
+		{clusterName: "cluster1", username: "admin1", password: "pass1"},
+		{clusterName: "cluster2", username: "admin2", password: "pass2"},
+		{clusterName: "cluster3", username: "admin3", password: "pass3"},
+	}
+
+	// only the changed credentials of the registered clusters are loaded
+	reaperClient.EXPECT().AddClusterWithCredentials(gomock.Any(), "cluster2-cassandra-dc1-0.cluster2-cassandra-dc1.ns.svc.cluster.local", "admin2", "pass2").Return(nil)
+	checksums, err := r.reloadJMXCredentials(context.Background(), cr, clusters, credentials, []string{"cluster1", "cluster2"})
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(clusterNames).To(Equal([]string{"cluster2"}))
+	asserts.Expect(checksums).To(Equal(map[string]string{
+		"cluster1": util.Sha1("admin1:pass1"),
+		"cluster2": util.Sha1("admin2:pass2"),
+	}))
+
+	checksums, err = r.reloadJMXCredentials(context.Background(), cr, clusters, credentials, nil)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(checksums).To(BeNil())
+}
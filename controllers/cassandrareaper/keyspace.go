@@ -0,0 +1,97 @@
+package cassandrareaper
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/names"
+)
+
+const (
+	defaultStorageKeyspace = "reaper"
+	defaultStorageRF       = 3
+)
+
+// storageKeyspace returns the keyspace Reaper keeps its state in on the storage cluster
+func storageKeyspace(storageCluster *v1alpha1.CassandraCluster) string {
+	if storageCluster.Spec.Reaper != nil && len(storageCluster.Spec.Reaper.Keyspace) != 0 {
+		return storageCluster.Spec.Reaper.Keyspace
+	}
+
+	return defaultStorageKeyspace
+}
+
+// storageReplicationOptions replicates the keyspace to every DC of the storage cluster with at most 3 replicas per DC
+func storageReplicationOptions(storageCluster *v1alpha1.CassandraCluster) map[string]string {
+	options := make(map[string]string, len(storageCluster.Spec.DCs)+1)
+	for _, dc := range storageCluster.Spec.DCs {
+		rf := defaultStorageRF
+		if dc.Replicas != nil && int(*dc.Replicas) < rf {
+			rf = int(*dc.Replicas)
+		}
+		options[dc.Name] = strconv.Itoa(rf)
+	}
+	options["class"] = cql.ReplicationClassNetworkTopologyStrategy
+
+	return options
+}
+
+// reconcileStorageKeyspace creates the Reaper keyspace on the storage cluster.
+// A storage cluster that doesn't reference a shared Reaper manages the replication of its own Reaper keyspace,
+// otherwise the replication is kept in sync with the DCs of the storage cluster here.
+func (r *CassandraReaperReconciler) reconcileStorageKeyspace(storageCluster *v1alpha1.CassandraCluster, cqlClient cql.CqlClient) error {
+	keyspaceName := storageKeyspace(storageCluster)
+	keyspaces, err := cqlClient.GetKeyspacesInfo()
+	if err != nil {
+		return errors.Wrap(err, "failed to get keyspaces info")
+	}
+
+	replicationOptions := storageReplicationOptions(storageCluster)
+	var keyspace *cql.Keyspace
+	for i := range keyspaces {
+		if keyspaces[i].Name == keyspaceName {
+			keyspace = &keyspaces[i]
+			break
+		}
+	}
+
+	if keyspace == nil {
+		r.Log.Infof("Creating keyspace %s for Reaper on storage cluster %s", keyspaceName, storageCluster.Name)
+		query := fmt.Sprintf("CREATE KEYSPACE IF NOT EXISTS %s %s", keyspaceName, cql.ReplicationQuery(replicationOptions))
+		if err = cqlClient.Query(query); err != nil {
+			return errors.Wrapf(err, "failed to create keyspace %s", keyspaceName)
+		}
+		return nil
+	}
+
+	if len(storageCluster.Spec.ReaperRef) != 0 && !cmp.Equal(replicationOptions, keyspace.Replication) {
+		r.Log.Infof("Updating replication of keyspace %s on storage cluster %s. Diff: %s", keyspaceName, storageCluster.Name, cmp.Diff(replicationOptions, keyspace.Replication))
+		if err = cqlClient.UpdateRF(keyspaceName, replicationOptions); err != nil {
+			return errors.Wrapf(err, "failed to update replication of keyspace %s", keyspaceName)
+		}
+	}
+
+	return nil
+}
+
+// storageCqlClient connects to the storage cluster with its admin role.
+// Client encryption of the storage cluster is not supported by the CassandraReaper
+func (r *CassandraReaperReconciler) storageCqlClient(storageCluster *v1alpha1.CassandraCluster, storageSecret *v1.Secret) (cql.CqlClient, error) {
+	cassCfg := gocql.NewCluster(fmt.Sprintf("%s.%s.svc.cluster.local", names.DCService(storageCluster.Name, storageCluster.Spec.DCs[0].Name), storageCluster.Namespace))
+	cassCfg.Authenticator = &gocql.PasswordAuthenticator{
+		Username: string(storageSecret.Data[v1alpha1.CassandraOperatorAdminRole]),
+		Password: string(storageSecret.Data[v1alpha1.CassandraOperatorAdminPassword]),
+	}
+	cassCfg.Timeout = 6 * time.Second
+	cassCfg.ConnectTimeout = 6 * time.Second
+
+	return r.CqlClient(cassCfg)
+}
@@ -0,0 +1,66 @@
+package cassandrareaper
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/mocks"
+)
+
+func TestReconcileStorageKeyspace(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	mCtrl := gomock.NewController(t)
+	defer mCtrl.Finish()
+
+	r := &CassandraReaperReconciler{Log: zap.NewNop().Sugar()}
+	storageCluster := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{Name: "dc1", Replicas: proto.Int32(5)},
+				{Name: "dc2", Replicas: proto.Int32(1)},
+			},
+		},
+	}
+	desiredReplication := map[string]string{"class": cql.ReplicationClassNetworkTopologyStrategy, "dc1": "3", "dc2": "1"}
+	asserts.Expect(storageReplicationOptions(storageCluster)).To(Equal(desiredReplication))
+
+	// the keyspace is created if missing
+	cqlClient := mocks.NewMockCqlClient(mCtrl)
+	cqlClient.EXPECT().GetKeyspacesInfo().Return([]cql.Keyspace{{Name: "system_auth"}}, nil)
+	var query string
+	cqlClient.EXPECT().Query(gomock.Any()).DoAndReturn(func(stmt string, values ...interface{}) error {
+		query = stmt
+		return nil
+	})
+	asserts.Expect(r.reconcileStorageKeyspace(storageCluster, cqlClient)).To(Succeed())
+	asserts.Expect(query).To(HavePrefix("CREATE KEYSPACE IF NOT EXISTS reaper WITH replication"))
+	asserts.Expect(query).To(ContainSubstring("'dc1' : '3'"))
+	asserts.Expect(query).To(ContainSubstring("'dc2' : '1'"))
+
+	// the replication of an existing keyspace is left to a storage cluster that runs its own Reaper
+	outdatedKeyspace := cql.Keyspace{Name: "reaper", Replication: map[string]string{"class": cql.ReplicationClassNetworkTopologyStrategy, "dc1": "3"}}
+	cqlClient = mocks.NewMockCqlClient(mCtrl)
+	cqlClient.EXPECT().GetKeyspacesInfo().Return([]cql.Keyspace{outdatedKeyspace}, nil)
+	asserts.Expect(r.reconcileStorageKeyspace(storageCluster, cqlClient)).To(Succeed())
+
+	// and updated if the storage cluster is repaired by a shared Reaper
+	storageCluster.Spec.ReaperRef = "shared"
+	cqlClient = mocks.NewMockCqlClient(mCtrl)
+	cqlClient.EXPECT().GetKeyspacesInfo().Return([]cql.Keyspace{outdatedKeyspace}, nil)
+	cqlClient.EXPECT().UpdateRF("reaper", desiredReplication).Return(nil)
+	asserts.Expect(r.reconcileStorageKeyspace(storageCluster, cqlClient)).To(Succeed())
+
+	// a custom keyspace of the storage cluster is used
+	storageCluster.Spec.Reaper = &v1alpha1.Reaper{Keyspace: "reaper_db"}
+	cqlClient = mocks.NewMockCqlClient(mCtrl)
+	cqlClient.EXPECT().GetKeyspacesInfo().Return([]cql.Keyspace{outdatedKeyspace, {Name: "reaper_db", Replication: desiredReplication}}, nil)
+	asserts.Expect(r.reconcileStorageKeyspace(storageCluster, cqlClient)).To(Succeed())
+}
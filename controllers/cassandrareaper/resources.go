@@ -0,0 +1,424 @@
+package cassandrareaper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/compare"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/util"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const jmxCredentialsKey = "credentials"
+
+type jmxCredential struct {
+	clusterName string
+	username    string
+	password    string
+}
+
+// jmxCredentials are the JMX credentials of the clusters repaired by a shared Reaper
+type jmxCredentials []jmxCredential
+
+// String returns the credentials in the format of the REAPER_JMX_CREDENTIALS variable: `user:password@cluster,...`
+func (c jmxCredentials) String() string {
+	entries := make([]string, 0, len(c))
+	for _, credential := range c {
+		entries = append(entries, fmt.Sprintf("%s:%s@%s", credential.username, credential.password, credential.clusterName))
+	}
+	return strings.Join(entries, ",")
+}
+
+func (c jmxCredentials) clusterNames() []string {
+	clusterNames := make([]string, 0, len(c))
+	for _, credential := range c {
+		clusterNames = append(clusterNames, credential.clusterName)
+	}
+	return clusterNames
+}
+
+// jmxCredentials returns the admin credentials of the clusters. The clusters without the admin role created yet are skipped.
+func (r *CassandraReaperReconciler) jmxCredentials(ctx context.Context, clusters []v1alpha1.CassandraCluster) (jmxCredentials, error) {
+	credentials := make(jmxCredentials, 0, len(clusters))
+	for _, cc := range clusters {
+		adminSecret := &v1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Name: names.AdminAuthConfigSecret(cc.Name), Namespace: cc.Namespace}, adminSecret)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				r.Log.Debugf("Admin role of cluster %s is not created yet", cc.Name)
+				continue
+			}
+			return nil, errors.Wrapf(err, "can't get admin role secret of cluster %s", cc.Name)
+		}
+
+		credentials = append(credentials, jmxCredential{
+			clusterName: cc.Name,
+			username:    string(adminSecret.Data[v1alpha1.CassandraOperatorAdminRole]),
+			password:    string(adminSecret.Data[v1alpha1.CassandraOperatorAdminPassword]),
+		})
+	}
+
+	return credentials, nil
+}
+
+func (r *CassandraReaperReconciler) reconcileJMXCredentialsSecret(ctx context.Context, cr *v1alpha1.CassandraReaper, credentials jmxCredentials) error {
+	desiredSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.SharedReaperJMXCredentialsSecret(cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    reaperLabels(cr),
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			jmxCredentialsKey: []byte(credentials.String()),
+		},
+	}
+	if err := controllerutil.SetControllerReference(cr, desiredSecret, r.Scheme); err != nil {
+		return errors.Wrap(err, "Cannot set controller reference")
+	}
+
+	actualSecret := &v1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: desiredSecret.Name, Namespace: desiredSecret.Namespace}, actualSecret)
+	if err != nil && kerrors.IsNotFound(err) {
+		r.Log.Infof("Creating secret %s", desiredSecret.Name)
+		return errors.Wrapf(r.Create(ctx, desiredSecret), "Unable to create secret %s", desiredSecret.Name)
+	} else if err != nil {
+		return errors.Wrapf(err, "Could not get secret %s", desiredSecret.Name)
+	}
+
+	desiredSecret.Annotations = util.MergeMap(actualSecret.Annotations, desiredSecret.Annotations)
+	if compare.EqualSecret(actualSecret, desiredSecret) {
+		r.Log.Debugf("No updates for secret %s", desiredSecret.Name)
+		return nil
+	}
+
+	r.Log.Infof("Updating %s", desiredSecret.Name)
+	actualSecret.Labels = desiredSecret.Labels
+	actualSecret.Data = desiredSecret.Data
+	actualSecret.Annotations = desiredSecret.Annotations
+	actualSecret.OwnerReferences = desiredSecret.OwnerReferences
+	return errors.Wrapf(r.Update(ctx, actualSecret), "Could not update secret %s", desiredSecret.Name)
+}
+
+func (r *CassandraReaperReconciler) reconcileShiroConfigMap(ctx context.Context, cr *v1alpha1.CassandraReaper) error {
+	operatorCM := &v1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: names.OperatorShiroCM(), Namespace: r.Cfg.Namespace}, operatorCM)
+	if err != nil {
+		return errors.Wrapf(err, "Could not get %s", names.OperatorShiroCM())
+	}
+
+	desiredCM := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.SharedReaperShiroConfigMap(cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    reaperLabels(cr),
+		},
+		Data: operatorCM.Data,
+	}
+	if err = controllerutil.SetControllerReference(cr, desiredCM, r.Scheme); err != nil {
+		return errors.Wrap(err, "Cannot set controller reference")
+	}
+
+	actualCM := &v1.ConfigMap{}
+	err = r.Get(ctx, types.NamespacedName{Name: desiredCM.Name, Namespace: desiredCM.Namespace}, actualCM)
+	if err != nil && kerrors.IsNotFound(err) {
+		r.Log.Infof("Creating %s", desiredCM.Name)
+		return errors.Wrapf(r.Create(ctx, desiredCM), "Unable to create %s", desiredCM.Name)
+	} else if err != nil {
+		return errors.Wrapf(err, "Could not get %s", desiredCM.Name)
+	}
+
+	desiredCM.Annotations = util.MergeMap(actualCM.Annotations, desiredCM.Annotations)
+	if compare.EqualConfigMap(actualCM, desiredCM) {
+		r.Log.Debugf("No updates for %s", desiredCM.Name)
+		return nil
+	}
+
+	r.Log.Infof("Updating %s", desiredCM.Name)
+	r.Log.Debug(compare.DiffConfigMap(actualCM, desiredCM))
+	actualCM.Labels = desiredCM.Labels
+	actualCM.Data = desiredCM.Data
+	actualCM.Annotations = desiredCM.Annotations
+	actualCM.OwnerReferences = desiredCM.OwnerReferences
+	return errors.Wrapf(r.Update(ctx, actualCM), "Could not update %s", desiredCM.Name)
+}
+
+func (r *CassandraReaperReconciler) reconcileDeployment(ctx context.Context, cr *v1alpha1.CassandraReaper, storageCluster *v1alpha1.CassandraCluster, storageCredentialsChecksum string) error {
+	labels := reaperLabels(cr)
+	percent25 := intstr.FromInt(25)
+	desiredDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.SharedReaperDeployment(cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: proto.Int32(v1alpha1.ReaperReplicasNumber),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: &percent25,
+					MaxSurge:       &percent25,
+				},
+			},
+			RevisionHistoryLimit:    proto.Int32(10),
+			ProgressDeadlineSeconds: proto.Int32(1200),
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						reaperContainer(cr, storageCluster, storageCredentialsChecksum),
+					},
+					Volumes: []v1.Volume{
+						{
+							Name: "shiro-config",
+							VolumeSource: v1.VolumeSource{
+								ConfigMap: &v1.ConfigMapVolumeSource{
+									LocalObjectReference: v1.LocalObjectReference{
+										Name: names.SharedReaperShiroConfigMap(cr.Name),
+									},
+									DefaultMode: proto.Int32(v1.ConfigMapVolumeSourceDefaultMode),
+								},
+							},
+						},
+					},
+					Tolerations:                   cr.Spec.Tolerations,
+					NodeSelector:                  cr.Spec.NodeSelector,
+					RestartPolicy:                 v1.RestartPolicyAlways,
+					TerminationGracePeriodSeconds: proto.Int64(30),
+					DNSPolicy:                     v1.DNSClusterFirst,
+					SecurityContext:               &v1.PodSecurityContext{},
+				},
+			},
+		},
+	}
+
+	if len(cr.Spec.ImagePullSecretName) != 0 {
+		desiredDeployment.Spec.Template.Spec.ImagePullSecrets = []v1.LocalObjectReference{{Name: cr.Spec.ImagePullSecretName}}
+	}
+
+	if err := controllerutil.SetControllerReference(cr, desiredDeployment, r.Scheme); err != nil {
+		return errors.Wrap(err, "Cannot set controller reference")
+	}
+
+	actualDeployment := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: desiredDeployment.Name, Namespace: desiredDeployment.Namespace}, actualDeployment)
+	if err != nil && kerrors.IsNotFound(err) {
+		r.Log.Info("Creating reaper deployment")
+		return errors.Wrap(r.Create(ctx, desiredDeployment), "Failed to create deployment")
+	} else if err != nil {
+		return errors.Wrap(err, "Failed to get deployment")
+	}
+
+	desiredDeployment.Annotations = util.MergeMap(actualDeployment.Annotations, desiredDeployment.Annotations)
+	if compare.EqualDeployment(desiredDeployment, actualDeployment) {
+		r.Log.Debugf("No updates to reaper deployment")
+		return nil
+	}
+
+	r.Log.Info("Updating reaper deployment")
+	r.Log.Debug(compare.DiffDeployment(actualDeployment, desiredDeployment))
+	actualDeployment.Labels = util.MergeMap(actualDeployment.Labels, desiredDeployment.Labels)
+	actualDeployment.Spec = desiredDeployment.Spec
+	return errors.Wrap(r.Update(ctx, actualDeployment), "Failed to update deployment")
+}
+
+func (r *CassandraReaperReconciler) reconcileService(ctx context.Context, cr *v1alpha1.CassandraReaper) error {
+	desiredService := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.SharedReaperService(cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    reaperLabels(cr),
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{
+					Name:       "app",
+					Protocol:   v1.ProtocolTCP,
+					Port:       v1alpha1.ReaperAppPort,
+					TargetPort: intstr.FromInt(v1alpha1.ReaperAppPort),
+				},
+				{
+					Name:       "admin",
+					Protocol:   v1.ProtocolTCP,
+					Port:       v1alpha1.ReaperAdminPort,
+					TargetPort: intstr.FromInt(v1alpha1.ReaperAdminPort),
+				},
+			},
+			ClusterIP:                v1.ClusterIPNone,
+			Type:                     v1.ServiceTypeClusterIP,
+			SessionAffinity:          v1.ServiceAffinityNone,
+			PublishNotReadyAddresses: true,
+			Selector:                 reaperLabels(cr),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, desiredService, r.Scheme); err != nil {
+		return errors.Wrap(err, "Cannot set controller reference")
+	}
+
+	actualService := &v1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: desiredService.Name, Namespace: desiredService.Namespace}, actualService)
+	if err != nil && kerrors.IsNotFound(err) {
+		r.Log.Infof("Creating reaper service")
+		return errors.Wrap(r.Create(ctx, desiredService), "Failed to create reaper service")
+	} else if err != nil {
+		return errors.Wrap(err, "Failed to get reaper service")
+	}
+
+	// ClusterIP is immutable once created, so always enforce the same as existing
+	desiredService.Spec.ClusterIP = actualService.Spec.ClusterIP
+	desiredService.Spec.ClusterIPs = actualService.Spec.ClusterIPs
+	desiredService.Spec.IPFamilies = actualService.Spec.IPFamilies
+	desiredService.Spec.IPFamilyPolicy = actualService.Spec.IPFamilyPolicy
+	desiredService.Spec.InternalTrafficPolicy = actualService.Spec.InternalTrafficPolicy
+	if compare.EqualService(desiredService, actualService) {
+		r.Log.Debugf("No updates to reaper service")
+		return nil
+	}
+
+	r.Log.Infof("Updating reaper service")
+	r.Log.Debugf(compare.DiffService(actualService, desiredService))
+	actualService.Spec = desiredService.Spec
+	actualService.Labels = desiredService.Labels
+	actualService.Annotations = desiredService.Annotations
+	return errors.Wrap(r.Update(ctx, actualService), "failed to update reaper service")
+}
+
+func reaperContainer(cr *v1alpha1.CassandraReaper, storageCluster *v1alpha1.CassandraCluster, storageCredentialsChecksum string) v1.Container {
+	pingProbe := func(initialDelaySeconds int32) *v1.Probe {
+		return &v1.Probe{
+			ProbeHandler: v1.ProbeHandler{
+				HTTPGet: &v1.HTTPGetAction{
+					Port:   intstr.FromString("admin"),
+					Path:   "/ping",
+					Scheme: v1.URISchemeHTTP,
+				},
+			},
+			TimeoutSeconds:      1,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+			InitialDelaySeconds: initialDelaySeconds,
+		}
+	}
+
+	return v1.Container{
+		Name:            "reaper",
+		Image:           cr.Spec.Image,
+		ImagePullPolicy: cr.Spec.ImagePullPolicy,
+		Ports: []v1.ContainerPort{
+			{
+				Name:          "app",
+				ContainerPort: v1alpha1.ReaperAppPort,
+				Protocol:      v1.ProtocolTCP,
+			},
+			{
+				Name:          "admin",
+				ContainerPort: v1alpha1.ReaperAdminPort,
+				Protocol:      v1.ProtocolTCP,
+			},
+		},
+		ReadinessProbe: pingProbe(60),
+		LivenessProbe:  pingProbe(600), //first init may take a long time because of the DB migration
+		Resources:      cr.Spec.Resources,
+		Env:            reaperEnvironment(cr, storageCluster, storageCredentialsChecksum),
+		VolumeMounts: []v1.VolumeMount{
+			{
+				Name:      "shiro-config",
+				MountPath: "/shiro/shiro.ini",
+				SubPath:   "shiro.ini",
+			},
+		},
+		TerminationMessagePath:   "/dev/termination-log",
+		TerminationMessagePolicy: v1.TerminationMessageReadFile,
+	}
+}
+
+func reaperEnvironment(cr *v1alpha1.CassandraReaper, storageCluster *v1alpha1.CassandraCluster, storageCredentialsChecksum string) []v1.EnvVar {
+	contactPoints := make([]string, 0, len(storageCluster.Spec.DCs))
+	for _, dc := range storageCluster.Spec.DCs {
+		contactPoints = append(contactPoints, names.DC(storageCluster.Name, dc.Name))
+	}
+
+	reaperEnv := []v1.EnvVar{
+		// http://cassandra-reaper.io/docs/configuration/docker_vars/
+		// restarts Reaper when the credentials of the storage cluster change as they are read on startup only.
+		// The JMX credentials of the repaired clusters are updated through the Reaper API instead
+		{Name: "CREDENTIALS_SHA1", Value: storageCredentialsChecksum},
+		{Name: "REAPER_CASS_ACTIVATE_QUERY_LOGGER", Value: "true"},
+		{Name: "REAPER_LOGGING_ROOT_LEVEL", Value: "INFO"},
+		{Name: "REAPER_LOGGING_APPENDERS_CONSOLE_THRESHOLD", Value: "INFO"},
+		// a single instance connects to the nodes of all DCs
+		{Name: "REAPER_DATACENTER_AVAILABILITY", Value: "ALL"},
+		{Name: "REAPER_REPAIR_INTENSITY", Value: cr.Spec.RepairIntensity},
+		{Name: "REAPER_REPAIR_MANAGER_SCHEDULING_INTERVAL_SECONDS", Value: fmt.Sprint(cr.Spec.RepairManagerSchedulingIntervalSeconds)},
+		{Name: "REAPER_BLACKLIST_TWCS", Value: fmt.Sprint(cr.Spec.BlacklistTWCS)},
+		{Name: "REAPER_CASS_CONTACT_POINTS", Value: fmt.Sprintf("[ %s ]", strings.Join(contactPoints, ", "))},
+		{Name: "REAPER_CASS_CLUSTER_NAME", Value: storageCluster.Name},
+		{Name: "REAPER_STORAGE_TYPE", Value: "cassandra"},
+		{Name: "REAPER_CASS_KEYSPACE", Value: storageKeyspace(storageCluster)},
+		{Name: "REAPER_CASS_PORT", Value: fmt.Sprintf("%d", v1alpha1.CqlPort)},
+		{Name: "REAPER_CASS_AUTH_ENABLED", Value: "true"},
+		{Name: "REAPER_SHIRO_INI", Value: "/shiro/shiro.ini"},
+		secretEnvVar("REAPER_CASS_AUTH_USERNAME", names.AdminAuthConfigSecret(storageCluster.Name), v1alpha1.CassandraOperatorAdminRole),
+		secretEnvVar("REAPER_CASS_AUTH_PASSWORD", names.AdminAuthConfigSecret(storageCluster.Name), v1alpha1.CassandraOperatorAdminPassword),
+		secretEnvVar("REAPER_JMX_CREDENTIALS", names.SharedReaperJMXCredentialsSecret(cr.Name), jmxCredentialsKey),
+	}
+
+	if cr.Spec.RepairRunThreads > 0 {
+		reaperEnv = append(reaperEnv, v1.EnvVar{Name: "REAPER_REPAIR_RUN_THREADS", Value: fmt.Sprint(cr.Spec.RepairRunThreads)})
+	}
+
+	if cr.Spec.MaxParallelRepairs > 0 {
+		reaperEnv = append(reaperEnv, v1.EnvVar{Name: "REAPER_MAX_PARALLEL_REPAIRS", Value: fmt.Sprint(cr.Spec.MaxParallelRepairs)})
+	}
+
+	if cr.Spec.HangingRepairTimeoutMins > 0 {
+		reaperEnv = append(reaperEnv, v1.EnvVar{Name: "REAPER_HANGING_REPAIR_TIMEOUT_MINS", Value: fmt.Sprint(cr.Spec.HangingRepairTimeoutMins)})
+	}
+
+	if cr.Spec.SegmentCountPerNode > 0 {
+		reaperEnv = append(reaperEnv, v1.EnvVar{Name: "REAPER_SEGMENT_COUNT_PER_NODE", Value: fmt.Sprint(cr.Spec.SegmentCountPerNode)})
+	}
+
+	return reaperEnv
+}
+
+func secretEnvVar(name, secretName, key string) v1.EnvVar {
+	return v1.EnvVar{
+		Name: name,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// reaperLabels selects the pods of the shared Reaper. The component label lets the pods through the network policies of the clusters
+func reaperLabels(cr *v1alpha1.CassandraReaper) map[string]string {
+	return map[string]string{
+		v1alpha1.CassandraReaperInstance:   cr.Name,
+		v1alpha1.CassandraClusterComponent: v1alpha1.CassandraClusterComponentReaper,
+	}
+}
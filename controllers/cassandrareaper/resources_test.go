@@ -0,0 +1,56 @@
+package cassandrareaper
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+func TestJMXCredentials(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	credentials := jmxCredentials{
+		{clusterName: "cluster1", username: "admin1", password: "pass1"},
+		{clusterName: "cluster2", username: "admin2", password: "pass2"},
+	}
+
+	asserts.Expect(credentials.String()).To(Equal("admin1:pass1@cluster1,admin2:pass2@cluster2"))
+	asserts.Expect(credentials.clusterNames()).To(Equal([]string{"cluster1", "cluster2"}))
+	asserts.Expect(jmxCredentials{}.String()).To(BeEmpty())
+}
+
+func TestReaperEnvironment(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	cr := &v1alpha1.CassandraReaper{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+		Spec: v1alpha1.CassandraReaperSpec{
+			RepairIntensity:     "0.5",
+			SegmentCountPerNode: 32,
+		},
+	}
+	storageCluster := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{{Name: "dc1"}, {Name: "dc2"}},
+		},
+	}
+
+	env := reaperEnvironment(cr, storageCluster, "storage-checksum")
+	asserts.Expect(env).To(ContainElements(
+		v1.EnvVar{Name: "CREDENTIALS_SHA1", Value: "storage-checksum"},
+		v1.EnvVar{Name: "REAPER_CASS_CONTACT_POINTS", Value: "[ storage-cassandra-dc1, storage-cassandra-dc2 ]"},
+		v1.EnvVar{Name: "REAPER_CASS_CLUSTER_NAME", Value: "storage"},
+		v1.EnvVar{Name: "REAPER_CASS_KEYSPACE", Value: "reaper"},
+		v1.EnvVar{Name: "REAPER_REPAIR_INTENSITY", Value: "0.5"},
+		v1.EnvVar{Name: "REAPER_SEGMENT_COUNT_PER_NODE", Value: "32"},
+		secretEnvVar("REAPER_JMX_CREDENTIALS", "shared-cassandra-reaper-jmx-credentials", jmxCredentialsKey),
+		secretEnvVar("REAPER_CASS_AUTH_USERNAME", "storage-auth-config-admin", v1alpha1.CassandraOperatorAdminRole),
+	))
+
+	storageCluster.Spec.Reaper = &v1alpha1.Reaper{Keyspace: "shared_reaper"}
+	env = reaperEnvironment(cr, storageCluster, "checksum")
+	asserts.Expect(env).To(ContainElement(v1.EnvVar{Name: "REAPER_CASS_KEYSPACE", Value: "shared_reaper"}))
+}
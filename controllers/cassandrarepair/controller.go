@@ -0,0 +1,250 @@
+package cassandrarepair
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// repairRunFinalizer makes sure the repair run is aborted before the CassandraRepair is removed
+	repairRunFinalizer = "db.ibm.com/abort-repair-run"
+
+	repairRunRefreshInterval = 30 * time.Second
+	// abortTimeout is how long a deleted CassandraRepair waits for an unreachable Reaper to abort the repair run
+	abortTimeout = 10 * time.Minute
+)
+
+// CassandraRepairReconciler reconciles a CassandraRepair object
+type CassandraRepairReconciler struct {
+	client.Client
+	Log          *zap.SugaredLogger
+	Scheme       *runtime.Scheme
+	Cfg          config.Config
+	Events       *events.EventRecorder
+	ReaperClient func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient
+}
+
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrarepairs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrarepairs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrarepairs/finalizers,verbs=update
+
+func (r *CassandraRepairReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cr := &v1alpha1.CassandraRepair{}
+	err := r.Get(ctx, req.NamespacedName, cr)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	res, err := r.reconcileRepair(ctx, cr)
+	if err != nil {
+		if statusErr, ok := errors.Cause(err).(*kerrors.StatusError); ok && statusErr.ErrStatus.Reason == metav1.StatusReasonConflict {
+			r.Log.Info("Conflict occurred. Retrying...", zap.Error(err))
+			return ctrl.Result{Requeue: true}, nil //retry but do not treat conflicts as errors
+		}
+
+		r.Log.Errorf("%+v", err)
+		return ctrl.Result{}, err
+	}
+
+	return res, nil
+}
+
+func (r *CassandraRepairReconciler) reconcileRepair(ctx context.Context, cr *v1alpha1.CassandraRepair) (ctrl.Result, error) {
+	cc := &v1alpha1.CassandraCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: cr.Spec.CassandraCluster, Namespace: cr.Namespace}, cc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			if cr.DeletionTimestamp != nil {
+				// Reaper is removed together with the cluster
+				return ctrl.Result{}, r.removeFinalizer(ctx, cr)
+			}
+			errMsg := fmt.Sprintf("Failed to repair cluster %q. Cluster not found.", cr.Spec.CassandraCluster)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventCassandraClusterNotFound, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if cr.DeletionTimestamp != nil {
+		return r.reconcileDeletion(ctx, cc, cr)
+	}
+
+	if cc.Spec.RepairEngine == v1alpha1.RepairEngineBuiltin {
+		errMsg := fmt.Sprintf("Failed to repair cluster %q. CassandraRepair requires Reaper, but the cluster uses the builtin repair engine.", cc.Name)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventRepairEngineUnsupported, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	if repairRunFinished(cr.Status.State) {
+		r.Log.Debugf("Repair run of CassandraRepair %s/%s has finished with state %s", cr.Namespace, cr.Name, cr.Status.State)
+		return ctrl.Result{}, nil
+	}
+
+	reaperClient, ready, err := r.reaperClient(ctx, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !ready {
+		r.Log.Infof("Reaper of cluster %s/%s is not ready. Trying again in %s...", cc.Namespace, cc.Name, r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(cr, repairRunFinalizer) {
+		controllerutil.AddFinalizer(cr, repairRunFinalizer)
+		if err = r.Update(ctx, cr); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to add finalizer")
+		}
+	}
+
+	repairRun, found, err := r.repairRun(ctx, reaperClient, cr)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !found {
+		if len(cr.Status.RunID) != 0 {
+			// removed from Reaper by the user
+			errMsg := fmt.Sprintf("Repair run %s of keyspace %q not found in Reaper", cr.Status.RunID, cr.Spec.Keyspace)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventRepairRunLost, errMsg)
+			cr.Status.State = reaper.RepairStateDeleted
+			return ctrl.Result{}, r.Status().Update(ctx, cr)
+		}
+
+		created, err := r.createRepairRun(ctx, reaperClient, cr)
+		if err != nil || !created {
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if repairRun.State == reaper.RepairStateNotStarted && cc.Status.RepairsPause != nil {
+		r.Log.Infof("Repairs of cluster %s/%s are paused while %s is in progress, not starting repair run %s",
+			cc.Namespace, cc.Name, cc.Status.RepairsPause.Reason, repairRun.ID)
+	} else if repairRun.State == reaper.RepairStateNotStarted {
+		r.Log.Infof("Starting repair run %s of keyspace %q", repairRun.ID, cr.Spec.Keyspace)
+		if err = reaperClient.SetRepairRunState(ctx, repairRun.ID, reaper.RepairStateRunning); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to start repair run %s", repairRun.ID)
+		}
+		repairRun.State = reaper.RepairStateRunning
+	}
+
+	err = r.updateStatus(ctx, cr, repairRun)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if repairRunFinished(cr.Status.State) {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: repairRunRefreshInterval}, nil
+}
+
+// reconcileDeletion aborts the repair run before the finalizer is removed. The finalizer is removed without aborting the run
+// if the cluster uses the builtin repair engine, if Reaper is not deployed, or if Reaper can't be reached within abortTimeout.
+func (r *CassandraRepairReconciler) reconcileDeletion(ctx context.Context, cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRepair) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(cr, repairRunFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if cc.Spec.RepairEngine == v1alpha1.RepairEngineBuiltin || repairRunFinished(cr.Status.State) {
+		return ctrl.Result{}, r.removeFinalizer(ctx, cr)
+	}
+
+	reaperService := &v1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: names.ClusterReaperService(cc), Namespace: cc.Namespace}, reaperService)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			r.Log.Infof("Reaper of cluster %s/%s is not deployed, not aborting the repair run of CassandraRepair %s", cc.Namespace, cc.Name, cr.Name)
+			return ctrl.Result{}, r.removeFinalizer(ctx, cr)
+		}
+		return ctrl.Result{}, errors.Wrap(err, "failed to get Reaper service")
+	}
+
+	reaperClient, ready, err := r.reaperClient(ctx, cc)
+	if err != nil || !ready {
+		if time.Since(cr.DeletionTimestamp.Time) > abortTimeout {
+			errMsg := fmt.Sprintf("Reaper of cluster %s/%s is not reachable for %s, removing CassandraRepair without aborting its repair run %s",
+				cc.Namespace, cc.Name, abortTimeout, cr.Status.RunID)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventRepairRunLost, errMsg)
+			return ctrl.Result{}, r.removeFinalizer(ctx, cr)
+		}
+
+		r.Log.Infof("Reaper of cluster %s/%s is not ready to abort the repair run. Trying again in %s...", cc.Namespace, cc.Name, r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	if err = r.abortRepairRun(ctx, reaperClient, cr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, r.removeFinalizer(ctx, cr)
+}
+
+// reaperClient returns the client of the cluster's Reaper. Returns false if Reaper is not running or doesn't manage the cluster yet.
+func (r *CassandraRepairReconciler) reaperClient(ctx context.Context, cc *v1alpha1.CassandraCluster) (reaper.ReaperClient, bool, error) {
+	var repairThreadCount int32
+	if cc.Spec.Reaper != nil {
+		repairThreadCount = cc.Spec.Reaper.RepairThreadCount
+	}
+
+	reaperClient := r.ReaperClient(names.ReaperServiceURL(cc), cc.Name, repairThreadCount)
+	isRunning, err := reaperClient.IsRunning(ctx)
+	if err != nil || !isRunning {
+		return nil, false, nil
+	}
+
+	clusterExists, err := reaperClient.ClusterExists(ctx)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to check if the cluster is added to Reaper")
+	}
+
+	return reaperClient, clusterExists, nil
+}
+
+func (r *CassandraRepairReconciler) removeFinalizer(ctx context.Context, cr *v1alpha1.CassandraRepair) error {
+	if !controllerutil.ContainsFinalizer(cr, repairRunFinalizer) {
+		return nil
+	}
+
+	controllerutil.RemoveFinalizer(cr, repairRunFinalizer)
+	return errors.Wrap(r.Update(ctx, cr), "failed to remove finalizer")
+}
+
+func SetupCassandraRepairReconciler(r reconcile.Reconciler, mgr manager.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		Named("cassandrarepair").
+		For(&v1alpha1.CassandraRepair{})
+
+	return builder.Complete(r)
+}
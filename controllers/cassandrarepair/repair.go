@@ -0,0 +1,116 @@
+package cassandrarepair
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+)
+
+// repairRun finds the repair run of the CassandraRepair. The run is looked up by its cause if its ID is not saved yet,
+// as the status update may have failed after the run was created.
+func (r *CassandraRepairReconciler) repairRun(ctx context.Context, reaperClient reaper.ReaperClient, cr *v1alpha1.CassandraRepair) (reaper.RepairRun, bool, error) {
+	repairRuns, err := reaperClient.RepairRuns(ctx, "")
+	if err != nil {
+		return reaper.RepairRun{}, false, errors.Wrap(err, "failed to get repair runs")
+	}
+
+	for _, repairRun := range repairRuns {
+		if len(cr.Status.RunID) != 0 {
+			if repairRun.ID == cr.Status.RunID {
+				return repairRun, true, nil
+			}
+			continue
+		}
+
+		if repairRun.Owner == reaper.OwnerCassandraOperator && repairRun.Cause == repairRunCause(cr) {
+			return repairRun, true, nil
+		}
+	}
+
+	return reaper.RepairRun{}, false, nil
+}
+
+// createRepairRun creates the repair run in Reaper. Returns false if Reaper has rejected the run.
+func (r *CassandraRepairReconciler) createRepairRun(ctx context.Context, reaperClient reaper.ReaperClient, cr *v1alpha1.CassandraRepair) (bool, error) {
+	r.Log.Infof("Creating repair run of keyspace %q for CassandraRepair %s/%s", cr.Spec.Keyspace, cr.Namespace, cr.Name)
+	repairRun, err := reaperClient.CreateRepairRun(ctx, cr.Spec, repairRunCause(cr))
+	if err != nil {
+		errMsg := fmt.Sprintf("Failed to create repair run of keyspace %q: %s. Trying again in %s", cr.Spec.Keyspace, err.Error(), r.Cfg.RetryDelay)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventRepairRunFailed, errMsg)
+		cr.Status.Error = err.Error()
+		return false, r.Status().Update(ctx, cr)
+	}
+
+	cr.Status.RunID = repairRun.ID
+	cr.Status.State = repairRun.State
+	cr.Status.Error = ""
+	return true, r.Status().Update(ctx, cr)
+}
+
+// abortRepairRun deletes the unfinished repair run from Reaper. Finished runs are kept in Reaper's history.
+func (r *CassandraRepairReconciler) abortRepairRun(ctx context.Context, reaperClient reaper.ReaperClient, cr *v1alpha1.CassandraRepair) error {
+	repairRun, found, err := r.repairRun(ctx, reaperClient, cr)
+	if err != nil {
+		return err
+	}
+
+	if !found || repairRunFinished(repairRun.State) {
+		return nil
+	}
+
+	r.Log.Infof("Aborting repair run %s of keyspace %q", repairRun.ID, cr.Spec.Keyspace)
+	if err = reaperClient.DeleteRepairRun(ctx, repairRun); err != nil {
+		return errors.Wrapf(err, "failed to abort repair run %s", repairRun.ID)
+	}
+
+	r.Events.Normal(cr, events.EventRepairRunAborted, fmt.Sprintf("Repair run %s of keyspace %q is aborted", repairRun.ID, cr.Spec.Keyspace))
+	return nil
+}
+
+// updateStatus mirrors the state and progress of the repair run
+func (r *CassandraRepairReconciler) updateStatus(ctx context.Context, cr *v1alpha1.CassandraRepair, repairRun reaper.RepairRun) error {
+	oldStatus := cr.Status.DeepCopy()
+	cr.Status.RunID = repairRun.ID
+	cr.Status.State = repairRun.State
+	cr.Status.SegmentsRepaired = repairRun.SegmentsRepaired
+	cr.Status.TotalSegments = repairRun.TotalSegments
+	cr.Status.StartTime = reaper.ParseTime(repairRun.StartTime)
+	cr.Status.EndTime = reaper.ParseTime(repairRun.EndTime)
+	cr.Status.LastEvent = repairRun.LastEvent
+
+	if reflect.DeepEqual(oldStatus, &cr.Status) {
+		return nil
+	}
+
+	if oldStatus.State != cr.Status.State {
+		switch cr.Status.State {
+		case reaper.RepairStateDone:
+			msg := fmt.Sprintf("Repair run %s of keyspace %q has completed", repairRun.ID, cr.Spec.Keyspace)
+			r.Log.Info(msg)
+			r.Events.Normal(cr, events.EventRepairRunCompleted, msg)
+		case reaper.RepairStateError, reaper.RepairStateAborted:
+			errMsg := fmt.Sprintf("Repair run %s of keyspace %q has finished with state %s: %s", repairRun.ID, cr.Spec.Keyspace, repairRun.State, repairRun.LastEvent)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventRepairRunFailed, errMsg)
+		}
+	}
+
+	return r.Status().Update(ctx, cr)
+}
+
+// repairRunCause identifies the repair run of the CassandraRepair. The UID distinguishes recreated CassandraRepairs.
+func repairRunCause(cr *v1alpha1.CassandraRepair) string {
+	return fmt.Sprintf("CassandraRepair %s/%s (%s)", cr.Namespace, cr.Name, cr.UID)
+}
+
+func repairRunFinished(state string) bool {
+	return state == reaper.RepairStateDone || state == reaper.RepairStateError ||
+		state == reaper.RepairStateAborted || state == reaper.RepairStateDeleted
+}
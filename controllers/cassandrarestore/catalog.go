@@ -0,0 +1,57 @@
+package cassandrarestore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+// findCatalogBackup finds the backup to restore in the catalog. The snapshot tag has to identify a single backup,
+// the source cluster and schema version are used to choose between backups with the same snapshot tag.
+func findCatalogBackup(catalog *v1alpha1.CassandraBackupCatalog, cr *v1alpha1.CassandraRestore) (v1alpha1.CatalogBackup, error) {
+	var candidates []v1alpha1.CatalogBackup
+	var schemaVersions []string
+	for _, backup := range catalog.Status.Backups {
+		if backup.SnapshotTag != cr.Spec.SnapshotTag {
+			continue
+		}
+
+		if len(cr.Spec.SourceCluster) != 0 && backup.Cluster != cr.Spec.SourceCluster {
+			continue
+		}
+
+		schemaVersions = append(schemaVersions, backup.SchemaVersion)
+		if len(cr.Spec.SchemaVersion) != 0 && backup.SchemaVersion != cr.Spec.SchemaVersion {
+			continue
+		}
+
+		candidates = append(candidates, backup)
+	}
+
+	if len(candidates) == 0 {
+		if len(schemaVersions) != 0 {
+			return v1alpha1.CatalogBackup{}, fmt.Errorf("backup with snapshot tag %q and schema version %q not found in catalog %s. Available schema versions: %s",
+				cr.Spec.SnapshotTag, cr.Spec.SchemaVersion, catalog.Name, strings.Join(util.Uniq(schemaVersions), ", "))
+		}
+		return v1alpha1.CatalogBackup{}, fmt.Errorf("backup with snapshot tag %q not found in catalog %s", cr.Spec.SnapshotTag, catalog.Name)
+	}
+
+	var clusters []string
+	for _, candidate := range candidates {
+		clusters = append(clusters, candidate.Cluster)
+	}
+	clusters = util.Uniq(clusters)
+	if len(clusters) > 1 {
+		return v1alpha1.CatalogBackup{}, fmt.Errorf("backups with snapshot tag %q found for clusters %s. Set .spec.sourceCluster to choose one",
+			cr.Spec.SnapshotTag, strings.Join(clusters, ", "))
+	}
+
+	if len(candidates) > 1 {
+		return v1alpha1.CatalogBackup{}, fmt.Errorf("backups with snapshot tag %q found with schema versions %s. Set .spec.schemaVersion to choose one",
+			cr.Spec.SnapshotTag, strings.Join(util.Uniq(schemaVersions), ", "))
+	}
+
+	return candidates[0], nil
+}
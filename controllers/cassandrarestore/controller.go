@@ -3,17 +3,27 @@ package cassandrarestore
 import (
 	"context"
 	"fmt"
+	"net/url"
 
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 
+	"github.com/gocql/gocql"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
 	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/cql"
 	"github.com/ibm/cassandra-operator/controllers/events"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
-	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/metrics"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+	"github.com/ibm/cassandra-operator/controllers/util"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,18 +32,27 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // CassandraRestoreReconciler reconciles a CassandraRestore object
 type CassandraRestoreReconciler struct {
 	client.Client
-	Log          *zap.SugaredLogger
-	Scheme       *runtime.Scheme
-	Cfg          config.Config
-	Events       *events.EventRecorder
-	IcarusClient func(coordinatorPodURL string) icarus.Icarus
+	Log           *zap.SugaredLogger
+	Scheme        *runtime.Scheme
+	Cfg           config.Config
+	Events        *events.EventRecorder
+	IcarusClient  func(coordinatorPodURL string) icarus.Icarus
+	MedusaClient  func(addr string) medusa.Medusa
+	StorageClient func(location storage.Location, secret *v1.Secret, insecure bool) (storage.StorageClient, error)
+	NodectlClient func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl
+	CqlClient     func(cluster *gocql.ClusterConfig) (cql.CqlClient, error)
+	ReaperClient  func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient
+	Poller        *backupengine.Poller
 }
 
 // +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrarestores,verbs=get;list;watch;create;update;patch;delete
@@ -44,12 +63,17 @@ func (r *CassandraRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	err := r.Get(ctx, req.NamespacedName, cr)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
+			r.Poller.ForgetRestore(req.NamespacedName)
+			metrics.ForgetRestore(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
-	if cr.Status.State == icarus.StateCompleted {
+	// restores the metrics of existing resources after an operator restart
+	metrics.UpdateRestoreMetrics(cr)
+
+	if cr.Status.State == icarus.StateCompleted && verificationDone(cr) {
 		r.Log.Debugf("Restore %s is completed", cr.Name)
 		return ctrl.Result{}, nil
 	}
@@ -66,7 +90,8 @@ func (r *CassandraRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
-	if !cc.Status.Ready {
+	// the cluster isn't ready while its nodes are restarted to apply a Medusa restore
+	if !cc.Status.Ready && !util.Contains(cr.Status.OperationIDs, medusa.RestoreKey(cr)) {
 		r.Log.Warnf("CassandraCluster %s/%s is not ready. Not starting backup, trying again in %s...", cc.Namespace, cc.Name, r.Cfg.RetryDelay)
 		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
@@ -85,6 +110,45 @@ func (r *CassandraRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
+	if len(cr.Spec.CassandraBackupCatalog) > 0 {
+		catalog := &v1alpha1.CassandraBackupCatalog{}
+		err = r.Get(ctx, types.NamespacedName{Name: cr.Spec.CassandraBackupCatalog, Namespace: cr.Namespace}, catalog)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				errMsg := fmt.Sprintf("Restore failed. CassandraBackupCatalog %s not found", cr.Spec.CassandraBackupCatalog)
+				r.Log.Warn(errMsg)
+				r.Events.Warning(cr, events.EventCassandraBackupCatalogNotFound, errMsg)
+				return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+			}
+			return ctrl.Result{}, err
+		}
+
+		if catalog.Status.LastSyncTime == nil {
+			r.Log.Infof("CassandraBackupCatalog %s/%s is not synced yet. Trying again in %s...", catalog.Namespace, catalog.Name, r.Cfg.RetryDelay)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+
+		catalogBackup, err := findCatalogBackup(catalog, cr)
+		if err != nil {
+			if cr.Status.State == icarus.StateFailed {
+				return ctrl.Result{}, nil
+			}
+
+			errMsg := fmt.Sprintf("Restore failed: %s", err.Error())
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventCatalogBackupNotFound, errMsg)
+			return ctrl.Result{}, r.reconcileStatus(ctx, cr, icarus.Restore{
+				State:  icarus.StateFailed,
+				Errors: []icarus.Error{{Source: "cassandra-operator", Message: errMsg}},
+			})
+		}
+
+		// the backup from the catalog is used the same way as a CassandraBackup
+		cb.Spec.CassandraCluster = catalogBackup.Cluster
+		cb.Spec.StorageLocation = catalog.Spec.StorageLocation
+		cb.Spec.SecretName = catalog.Spec.SecretName
+	}
+
 	secretName := cr.Spec.SecretName
 	if len(secretName) == 0 {
 		secretName = cb.Spec.SecretName
@@ -94,9 +158,9 @@ func (r *CassandraRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	err = r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, storageCredentials)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
-			errMsg := fmt.Sprintf("Failed to create backup for cluster %q. Storage credentials secret %q not found.", cb.Spec.CassandraCluster, cb.Spec.SecretName)
+			errMsg := fmt.Sprintf("Failed to restore into cluster %q. Storage credentials secret %q not found.", cc.Name, secretName)
 			r.Log.Warn(errMsg)
-			r.Events.Warning(cb, events.EventStorageCredentialsSecretNotFound, errMsg)
+			r.Events.Warning(cr, events.EventStorageCredentialsSecretNotFound, errMsg)
 			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 		}
 
@@ -105,20 +169,41 @@ func (r *CassandraRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	err = v1alpha1.ValidateStorageSecret(r.Log, storageCredentials, cb.StorageProvider())
 	if err != nil {
-		errMsg := fmt.Sprintf("Storage credentials secret %q is invalid: %s", cb.Spec.SecretName, err.Error())
+		errMsg := fmt.Sprintf("Storage credentials secret %q is invalid: %s", secretName, err.Error())
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventStorageCredentialsSecretNotFound, errMsg)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	readyPods, err := backupengine.ReadyPods(ctx, r.Client, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if cr.Status.State == icarus.StateCompleted {
+		r.Poller.ForgetRestore(req.NamespacedName)
+		return r.reconcileResult(r.reconcileVerification(ctx, cr, cb, cc, storageCredentials, readyPods))
+	}
+
+	if cc.Spec.BackupEngine == v1alpha1.BackupEngineMedusa {
+		// Medusa sends the requests to all nodes, there's no coordinator
+		return r.reconcileResult(r.reconcileMedusaRestore(ctx, r.backupEngine(cc, v1.Pod{}, readyPods), cr, cc, readyPods))
+	}
+
+	coordinator, found := icarus.SelectCoordinator(readyPods, cr.Status.Coordinator)
+	if !found {
+		errMsg := fmt.Sprintf("No ready %s sidecar found in cluster %q to coordinate the restore. Trying again in %s", backupengine.Name(cc), cc.Name, r.Cfg.RetryDelay)
 		r.Log.Warn(errMsg)
-		r.Events.Warning(cb, events.EventStorageCredentialsSecretNotFound, errMsg)
+		r.Events.Warning(cr, events.EventIcarusCoordinatorUnavailable, errMsg)
 		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
-	dcName := cc.Spec.DCs[0].Name
-	svc := names.DC(cc.Name, dcName)
-	//always use the same pod as the coordinator as only that pod has the global request info
-	coordinatorPodURL := fmt.Sprintf("http://%s-0.%s.%s.svc.cluster.local:%d", svc, svc, cc.Namespace, v1alpha1.IcarusPort)
+	engine := r.backupEngine(cc, coordinator, readyPods)
 
-	ic := r.IcarusClient(coordinatorPodURL)
+	return r.reconcileResult(r.reconcileRestore(ctx, engine, cr, cb, cc, coordinator.Name, readyPods))
+}
 
-	res, err := r.reconcileRestore(ctx, ic, cr, cb, cc)
+func (r *CassandraRestoreReconciler) reconcileResult(res ctrl.Result, err error) (ctrl.Result, error) {
 	if err != nil {
 		if statusErr, ok := errors.Cause(err).(*kerrors.StatusError); ok && statusErr.ErrStatus.Reason == metav1.StatusReasonConflict {
 			r.Log.Info("Conflict occurred. Retrying...", zap.Error(err))
@@ -132,10 +217,16 @@ func (r *CassandraRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	return res, nil
 }
 
-func SetupCassandraRestoreReconciler(r reconcile.Reconciler, mgr manager.Manager) error {
+func (r *CassandraRestoreReconciler) backupEngine(cc *v1alpha1.CassandraCluster, coordinator v1.Pod, readyPods []v1.Pod) backupengine.Engine {
+	return backupengine.New(cc, backupengine.Clients{Icarus: r.IcarusClient, Medusa: r.MedusaClient}, coordinator, readyPods)
+}
+
+func SetupCassandraRestoreReconciler(r reconcile.Reconciler, mgr manager.Manager, reconcileChan chan event.GenericEvent) error {
 	builder := ctrl.NewControllerManagedBy(mgr).
 		Named("cassandrarestore").
-		For(&v1alpha1.CassandraRestore{})
+		For(&v1alpha1.CassandraRestore{}).
+		Owns(&batchv1.Job{}).
+		Watches(&source.Channel{Source: reconcileChan}, &handler.EnqueueRequestForObject{})
 
 	return builder.Complete(r)
 }
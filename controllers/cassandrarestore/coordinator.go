@@ -0,0 +1,87 @@
+package cassandrarestore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/util"
+	v1 "k8s.io/api/core/v1"
+)
+
+// reconcileCoordinator persists the coordinator in the status so that the same pod is used while it's ready
+func (r *CassandraRestoreReconciler) reconcileCoordinator(ctx context.Context, cr *v1alpha1.CassandraRestore, coordinator string) error {
+	if cr.Status.Coordinator == coordinator {
+		return nil
+	}
+
+	if len(cr.Status.Coordinator) != 0 {
+		msg := fmt.Sprintf("Icarus coordinator of restore %s/%s changed from pod %s to %s", cr.Namespace, cr.Name, cr.Status.Coordinator, coordinator)
+		r.Log.Info(msg)
+		r.Events.Normal(cr, events.EventIcarusCoordinatorChanged, msg)
+	}
+
+	cr.Status.Coordinator = coordinator
+	return r.Status().Update(ctx, cr)
+}
+
+// reconcileOperationIDs persists the IDs of the Icarus restore operations to be able to find them if the coordinator is lost
+func (r *CassandraRestoreReconciler) reconcileOperationIDs(ctx context.Context, cr *v1alpha1.CassandraRestore, icarusRestores []icarus.Restore) error {
+	var operationIDs []string
+	for _, icarusRestore := range icarusRestores {
+		if len(icarusRestore.Id) != 0 {
+			operationIDs = append(operationIDs, icarusRestore.Id)
+		}
+	}
+	operationIDs = util.Uniq(operationIDs)
+
+	if len(operationIDs) == 0 || equalIDs(operationIDs, cr.Status.OperationIDs) {
+		return nil
+	}
+
+	cr.Status.OperationIDs = operationIDs
+	return r.Status().Update(ctx, cr)
+}
+
+// findRestoreOnNodes looks for the restore operation of the DC on all nodes. Used if the coordinator has changed
+// or has been restarted and doesn't have the global request anymore.
+func (r *CassandraRestoreReconciler) findRestoreOnNodes(ctx context.Context, cc *v1alpha1.CassandraCluster, readyPods []v1.Pod,
+	operationIDs []string, dc string) (icarus.Restore, bool) {
+	var nodeRestores []icarus.Restore
+	for _, pod := range readyPods {
+		restores, err := r.backupEngine(cc, pod, []v1.Pod{pod}).Restores(ctx)
+		if err != nil {
+			r.Log.Warnf("Failed to get restores from %s of pod %s: %s", backupengine.Name(cc), pod.Name, err.Error())
+			continue
+		}
+
+		for _, restore := range restores {
+			if restore.DC == dc && util.Contains(operationIDs, restore.Id) {
+				nodeRestores = append(nodeRestores, restore)
+			}
+		}
+	}
+
+	if len(nodeRestores) == 0 {
+		return icarus.Restore{}, false
+	}
+
+	return mergeIcarusRestores(nodeRestores), true
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, id := range a {
+		if !util.Contains(b, id) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -9,7 +9,27 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/icarus"
 )
 
-func createRestoreReq(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraBackup, restore *v1alpha1.CassandraRestore) icarus.RestoreRequest {
+// createRestoreReqs returns a restore request per DC mapping or a single request if the cluster topology is not mapped
+func createRestoreReqs(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraBackup, restore *v1alpha1.CassandraRestore) []icarus.RestoreRequest {
+	restoreReq := createRestoreReq(cc, backup, restore)
+	if len(restore.Spec.TopologyMapping) == 0 {
+		return []icarus.RestoreRequest{restoreReq}
+	}
+
+	restoreReqs := make([]icarus.RestoreRequest, 0, len(restore.Spec.TopologyMapping))
+	for _, dcMapping := range restore.Spec.TopologyMapping {
+		dcRestoreReq := restoreReq
+		dcRestoreReq.StorageLocation = restoreStorageLocation(cc, backup, restore, dcMapping.Source, "1")
+		dcRestoreReq.DC = dcMapping.Target
+		// nodes of the target DC are matched with the source DC nodes using the topology file uploaded with the backup
+		dcRestoreReq.ResolveHostIdFromTopology = true
+		restoreReqs = append(restoreReqs, dcRestoreReq)
+	}
+
+	return restoreReqs
+}
+
+func restoreStorageLocation(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraBackup, restore *v1alpha1.CassandraRestore, dcName, nodeID string) string {
 	storageLocation := restore.Spec.StorageLocation
 	if len(storageLocation) == 0 {
 		storageLocation = backup.Spec.StorageLocation
@@ -18,7 +38,23 @@ func createRestoreReq(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraB
 		storageLocation += "/"
 	}
 
-	storageLocation = fmt.Sprintf("%s%s/%s/1", storageLocation, cc.Name, cc.Spec.DCs[0].Name)
+	return fmt.Sprintf("%s%s/%s/%s", storageLocation, sourceCluster(cc, backup, restore), dcName, nodeID)
+}
+
+func sourceCluster(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraBackup, restore *v1alpha1.CassandraRestore) string {
+	if len(restore.Spec.SourceCluster) != 0 {
+		return restore.Spec.SourceCluster
+	}
+
+	if len(backup.Spec.CassandraCluster) != 0 {
+		return backup.Spec.CassandraCluster
+	}
+
+	return cc.Name
+}
+
+func createRestoreReq(cc *v1alpha1.CassandraCluster, backup *v1alpha1.CassandraBackup, restore *v1alpha1.CassandraRestore) icarus.RestoreRequest {
+	storageLocation := restoreStorageLocation(cc, backup, restore, cc.Spec.DCs[0].Name, "1")
 	snapshotTag := restore.Spec.SnapshotTag
 	if len(snapshotTag) == 0 {
 		snapshotTag = backup.Name
@@ -0,0 +1,385 @@
+package cassandrarestore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	loaderDataDir   = "/var/lib/cassandra-loader"
+	loaderConfigDir = "/etc/cassandra-configmaps"
+	// max number of target nodes passed to sstableloader as initial contact points, the rest of the ring is discovered
+	loaderContactPoints = 3
+)
+
+// loaderRestoreRequired returns true if the number of nodes of any source DC differs from the mapped target DC.
+// In that case SSTables can't be copied to the nodes with matching host IDs and have to be streamed instead.
+func loaderRestoreRequired(cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore) bool {
+	for _, dcMapping := range cr.Spec.TopologyMapping {
+		if dcMapping.SourceNodes == 0 {
+			continue
+		}
+
+		for _, dc := range cc.Spec.DCs {
+			if dc.Name == dcMapping.Target && dc.Replicas != nil && *dc.Replicas != dcMapping.SourceNodes {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (r *CassandraRestoreReconciler) reconcileLoaderRestore(ctx context.Context, cr *v1alpha1.CassandraRestore,
+	cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster, snapshotTag string) (ctrl.Result, error) {
+	var loaderJobs []batchv1.Job
+	for _, dcMapping := range cr.Spec.TopologyMapping {
+		desiredJob := r.loaderJob(cc, cb, cr, dcMapping, snapshotTag)
+		if err := controllerutil.SetControllerReference(cr, desiredJob, r.Scheme); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "Cannot set controller reference")
+		}
+
+		actualJob := &batchv1.Job{}
+		err := r.Get(ctx, types.NamespacedName{Name: desiredJob.Name, Namespace: desiredJob.Namespace}, actualJob)
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return ctrl.Result{}, errors.Wrapf(err, "Failed to get loader job %s", desiredJob.Name)
+			}
+
+			if cr.Status.State == icarus.StateFailed {
+				continue
+			}
+
+			r.Log.Infof("Creating loader job %s to restore DC %s into DC %s", desiredJob.Name, dcMapping.Source, dcMapping.Target)
+			if err = r.Create(ctx, desiredJob); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "Failed to create loader job %s", desiredJob.Name)
+			}
+			actualJob = desiredJob
+		}
+
+		loaderJobs = append(loaderJobs, *actualJob)
+	}
+
+	loaderRestore := loaderJobsRestoreState(loaderJobs)
+	if err := r.reconcileStatus(ctx, cr, loaderRestore); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if loaderRestore.State == icarus.StateFailed {
+		r.Log.Infof("Restore %s/%s has failed. Recreate the CassandraRestore resource to start a new restore attempt", cr.Namespace, cr.Name)
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+}
+
+// loaderJobsRestoreState represents the state of the loader jobs the same way as Icarus reports restores
+func loaderJobsRestoreState(loaderJobs []batchv1.Job) icarus.Restore {
+	loaderRestore := icarus.Restore{State: icarus.StateRunning}
+	succeeded := 0
+	for _, job := range loaderJobs {
+		for _, condition := range job.Status.Conditions {
+			if condition.Status != v1.ConditionTrue {
+				continue
+			}
+
+			switch condition.Type {
+			case batchv1.JobComplete:
+				succeeded++
+			case batchv1.JobFailed:
+				loaderRestore.State = icarus.StateFailed
+				loaderRestore.Errors = append(loaderRestore.Errors, icarus.Error{
+					Source:  job.Name,
+					Message: fmt.Sprintf("%s: %s", condition.Reason, condition.Message),
+				})
+			}
+		}
+	}
+
+	if len(loaderJobs) > 0 {
+		loaderRestore.Progress = float64(succeeded) / float64(len(loaderJobs))
+	}
+
+	if loaderRestore.State != icarus.StateFailed && len(loaderJobs) > 0 && succeeded == len(loaderJobs) {
+		loaderRestore.State = icarus.StateCompleted
+	}
+
+	return loaderRestore
+}
+
+func (r *CassandraRestoreReconciler) loaderJob(cc *v1alpha1.CassandraCluster, cb *v1alpha1.CassandraBackup, cr *v1alpha1.CassandraRestore,
+	dcMapping v1alpha1.DCMapping, snapshotTag string) *batchv1.Job {
+	restoreReq := createRestoreReq(cc, cb, cr)
+	jobLabels := labels.CombinedComponentLabels(cc, v1alpha1.CassandraClusterComponentCassandra)
+
+	icarusImage := cc.Spec.Icarus.Image
+	if len(icarusImage) == 0 {
+		icarusImage = r.Cfg.DefaultIcarusImage
+	}
+
+	cassandraImage := r.Cfg.DefaultCassandraImage
+	if cc.Spec.Cassandra != nil && len(cc.Spec.Cassandra.Image) != 0 {
+		cassandraImage = cc.Spec.Cassandra.Image
+	}
+
+	loaderContainer := v1.Container{
+		Name:    "loader",
+		Image:   cassandraImage,
+		Command: []string{"bash", "-c"},
+		Args:    []string{loaderCommand(cc, dcMapping)},
+		Env: []v1.EnvVar{
+			secretEnvVar("ADMIN_ROLE", names.ActiveAdminSecret(cc.Name), v1alpha1.CassandraOperatorAdminRole),
+			secretEnvVar("ADMIN_PASSWORD", names.ActiveAdminSecret(cc.Name), v1alpha1.CassandraOperatorAdminPassword),
+		},
+		VolumeMounts: []v1.VolumeMount{loaderDataVolumeMount()},
+	}
+
+	volumes := []v1.Volume{
+		{
+			Name: "loader-data",
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	encryption := clusterEncryption(cc)
+	if encryption.Server.InternodeEncryption != v1alpha1.InternodeEncryptionNone || encryption.Client.Enabled {
+		// sstableloader reads the encryption options from the cassandra.yaml of the cluster
+		loaderContainer.VolumeMounts = append(loaderContainer.VolumeMounts, v1.VolumeMount{
+			Name:      "config",
+			MountPath: loaderConfigDir,
+		})
+		volumes = append(volumes, v1.Volume{
+			Name: "config",
+			VolumeSource: v1.VolumeSource{
+				ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: names.ConfigMap(cc.Name)},
+					Items:                []v1.KeyToPath{{Key: "cassandra.yaml", Path: "cassandra.yaml"}},
+				},
+			},
+		})
+	}
+
+	if encryption.Server.InternodeEncryption != v1alpha1.InternodeEncryptionNone {
+		loaderContainer.VolumeMounts = append(loaderContainer.VolumeMounts, v1.VolumeMount{
+			Name:      "server-tls",
+			MountPath: v1alpha1.CassandraServerTLSDir,
+		})
+		volumes = append(volumes, loaderTLSVolume("server-tls", encryption.Server.NodeTLSSecret))
+	}
+
+	if encryption.Client.Enabled {
+		loaderContainer.VolumeMounts = append(loaderContainer.VolumeMounts, v1.VolumeMount{
+			Name:      "client-tls",
+			MountPath: v1alpha1.CassandraClientTLSDir,
+		})
+		volumes = append(volumes, loaderTLSVolume("client-tls", encryption.Client.NodeTLSSecret))
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.RestoreLoaderJob(cr.Name, dcMapping.Source),
+			Namespace: cr.Namespace,
+			Labels:    jobLabels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          proto.Int32(int32(restoreReq.Retry.MaxAttempts)),
+			ActiveDeadlineSeconds: proto.Int64(restoreReq.Timeout * 3600),
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: jobLabels,
+				},
+				Spec: v1.PodSpec{
+					InitContainers: []v1.Container{
+						{
+							Name:            "download",
+							Image:           icarusImage,
+							ImagePullPolicy: cc.Spec.Icarus.ImagePullPolicy,
+							Command:         []string{"bash", "-c"},
+							Args:            []string{loaderDownloadCommand(cc, cb, cr, restoreReq, dcMapping, snapshotTag)},
+							VolumeMounts:    []v1.VolumeMount{loaderDataVolumeMount()},
+						},
+					},
+					Containers:         []v1.Container{loaderContainer},
+					Volumes:            volumes,
+					ImagePullSecrets:   imagePullSecrets(cc),
+					ServiceAccountName: names.CassandraServiceAccount(cc.Name),
+					RestartPolicy:      v1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+}
+
+// loaderDownloadCommand downloads the SSTables of every node of the source DC into a separate directory
+func loaderDownloadCommand(cc *v1alpha1.CassandraCluster, cb *v1alpha1.CassandraBackup, cr *v1alpha1.CassandraRestore,
+	restoreReq icarus.RestoreRequest, dcMapping v1alpha1.DCMapping, snapshotTag string) string {
+	sourceNodeName := names.DC(sourceCluster(cc, cb, cr), dcMapping.Source) + "-${i}"
+	restoreArgs := []string{
+		"java -jar icarus.jar esop restore",
+		"--storage-location=" + restoreStorageLocation(cc, cb, cr, dcMapping.Source, sourceNodeName),
+		"--snapshot-tag=" + snapshotTag,
+		"--k8s-namespace=" + restoreReq.K8sNamespace,
+		"--k8s-secret-name=" + restoreReq.K8sSecretName,
+		"--restoration-strategy-type=IN_PLACE",
+		"--resolve-host-id-from-topology",
+		fmt.Sprintf("--cassandra-dir=%s/${i}", loaderDataDir),
+		fmt.Sprintf("--concurrent-connections=%d", restoreReq.ConcurrentConnections),
+	}
+
+	if len(restoreReq.Entities) != 0 {
+		restoreArgs = append(restoreArgs, "--entities="+restoreReq.Entities)
+	}
+
+	if len(restoreReq.SchemaVersion) != 0 {
+		restoreArgs = append(restoreArgs, "--schema-version="+restoreReq.SchemaVersion)
+	}
+
+	if restoreReq.ExactSchemaVersion {
+		restoreArgs = append(restoreArgs, "--exact-schema-version")
+	}
+
+	if restoreReq.Insecure {
+		restoreArgs = append(restoreArgs, "--insecure")
+	}
+
+	if restoreReq.SkipBucketVerification {
+		restoreArgs = append(restoreArgs, "--skip-bucket-verification")
+	}
+
+	return fmt.Sprintf(`for i in $(seq 0 %d); do
+  echo downloading SSTables of node %s
+  %s || exit 1
+done`, sourceNodes(cc, dcMapping)-1, sourceNodeName, strings.Join(restoreArgs, " \\\n    "))
+}
+
+// sourceNodes returns the number of nodes in the source DC, which defaults to the number of nodes in the target DC
+func sourceNodes(cc *v1alpha1.CassandraCluster, dcMapping v1alpha1.DCMapping) int32 {
+	if dcMapping.SourceNodes != 0 {
+		return dcMapping.SourceNodes
+	}
+
+	for _, dc := range cc.Spec.DCs {
+		if dc.Name == dcMapping.Target && dc.Replicas != nil {
+			return *dc.Replicas
+		}
+	}
+
+	return 0
+}
+
+// loaderCommand streams the downloaded SSTables of all non system tables into the target DC
+func loaderCommand(cc *v1alpha1.CassandraCluster, dcMapping v1alpha1.DCMapping) string {
+	var contactPoints []string
+	for _, dc := range cc.Spec.DCs {
+		if dc.Name != dcMapping.Target || dc.Replicas == nil {
+			continue
+		}
+
+		svc := names.DCService(cc.Name, dc.Name)
+		for i := int32(0); i < *dc.Replicas && i < loaderContactPoints; i++ {
+			contactPoints = append(contactPoints, fmt.Sprintf("%s-%d.%s.%s.svc.cluster.local", names.DC(cc.Name, dc.Name), i, svc, cc.Namespace))
+		}
+	}
+
+	return fmt.Sprintf(`for table_dir in %s/*/data/*/*/; do
+  keyspace=$(basename $(dirname ${table_dir}))
+  case "${keyspace}" in
+    system|system_*) continue ;;
+  esac
+  echo loading ${table_dir}
+  sstableloader --nodes %s --port %d --username "${ADMIN_ROLE}" --password "${ADMIN_PASSWORD}"%s ${table_dir} || exit 1
+done`, loaderDataDir, strings.Join(contactPoints, ","), v1alpha1.CqlPort, loaderConfigArgs(cc))
+}
+
+// loaderConfigArgs returns the sstableloader options needed to connect to a cluster with client or internode encryption enabled
+func loaderConfigArgs(cc *v1alpha1.CassandraCluster) string {
+	encryption := clusterEncryption(cc)
+	if encryption.Server.InternodeEncryption == v1alpha1.InternodeEncryptionNone && !encryption.Client.Enabled {
+		return ""
+	}
+
+	return fmt.Sprintf(" --conf-path %s/cassandra.yaml", loaderConfigDir)
+}
+
+// clusterEncryption returns the encryption options of the cluster with the defaults set by the cluster controller
+func clusterEncryption(cc *v1alpha1.CassandraCluster) v1alpha1.Encryption {
+	encryption := *cc.Spec.Encryption.DeepCopy()
+	encryption.Server.SetDefaults()
+	encryption.Client.SetDefaults()
+
+	if encryption.Server.NodeTLSSecret.Name == "" {
+		encryption.Server.NodeTLSSecret.Name = names.CassandraClusterTLSNode(cc.Name)
+	}
+
+	if encryption.Client.NodeTLSSecret.Name == "" {
+		encryption.Client.NodeTLSSecret.Name = names.CassandraClientTLSNode(cc.Name)
+	}
+
+	return encryption
+}
+
+// loaderTLSVolume mounts the keystore and truststore of the node TLS secret
+func loaderTLSVolume(name string, nodeTLSSecret v1alpha1.NodeTLSSecret) v1.Volume {
+	return v1.Volume{
+		Name: name,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: nodeTLSSecret.Name,
+				Items: []v1.KeyToPath{
+					{
+						Key:  nodeTLSSecret.KeystoreFileKey,
+						Path: nodeTLSSecret.KeystoreFileKey,
+					},
+					{
+						Key:  nodeTLSSecret.TruststoreFileKey,
+						Path: nodeTLSSecret.TruststoreFileKey,
+					},
+				},
+			},
+		},
+	}
+}
+
+func imagePullSecrets(cc *v1alpha1.CassandraCluster) []v1.LocalObjectReference {
+	if len(cc.Spec.ImagePullSecretName) == 0 {
+		return nil
+	}
+
+	return []v1.LocalObjectReference{{Name: cc.Spec.ImagePullSecretName}}
+}
+
+func secretEnvVar(name, secretName, key string) v1.EnvVar {
+	return v1.EnvVar{
+		Name: name,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+func loaderDataVolumeMount() v1.VolumeMount {
+	return v1.VolumeMount{
+		Name:      "loader-data",
+		MountPath: loaderDataDir,
+	}
+}
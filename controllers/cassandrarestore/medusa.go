@@ -0,0 +1,204 @@
+package cassandrarestore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+// reconcileMedusaRestore prepares the restore on the nodes of the restored DCs and tracks the restart of the nodes.
+// Medusa applies a prepared restore when the node starts: once the restore key is persisted, the CassandraCluster controller
+// adds the medusa-restore init container to the statefulsets of the DCs, which restarts their nodes one by one.
+func (r *CassandraRestoreReconciler) reconcileMedusaRestore(ctx context.Context, engine backupengine.Engine,
+	cr *v1alpha1.CassandraRestore, cc *v1alpha1.CassandraCluster, readyPods []v1.Pod) (ctrl.Result, error) {
+	r.Poller.ForgetRestore(client.ObjectKeyFromObject(cr))
+	if cr.Status.State == icarus.StateFailed {
+		return ctrl.Result{}, nil
+	}
+
+	dcs := medusa.RestoreDCs(cc, cr)
+	if err := validateMedusaRestore(cc, cr, dcs); err != nil {
+		errMsg := fmt.Sprintf("Can't restore with the %q backup engine: %s", v1alpha1.BackupEngineMedusa, err.Error())
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cr, events.EventRestoreUnsupported, errMsg)
+		return ctrl.Result{}, r.reconcileStatus(ctx, cr, icarus.Restore{
+			State:  icarus.StateFailed,
+			Errors: []icarus.Error{{Source: "cassandra-operator", Message: errMsg}},
+		})
+	}
+
+	restoreKey := medusa.RestoreKey(cr)
+	backupName := medusa.RestoreBackupName(cr)
+	if !util.Contains(cr.Status.OperationIDs, restoreKey) {
+		if !backupengine.AllNodesReady(cc, readyPods, dcs...) {
+			errMsg := fmt.Sprintf("Not all Medusa sidecars of DCs %s are ready to prepare the restore. Trying again in %s", strings.Join(dcs, ", "), r.Cfg.RetryDelay)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventMedusaNodesNotReady, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+
+		err := engine.Restore(ctx, icarus.RestoreRequest{SnapshotTag: backupName, DC: strings.Join(dcs, ","), RestoreKey: restoreKey})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		msg := fmt.Sprintf("Restore of backup %s is prepared on the nodes of DCs %s. The nodes are restarted to apply it", backupName, strings.Join(dcs, ", "))
+		r.Log.Info(msg)
+		r.Events.Normal(cr, events.EventMedusaRestorePrepared, msg)
+		if err = r.reconcileOperationIDs(ctx, cr, []icarus.Restore{{Id: restoreKey}}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	restoreState, err := r.medusaRestoreState(ctx, cc, restoreKey, dcs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if restoreState.State == icarus.StateFailed && cr.Status.State != icarus.StateFailed {
+		r.Log.Warn(restoreState.Errors[0].Message)
+		r.Events.Warning(cr, events.EventMedusaRestoreFailed, restoreState.Errors[0].Message)
+	}
+
+	if err = r.reconcileStatus(ctx, cr, restoreState); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if restoreState.State == icarus.StateRunning {
+		// the restarts are not polled
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// validateMedusaRestore checks that the restore can be done by Medusa. Medusa restores the whole backup of the cluster in place.
+func validateMedusaRestore(cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore, dcs []string) error {
+	switch {
+	case cr.Spec.PointInTime != nil:
+		return errors.New("point-in-time restores are not supported")
+	case len(cr.Spec.TopologyMapping) != 0 || (len(cr.Spec.SourceCluster) != 0 && cr.Spec.SourceCluster != cc.Name):
+		return errors.New("restores from another cluster or DC are not supported")
+	case len(cr.Spec.Rename) != 0:
+		return errors.New("renaming tables is not supported")
+	case len(cr.Spec.Entities) != 0:
+		return errors.New("restoring a subset of the keyspaces is not supported")
+	case len(medusa.RestoreBackupName(cr)) == 0:
+		return errors.New("no backup to restore. It should be set in .spec.snapshotTag or .spec.cassandraBackup")
+	case len(dcs) == 0:
+		return errors.Errorf("no DC of the cluster matches %q", cr.Spec.DC)
+	}
+
+	return nil
+}
+
+// medusaRestoreState returns the state of the restore from the rollout of the statefulsets of the restored DCs
+func (r *CassandraRestoreReconciler) medusaRestoreState(ctx context.Context, cc *v1alpha1.CassandraCluster, restoreKey string, dcs []string) (icarus.Restore, error) {
+	restore := icarus.Restore{Id: restoreKey, State: icarus.StateCompleted}
+	var replicas, restoredReplicas int32
+	for _, dc := range dcs {
+		sts := &appsv1.StatefulSet{}
+		err := r.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, dc), Namespace: cc.Namespace}, sts)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				restore.State = icarus.StateRunning
+				continue
+			}
+			return icarus.Restore{}, errors.Wrapf(err, "failed to get statefulset of DC %s", dc)
+		}
+
+		dcReplicas := int32(1)
+		if sts.Spec.Replicas != nil {
+			dcReplicas = *sts.Spec.Replicas
+		}
+		replicas += dcReplicas
+
+		// the cluster controller hasn't updated the statefulset with the restore yet
+		if restoreContainerKey(sts.Spec.Template.Spec) != restoreKey || sts.Status.ObservedGeneration < sts.Generation {
+			restore.State = icarus.StateRunning
+			continue
+		}
+
+		if sts.Status.UpdatedReplicas < sts.Status.ReadyReplicas {
+			restoredReplicas += sts.Status.UpdatedReplicas
+		} else {
+			restoredReplicas += sts.Status.ReadyReplicas
+		}
+		if sts.Status.UpdatedReplicas < dcReplicas || sts.Status.ReadyReplicas < dcReplicas || sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+			restore.State = icarus.StateRunning
+		}
+	}
+
+	if replicas > 0 {
+		restore.Progress = float64(restoredReplicas) / float64(replicas)
+	}
+
+	if restore.State == icarus.StateCompleted {
+		return restore, nil
+	}
+
+	podList := &v1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(cc.Namespace), client.MatchingLabels(labels.Cassandra(cc))); err != nil {
+		return icarus.Restore{}, errors.Wrap(err, "failed to list Cassandra pods")
+	}
+
+	for _, pod := range podList.Items {
+		if restoreContainerKey(pod.Spec) != restoreKey {
+			continue
+		}
+
+		for _, containerStatus := range pod.Status.InitContainerStatuses {
+			if containerStatus.Name != medusa.RestoreContainerName {
+				continue
+			}
+
+			terminated := containerStatus.State.Terminated
+			if terminated == nil {
+				terminated = containerStatus.LastTerminationState.Terminated
+			}
+			if terminated != nil && terminated.ExitCode != 0 {
+				restore.State = icarus.StateFailed
+				restore.Errors = append(restore.Errors, icarus.Error{
+					Source:  pod.Name,
+					Message: fmt.Sprintf("Medusa restore failed on pod %s with exit code %d: %s", pod.Name, terminated.ExitCode, terminated.Message),
+				})
+			}
+		}
+	}
+
+	return restore, nil
+}
+
+// restoreContainerKey returns the restore key of the medusa-restore init container of the pod spec
+func restoreContainerKey(podSpec v1.PodSpec) string {
+	for _, container := range podSpec.InitContainers {
+		if container.Name != medusa.RestoreContainerName {
+			continue
+		}
+
+		for _, env := range container.Env {
+			if env.Name == medusa.RestoreKeyEnvVar {
+				return env.Value
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,196 @@
+package cassandrarestore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const annotationCommitLogReplay = "commitlog-replay"
+
+func validatePointInTime(cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore) error {
+	if cc.Spec.Cassandra == nil || cc.Spec.Cassandra.CommitLogArchiving == nil {
+		return errors.Errorf("CassandraCluster %s doesn't have commitlog archiving configured (.spec.cassandra.commitLogArchiving)", cc.Name)
+	}
+
+	if loaderRestoreRequired(cc, cr) {
+		return errors.New("point-in-time restore is not supported if the number of nodes of the source and target DCs differ")
+	}
+
+	return nil
+}
+
+// reconcilePointInTime downloads the archived commitlogs on every node and restarts the nodes to replay them.
+// The returned restore reflects the state of the commitlog replay.
+func (r *CassandraRestoreReconciler) reconcilePointInTime(ctx context.Context, cr *v1alpha1.CassandraRestore,
+	cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster) (icarus.Restore, error) {
+	var commitLogRestores []icarus.CommitLogRestore
+	restoreRequestSent := false
+	for _, dc := range cc.Spec.DCs {
+		sourceDC := dc.Name
+		for _, dcMapping := range cr.Spec.TopologyMapping {
+			if dcMapping.Target == dc.Name {
+				sourceDC = dcMapping.Source
+			}
+		}
+
+		replicas := int32(0)
+		if dc.Replicas != nil {
+			replicas = *dc.Replicas
+		}
+
+		for i := 0; i < int(replicas); i++ {
+			podName := fmt.Sprintf("%s-%d", names.DC(cc.Name, dc.Name), i)
+			sourcePodName := fmt.Sprintf("%s-%d", names.DC(sourceCluster(cc, cb, cr), sourceDC), i)
+			svc := names.DC(cc.Name, dc.Name)
+			ic := r.IcarusClient(fmt.Sprintf("http://%s.%s.%s.svc.cluster.local:%d", podName, svc, cc.Namespace, v1alpha1.IcarusPort))
+
+			existingRestores, err := ic.CommitLogRestores(ctx)
+			if err != nil {
+				return icarus.Restore{}, errors.Wrapf(err, "failed to get commitlog restores of pod %s", podName)
+			}
+
+			restoreReq := createCommitLogRestoreReq(cc, cb, cr, sourceDC, sourcePodName)
+			relatedRestore, found := findRelatedCommitLogRestore(existingRestores, restoreReq)
+			if !found {
+				if err = ic.CommitLogRestore(ctx, restoreReq); err != nil {
+					return icarus.Restore{}, errors.Wrapf(err, "failed to send commitlog restore request to pod %s", podName)
+				}
+
+				r.Log.Infof("Commitlog restore request sent to pod %s", podName)
+				restoreRequestSent = true
+				continue
+			}
+
+			commitLogRestores = append(commitLogRestores, relatedRestore)
+		}
+	}
+
+	if restoreRequestSent {
+		return icarus.Restore{State: icarus.StateRunning}, nil
+	}
+
+	downloadState := mergeCommitLogRestores(commitLogRestores)
+	if downloadState.State != icarus.StateCompleted {
+		return downloadState, nil
+	}
+
+	restarting, err := r.restartForCommitLogReplay(ctx, cr, cc)
+	if err != nil {
+		return icarus.Restore{}, err
+	}
+
+	if restarting {
+		downloadState.State = icarus.StateRunning
+	}
+
+	return downloadState, nil
+}
+
+func createCommitLogRestoreReq(cc *v1alpha1.CassandraCluster, cb *v1alpha1.CassandraBackup, cr *v1alpha1.CassandraRestore, sourceDC, sourcePodName string) icarus.CommitLogRestoreRequest {
+	secretName := cr.Spec.SecretName
+	if len(secretName) == 0 {
+		secretName = cb.Spec.SecretName
+	}
+
+	restoreReq := icarus.CommitLogRestoreRequest{
+		Type:                     "commitlog-restore",
+		StorageLocation:          restoreStorageLocation(cc, cb, cr, sourceDC, sourcePodName),
+		CassandraDirectory:       "/var/lib/cassandra",
+		CassandraConfigDirectory: v1alpha1.CommitLogRestoreDir,
+		CommitlogDownloadDir:     v1alpha1.CommitLogRestoreDir + "/commitlogs",
+		TimestampEnd:             cr.Spec.PointInTime.UnixMilli(),
+		K8sNamespace:             cr.Namespace,
+		K8sSecretName:            secretName,
+		ConcurrentConnections:    cr.Spec.ConcurrentConnections,
+		Insecure:                 cr.Spec.Insecure,
+		SkipBucketVerification:   cr.Spec.SkipBucketVerification,
+	}
+
+	if restoreReq.ConcurrentConnections == 0 {
+		restoreReq.ConcurrentConnections = 10
+	}
+
+	return restoreReq
+}
+
+func findRelatedCommitLogRestore(commitLogRestores []icarus.CommitLogRestore, restoreReq icarus.CommitLogRestoreRequest) (icarus.CommitLogRestore, bool) {
+	for i, existingRestore := range commitLogRestores {
+		if existingRestore.StorageLocation == restoreReq.StorageLocation && existingRestore.TimestampEnd == restoreReq.TimestampEnd {
+			return commitLogRestores[i], true
+		}
+	}
+
+	return icarus.CommitLogRestore{}, false
+}
+
+func mergeCommitLogRestores(commitLogRestores []icarus.CommitLogRestore) icarus.Restore {
+	merged := icarus.Restore{State: icarus.StateCompleted}
+	for _, commitLogRestore := range commitLogRestores {
+		merged.Progress += commitLogRestore.Progress / float64(len(commitLogRestores))
+		merged.Errors = append(merged.Errors, commitLogRestore.Errors...)
+		switch {
+		case commitLogRestore.State == icarus.StateFailed || merged.State == icarus.StateFailed:
+			merged.State = icarus.StateFailed
+		case commitLogRestore.State != icarus.StateCompleted:
+			merged.State = commitLogRestore.State
+		}
+	}
+
+	return merged
+}
+
+// restartForCommitLogReplay triggers a rolling restart of the cassandra statefulsets so that the nodes replay the downloaded commitlogs.
+// Returns true until all statefulsets are rolled out.
+func (r *CassandraRestoreReconciler) restartForCommitLogReplay(ctx context.Context, cr *v1alpha1.CassandraRestore, cc *v1alpha1.CassandraCluster) (bool, error) {
+	restarting := false
+	for _, dc := range cc.Spec.DCs {
+		sts := &appsv1.StatefulSet{}
+		err := r.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, dc.Name), Namespace: cc.Namespace}, sts)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get statefulset for dc %s", dc.Name)
+		}
+
+		if sts.Spec.Template.Annotations[annotationCommitLogReplay] != string(cr.UID) {
+			patch := client.MergeFrom(sts.DeepCopy())
+			if sts.Spec.Template.Annotations == nil {
+				sts.Spec.Template.Annotations = make(map[string]string)
+			}
+			sts.Spec.Template.Annotations[annotationCommitLogReplay] = string(cr.UID)
+			if err = r.Patch(ctx, sts, patch); err != nil {
+				return false, errors.Wrapf(err, "failed to restart statefulset %s", sts.Name)
+			}
+
+			msg := fmt.Sprintf("Restarting pods of dc %s to replay commitlogs up to %s", dc.Name, cr.Spec.PointInTime.UTC().String())
+			r.Log.Info(msg)
+			r.Events.Normal(cr, events.EventCommitLogReplayStarted, msg)
+			restarting = true
+			continue
+		}
+
+		if !statefulSetRolledOut(sts) {
+			restarting = true
+		}
+	}
+
+	return restarting, nil
+}
+
+func statefulSetRolledOut(sts *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+
+	return sts.Status.ObservedGeneration >= sts.Generation &&
+		sts.Status.UpdatedReplicas == replicas &&
+		sts.Status.ReadyReplicas == replicas
+}
@@ -2,15 +2,21 @@ package cassandrarestore
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/events"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/util"
 	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (r *CassandraRestoreReconciler) reconcileRestore(ctx context.Context, ic icarus.Icarus,
-	cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster) (ctrl.Result, error) {
+func (r *CassandraRestoreReconciler) reconcileRestore(ctx context.Context, engine backupengine.Engine,
+	cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster, coordinator string, readyPods []v1.Pod) (ctrl.Result, error) {
 	snapshotTag := cr.Spec.SnapshotTag
 	if len(snapshotTag) == 0 {
 		if cb == nil {
@@ -20,47 +26,127 @@ func (r *CassandraRestoreReconciler) reconcileRestore(ctx context.Context, ic ic
 		snapshotTag = cb.Name
 	}
 
-	icarusRestores, err := ic.Restores(ctx)
+	if cr.Spec.PointInTime != nil && cr.Status.State != icarus.StateFailed {
+		if err := validatePointInTime(cc, cr); err != nil {
+			errMsg := fmt.Sprintf("Can't restore to point in time: %s", err.Error())
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cr, events.EventPointInTimeRestoreUnsupported, errMsg)
+			return ctrl.Result{}, r.reconcileStatus(ctx, cr, icarus.Restore{
+				State:  icarus.StateFailed,
+				Errors: []icarus.Error{{Source: "cassandra-operator", Message: errMsg}},
+			})
+		}
+	}
+
+	if loaderRestoreRequired(cc, cr) {
+		return r.reconcileLoaderRestore(ctx, cr, cb, cc, snapshotTag)
+	}
+
+	if err := r.reconcileCoordinator(ctx, cr, coordinator); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	icarusRestores, err := r.Poller.Restores(ctx, cc, engine, cr)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	relatedIcarusRestore, relatedIcarusRestoreFound := findRelatedIcarusRestore(icarusRestores, snapshotTag)
+	restoreReqs := createRestoreReqs(cc, cb, cr)
+	var relatedIcarusRestores []icarus.Restore
+	restoreRequestSent := false
+	operationLost := false
+	for _, restoreReq := range restoreReqs {
+		relatedIcarusRestore, relatedIcarusRestoreFound := findRelatedIcarusRestore(icarusRestores, snapshotTag, restoreReq.DC)
+		if len(cr.Status.OperationIDs) != 0 && (!relatedIcarusRestoreFound || !util.Contains(cr.Status.OperationIDs, relatedIcarusRestore.Id)) {
+			// the coordinator has changed or lost the global request after a restart
+			relatedIcarusRestore, relatedIcarusRestoreFound = r.findRestoreOnNodes(ctx, cc, readyPods, cr.Status.OperationIDs, restoreReq.DC)
+			if !relatedIcarusRestoreFound && cr.Status.State != icarus.StateFailed {
+				errMsg := fmt.Sprintf("Restore operation of DC %s of restore %s/%s not found on any node. Sending a new restore request", restoreReq.DC, cr.Namespace, cr.Name)
+				r.Log.Warn(errMsg)
+				r.Events.Warning(cr, events.EventIcarusOperationLost, errMsg)
+				operationLost = true
+			}
+		}
+
+		if cr.Status.State == icarus.StateFailed {
+			if !relatedIcarusRestoreFound {
+				r.Log.Infof("Restore %s/%s has failed and no matching restore request found in icarus. "+
+					"Recreate the CassandraRestore resource to start a new restore attempt", cr.Namespace, cr.Name)
+				continue
+			}
+			if err = r.reconcileFailedRestore(ctx, engine, cc, cr, cb, relatedIcarusRestore, restoreReq); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+
+		if !relatedIcarusRestoreFound { // doesn't exist yet, create it
+			err = engine.Restore(ctx, restoreReq)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			r.Log.Info("Restore request sent")
+			restoreRequestSent = true
+			continue
+		}
+
+		relatedIcarusRestores = append(relatedIcarusRestores, relatedIcarusRestore)
+	}
 
 	if cr.Status.State == icarus.StateFailed {
-		if !relatedIcarusRestoreFound {
-			r.Log.Infof("Restore %s/%s has failed and no matching restore request found in icarus. "+
-				"Recreate the CassandraRestore resource to start a new restore attempt", cr.Namespace, cr.Name)
-			return ctrl.Result{}, nil
+		// a failed restore is retried only on a configuration change, which triggers the reconcile itself
+		r.Poller.ForgetRestore(client.ObjectKeyFromObject(cr))
+		return ctrl.Result{}, nil
+	}
+
+	if operationLost {
+		// the new operations will be found on the coordinator and their IDs persisted
+		cr.Status.OperationIDs = nil
+		if err = r.Status().Update(ctx, cr); err != nil {
+			return ctrl.Result{}, err
 		}
-		return ctrl.Result{}, r.reconcileFailedRestore(ctx, ic, cr, cb, cc, relatedIcarusRestore)
 	}
 
-	if !relatedIcarusRestoreFound { // doesn't exist yet, create it
-		err = ic.Restore(ctx, createRestoreReq(cc, cb, cr))
+	if restoreRequestSent {
+		// the poller triggers the reconcile when the new operations show up
+		r.Poller.Refresh(cc)
+		return ctrl.Result{}, nil
+	}
+
+	if err = r.reconcileOperationIDs(ctx, cr, relatedIcarusRestores); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	restoreState := mergeIcarusRestores(relatedIcarusRestores)
+	if restoreState.State == icarus.StateCompleted && cr.Spec.PointInTime != nil {
+		// the snapshot is restored, replay the commitlogs up to the requested point in time
+		restoreState, err = r.reconcilePointInTime(ctx, cr, cb, cc)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-
-		r.Log.Info("Restore request sent")
-		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
-	err = r.reconcileStatus(ctx, cr, relatedIcarusRestore)
+	err = r.reconcileStatus(ctx, cr, restoreState)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	if cr.Spec.PointInTime != nil {
+		// the commitlog restores are not polled
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	return ctrl.Result{}, nil
 }
 
-func findRelatedIcarusRestore(icarusRestores []icarus.Restore, snapshotTag string) (icarus.Restore, bool) {
+func findRelatedIcarusRestore(icarusRestores []icarus.Restore, snapshotTag, dc string) (icarus.Restore, bool) {
 	for i, existingRestore := range icarusRestores {
 		if !existingRestore.GlobalRequest { //filter out non coordinator requests
 			continue
 		}
 
-		if existingRestore.SnapshotTag != snapshotTag {
+		if existingRestore.SnapshotTag != snapshotTag || existingRestore.DC != dc {
 			continue
 		}
 
@@ -70,17 +156,41 @@ func findRelatedIcarusRestore(icarusRestores []icarus.Restore, snapshotTag strin
 	return icarus.Restore{}, false
 }
 
-func (r *CassandraRestoreReconciler) reconcileFailedRestore(ctx context.Context, ic icarus.Icarus, cr *v1alpha1.CassandraRestore,
-	cb *v1alpha1.CassandraBackup, cc *v1alpha1.CassandraCluster, relatedIcarusRestore icarus.Restore) error {
-	newRestoreRequest := createRestoreReq(cc, cb, cr)
+// mergeIcarusRestores combines the restores of mapped DCs or of the nodes into one to reflect the state of the whole restore
+func mergeIcarusRestores(icarusRestores []icarus.Restore) icarus.Restore {
+	if len(icarusRestores) == 1 {
+		return icarusRestores[0]
+	}
+
+	merged := icarusRestores[0]
+	merged.State = icarus.StateCompleted
+	merged.Progress = 0
+	merged.Errors = nil
+	for _, icarusRestore := range icarusRestores {
+		merged.Progress += icarusRestore.Progress / float64(len(icarusRestores))
+		merged.Errors = append(merged.Errors, icarusRestore.Errors...)
+		switch {
+		case icarusRestore.State == icarus.StateFailed || merged.State == icarus.StateFailed:
+			merged.State = icarus.StateFailed
+		case icarusRestore.State != icarus.StateCompleted:
+			merged.State = icarusRestore.State
+		}
+	}
+
+	return merged
+}
+
+func (r *CassandraRestoreReconciler) reconcileFailedRestore(ctx context.Context, engine backupengine.Engine, cc *v1alpha1.CassandraCluster,
+	cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup, relatedIcarusRestore icarus.Restore, newRestoreRequest icarus.RestoreRequest) error {
 	if r.restoreConfigChanged(relatedIcarusRestore, newRestoreRequest) {
 		r.Log.Info("Detected a configuration change for restore %s/%s, sending a new restore request", cb.Namespace, cb.Name)
-		err := ic.Restore(ctx, newRestoreRequest)
+		err := engine.Restore(ctx, newRestoreRequest)
 		if err != nil {
 			return err
 		}
+		r.Poller.Refresh(cc)
 
-		cr.Status = v1alpha1.CassandraRestoreStatus{} // reset status since we're restarting restore in Icarus
+		cr.Status = v1alpha1.CassandraRestoreStatus{Coordinator: cr.Status.Coordinator} // reset status since we're restarting restore in Icarus
 		err = r.Status().Update(ctx, cr)
 		if err != nil {
 			return err
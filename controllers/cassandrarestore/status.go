@@ -6,9 +6,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/metrics"
 )
 
 func (r *CassandraRestoreReconciler) reconcileStatus(ctx context.Context, cr *v1alpha1.CassandraRestore, relatedIcarusRestore icarus.Restore) error {
+	metrics.ObserveRestore(cr, relatedIcarusRestore)
+
 	restoreStatus := cr.DeepCopy()
 	restoreStatus.Status.Progress = int(relatedIcarusRestore.Progress * 100)
 	if cr.Status.State != relatedIcarusRestore.State {
@@ -35,5 +38,6 @@ func (r *CassandraRestoreReconciler) reconcileStatus(ctx context.Context, cr *v1
 		}
 	}
 
+	metrics.UpdateRestoreMetrics(restoreStatus)
 	return nil
 }
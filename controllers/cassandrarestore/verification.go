@@ -0,0 +1,582 @@
+package cassandrarestore
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+const (
+	ReasonVerifying          = "Verifying"
+	ReasonVerified           = "Verified"
+	ReasonVerificationFailed = "VerificationFailed"
+	// ReasonVerificationSkipped is used if the backup manifests can't be read for the restore
+	ReasonVerificationSkipped = "VerificationSkipped"
+
+	repairCauseRestoreVerification = "restore verification"
+	defaultVerificationConsistency = "LOCAL_QUORUM"
+	defaultVerificationMinDataPct  = 50
+	// how long the verification waits for Reaper to run the repair of the restored keyspaces
+	verificationReaperTimeout = 30 * time.Minute
+)
+
+type tableID struct {
+	dc       string
+	keyspace string
+	table    string
+}
+
+// backupTable is the data of a table in the backup manifests of a DC
+type backupTable struct {
+	sstables  int64
+	sizeBytes int64
+}
+
+// verificationDone returns true if the restored data doesn't need to be verified or the verification has finished
+func verificationDone(cr *v1alpha1.CassandraRestore) bool {
+	if cr.Spec.Verification == nil {
+		return true
+	}
+
+	condition := meta.FindStatusCondition(cr.Status.Conditions, v1alpha1.RestoreConditionVerified)
+	return condition != nil && condition.Status != metav1.ConditionUnknown
+}
+
+// reconcileVerification checks the restored tables against the backup manifests once the restore is completed.
+// Every restored DC has to have live SSTables and partitions for the tables that have SSTables in the backup,
+// the size of their live SSTables has to be at least the configured percentage of their size in the backup
+// and a sample read of those tables has to return data. A repair of the restored keyspaces is run if requested.
+// Only then the restore is marked as verified. The verification is skipped if the backup manifests can't be read.
+func (r *CassandraRestoreReconciler) reconcileVerification(ctx context.Context, cr *v1alpha1.CassandraRestore, cb *v1alpha1.CassandraBackup,
+	cc *v1alpha1.CassandraCluster, storageCredentials *v1.Secret, readyPods []v1.Pod) (ctrl.Result, error) {
+	if meta.FindStatusCondition(cr.Status.Conditions, v1alpha1.RestoreConditionVerified) == nil {
+		meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+			Type:               v1alpha1.RestoreConditionVerified,
+			Status:             metav1.ConditionUnknown,
+			Reason:             ReasonVerifying,
+			Message:            "Verifying the restored tables",
+			ObservedGeneration: cr.Generation,
+		})
+		if err := r.Status().Update(ctx, cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	consistency, err := verificationConsistency(cr)
+	if err != nil {
+		return ctrl.Result{}, r.verificationFailed(ctx, cr, []string{err.Error()})
+	}
+
+	if cc.Spec.BackupEngine == v1alpha1.BackupEngineMedusa {
+		return ctrl.Result{}, r.verificationSkipped(ctx, cr, "Verification of restores made by the medusa backup engine is not supported")
+	}
+
+	storageLocation := cr.Spec.StorageLocation
+	if len(storageLocation) == 0 {
+		storageLocation = cb.Spec.StorageLocation
+	}
+
+	location, err := storage.ParseLocation(storageLocation)
+	if err != nil {
+		return ctrl.Result{}, r.verificationFailed(ctx, cr, []string{err.Error()})
+	}
+
+	storageClient, err := r.StorageClient(location, storageCredentials, cr.Spec.Insecure)
+	if errors.Cause(err) == storage.ErrUnsupportedProvider {
+		msg := fmt.Sprintf("Reading backup manifests from storage provider %s is not supported", location.Provider)
+		return ctrl.Result{}, r.verificationSkipped(ctx, cr, msg)
+	}
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Can't read backup manifests from storage location %s: %s", storageLocation, err.Error())
+		return ctrl.Result{}, r.verificationFailed(ctx, cr, []string{errMsg})
+	}
+
+	backupTables, err := backupTables(ctx, storageClient, location, cc, cb, cr)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if len(backupTables) == 0 {
+		errMsg := fmt.Sprintf("No backup manifests found for snapshot tag %q of cluster %s", restoreSnapshotTag(cb, cr), sourceCluster(cc, cb, cr))
+		return ctrl.Result{}, r.verificationFailed(ctx, cr, []string{errMsg})
+	}
+
+	adminRole, adminPassword, err := r.adminCredentials(ctx, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	nctl := r.NodectlClient(names.JolokiaURL(cc).String(), adminRole, adminPassword, r.Log)
+	verifiedTables, err := r.restoredTableStats(ctx, nctl, cc, readyPods, backupTables)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	minDataPercent := cr.Spec.Verification.MinDataPercent
+	if minDataPercent == 0 {
+		minDataPercent = defaultVerificationMinDataPct
+	}
+
+	var failures []string
+	sampledTables := make(map[string]bool)
+	var sampleReads []tableID
+	for _, verifiedTable := range verifiedTables {
+		if verifiedTable.BackupSSTables == 0 {
+			continue
+		}
+
+		if verifiedTable.LiveSSTables == 0 || verifiedTable.EstimatedPartitions == 0 {
+			failures = append(failures, fmt.Sprintf("Table %s.%s in DC %s has %d live SSTables and %d estimated partitions while the backup has %d SSTables",
+				verifiedTable.Keyspace, verifiedTable.Table, verifiedTable.DC, verifiedTable.LiveSSTables, verifiedTable.EstimatedPartitions, verifiedTable.BackupSSTables))
+		} else if verifiedTable.LiveSizeBytes*100 < verifiedTable.BackupSizeBytes*int64(minDataPercent) {
+			failures = append(failures, fmt.Sprintf("Table %s.%s in DC %s has %d bytes of live SSTables which is less than %d%% of the %d bytes in the backup",
+				verifiedTable.Keyspace, verifiedTable.Table, verifiedTable.DC, verifiedTable.LiveSizeBytes, minDataPercent, verifiedTable.BackupSizeBytes))
+		}
+
+		if !sampledTables[verifiedTable.Keyspace+"."+verifiedTable.Table] {
+			sampledTables[verifiedTable.Keyspace+"."+verifiedTable.Table] = true
+			sampleReads = append(sampleReads, tableID{keyspace: verifiedTable.Keyspace, table: verifiedTable.Table})
+		}
+	}
+
+	cqlClient, err := r.cqlClient(ctx, cc, adminRole, adminPassword)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "can't establish cql connection")
+	}
+	defer cqlClient.CloseSession()
+
+	for _, table := range sampleReads {
+		found, err := cqlClient.ReadSample(table.keyspace, table.table, consistency)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("Sample read of table %s.%s at consistency level %s failed: %s", table.keyspace, table.table, consistency, err.Error()))
+			continue
+		}
+
+		if !found {
+			failures = append(failures, fmt.Sprintf("Sample read of table %s.%s at consistency level %s returned no data", table.keyspace, table.table, consistency))
+		}
+	}
+
+	cr.Status.VerifiedTables = verifiedTables
+	if len(failures) != 0 {
+		return ctrl.Result{}, r.verificationFailed(ctx, cr, failures)
+	}
+
+	if cr.Spec.Verification.Repair {
+		if cc.Spec.RepairEngine == v1alpha1.RepairEngineBuiltin {
+			errMsg := fmt.Sprintf("Repair of the restored keyspaces requires Reaper, but cluster %s uses the %s repair engine", cc.Name, v1alpha1.RepairEngineBuiltin)
+			return ctrl.Result{}, r.verificationFailed(ctx, cr, []string{errMsg})
+		}
+
+		repaired, err := r.repairRestoredKeyspaces(ctx, cc, verifiedTables)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if !repaired {
+			verifying := meta.FindStatusCondition(cr.Status.Conditions, v1alpha1.RestoreConditionVerified)
+			if time.Since(verifying.LastTransitionTime.Time) > verificationReaperTimeout {
+				errMsg := fmt.Sprintf("Reaper is not running for %s. Can't repair the restored keyspaces", verificationReaperTimeout)
+				return ctrl.Result{}, r.verificationFailed(ctx, cr, []string{errMsg})
+			}
+
+			r.Log.Infof("Reaper is not running. Not repairing the restored keyspaces, trying again in %s...", r.Cfg.RetryDelay)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+	}
+
+	msg := fmt.Sprintf("Verified %d restored tables", len(sampleReads))
+	if cr.Spec.Verification.Repair {
+		msg += ". Repair of the restored keyspaces is started"
+	}
+	r.Log.Infof("Restore %s/%s: %s", cr.Namespace, cr.Name, msg)
+	r.Events.Normal(cr, events.EventRestoreVerified, msg)
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.RestoreConditionVerified,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonVerified,
+		Message:            msg,
+		ObservedGeneration: cr.Generation,
+	})
+
+	return ctrl.Result{}, r.Status().Update(ctx, cr)
+}
+
+// verificationSkipped marks the restore as not verified without recording restore errors
+func (r *CassandraRestoreReconciler) verificationSkipped(ctx context.Context, cr *v1alpha1.CassandraRestore, reason string) error {
+	msg := fmt.Sprintf("Restored data verification skipped: %s", reason)
+	r.Log.Warnf("Restore %s/%s: %s", cr.Namespace, cr.Name, msg)
+	r.Events.Warning(cr, events.EventRestoreVerificationSkipped, msg)
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.RestoreConditionVerified,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonVerificationSkipped,
+		Message:            msg,
+		ObservedGeneration: cr.Generation,
+	})
+
+	return r.Status().Update(ctx, cr)
+}
+
+// verificationFailed records the failed checks as restore errors and marks the restore as not verified
+func (r *CassandraRestoreReconciler) verificationFailed(ctx context.Context, cr *v1alpha1.CassandraRestore, failures []string) error {
+	for _, failure := range failures {
+		r.Log.Warnf("Restore %s/%s verification failed: %s", cr.Namespace, cr.Name, failure)
+		cr.Status.Errors = append(cr.Status.Errors, v1alpha1.RestoreError{Source: "cassandra-operator", Message: failure})
+	}
+
+	msg := fmt.Sprintf("Restored data verification failed: %s", strings.Join(failures, ". "))
+	r.Events.Warning(cr, events.EventRestoreVerificationFailed, msg)
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.RestoreConditionVerified,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonVerificationFailed,
+		Message:            msg,
+		ObservedGeneration: cr.Generation,
+	})
+
+	return r.Status().Update(ctx, cr)
+}
+
+// backupTables returns the number of SSTables and the size of every restored table in the backup manifests per DC of the restored cluster.
+// The latest manifest of every backed up node is used.
+func backupTables(ctx context.Context, storageClient storage.StorageClient, location storage.Location,
+	cc *v1alpha1.CassandraCluster, cb *v1alpha1.CassandraBackup, cr *v1alpha1.CassandraRestore) (map[tableID]backupTable, error) {
+	locationPrefix := ""
+	if len(location.Path) != 0 {
+		locationPrefix = location.Path + "/"
+	}
+
+	objects, err := storageClient.ListObjects(ctx, locationPrefix+sourceCluster(cc, cb, cr)+"/")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list objects in bucket %s", location.Bucket)
+	}
+
+	snapshotTag := restoreSnapshotTag(cb, cr)
+	nodeManifests := make(map[string]icarus.ManifestKey)
+	for _, object := range objects {
+		key, ok := icarus.ParseManifestKey(strings.TrimPrefix(object.Key, locationPrefix))
+		if !ok || key.SnapshotTag != snapshotTag {
+			continue
+		}
+
+		if len(cr.Spec.SchemaVersion) != 0 && key.SchemaVersion != cr.Spec.SchemaVersion {
+			continue
+		}
+
+		if _, restored := restoredDC(cc, cr, key.DC); !restored {
+			continue
+		}
+
+		key.Key = object.Key
+		node := key.DC + "/" + key.Node
+		if existingKey, found := nodeManifests[node]; !found || key.Timestamp.After(existingKey.Timestamp) {
+			nodeManifests[node] = key
+		}
+	}
+
+	tables := make(map[tableID]backupTable)
+	for _, key := range nodeManifests {
+		data, err := storageClient.GetObject(ctx, key.Key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get manifest %s", key.Key)
+		}
+
+		m := &icarus.Manifest{}
+		if err = json.Unmarshal(data, m); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse manifest %s", key.Key)
+		}
+
+		dc, _ := restoredDC(cc, cr, key.DC)
+		for keyspaceName, keyspace := range m.Snapshot.Keyspaces {
+			for tableName, table := range keyspace.Tables {
+				restoredKeyspace, restoredTable, restored := restoredTable(cr, keyspaceName, tableName)
+				if !restored {
+					continue
+				}
+
+				id := tableID{dc: dc, keyspace: restoredKeyspace, table: restoredTable}
+				tables[id] = backupTable{
+					sstables:  tables[id].sstables + int64(table.SSTables()),
+					sizeBytes: tables[id].sizeBytes + table.Size(),
+				}
+			}
+		}
+	}
+
+	return tables, nil
+}
+
+// restoredTableStats collects the live SSTables and estimated partitions of the restored tables from the nodes of every restored DC
+func (r *CassandraRestoreReconciler) restoredTableStats(ctx context.Context, nctl nodectl.Nodectl, cc *v1alpha1.CassandraCluster,
+	readyPods []v1.Pod, backupTables map[tableID]backupTable) ([]v1alpha1.VerifiedTable, error) {
+	ips, err := backupengine.NodeIPs(ctx, r.Client, cc, readyPods)
+	if err != nil {
+		return nil, err
+	}
+
+	verifiedTables := make([]v1alpha1.VerifiedTable, 0, len(backupTables))
+	for table, backup := range backupTables {
+		verifiedTable := v1alpha1.VerifiedTable{
+			Keyspace:        table.keyspace,
+			Table:           table.table,
+			DC:              table.dc,
+			BackupSSTables:  backup.sstables,
+			BackupSizeBytes: backup.sizeBytes,
+		}
+
+		for _, pod := range readyPods {
+			if pod.Labels[v1alpha1.CassandraClusterDC] != table.dc {
+				continue
+			}
+
+			stats, err := nctl.TableStats(ctx, ips[pod.Name], table.keyspace, table.table)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get stats of table %s.%s from pod %s", table.keyspace, table.table, pod.Name)
+			}
+
+			verifiedTable.LiveSSTables += stats.LiveSSTables
+			verifiedTable.EstimatedPartitions += stats.EstimatedPartitions
+			verifiedTable.LiveSizeBytes += stats.LiveDiskSpaceUsed
+		}
+
+		verifiedTables = append(verifiedTables, verifiedTable)
+	}
+
+	sort.Slice(verifiedTables, func(i, j int) bool {
+		if verifiedTables[i].DC != verifiedTables[j].DC {
+			return verifiedTables[i].DC < verifiedTables[j].DC
+		}
+		if verifiedTables[i].Keyspace != verifiedTables[j].Keyspace {
+			return verifiedTables[i].Keyspace < verifiedTables[j].Keyspace
+		}
+		return verifiedTables[i].Table < verifiedTables[j].Table
+	})
+
+	return verifiedTables, nil
+}
+
+// repairRestoredKeyspaces starts a repair of the restored keyspaces. Returns false if Reaper is not running yet.
+func (r *CassandraRestoreReconciler) repairRestoredKeyspaces(ctx context.Context, cc *v1alpha1.CassandraCluster, verifiedTables []v1alpha1.VerifiedTable) (bool, error) {
+	var repairThreadCount int32
+	if cc.Spec.Reaper != nil {
+		repairThreadCount = cc.Spec.Reaper.RepairThreadCount
+	}
+
+	reaperClient := r.ReaperClient(names.ReaperServiceURL(cc), cc.Name, repairThreadCount)
+	isRunning, err := reaperClient.IsRunning(ctx)
+	if err != nil || !isRunning {
+		return false, nil
+	}
+
+	var keyspaces []string
+	for _, verifiedTable := range verifiedTables {
+		keyspaces = append(keyspaces, verifiedTable.Keyspace)
+	}
+
+	for _, keyspace := range util.Uniq(keyspaces) {
+		r.Log.Infof("Running repair for restored keyspace %s", keyspace)
+		if err = reaperClient.RunRepair(ctx, keyspace, repairCauseRestoreVerification); err != nil {
+			return false, errors.Wrapf(err, "failed to run repair for keyspace %s", keyspace)
+		}
+	}
+
+	return true, nil
+}
+
+// restoredDC returns the DC of the restored cluster the data of the backed up DC is restored into
+func restoredDC(cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore, sourceDC string) (string, bool) {
+	if len(cr.Spec.TopologyMapping) != 0 {
+		for _, dcMapping := range cr.Spec.TopologyMapping {
+			if dcMapping.Source == sourceDC {
+				return dcMapping.Target, true
+			}
+		}
+		return "", false
+	}
+
+	if len(cr.Spec.DC) != 0 && !util.Contains(splitList(cr.Spec.DC), sourceDC) {
+		return "", false
+	}
+
+	for _, dc := range cc.Spec.DCs {
+		if dc.Name == sourceDC {
+			return dc.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// restoredTable returns the keyspace and table the backed up table is restored into.
+// System keyspaces and the tables not matching the restored entities are not restored.
+func restoredTable(cr *v1alpha1.CassandraRestore, keyspace, table string) (string, string, bool) {
+	if strings.HasPrefix(keyspace, "system") {
+		return "", "", false
+	}
+
+	if len(cr.Spec.Entities) != 0 {
+		entities := splitList(cr.Spec.Entities)
+		if !util.Contains(entities, keyspace) && !util.Contains(entities, keyspace+"."+table) {
+			return "", "", false
+		}
+	}
+
+	if renamed, found := cr.Spec.Rename[keyspace+"."+table]; found {
+		if keyspaceAndTable := strings.SplitN(renamed, ".", 2); len(keyspaceAndTable) == 2 {
+			return keyspaceAndTable[0], keyspaceAndTable[1], true
+		}
+	}
+
+	return keyspace, table, true
+}
+
+func verificationConsistency(cr *v1alpha1.CassandraRestore) (gocql.Consistency, error) {
+	consistency := cr.Spec.Verification.ConsistencyLevel
+	if len(consistency) == 0 {
+		consistency = defaultVerificationConsistency
+	}
+
+	c, err := gocql.ParseConsistencyWrapper(consistency)
+	if err != nil {
+		return gocql.Any, errors.Wrapf(err, "invalid consistency level %q", consistency)
+	}
+
+	return c, nil
+}
+
+func (r *CassandraRestoreReconciler) adminCredentials(ctx context.Context, cc *v1alpha1.CassandraCluster) (string, string, error) {
+	adminSecret := &v1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: names.ActiveAdminSecret(cc.Name), Namespace: cc.Namespace}, adminSecret)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get active admin secret %s", names.ActiveAdminSecret(cc.Name))
+	}
+
+	roleName := string(adminSecret.Data[v1alpha1.CassandraOperatorAdminRole])
+	rolePassword := string(adminSecret.Data[v1alpha1.CassandraOperatorAdminPassword])
+	if len(roleName) == 0 || len(rolePassword) == 0 {
+		return "", "", errors.Errorf("admin role or password is empty in secret %s", names.ActiveAdminSecret(cc.Name))
+	}
+
+	return roleName, rolePassword, nil
+}
+
+// SchemaTables returns the tables of the cluster by keyspace. Used by the restore webhook to validate the rename targets.
+func (r *CassandraRestoreReconciler) SchemaTables(ctx context.Context, cc *v1alpha1.CassandraCluster) (map[string][]string, error) {
+	adminRole, adminPassword, err := r.adminCredentials(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	cqlClient, err := r.cqlClient(ctx, cc, adminRole, adminPassword)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't establish cql connection")
+	}
+	defer cqlClient.CloseSession()
+
+	tablesInfo, err := cqlClient.GetTablesInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get tables info")
+	}
+
+	tables := make(map[string][]string)
+	for _, table := range tablesInfo {
+		tables[table.Keyspace] = append(tables[table.Keyspace], table.Name)
+	}
+
+	return tables, nil
+}
+
+// cqlClient connects to the cluster the same way the cluster controller does.
+// The client TLS certificates are read from the secret as the files written by the cluster controller are temporary.
+func (r *CassandraRestoreReconciler) cqlClient(ctx context.Context, cc *v1alpha1.CassandraCluster, adminRole, adminPassword string) (cql.CqlClient, error) {
+	cassCfg := gocql.NewCluster(fmt.Sprintf("%s.%s.svc.cluster.local", names.DCService(cc.Name, cc.Spec.DCs[0].Name), cc.Namespace))
+	cassCfg.Authenticator = &gocql.PasswordAuthenticator{
+		Username: adminRole,
+		Password: adminPassword,
+	}
+	cassCfg.Timeout = 6 * time.Second
+	cassCfg.ConnectTimeout = 6 * time.Second
+
+	if cc.Spec.Encryption.Client.Enabled {
+		tlsConfig, err := r.clientTLSConfig(ctx, cc)
+		if err != nil {
+			return nil, err
+		}
+
+		cassCfg.SslOpts = &gocql.SslOptions{
+			Config:                 tlsConfig,
+			EnableHostVerification: false,
+		}
+	}
+
+	return r.CqlClient(cassCfg)
+}
+
+func (r *CassandraRestoreReconciler) clientTLSConfig(ctx context.Context, cc *v1alpha1.CassandraCluster) (*tls.Config, error) {
+	nodeTLSSecret := clusterEncryption(cc).Client.NodeTLSSecret
+
+	secret := &v1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: nodeTLSSecret.Name, Namespace: cc.Namespace}, secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get client TLS secret %s", nodeTLSSecret.Name)
+	}
+
+	cert, err := tls.X509KeyPair(secret.Data[nodeTLSSecret.CrtFileKey], secret.Data[nodeTLSSecret.FileKey])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid client TLS certificate in secret %s", nodeTLSSecret.Name)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(secret.Data[nodeTLSSecret.CACrtFileKey]) {
+		return nil, errors.Errorf("invalid CA certificate in client TLS secret %s", nodeTLSSecret.Name)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+	}, nil
+}
+
+func restoreSnapshotTag(cb *v1alpha1.CassandraBackup, cr *v1alpha1.CassandraRestore) string {
+	if len(cr.Spec.SnapshotTag) != 0 {
+		return cr.Spec.SnapshotTag
+	}
+
+	return cb.Name
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); len(item) != 0 {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,215 @@
+package cassandrasnapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/config"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// snapshotFinalizer makes sure the snapshot is cleared from the nodes before the CassandraSnapshot is removed
+	snapshotFinalizer = "db.ibm.com/clear-snapshot"
+
+	diskUsageRefreshInterval       = 5 * time.Minute
+	defaultDiskUsageWarningPercent = 50
+
+	ReasonDiskUsageNormal = "DiskUsageNormal"
+	ReasonDiskUsageHigh   = "DiskUsageHigh"
+	ReasonUnknown         = "Unknown"
+)
+
+// CassandraSnapshotReconciler reconciles a CassandraSnapshot object
+type CassandraSnapshotReconciler struct {
+	client.Client
+	Log           *zap.SugaredLogger
+	Scheme        *runtime.Scheme
+	Cfg           config.Config
+	Events        *events.EventRecorder
+	NodectlClient func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl
+}
+
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrasnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrasnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=db.ibm.com,resources=cassandrasnapshots/finalizers,verbs=update
+
+func (r *CassandraSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cs := &v1alpha1.CassandraSnapshot{}
+	err := r.Get(ctx, req.NamespacedName, cs)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	res, err := r.reconcileSnapshot(ctx, cs)
+	if err != nil {
+		if statusErr, ok := errors.Cause(err).(*kerrors.StatusError); ok && statusErr.ErrStatus.Reason == metav1.StatusReasonConflict {
+			r.Log.Info("Conflict occurred. Retrying...", zap.Error(err))
+			return ctrl.Result{Requeue: true}, nil //retry but do not treat conflicts as errors
+		}
+
+		r.Log.Errorf("%+v", err)
+		return ctrl.Result{}, err
+	}
+
+	return res, nil
+}
+
+func (r *CassandraSnapshotReconciler) reconcileSnapshot(ctx context.Context, cs *v1alpha1.CassandraSnapshot) (ctrl.Result, error) {
+	cc := &v1alpha1.CassandraCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: cs.Spec.CassandraCluster, Namespace: cs.Namespace}, cc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			if cs.DeletionTimestamp != nil {
+				// the snapshots are gone together with the cluster's data
+				return ctrl.Result{}, r.removeFinalizer(ctx, cs)
+			}
+			errMsg := fmt.Sprintf("Failed to take snapshot of cluster %q. Cluster not found.", cs.Spec.CassandraCluster)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cs, events.EventCassandraClusterNotFound, errMsg)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if cs.DeletionTimestamp != nil {
+		if !controllerutil.ContainsFinalizer(cs, snapshotFinalizer) {
+			return ctrl.Result{}, nil
+		}
+
+		if cs.Status.State != v1alpha1.SnapshotStateCleared {
+			nctl, err := r.nodectl(ctx, cc)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			cleared, err := r.clearSnapshot(ctx, nctl, cs, cc)
+			if err != nil || !cleared {
+				return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, err
+			}
+		}
+
+		return ctrl.Result{}, r.removeFinalizer(ctx, cs)
+	}
+
+	if !controllerutil.ContainsFinalizer(cs, snapshotFinalizer) {
+		controllerutil.AddFinalizer(cs, snapshotFinalizer)
+		if err = r.Update(ctx, cs); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to add finalizer")
+		}
+	}
+
+	if cs.Status.State == v1alpha1.SnapshotStateCleared {
+		r.Log.Debugf("Snapshot %s/%s is cleared", cs.Namespace, cs.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if cs.Status.State == v1alpha1.SnapshotStateFailed {
+		r.Log.Debugf("Snapshot %s/%s is failed", cs.Namespace, cs.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if cs.Status.State != v1alpha1.SnapshotStateCompleted && snapshotTimedOut(cs, time.Now()) {
+		return ctrl.Result{}, r.failSnapshot(ctx, cs, cc)
+	}
+
+	// safety snapshots are taken in the middle of the cluster reconciliation, so they only wait for the pods to be ready
+	if len(cs.Status.State) == 0 && !isSafetySnapshot(cs, cc) && !cc.Status.Ready {
+		r.Log.Warnf("CassandraCluster %s/%s is not ready. Not taking snapshot, trying again in %s...", cc.Namespace, cc.Name, r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+	}
+
+	nctl, err := r.nodectl(ctx, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if cs.Status.State != v1alpha1.SnapshotStateCompleted {
+		completed, err := r.takeSnapshot(ctx, nctl, cs, cc)
+		if err != nil || !completed {
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, err
+		}
+	}
+
+	if cs.Status.ExpirationTime != nil && !time.Now().Before(cs.Status.ExpirationTime.Time) {
+		cleared, err := r.clearSnapshot(ctx, nctl, cs, cc)
+		if err != nil || !cleared {
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, err
+		}
+		// show the disk space freed by clearing the snapshot
+		return ctrl.Result{}, r.reconcileDiskUsage(ctx, nctl, cs, cc)
+	}
+
+	err = r.reconcileDiskUsage(ctx, nctl, cs, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	requeueAfter := diskUsageRefreshInterval
+	if cs.Status.ExpirationTime != nil {
+		if untilExpiration := time.Until(cs.Status.ExpirationTime.Time); untilExpiration < requeueAfter {
+			requeueAfter = untilExpiration
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *CassandraSnapshotReconciler) failSnapshot(ctx context.Context, cs *v1alpha1.CassandraSnapshot, cc *v1alpha1.CassandraCluster) error {
+	errMsg := fmt.Sprintf("Snapshot %q of cluster %q wasn't taken on all nodes within %d seconds", snapshotTag(cs), cc.Name, cs.Spec.TimeoutSeconds)
+	r.Log.Warn(errMsg)
+	r.Events.Warning(cs, events.EventSnapshotFailed, errMsg)
+	cs.Status.State = v1alpha1.SnapshotStateFailed
+	return r.Status().Update(ctx, cs)
+}
+
+// snapshotTimedOut returns true if the snapshot has a timeout and it's not taken within it
+func snapshotTimedOut(cs *v1alpha1.CassandraSnapshot, now time.Time) bool {
+	if cs.Spec.TimeoutSeconds == 0 {
+		return false
+	}
+
+	return now.After(cs.CreationTimestamp.Add(time.Duration(cs.Spec.TimeoutSeconds) * time.Second))
+}
+
+// isSafetySnapshot returns true if the snapshot is taken by the operator before a risky operation on the cluster
+func isSafetySnapshot(cs *v1alpha1.CassandraSnapshot, cc *v1alpha1.CassandraCluster) bool {
+	return metav1.IsControlledBy(cs, cc)
+}
+
+func (r *CassandraSnapshotReconciler) removeFinalizer(ctx context.Context, cs *v1alpha1.CassandraSnapshot) error {
+	if !controllerutil.ContainsFinalizer(cs, snapshotFinalizer) {
+		return nil
+	}
+
+	controllerutil.RemoveFinalizer(cs, snapshotFinalizer)
+	return errors.Wrap(r.Update(ctx, cs), "failed to remove finalizer")
+}
+
+func SetupCassandraSnapshotReconciler(r reconcile.Reconciler, mgr manager.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		Named("cassandrasnapshot").
+		For(&v1alpha1.CassandraSnapshot{})
+
+	return builder.Complete(r)
+}
@@ -0,0 +1,33 @@
+package cassandrasnapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSnapshotTimedOut(t *testing.T) {
+	g := NewWithT(t)
+	created := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	cs := &v1alpha1.CassandraSnapshot{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}}
+
+	g.Expect(snapshotTimedOut(cs, created.Add(24*time.Hour))).To(BeFalse(), "snapshots without a timeout never time out")
+
+	cs.Spec.TimeoutSeconds = 600
+	g.Expect(snapshotTimedOut(cs, created.Add(5*time.Minute))).To(BeFalse())
+	g.Expect(snapshotTimedOut(cs, created.Add(11*time.Minute))).To(BeTrue())
+}
+
+func TestIsSafetySnapshot(t *testing.T) {
+	g := NewWithT(t)
+	cc := &v1alpha1.CassandraCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", UID: "cluster-uid"}}
+	cs := &v1alpha1.CassandraSnapshot{}
+	g.Expect(isSafetySnapshot(cs, cc)).To(BeFalse())
+
+	cs.OwnerReferences = []metav1.OwnerReference{{Name: cc.Name, UID: cc.UID, Controller: proto.Bool(true)}}
+	g.Expect(isSafetySnapshot(cs, cc)).To(BeTrue())
+}
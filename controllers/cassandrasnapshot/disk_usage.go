@@ -0,0 +1,119 @@
+package cassandrasnapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+)
+
+// reconcileDiskUsage refreshes the disk space used by snapshots on each node and warns if it
+// exceeds .spec.diskUsageWarningPercent of the data volume
+func (r *CassandraSnapshotReconciler) reconcileDiskUsage(ctx context.Context, nctl nodectl.Nodectl, cs *v1alpha1.CassandraSnapshot, cc *v1alpha1.CassandraCluster) error {
+	pods, err := cassandraPods(ctx, r.Client, cc)
+	if err != nil {
+		return err
+	}
+
+	ips, err := backupengine.NodeIPs(ctx, r.Client, cc, pods)
+	if err != nil {
+		return err
+	}
+
+	oldStatus := cs.Status.DeepCopy()
+	capacity, capacityKnown := dataVolumeCapacity(cc)
+	warningPercent := diskUsageWarningPercent(cs)
+	var podsUnderPressure []string
+	for i, node := range cs.Status.Nodes {
+		ip, found := ips[node.Pod]
+		if !found {
+			continue
+		}
+
+		size, err := nctl.SnapshotsSize(ctx, ip)
+		if err != nil {
+			r.Log.Warnf("Failed to get snapshots size on pod %s: %s", node.Pod, err.Error())
+			continue
+		}
+
+		cs.Status.Nodes[i].SnapshotsSizeBytes = size
+		if !capacityKnown {
+			continue
+		}
+
+		usagePercent := diskUsagePercent(size, capacity)
+		cs.Status.Nodes[i].DataVolumeUsagePercent = &usagePercent
+		if usagePercent >= warningPercent {
+			podsUnderPressure = append(podsUnderPressure, node.Pod)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               v1alpha1.SnapshotConditionDiskPressure,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonDiskUsageNormal,
+		Message:            fmt.Sprintf("Snapshots take less than %d%% of the data volumes", warningPercent),
+		ObservedGeneration: cs.Generation,
+	}
+
+	if !capacityKnown {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = ReasonUnknown
+		condition.Message = "Persistence is disabled, the size of the data volumes is unknown"
+	} else if len(podsUnderPressure) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonDiskUsageHigh
+		condition.Message = fmt.Sprintf("Snapshots take %d%% or more of the data volumes of pods: %s", warningPercent, strings.Join(podsUnderPressure, ", "))
+		r.Log.Warn(condition.Message)
+		if !meta.IsStatusConditionTrue(cs.Status.Conditions, condition.Type) {
+			r.Events.Warning(cs, events.EventSnapshotDiskPressure, condition.Message)
+		}
+	}
+
+	meta.SetStatusCondition(&cs.Status.Conditions, condition)
+	if equality.Semantic.DeepEqual(oldStatus, &cs.Status) {
+		return nil
+	}
+
+	return r.Status().Update(ctx, cs)
+}
+
+// dataVolumeCapacity returns the requested size of the data volume in bytes. The size is unknown if persistence is disabled.
+func dataVolumeCapacity(cc *v1alpha1.CassandraCluster) (int64, bool) {
+	if cc.Spec.Cassandra == nil || !cc.Spec.Cassandra.Persistence.Enabled {
+		return 0, false
+	}
+
+	storage, found := cc.Spec.Cassandra.Persistence.DataVolumeClaimSpec.Resources.Requests[v1.ResourceStorage]
+	if !found || storage.Value() <= 0 {
+		return 0, false
+	}
+
+	return storage.Value(), true
+}
+
+func diskUsagePercent(size, capacity int64) int32 {
+	percent := size * 100 / capacity
+	if percent > 100 {
+		return 100
+	}
+
+	return int32(percent)
+}
+
+func diskUsageWarningPercent(cs *v1alpha1.CassandraSnapshot) int32 {
+	if cs.Spec.DiskUsageWarningPercent == 0 {
+		return defaultDiskUsageWarningPercent
+	}
+
+	return cs.Spec.DiskUsageWarningPercent
+}
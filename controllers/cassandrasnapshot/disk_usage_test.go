@@ -0,0 +1,52 @@
+package cassandrasnapshot
+
+import (
+	"testing"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestDataVolumeCapacity(t *testing.T) {
+	g := NewWithT(t)
+
+	cc := &v1alpha1.CassandraCluster{}
+	_, known := dataVolumeCapacity(cc)
+	g.Expect(known).To(BeFalse())
+
+	cc.Spec.Cassandra = &v1alpha1.Cassandra{}
+	_, known = dataVolumeCapacity(cc)
+	g.Expect(known).To(BeFalse())
+
+	cc.Spec.Cassandra.Persistence.Enabled = true
+	_, known = dataVolumeCapacity(cc)
+	g.Expect(known).To(BeFalse())
+
+	cc.Spec.Cassandra.Persistence.DataVolumeClaimSpec.Resources.Requests = v1.ResourceList{
+		v1.ResourceStorage: resource.MustParse("10Gi"),
+	}
+	capacity, known := dataVolumeCapacity(cc)
+	g.Expect(known).To(BeTrue())
+	g.Expect(capacity).To(BeEquivalentTo(10 * 1024 * 1024 * 1024))
+}
+
+func TestDiskUsagePercent(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(diskUsagePercent(0, 1000)).To(BeEquivalentTo(0))
+	g.Expect(diskUsagePercent(499, 1000)).To(BeEquivalentTo(49))
+	g.Expect(diskUsagePercent(500, 1000)).To(BeEquivalentTo(50))
+	g.Expect(diskUsagePercent(2000, 1000)).To(BeEquivalentTo(100))
+}
+
+func TestDiskUsageWarningPercent(t *testing.T) {
+	g := NewWithT(t)
+
+	cs := &v1alpha1.CassandraSnapshot{}
+	g.Expect(diskUsageWarningPercent(cs)).To(BeEquivalentTo(defaultDiskUsageWarningPercent))
+
+	cs.Spec.DiskUsageWarningPercent = 80
+	g.Expect(diskUsageWarningPercent(cs)).To(BeEquivalentTo(80))
+}
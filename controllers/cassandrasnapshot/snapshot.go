@@ -0,0 +1,200 @@
+package cassandrasnapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
+)
+
+// takeSnapshot takes the snapshot on the nodes it's not taken yet. The list of nodes is fixed when the snapshot is started,
+// so that pods added later don't make the snapshot inconsistent. Returns true if the snapshot is taken on all nodes.
+func (r *CassandraSnapshotReconciler) takeSnapshot(ctx context.Context, nctl nodectl.Nodectl, cs *v1alpha1.CassandraSnapshot, cc *v1alpha1.CassandraCluster) (bool, error) {
+	pods, err := cassandraPods(ctx, r.Client, cc)
+	if err != nil {
+		return false, err
+	}
+
+	if len(cs.Status.Nodes) == 0 {
+		// the cluster readiness is not used for safety snapshots as the operator takes them in the middle of the cluster reconciliation
+		if len(pods) == 0 || (isSafetySnapshot(cs, cc) && !podsReady(pods)) {
+			r.Log.Warnf("Not all Cassandra pods of cluster %s/%s are ready. Not taking snapshot, trying again in %s...", cc.Namespace, cc.Name, r.Cfg.RetryDelay)
+			return false, nil
+		}
+
+		for _, pod := range pods {
+			cs.Status.Nodes = append(cs.Status.Nodes, v1alpha1.SnapshotNode{
+				Pod: pod.Name,
+				DC:  pod.Labels[v1alpha1.CassandraClusterDC],
+			})
+		}
+		cs.Status.State = v1alpha1.SnapshotStateRunning
+	}
+
+	ips, err := backupengine.NodeIPs(ctx, r.Client, cc, pods)
+	if err != nil {
+		return false, err
+	}
+
+	tag := snapshotTag(cs)
+	var snapshotErr error
+	for i, node := range cs.Status.Nodes {
+		if node.SnapshotTime != nil {
+			continue
+		}
+
+		ip, found := ips[node.Pod]
+		if !found {
+			snapshotErr = errors.Errorf("pod %s not found", node.Pod)
+			break
+		}
+
+		r.Log.Debugf("Taking snapshot %q on pod %s", tag, node.Pod)
+		err = nctl.TakeSnapshot(ctx, ip, tag, cs.Spec.Keyspaces)
+		// the snapshot may be taken already if the status update failed after it was taken
+		if err != nil && !strings.Contains(err.Error(), "already exists") {
+			snapshotErr = errors.Wrapf(err, "failed to take snapshot on pod %s", node.Pod)
+			break
+		}
+
+		now := metav1.Now()
+		cs.Status.Nodes[i].SnapshotTime = &now
+	}
+
+	if snapshotErr != nil {
+		errMsg := fmt.Sprintf("Failed to take snapshot %q of cluster %q: %s. Trying again in %s", tag, cc.Name, snapshotErr.Error(), r.Cfg.RetryDelay)
+		r.Log.Warn(errMsg)
+		r.Events.Warning(cs, events.EventSnapshotFailed, errMsg)
+		return false, r.Status().Update(ctx, cs)
+	}
+
+	now := metav1.Now()
+	cs.Status.State = v1alpha1.SnapshotStateCompleted
+	cs.Status.SnapshotTime = &now
+	if cs.Spec.RetentionSeconds > 0 {
+		expirationTime := metav1.NewTime(now.Add(time.Duration(cs.Spec.RetentionSeconds) * time.Second))
+		cs.Status.ExpirationTime = &expirationTime
+	}
+
+	msg := fmt.Sprintf("Snapshot %q is taken on %d nodes", tag, len(cs.Status.Nodes))
+	r.Log.Info(msg)
+	r.Events.Normal(cs, events.EventSnapshotTaken, msg)
+	return true, r.Status().Update(ctx, cs)
+}
+
+// clearSnapshot removes the snapshot from the nodes it was taken on. Returns true if the snapshot is cleared on all nodes.
+func (r *CassandraSnapshotReconciler) clearSnapshot(ctx context.Context, nctl nodectl.Nodectl, cs *v1alpha1.CassandraSnapshot, cc *v1alpha1.CassandraCluster) (bool, error) {
+	pods, err := cassandraPods(ctx, r.Client, cc)
+	if err != nil {
+		return false, err
+	}
+
+	ips, err := backupengine.NodeIPs(ctx, r.Client, cc, pods)
+	if err != nil {
+		return false, err
+	}
+
+	tag := snapshotTag(cs)
+	for _, node := range cs.Status.Nodes {
+		if node.SnapshotTime == nil {
+			continue
+		}
+
+		ip, found := ips[node.Pod]
+		if !found {
+			r.Log.Warnf("Pod %s not found, skipping clearing snapshot %q on it", node.Pod, tag)
+			continue
+		}
+
+		r.Log.Debugf("Clearing snapshot %q on pod %s", tag, node.Pod)
+		err = nctl.ClearSnapshot(ctx, ip, tag, cs.Spec.Keyspaces)
+		if err != nil {
+			errMsg := fmt.Sprintf("Failed to clear snapshot %q on pod %s: %s. Trying again in %s", tag, node.Pod, err.Error(), r.Cfg.RetryDelay)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cs, events.EventSnapshotClearFailed, errMsg)
+			return false, nil
+		}
+	}
+
+	cs.Status.State = v1alpha1.SnapshotStateCleared
+	msg := fmt.Sprintf("Snapshot %q is cleared", tag)
+	r.Log.Info(msg)
+	r.Events.Normal(cs, events.EventSnapshotCleared, msg)
+	return true, r.Status().Update(ctx, cs)
+}
+
+func (r *CassandraSnapshotReconciler) nodectl(ctx context.Context, cc *v1alpha1.CassandraCluster) (nodectl.Nodectl, error) {
+	adminSecret := &v1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: names.ActiveAdminSecret(cc.Name), Namespace: cc.Namespace}, adminSecret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get active admin secret %s", names.ActiveAdminSecret(cc.Name))
+	}
+
+	roleName := string(adminSecret.Data[v1alpha1.CassandraOperatorAdminRole])
+	rolePassword := string(adminSecret.Data[v1alpha1.CassandraOperatorAdminPassword])
+	if len(roleName) == 0 || len(rolePassword) == 0 {
+		return nil, errors.Errorf("admin role or password is empty in secret %s", names.ActiveAdminSecret(cc.Name))
+	}
+
+	return r.NodectlClient(names.JolokiaURL(cc).String(), roleName, rolePassword, r.Log), nil
+}
+
+// cassandraPods returns the Cassandra pods of the cluster sorted by name
+func cassandraPods(ctx context.Context, c client.Client, cc *v1alpha1.CassandraCluster) ([]v1.Pod, error) {
+	podList := &v1.PodList{}
+	err := c.List(ctx, podList, client.InNamespace(cc.Namespace), client.MatchingLabels(labels.Cassandra(cc)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list Cassandra pods")
+	}
+
+	var pods []v1.Pod
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil || len(pod.Labels[v1alpha1.CassandraClusterDC]) == 0 {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	return pods, nil
+}
+
+func podsReady(pods []v1.Pod) bool {
+	for _, pod := range pods {
+		if len(pod.Status.ContainerStatuses) == 0 {
+			return false
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if !containerStatus.Ready {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func snapshotTag(cs *v1alpha1.CassandraSnapshot) string {
+	if len(cs.Spec.SnapshotTag) == 0 {
+		return cs.Name
+	}
+
+	return cs.Spec.SnapshotTag
+}
@@ -18,19 +18,22 @@ var (
 
 // Config contains the Cassandra Operator configs
 type Config struct {
-	Namespace             string        `env:"NAMESPACE" envDefault:"default"`
-	LeaderElectionEnabled bool          `env:"LEADERELECTION_ENABLED" envDefault:"true"`
-	LogLevel              zapcore.Level `env:"LOGLEVEL" envDefault:"info"`
-	LogFormat             string        `env:"LOGFORMAT" envDefault:"json"`
-	WebhooksEnabled       bool          `env:"WEBHOOKS_ENABLED" envDefault:"true"`
-	WebhooksPort          int32         `env:"WEBHOOKS_PORT" envDefault:"9443"`
-	MetricsPort           int32         `env:"METRICS_PORT" envDefault:"8329"`
-	RetryDelay            time.Duration `env:"RETRY_DELAY" envDefault:"10s"`
-	DefaultCassandraImage string        `env:"DEFAULT_CASSANDRA_IMAGE,required"`
-	DefaultProberImage    string        `env:"DEFAULT_PROBER_IMAGE,required"`
-	DefaultJolokiaImage   string        `env:"DEFAULT_JOLOKIA_IMAGE,required"`
-	DefaultReaperImage    string        `env:"DEFAULT_REAPER_IMAGE,required"`
-	DefaultIcarusImage    string        `env:"DEFAULT_ICARUS_IMAGE,required"`
+	Namespace                 string        `env:"NAMESPACE" envDefault:"default"`
+	LeaderElectionEnabled     bool          `env:"LEADERELECTION_ENABLED" envDefault:"true"`
+	LogLevel                  zapcore.Level `env:"LOGLEVEL" envDefault:"info"`
+	LogFormat                 string        `env:"LOGFORMAT" envDefault:"json"`
+	WebhooksEnabled           bool          `env:"WEBHOOKS_ENABLED" envDefault:"true"`
+	WebhooksPort              int32         `env:"WEBHOOKS_PORT" envDefault:"9443"`
+	MetricsPort               int32         `env:"METRICS_PORT" envDefault:"8329"`
+	RetryDelay                time.Duration `env:"RETRY_DELAY" envDefault:"10s"`
+	OperationsPollInterval    time.Duration `env:"OPERATIONS_POLL_INTERVAL" envDefault:"10s"`
+	OperationsMaxPollInterval time.Duration `env:"OPERATIONS_MAX_POLL_INTERVAL" envDefault:"2m"`
+	DefaultCassandraImage     string        `env:"DEFAULT_CASSANDRA_IMAGE,required"`
+	DefaultProberImage        string        `env:"DEFAULT_PROBER_IMAGE,required"`
+	DefaultJolokiaImage       string        `env:"DEFAULT_JOLOKIA_IMAGE,required"`
+	DefaultReaperImage        string        `env:"DEFAULT_REAPER_IMAGE,required"`
+	DefaultIcarusImage        string        `env:"DEFAULT_ICARUS_IMAGE,required"`
+	DefaultMedusaImage        string        `env:"DEFAULT_MEDUSA_IMAGE" envDefault:"docker.io/k8ssandra/medusa:0.16.3"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -14,16 +14,20 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"reflect"
 	"time"
 
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/builtinrepair"
 	"github.com/ibm/cassandra-operator/controllers/config"
 	"github.com/ibm/cassandra-operator/controllers/cql"
 	"github.com/ibm/cassandra-operator/controllers/eventhandler"
 	"github.com/ibm/cassandra-operator/controllers/events"
 	"github.com/ibm/cassandra-operator/controllers/jobs"
+	"github.com/ibm/cassandra-operator/controllers/metrics"
 	"github.com/ibm/cassandra-operator/controllers/names"
 	"github.com/ibm/cassandra-operator/controllers/nodectl"
 	"github.com/ibm/cassandra-operator/controllers/prober"
@@ -58,17 +62,20 @@ import (
 const (
 	maintenanceDir               = "/etc/maintenance"
 	cassandraCommitLogDir        = "/var/lib/cassandra-commitlog"
-	cassandraServerTLSDir        = "/etc/cassandra-server-tls"
+	restoreFromBackupDir         = "/var/lib/cassandra/restore"
+	cassandraServerTLSDir        = v1alpha1.CassandraServerTLSDir
 	cassandraServerTLSVolumeName = "server-keystore"
-	cassandraClientTLSDir        = "/etc/cassandra-client-tls"
+	cassandraClientTLSDir        = v1alpha1.CassandraClientTLSDir
 	cassandraClientTLSVolumeName = "client-keystore"
 	defaultCQLConfigMapLabelKey  = "cql-scripts"
 	retryAttempts                = 3
 	initialRetryDelaySeconds     = 5
 
-	repairCauseKeyspacesInit = "keyspaces-init"
-	repairCauseCQLConfigMap  = "cql-configmap"
-	repairCauseReaperInit    = "reaper-init"
+	repairCauseKeyspacesInit     = "keyspaces-init"
+	repairCauseCQLConfigMap      = "cql-configmap"
+	repairCauseReaperInit        = "reaper-init"
+	repairCauseBuiltinRepairInit = "builtin-repair-init"
+	repairCauseIncrementalRepair = "incremental-repair-migration"
 
 	jmxAuthenticationInternal   = "internal"
 	jmxAuthenticationLocalFiles = "local_files"
@@ -77,15 +84,16 @@ const (
 // CassandraClusterReconciler reconciles a CassandraCluster object
 type CassandraClusterReconciler struct {
 	client.Client
-	Log           *zap.SugaredLogger
-	Scheme        *runtime.Scheme
-	Cfg           config.Config
-	Events        *events.EventRecorder
-	Jobs          *jobs.JobManager
-	ProberClient  func(url *url.URL, user, password string) prober.ProberClient
-	CqlClient     func(cluster *gocql.ClusterConfig) (cql.CqlClient, error)
-	ReaperClient  func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient
-	NodectlClient func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl
+	Log            *zap.SugaredLogger
+	Scheme         *runtime.Scheme
+	Cfg            config.Config
+	Events         *events.EventRecorder
+	Jobs           *jobs.JobManager
+	ProberClient   func(url *url.URL, user, password string) prober.ProberClient
+	CqlClient      func(cluster *gocql.ClusterConfig) (cql.CqlClient, error)
+	ReaperClient   func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient
+	NodectlClient  func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl
+	BuiltinRepairs *builtinrepair.SegmentWatcher
 }
 
 // +kubebuilder:rbac:groups=db.ibm.com,resources=cassandraclusters,verbs=get;list;watch;create;update;patch;delete
@@ -98,7 +106,7 @@ type CassandraClusterReconciler struct {
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch;create;update;deletecollection;
 // +kubebuilder:rbac:groups="",resources=pods/status,verbs=get;patch;update
 // +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
-// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=list;get;watch;create;update;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=list;get;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services;serviceaccounts,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=list;watch
@@ -118,6 +126,15 @@ func (r *CassandraClusterReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return ctrl.Result{Requeue: true}, nil //retry but do not treat conflicts as errors
 		}
 
+		if errors.Cause(err) == errSafetySnapshotInProgress {
+			r.Log.Infof("Waiting for the safety snapshot to be taken. Trying again in %s...", r.Cfg.RetryDelay)
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+
+		if errors.Cause(err) == errSafetySnapshotFailed {
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		}
+
 		r.Log.Errorf("%+v", err)
 		return ctrl.Result{}, err
 	}
@@ -130,6 +147,8 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 	err := r.Get(ctx, types.NamespacedName{Name: req.Name, Namespace: req.Namespace}, cc)
 	if err != nil {
 		if apierrors.IsNotFound(err) { //do not react to CRD delete events
+			metrics.ForgetRepairCoverage(req.NamespacedName)
+			r.BuiltinRepairs.Forget(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		r.Log.With(zap.Error(err)).Error("Can't get cassandracluster")
@@ -145,14 +164,34 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 	clusterReady := false
 	ccStatus := cc.DeepCopy()
 	defer func() {
-		if ccStatus.Status.Ready != clusterReady {
+		if ccStatus.Status.Ready != clusterReady || ccStatus.Status.RestoreFromState != cc.Status.RestoreFromState ||
+			!reflect.DeepEqual(ccStatus.Status.RepairSchedules, cc.Status.RepairSchedules) ||
+			!reflect.DeepEqual(ccStatus.Status.RepairsPause, cc.Status.RepairsPause) ||
+			!reflect.DeepEqual(ccStatus.Status.PausedRepairSchedules, cc.Status.PausedRepairSchedules) ||
+			!reflect.DeepEqual(ccStatus.Status.RepairCoverage, cc.Status.RepairCoverage) ||
+			!reflect.DeepEqual(ccStatus.Status.IncrementalRepairMigrations, cc.Status.IncrementalRepairMigrations) ||
+			!reflect.DeepEqual(ccStatus.Status.MedusaRestore, cc.Status.MedusaRestore) {
+			// a patch, as the status may have been persisted during the reconcile
+			patch := client.MergeFrom(ccStatus.DeepCopy())
 			ccStatus.Status.Ready = clusterReady
-			statusErr := r.Status().Update(ctx, ccStatus)
+			ccStatus.Status.RestoreFromState = cc.Status.RestoreFromState
+			ccStatus.Status.RepairSchedules = cc.Status.RepairSchedules
+			ccStatus.Status.RepairsPause = cc.Status.RepairsPause
+			ccStatus.Status.PausedRepairSchedules = cc.Status.PausedRepairSchedules
+			ccStatus.Status.RepairCoverage = cc.Status.RepairCoverage
+			ccStatus.Status.IncrementalRepairMigrations = cc.Status.IncrementalRepairMigrations
+			ccStatus.Status.MedusaRestore = cc.Status.MedusaRestore
+			statusErr := r.Status().Patch(ctx, ccStatus, patch)
 			if statusErr != nil {
 				r.Log.Errorf("Failed to update cluster readiness state: %#v", statusErr)
 			}
 		}
 	}()
+
+	if cc.Spec.RestoreFrom != nil && cc.Status.RestoreFromState == "" {
+		cc.Status.RestoreFromState = v1alpha1.RestoreFromStateRestoring
+	}
+
 	err = r.reconcileCassandraRBAC(ctx, cc)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -206,6 +245,14 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile collectd configmap")
 	}
 
+	if err = r.reconcileMedusaConfigMap(ctx, cc); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile medusa configmap")
+	}
+
+	if err = r.reconcileMedusaRestore(ctx, cc); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile medusa restore")
+	}
+
 	if err = r.reconcileCassandraServiceMonitor(ctx, cc); err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile service monitor")
 	}
@@ -261,6 +308,16 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 		return ctrl.Result{}, errors.Wrap(err, "Error reconciling cassandra pods configmap")
 	}
 
+	// the repairs are paused before the statefulsets are updated or scaled, the disruptive steps wait for the pause
+	repairsPauseReason, err := r.repairsPauseReason(ctx, cc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = r.reconcileRepairsPause(ctx, cc, repairsPauseReason); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile repairs pause")
+	}
+
 	if err = r.reconcileCassandra(ctx, cc, restartChecksum); err != nil {
 		if errors.Cause(err) == errTLSSecretNotFound || errors.Cause(err) == errTLSSecretInvalid {
 			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
@@ -316,6 +373,8 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
 	}
 
+	r.completeRestoreFromBackup(cc)
+
 	cqlClient, err := r.reconcileAdminRole(ctx, cc, auth, allDCs)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile Admin Role")
@@ -327,59 +386,31 @@ func (r *CassandraClusterReconciler) reconcileWithContext(ctx context.Context, r
 		return ctrl.Result{}, err
 	}
 
-	waitForFirstRegionReaper, err := r.waitForFirstRegionReaper(ctx, cc, proberClient)
-	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "Error checking for first region reaper readiness")
-	}
-
-	if waitForFirstRegionReaper {
-		r.Log.Infof("Reaper is not ready in the first region. Trying again in %s...", r.Cfg.RetryDelay)
-		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
-	}
-
-	if err := r.reconcileReaperKeyspace(cc, cqlClient, allDCs); err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "Error reconciling reaper keyspace")
-	}
-
-	if res, err := r.reconcileReaper(ctx, cc, podList, nodeList); needsRequeue(res, err) {
-		return res, err
-	}
+	var repairs repairRunner
+	if builtinRepairEnabled(cc) {
+		if err = r.removeReaper(ctx, cc); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "Failed to remove reaper")
+		}
 
-	reaperClient := r.ReaperClient(reaperServiceURL(cc), cc.Name, cc.Spec.Reaper.RepairThreadCount)
-	isRunning, err := reaperClient.IsRunning(ctx)
-	if err != nil {
-		if updErr := proberClient.UpdateReaperStatus(ctx, false); updErr != nil {
-			return ctrl.Result{}, updErr
+		if err = r.reconcileBuiltinRepairs(ctx, cc, cqlClient, podList, nodeList); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile builtin repairs")
 		}
-		r.Log.Warnf("Reaper ping request failed: %s. Trying again in %s...", err.Error(), r.Cfg.RetryDelay)
-		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
-	}
-	if !isRunning {
-		if err = proberClient.UpdateReaperStatus(ctx, false); err != nil {
-			return ctrl.Result{}, err
+		repairs = &builtinRepairRunner{r: r, cc: cc}
+	} else {
+		r.BuiltinRepairs.Forget(client.ObjectKeyFromObject(cc))
+		res, reaperClient, err := r.reconcileReaperRepairs(ctx, cc, cqlClient, proberClient, podList, nodeList, allDCs)
+		if needsRequeue(res, err) {
+			return res, err
 		}
-		r.Log.Infof("Reaper is not ready. Trying again in %s...", r.Cfg.RetryDelay)
-		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil
+		repairs = reaperClient
 	}
 
-	if err = r.reaperInitialization(ctx, cc, reaperClient); err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "Failed to initialize reaper")
-	}
-
-	if err = proberClient.UpdateReaperStatus(ctx, true); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	if err = r.reconcileRepairSchedules(ctx, cc, reaperClient); err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile repair schedules")
-	}
-
-	err = r.reconcileKeyspaces(ctx, cc, cqlClient, reaperClient, allDCs)
+	err = r.reconcileKeyspaces(ctx, cc, cqlClient, repairs, allDCs)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile keyspaces")
 	}
 
-	if err = r.reconcileCQLConfigMaps(ctx, cc, cqlClient, reaperClient); err != nil {
+	if err = r.reconcileCQLConfigMaps(ctx, cc, cqlClient, repairs); err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "Failed to reconcile CQL configmaps")
 	}
 
@@ -399,6 +430,24 @@ func needsRequeue(result ctrl.Result, err error) bool {
 	return result.Requeue || result.RequeueAfter.Nanoseconds() > 0 || err != nil
 }
 
+// persistStatus patches the status fields that track the changes made by the operator outside of the cluster resource,
+// e.g. the repair schedules paused in Reaper. They are persisted right away, so they are not lost if the status update
+// at the end of the reconcile fails.
+func (r *CassandraClusterReconciler) persistStatus(ctx context.Context, cc *v1alpha1.CassandraCluster) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"repairsPause":                cc.Status.RepairsPause,
+			"pausedRepairSchedules":       cc.Status.PausedRepairSchedules,
+			"incrementalRepairMigrations": cc.Status.IncrementalRepairMigrations,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal status patch")
+	}
+
+	return errors.Wrap(r.Status().Patch(ctx, cc.DeepCopy(), client.RawPatch(types.MergePatchType, patch)), "failed to persist cluster status")
+}
+
 func SetupCassandraReconciler(r reconcile.Reconciler, mgr manager.Manager, logr *zap.SugaredLogger, reconcileChan chan event.GenericEvent) error {
 	builder := ctrl.NewControllerManagedBy(mgr).
 		Named("cassandracluster").
@@ -413,6 +462,11 @@ func SetupCassandraReconciler(r reconcile.Reconciler, mgr manager.Manager, logr
 		Owns(&v1.ServiceAccount{}).
 		Watches(&source.Kind{Type: &v1.Secret{}}, eventhandler.NewAnnotationEventHandler()).
 		Watches(&source.Kind{Type: &v1.ConfigMap{}}, eventhandler.NewAnnotationEventHandler()).
+		// the prepared Medusa restores are applied by the cluster's statefulsets
+		Watches(&source.Kind{Type: &v1alpha1.CassandraRestore{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+			restore := object.(*v1alpha1.CassandraRestore)
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: restore.Spec.CassandraCluster, Namespace: restore.Namespace}}}
+		})).
 		Watches(&source.Channel{Source: reconcileChan}, &handler.EnqueueRequestForObject{})
 
 	// WithEventFilter(predicate.NewPredicate(logr)) // uncomment to see kubernetes events in the logs, e.g. ConfigMap updates
@@ -7,6 +7,7 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/golang/mock/gomock"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/builtinrepair"
 	"github.com/ibm/cassandra-operator/controllers/config"
 	"github.com/ibm/cassandra-operator/controllers/cql"
 	"github.com/ibm/cassandra-operator/controllers/events"
@@ -19,6 +20,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 var baseScheme = setupScheme()
@@ -53,6 +55,7 @@ func createMockedReconciler(t *testing.T) (*CassandraClusterReconciler, *gomock.
 		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
 			return reaperClientMock
 		},
+		BuiltinRepairs: builtinrepair.NewSegmentWatcher(make(chan event.GenericEvent), builtinrepair.SegmentCheckInterval, zap.NewNop().Sugar()),
 	}
 
 	m := mockedClients{prober: proberClientMock, cql: cqlClientMock, reaper: reaperClientMock}
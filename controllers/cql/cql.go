@@ -14,12 +14,14 @@ const (
 
 type CqlClient interface {
 	GetKeyspacesInfo() ([]Keyspace, error)
+	GetTablesInfo() ([]Table, error)
 	UpdateRF(keyspaceName string, strategyOptions map[string]string) error
 	GetRoles() ([]Role, error)
 	CreateRole(role Role) error
 	UpdateRole(role Role) error
 	UpdateRolePassword(roleName, newPassword string) error
 	Query(stmt string, values ...interface{}) error
+	ReadSample(keyspace, table string, consistency gocql.Consistency) (bool, error)
 	DropRole(role Role) error
 	CloseSession()
 }
@@ -50,10 +52,28 @@ type Keyspace struct {
 	Replication map[string]string
 }
 
+type Table struct {
+	Keyspace       string
+	Name           string
+	GCGraceSeconds int32
+}
+
 func (c cassandraClient) Query(stmt string, values ...interface{}) error {
 	return c.Session.Query(stmt, values).Exec()
 }
 
+// ReadSample reads a single row of the table. Returns false if the table is empty.
+func (c cassandraClient) ReadSample(keyspace, table string, consistency gocql.Consistency) (bool, error) {
+	query := fmt.Sprintf("SELECT * FROM \"%s\".\"%s\" LIMIT 1", keyspace, table)
+	iter := c.Session.Query(query).Consistency(consistency).Iter()
+	found := iter.MapScan(make(map[string]interface{}))
+	if err := iter.Close(); err != nil {
+		return false, errors.Wrapf(err, "failed to read from table %s.%s", keyspace, table)
+	}
+
+	return found, nil
+}
+
 func (c cassandraClient) GetKeyspacesInfo() ([]Keyspace, error) {
 	iter := c.Session.Query("SELECT keyspace_name,replication FROM system_schema.keyspaces").Iter()
 	var keyspaceName string
@@ -72,6 +92,20 @@ func (c cassandraClient) GetKeyspacesInfo() ([]Keyspace, error) {
 	return keyspaces, nil
 }
 
+func (c cassandraClient) GetTablesInfo() ([]Table, error) {
+	iter := c.Session.Query("SELECT keyspace_name,table_name,gc_grace_seconds FROM system_schema.tables").Iter()
+	var table Table
+	tables := make([]Table, 0, iter.NumRows())
+	for iter.Scan(&table.Keyspace, &table.Name, &table.GCGraceSeconds) {
+		tables = append(tables, table)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrapf(err, "failed to close iterator")
+	}
+	return tables, nil
+}
+
 func (c cassandraClient) UpdateRF(keyspaceName string, rfOptions map[string]string) error {
 	query := fmt.Sprintf("ALTER KEYSPACE %s %s ;", keyspaceName, ReplicationQuery(rfOptions))
 	return c.Session.Query(query).Exec()
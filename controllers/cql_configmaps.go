@@ -10,7 +10,6 @@ import (
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/cql"
 	"github.com/ibm/cassandra-operator/controllers/events"
-	"github.com/ibm/cassandra-operator/controllers/reaper"
 	"github.com/ibm/cassandra-operator/controllers/util"
 
 	"github.com/pkg/errors"
@@ -23,7 +22,7 @@ const (
 	annotationCQLChecksum    = "cql-checksum"
 )
 
-func (r *CassandraClusterReconciler) reconcileCQLConfigMaps(ctx context.Context, cc *dbv1alpha1.CassandraCluster, cqlClient cql.CqlClient, reaperClient reaper.ReaperClient) error {
+func (r *CassandraClusterReconciler) reconcileCQLConfigMaps(ctx context.Context, cc *dbv1alpha1.CassandraCluster, cqlClient cql.CqlClient, repairs repairRunner) error {
 	cmList := &v1.ConfigMapList{}
 	err := r.List(ctx, cmList, client.HasLabels{cc.Spec.CQLConfigMapLabelKey}, client.InNamespace(cc.Namespace))
 	if err != nil {
@@ -44,7 +43,12 @@ func (r *CassandraClusterReconciler) reconcileCQLConfigMaps(ctx context.Context,
 			continue
 		}
 
-		err := r.executeCQLCMScripts(cc, cm, cqlClient)
+		err := r.ensureSafetySnapshot(ctx, cc, safetySnapshotCQLConfigMap, cm.Name+"/"+checksum, nil)
+		if err != nil {
+			return err
+		}
+
+		err = r.executeCQLCMScripts(cc, cm, cqlClient)
 		if err != nil {
 			return errors.Wrapf(err, "failed to execute CQL scripts from ConfigMap %s/%s", cm.Namespace, cm.Name)
 		}
@@ -52,7 +56,7 @@ func (r *CassandraClusterReconciler) reconcileCQLConfigMaps(ctx context.Context,
 		keyspaceToRepair := cm.Annotations[annotationRepairKeyspace]
 		if len(keyspaceToRepair) > 0 {
 			r.Log.Infof("Starting repair for %q keyspace", keyspaceToRepair)
-			err := reaperClient.RunRepair(ctx, keyspaceToRepair, repairCauseCQLConfigMap)
+			err := repairs.RunRepair(ctx, keyspaceToRepair, repairCauseCQLConfigMap)
 			if err != nil {
 				return errors.Wrapf(err, "failed to run repair on %q keyspace", keyspaceToRepair)
 			}
@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	robfigcron "github.com/robfig/cron/v3"
+)
+
+const day = 24 * time.Hour
+
+// Schedule is a parsed cron expression. The schedule is evaluated in UTC.
+type Schedule struct {
+	schedule robfigcron.Schedule
+}
+
+// Parse parses the cron expression in the standard 5 field format: minute, hour, day of month, month and day of week.
+// Fields support lists, ranges, steps and the month and day of week names. Descriptors such as @weekly are supported as well.
+func Parse(expr string) (*Schedule, error) {
+	schedule, err := robfigcron.ParseStandard(strings.TrimSpace(expr))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cron expression %q", expr)
+	}
+
+	return &Schedule{schedule: schedule}, nil
+}
+
+// Next returns the first activation after the given time. Returns the zero time if the schedule never activates,
+// e.g. on February 30.
+func (s *Schedule) Next(t time.Time) time.Time {
+	return s.schedule.Next(t.UTC())
+}
+
+// DaysBetween returns the number of days between the activations of the schedule. Reaper runs the repair schedules
+// in a fixed number of days, so only the schedules that activate at the same time of the day every N days are supported.
+func DaysBetween(s *Schedule, from time.Time) (int32, error) {
+	prev := s.Next(from)
+	if prev.IsZero() {
+		return 0, errors.New("the schedule never activates")
+	}
+
+	var interval time.Duration
+	// a year covers the repeating patterns of the supported fields
+	for until := prev.Add(366 * day); prev.Before(until); {
+		next := s.Next(prev)
+		if next.IsZero() {
+			return 0, errors.New("the schedule activates only once")
+		}
+
+		gap := next.Sub(prev)
+		if gap < day || gap%day != 0 {
+			return 0, errors.New("the activations must be a whole number of days apart")
+		}
+		if interval != 0 && gap != interval {
+			return 0, errors.Errorf("the activations must be evenly spaced, found %d and %d days between them", interval/day, gap/day)
+		}
+
+		interval = gap
+		prev = next
+	}
+
+	return int32(interval / day), nil
+}
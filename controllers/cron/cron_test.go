@@ -0,0 +1,127 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParse(t *testing.T) {
+	asserts := NewWithT(t)
+
+	for _, expr := range []string{
+		"0 1 * * SAT",
+		"*/15 0-6 * * *",
+		"0 1 1,15 * *",
+		"30 2 * JAN-MAR mon-fri",
+		"@weekly",
+	} {
+		_, err := Parse(expr)
+		asserts.Expect(err).ToNot(HaveOccurred(), expr)
+	}
+
+	for _, expr := range []string{
+		"",
+		"0 1 * *",
+		"0 1 * * * *",
+		"60 1 * * *",
+		"0 24 * * *",
+		"0 1 0 * *",
+		"0 1 * 13 *",
+		"0 1 * * 7",
+		"0 1 * * SUNDAY",
+		"*/0 1 * * *",
+		"5-1 1 * * *",
+	} {
+		_, err := Parse(expr)
+		asserts.Expect(err).To(HaveOccurred(), expr)
+	}
+}
+
+func TestNext(t *testing.T) {
+	asserts := NewWithT(t)
+	from := time.Date(2021, 9, 1, 10, 30, 0, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"0 1 * * SAT", time.Date(2021, 9, 4, 1, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2021, 9, 1, 10, 45, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2021, 9, 2, 10, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1/10 * *", time.Date(2021, 9, 11, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15 * MON", time.Date(2021, 9, 6, 0, 0, 0, 0, time.UTC)}, // day of month or day of week
+		{"0 0 * * SUN", time.Date(2021, 9, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, test := range tests {
+		s, err := Parse(test.expr)
+		asserts.Expect(err).ToNot(HaveOccurred(), test.expr)
+		asserts.Expect(s.Next(from)).To(Equal(test.expected), test.expr)
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	asserts := NewWithT(t)
+	from := time.Date(2021, 9, 1, 10, 30, 0, 0, time.UTC)
+
+	for expr, expected := range map[string]int32{
+		"0 1 * * SAT": 7,
+		"0 4 * * *":   1,
+		"@weekly":     7,
+	} {
+		s, err := Parse(expr)
+		asserts.Expect(err).ToNot(HaveOccurred(), expr)
+		days, err := DaysBetween(s, from)
+		asserts.Expect(err).ToNot(HaveOccurred(), expr)
+		asserts.Expect(days).To(Equal(expected), expr)
+	}
+
+	for _, expr := range []string{
+		"0 * * * *",       // hourly
+		"0 1 * * MON,THU", // not evenly spaced
+		"0 0 1 * *",       // months have different lengths
+		"0 0 30 2 *",      // never
+	} {
+		s, err := Parse(expr)
+		asserts.Expect(err).ToNot(HaveOccurred(), expr)
+		_, err = DaysBetween(s, from)
+		asserts.Expect(err).To(HaveOccurred(), expr)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	asserts := NewWithT(t)
+	monday := time.Date(2021, 9, 6, 0, 0, 0, 0, time.UTC)
+
+	peak, err := ParseWindow([]string{"MON", "TUE", "WED", "THU", "FRI"}, "08:00", "20:00")
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(peak.Contains(monday.Add(7 * time.Hour))).To(BeFalse())
+	asserts.Expect(peak.Contains(monday.Add(8 * time.Hour))).To(BeTrue())
+	asserts.Expect(peak.Contains(monday.Add(19*time.Hour + 59*time.Minute))).To(BeTrue())
+	asserts.Expect(peak.Contains(monday.Add(20 * time.Hour))).To(BeFalse())
+	asserts.Expect(peak.Contains(monday.Add(-12*time.Hour))).To(BeFalse(), "Sunday")
+
+	night, err := ParseWindow([]string{"sat"}, "22:00", "06:00")
+	asserts.Expect(err).ToNot(HaveOccurred())
+	saturday := monday.Add(-2 * 24 * time.Hour)
+	asserts.Expect(night.Contains(saturday.Add(23 * time.Hour))).To(BeTrue())
+	asserts.Expect(night.Contains(saturday.Add(29*time.Hour))).To(BeTrue(), "Sunday morning")
+	asserts.Expect(night.Contains(saturday.Add(30 * time.Hour))).To(BeFalse())
+	asserts.Expect(night.Contains(saturday.Add(5*time.Hour))).To(BeFalse(), "Saturday morning")
+
+	allDay, err := ParseWindow(nil, "00:00", "00:00")
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(allDay.Contains(monday.Add(13 * time.Hour))).To(BeTrue())
+
+	_, err = ParseWindow([]string{"MONDAY"}, "08:00", "20:00")
+	asserts.Expect(err).To(HaveOccurred())
+	_, err = ParseWindow(nil, "8am", "20:00")
+	asserts.Expect(err).To(HaveOccurred())
+	_, err = ParseWindow(nil, "08:00", "24:00")
+	asserts.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var weekdays = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// Window is a time window that repeats on the given days of the week. The window is evaluated in UTC.
+type Window struct {
+	days       uint8 // bitset of the weekdays the window starts on
+	start, end int   // minutes since midnight
+}
+
+// ParseWindow parses a window from the days of the week it starts on and its start and end time in HH:MM format.
+// The window starts every day if no days are given. A window whose end is before its start ends on the next day,
+// a window with the same start and end lasts the whole day.
+func ParseWindow(days []string, start, end string) (Window, error) {
+	w := Window{}
+	if len(days) == 0 {
+		w.days = 1<<7 - 1
+	}
+
+	for _, d := range days {
+		weekday, ok := weekdays[strings.ToUpper(d)]
+		if !ok {
+			return Window{}, errors.Errorf("invalid day %q, must be one of MON, TUE, WED, THU, FRI, SAT, SUN", d)
+		}
+		w.days |= 1 << uint(weekday)
+	}
+
+	var err error
+	if w.start, err = parseTimeOfDay(start); err != nil {
+		return Window{}, errors.Wrap(err, "invalid start")
+	}
+	if w.end, err = parseTimeOfDay(end); err != nil {
+		return Window{}, errors.Wrap(err, "invalid end")
+	}
+
+	return w, nil
+}
+
+func parseTimeOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, errors.Errorf("%q must be in HH:MM format", value)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Contains returns true if the time is within the window
+func (w Window) Contains(t time.Time) bool {
+	t = t.UTC()
+	minute := t.Hour()*60 + t.Minute()
+	today := w.days&(1<<uint(t.Weekday())) != 0
+	yesterday := w.days&(1<<uint((t.Weekday()+6)%7)) != 0
+
+	switch {
+	case w.start == w.end:
+		return today
+	case w.start < w.end:
+		return today && minute >= w.start && minute < w.end
+	case minute >= w.start: // the window ends on the next day
+		return today
+	default:
+		return yesterday && minute < w.end
+	}
+}
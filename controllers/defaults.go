@@ -10,19 +10,6 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
-var (
-	defaultCipherSuites = []string{
-		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
-		"TLS_ECDH_RSA_WITH_AES_128_CBC_SHA",
-		"TLS_ECDH_ECDSA_WITH_AES_256_CBC_SHA",
-		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
-		"TLS_RSA_WITH_AES_128_CBC_SHA",
-		"TLS_RSA_WITH_AES_256_CBC_SHA",
-		"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA",
-		"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
-	}
-)
-
 func (r *CassandraClusterReconciler) defaultCassandraCluster(cc *dbv1alpha1.CassandraCluster) {
 	if cc.Spec.CQLConfigMapLabelKey == "" {
 		cc.Spec.CQLConfigMapLabelKey = defaultCQLConfigMapLabelKey
@@ -31,7 +18,12 @@ func (r *CassandraClusterReconciler) defaultCassandraCluster(cc *dbv1alpha1.Cass
 	r.defaultCassandra(cc)
 	r.defaultProber(cc)
 	r.defaultIcarus(cc)
+	r.defaultMedusa(cc)
 	r.defaultReaper(cc)
+	r.defaultBuiltinRepair(cc)
+	r.defaultRestoreFrom(cc)
+	r.defaultCommitLogArchiving(cc)
+	r.defaultSafetySnapshots(cc)
 
 	if len(cc.Spec.Maintenance) > 0 {
 		for i, entry := range cc.Spec.Maintenance {
@@ -100,6 +92,10 @@ func (r *CassandraClusterReconciler) defaultReaper(cc *dbv1alpha1.CassandraClust
 		cc.Spec.Reaper.RepairThreadCount = 1
 	}
 
+	if cc.Spec.Reaper.GCGraceWarningPercent == 0 {
+		cc.Spec.Reaper.GCGraceWarningPercent = 90
+	}
+
 	if len(cc.Spec.Reaper.RepairSchedules.Repairs) != 0 {
 		for i, repair := range cc.Spec.Reaper.RepairSchedules.Repairs {
 			if repair.RepairParallelism == "" {
@@ -134,7 +130,29 @@ func (r *CassandraClusterReconciler) defaultReaper(cc *dbv1alpha1.CassandraClust
 	}
 }
 
+func (r *CassandraClusterReconciler) defaultBuiltinRepair(cc *dbv1alpha1.CassandraCluster) {
+	if cc.Spec.RepairEngine == "" {
+		cc.Spec.RepairEngine = dbv1alpha1.RepairEngineReaper
+	}
+
+	if cc.Spec.BuiltinRepair.MaxConcurrentSegments == 0 {
+		cc.Spec.BuiltinRepair.MaxConcurrentSegments = 1
+	}
+
+	if cc.Spec.BuiltinRepair.SegmentCountPerNode == 0 {
+		cc.Spec.BuiltinRepair.SegmentCountPerNode = 16
+	}
+
+	if cc.Spec.BuiltinRepair.SegmentTimeoutMins == 0 {
+		cc.Spec.BuiltinRepair.SegmentTimeoutMins = 30
+	}
+}
+
 func (r *CassandraClusterReconciler) defaultProber(cc *dbv1alpha1.CassandraCluster) {
+	if cc.Spec.Prober.Replicas == 0 {
+		cc.Spec.Prober.Replicas = 1
+	}
+
 	if cc.Spec.Prober.Image == "" {
 		cc.Spec.Prober.Image = r.Cfg.DefaultProberImage
 	}
@@ -166,6 +184,52 @@ func (r *CassandraClusterReconciler) defaultProber(cc *dbv1alpha1.CassandraClust
 	}
 }
 
+func (r *CassandraClusterReconciler) defaultRestoreFrom(cc *dbv1alpha1.CassandraCluster) {
+	if cc.Spec.RestoreFrom == nil {
+		return
+	}
+
+	if cc.Spec.RestoreFrom.SourceCluster == "" {
+		cc.Spec.RestoreFrom.SourceCluster = cc.Name
+	}
+
+	if cc.Spec.RestoreFrom.ConcurrentConnections == 0 {
+		cc.Spec.RestoreFrom.ConcurrentConnections = 10
+	}
+}
+
+func (r *CassandraClusterReconciler) defaultCommitLogArchiving(cc *dbv1alpha1.CassandraCluster) {
+	if cc.Spec.Cassandra.CommitLogArchiving == nil {
+		return
+	}
+
+	if cc.Spec.Cassandra.CommitLogArchiving.IntervalSeconds == 0 {
+		cc.Spec.Cassandra.CommitLogArchiving.IntervalSeconds = 60
+	}
+
+	if cc.Spec.Cassandra.CommitLogArchiving.ConcurrentConnections == 0 {
+		cc.Spec.Cassandra.CommitLogArchiving.ConcurrentConnections = 10
+	}
+}
+
+func (r *CassandraClusterReconciler) defaultSafetySnapshots(cc *dbv1alpha1.CassandraCluster) {
+	if cc.Spec.SafetySnapshots == nil {
+		return
+	}
+
+	if cc.Spec.SafetySnapshots.RetentionSeconds == 0 {
+		cc.Spec.SafetySnapshots.RetentionSeconds = 86400
+	}
+
+	if cc.Spec.SafetySnapshots.TimeoutSeconds == 0 {
+		cc.Spec.SafetySnapshots.TimeoutSeconds = 1800
+	}
+
+	if cc.Spec.SafetySnapshots.FailurePolicy == "" {
+		cc.Spec.SafetySnapshots.FailurePolicy = dbv1alpha1.SafetySnapshotFailurePolicyFail
+	}
+}
+
 func (r *CassandraClusterReconciler) defaultIcarus(cc *dbv1alpha1.CassandraCluster) {
 	if cc.Spec.Icarus.Image == "" {
 		cc.Spec.Icarus.Image = r.Cfg.DefaultIcarusImage
@@ -176,6 +240,24 @@ func (r *CassandraClusterReconciler) defaultIcarus(cc *dbv1alpha1.CassandraClust
 	}
 }
 
+func (r *CassandraClusterReconciler) defaultMedusa(cc *dbv1alpha1.CassandraCluster) {
+	if cc.Spec.BackupEngine == "" {
+		cc.Spec.BackupEngine = dbv1alpha1.BackupEngineIcarus
+	}
+
+	if cc.Spec.Medusa == nil {
+		return
+	}
+
+	if cc.Spec.Medusa.Image == "" {
+		cc.Spec.Medusa.Image = r.Cfg.DefaultMedusaImage
+	}
+
+	if cc.Spec.Medusa.ImagePullPolicy == "" {
+		cc.Spec.Medusa.ImagePullPolicy = v1.PullIfNotPresent
+	}
+}
+
 func (r *CassandraClusterReconciler) defaultCassandra(cc *dbv1alpha1.CassandraCluster) {
 	if cc.Spec.Cassandra == nil {
 		cc.Spec.Cassandra = &dbv1alpha1.Cassandra{}
@@ -222,102 +304,12 @@ func (r *CassandraClusterReconciler) defaultCassandra(cc *dbv1alpha1.CassandraCl
 	r.defaultMonitoring(cc)
 }
 
-func (r *CassandraClusterReconciler) defaultCATLSKeys(caTLSSecret *dbv1alpha1.CATLSSecret) {
-	if caTLSSecret.FileKey == "" {
-		caTLSSecret.FileKey = "ca.key"
-	}
-
-	if caTLSSecret.CrtFileKey == "" {
-		caTLSSecret.CrtFileKey = "ca.crt"
-	}
-}
-
-func (r *CassandraClusterReconciler) defaultNodeTLSKeys(nodeTLSSecret *dbv1alpha1.NodeTLSSecret) {
-	if nodeTLSSecret.FileKey == "" {
-		nodeTLSSecret.FileKey = "tls.key"
-	}
-
-	if nodeTLSSecret.CrtFileKey == "" {
-		nodeTLSSecret.CrtFileKey = "tls.crt"
-	}
-
-	if nodeTLSSecret.CACrtFileKey == "" {
-		nodeTLSSecret.CACrtFileKey = "ca.crt"
-	}
-
-	if nodeTLSSecret.KeystoreFileKey == "" {
-		nodeTLSSecret.KeystoreFileKey = "keystore.p12"
-	}
-
-	if nodeTLSSecret.KeystorePasswordKey == "" {
-		nodeTLSSecret.KeystorePasswordKey = "keystore.password"
-	}
-
-	if nodeTLSSecret.TruststoreFileKey == "" {
-		nodeTLSSecret.TruststoreFileKey = "truststore.p12"
-	}
-
-	if nodeTLSSecret.TruststorePasswordKey == "" {
-		nodeTLSSecret.TruststorePasswordKey = "truststore.password"
-	}
-
-	if nodeTLSSecret.GenerateKeystorePassword == "" {
-		nodeTLSSecret.GenerateKeystorePassword = "cassandra"
-	}
-}
-
 func (r *CassandraClusterReconciler) defaultServerTLS(cc *dbv1alpha1.CassandraCluster) {
-	if cc.Spec.Encryption.Server.InternodeEncryption == "" {
-		cc.Spec.Encryption.Server.InternodeEncryption = dbv1alpha1.InternodeEncryptionNone
-	}
-
-	if cc.Spec.Encryption.Server.RequireClientAuth == nil {
-		cc.Spec.Encryption.Server.RequireClientAuth = proto.Bool(true)
-	}
-
-	if cc.Spec.Encryption.Server.Protocol == "" {
-		cc.Spec.Encryption.Server.Protocol = "TLS"
-	}
-
-	if cc.Spec.Encryption.Server.Algorithm == "" {
-		cc.Spec.Encryption.Server.Algorithm = "SunX509"
-	}
-
-	if cc.Spec.Encryption.Server.StoreType == "" {
-		cc.Spec.Encryption.Server.StoreType = "PKCS12"
-	}
-
-	if len(cc.Spec.Encryption.Server.CipherSuites) == 0 {
-		cc.Spec.Encryption.Server.CipherSuites = defaultCipherSuites
-	}
-
-	r.defaultCATLSKeys(&cc.Spec.Encryption.Server.CATLSSecret)
-	r.defaultNodeTLSKeys(&cc.Spec.Encryption.Server.NodeTLSSecret)
+	cc.Spec.Encryption.Server.SetDefaults()
 }
 
 func (r *CassandraClusterReconciler) defaultClientTLS(cc *dbv1alpha1.CassandraCluster) {
-	if cc.Spec.Encryption.Client.RequireClientAuth == nil {
-		cc.Spec.Encryption.Client.RequireClientAuth = proto.Bool(true)
-	}
-
-	if cc.Spec.Encryption.Client.Protocol == "" {
-		cc.Spec.Encryption.Client.Protocol = "TLS"
-	}
-
-	if cc.Spec.Encryption.Client.Algorithm == "" {
-		cc.Spec.Encryption.Client.Algorithm = "SunX509"
-	}
-
-	if cc.Spec.Encryption.Client.StoreType == "" {
-		cc.Spec.Encryption.Client.StoreType = "PKCS12"
-	}
-
-	if len(cc.Spec.Encryption.Client.CipherSuites) == 0 {
-		cc.Spec.Encryption.Client.CipherSuites = defaultCipherSuites
-	}
-
-	r.defaultCATLSKeys(&cc.Spec.Encryption.Client.CATLSSecret)
-	r.defaultNodeTLSKeys(&cc.Spec.Encryption.Client.NodeTLSSecret)
+	cc.Spec.Encryption.Client.SetDefaults()
 }
 
 func (r *CassandraClusterReconciler) defaultMonitoring(cc *dbv1alpha1.CassandraCluster) {
@@ -8,6 +8,7 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/config"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestDefaultingFunction(t *testing.T) {
@@ -19,6 +20,7 @@ func TestDefaultingFunction(t *testing.T) {
 			DefaultJolokiaImage:   "jolokia/image",
 			DefaultCassandraImage: "cassandra/image",
 			DefaultReaperImage:    "reaper/image",
+			DefaultMedusaImage:    "medusa/image",
 		},
 	}
 
@@ -33,6 +35,7 @@ func TestDefaultingFunction(t *testing.T) {
 	g.Expect(cc.Spec.Cassandra.NumSeeds).To(Equal(int32(2)))
 	g.Expect(cc.Spec.Cassandra.PurgeGossip).ToNot(BeNil())
 	g.Expect(*cc.Spec.Cassandra.PurgeGossip).To(Equal(true))
+	g.Expect(cc.Spec.Prober.Replicas).To(Equal(int32(1)))
 	g.Expect(cc.Spec.Prober.Image).To(Equal("prober/image"))
 	g.Expect(cc.Spec.Prober.ImagePullPolicy).To(Equal(v1.PullIfNotPresent))
 	g.Expect(cc.Spec.Prober.Jolokia.Image).To(Equal("jolokia/image"))
@@ -53,6 +56,10 @@ func TestDefaultingFunction(t *testing.T) {
 	g.Expect(cc.Spec.Reaper.ServiceMonitor.ScrapeInterval).To(BeEmpty())
 	g.Expect(cc.Spec.Maintenance).To(BeNil())
 	g.Expect(cc.Status.MaintenanceState).To(BeNil())
+	g.Expect(cc.Spec.RestoreFrom).To(BeNil())
+	g.Expect(cc.Spec.BackupEngine).To(Equal(v1alpha1.BackupEngineIcarus))
+	g.Expect(cc.Spec.Medusa).To(BeNil())
+	g.Expect(cc.Spec.Cassandra.CommitLogArchiving).To(BeNil())
 	g.Expect(cc.Spec.Encryption.Server.InternodeEncryption).To(Equal(v1alpha1.InternodeEncryptionNone))
 	g.Expect(cc.Spec.Encryption.Client.Enabled).To(BeFalse())
 	g.Expect(cc.Spec.Cassandra.Monitoring.Enabled).To(BeFalse())
@@ -77,6 +84,9 @@ func TestDefaultingFunction(t *testing.T) {
 	}))
 
 	cc = &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
 		Spec: v1alpha1.CassandraClusterSpec{
 			DCs: []v1alpha1.DC{
 				{
@@ -97,6 +107,10 @@ func TestDefaultingFunction(t *testing.T) {
 						Namespace: "",
 					},
 				},
+				CommitLogArchiving: &v1alpha1.CommitLogArchiving{
+					StorageLocation: "s3://bucket",
+					SecretName:      "storage-credentials",
+				},
 			},
 			Reaper: &v1alpha1.Reaper{
 				RepairSchedules: v1alpha1.RepairSchedules{
@@ -165,6 +179,16 @@ func TestDefaultingFunction(t *testing.T) {
 				},
 			},
 			TopologySpreadByZone: proto.Bool(false),
+			RestoreFrom: &v1alpha1.RestoreFrom{
+				StorageLocation: "s3://bucket",
+				SnapshotTag:     "snapshot",
+				SecretName:      "storage-credentials",
+			},
+			BackupEngine: v1alpha1.BackupEngineMedusa,
+			Medusa: &v1alpha1.Medusa{
+				StorageLocation: "s3://bucket/medusa",
+				SecretName:      "storage-credentials",
+			},
 		},
 	}
 	reconciler.defaultCassandraCluster(cc)
@@ -199,6 +223,14 @@ func TestDefaultingFunction(t *testing.T) {
 	g.Expect(cc.Spec.Reaper.ServiceMonitor.Enabled).To(BeTrue())
 	g.Expect(cc.Spec.Reaper.ServiceMonitor.Labels).To(BeEmpty())
 	g.Expect(cc.Spec.Reaper.ServiceMonitor.ScrapeInterval).To(BeEquivalentTo("60s"))
+	g.Expect(cc.Spec.Reaper.GCGraceWarningPercent).To(Equal(int32(90)))
+	g.Expect(cc.Spec.RepairEngine).To(Equal(v1alpha1.RepairEngineReaper))
+	g.Expect(cc.Spec.BuiltinRepair).To(Equal(v1alpha1.BuiltinRepair{MaxConcurrentSegments: 1, SegmentCountPerNode: 16, SegmentTimeoutMins: 30}))
+
+	// Medusa
+	g.Expect(cc.Spec.BackupEngine).To(Equal(v1alpha1.BackupEngineMedusa))
+	g.Expect(cc.Spec.Medusa.Image).To(Equal("medusa/image"))
+	g.Expect(cc.Spec.Medusa.ImagePullPolicy).To(Equal(v1.PullIfNotPresent))
 
 	// Maintenance mode
 	g.Expect(cc.Spec.Maintenance[0].DC).To(Equal("dc1"))
@@ -246,4 +278,12 @@ func TestDefaultingFunction(t *testing.T) {
 	g.Expect(cc.Spec.Cassandra.Monitoring.ServiceMonitor.Enabled).To(BeTrue())
 	g.Expect(cc.Spec.Cassandra.Monitoring.ServiceMonitor.Labels).To(BeEmpty())
 	g.Expect(cc.Spec.Cassandra.Monitoring.ServiceMonitor.ScrapeInterval).To(BeEquivalentTo("30s"))
+
+	// Restore from backup
+	g.Expect(cc.Spec.RestoreFrom.SourceCluster).To(Equal("test-cluster"))
+	g.Expect(cc.Spec.RestoreFrom.ConcurrentConnections).To(Equal(int64(10)))
+
+	// Commitlog archiving
+	g.Expect(cc.Spec.Cassandra.CommitLogArchiving.IntervalSeconds).To(Equal(int32(60)))
+	g.Expect(cc.Spec.Cassandra.CommitLogArchiving.ConcurrentConnections).To(Equal(int64(10)))
 }
@@ -25,6 +25,43 @@ const (
 	EventCassandraBackupNotFound          = "CassandraBackupNotFound"
 	EventStorageCredentialsSecretNotFound = "StorageCredentialsSecretNotFound"
 	EventStorageCredentialsSecretInvalid  = "StorageCredentialsSecretInvalid"
+	EventStorageVerificationFailed        = "StorageVerificationFailed"
+	EventRestoreFromBackupCompleted       = "RestoreFromBackupCompleted"
+	EventCommitLogReplayStarted           = "CommitLogReplayStarted"
+	EventPointInTimeRestoreUnsupported    = "PointInTimeRestoreUnsupported"
+	EventRestoreUnsupported               = "RestoreUnsupported"
+	EventIcarusCoordinatorChanged         = "IcarusCoordinatorChanged"
+	EventIcarusCoordinatorUnavailable     = "IcarusCoordinatorUnavailable"
+	EventIcarusOperationLost              = "IcarusOperationLost"
+	EventMedusaNodesNotReady              = "MedusaNodesNotReady"
+	EventMedusaRestorePrepared            = "MedusaRestorePrepared"
+	EventMedusaRestoreStarted             = "MedusaRestoreStarted"
+	EventMedusaRestoreFailed              = "MedusaRestoreFailed"
+	EventBackupCatalogSyncFailed          = "BackupCatalogSyncFailed"
+	EventCassandraBackupCatalogNotFound   = "CassandraBackupCatalogNotFound"
+	EventCatalogBackupNotFound            = "CatalogBackupNotFound"
+	EventSnapshotTaken                    = "SnapshotTaken"
+	EventSnapshotFailed                   = "SnapshotFailed"
+	EventSnapshotCleared                  = "SnapshotCleared"
+	EventSnapshotClearFailed              = "SnapshotClearFailed"
+	EventSnapshotDiskPressure             = "SnapshotDiskPressure"
+	EventSafetySnapshotStarted            = "SafetySnapshotStarted"
+	EventSafetySnapshotFailed             = "SafetySnapshotFailed"
+	EventRestoreVerified                  = "RestoreVerified"
+	EventRestoreVerificationFailed        = "RestoreVerificationFailed"
+	EventRestoreVerificationSkipped       = "RestoreVerificationSkipped"
+	EventRepairRunCompleted               = "RepairRunCompleted"
+	EventRepairRunFailed                  = "RepairRunFailed"
+	EventRepairRunAborted                 = "RepairRunAborted"
+	EventRepairRunLost                    = "RepairRunLost"
+	EventRepairsPaused                    = "RepairsPaused"
+	EventRepairsResumed                   = "RepairsResumed"
+	EventRepairCoverageWarning            = "RepairCoverageWarning"
+	EventRepairEngineUnsupported          = "RepairEngineUnsupported"
+	EventCassandraReaperNotFound          = "CassandraReaperNotFound"
+	EventReaperStorageUnsupported         = "ReaperStorageUnsupported"
+	EventIncrementalRepairMigration       = "IncrementalRepairMigration"
+	EventIncrementalRepairMigrationFailed = "IncrementalRepairMigrationFailed"
 
 	EventAdminRoleChanged = "AdminRoleChanged"
 	EventRegionInit       = "RegionInit"
@@ -51,6 +51,7 @@ type Backup struct {
 	Errors                 []Error   `json:"errors"`
 	Progress               float64   `json:"progress"`
 	StartTime              string    `json:"startTime"`
+	CompletionTime         string    `json:"completionTime"`
 	Type                   string    `json:"type"`
 	StorageLocation        string    `json:"storageLocation"`
 	ConcurrentConnections  int64     `json:"concurrentConnections"`
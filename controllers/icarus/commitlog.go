@@ -0,0 +1,99 @@
+package icarus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+type CommitLogRestoreRequest struct {
+	Type                     string `json:"type"`
+	StorageLocation          string `json:"storageLocation"`
+	CassandraDirectory       string `json:"cassandraDirectory"`
+	CassandraConfigDirectory string `json:"cassandraConfigDirectory"`
+	CommitlogDownloadDir     string `json:"commitlogDownloadDir"`
+	TimestampEnd             int64  `json:"timestampEnd"`
+	K8sNamespace             string `json:"k8sNamespace,omitempty"`
+	K8sSecretName            string `json:"k8sSecretName,omitempty"`
+	ConcurrentConnections    int64  `json:"concurrentConnections,omitempty"`
+	Insecure                 bool   `json:"insecure,omitempty"`
+	SkipBucketVerification   bool   `json:"skipBucketVerification,omitempty"`
+}
+
+type CommitLogRestore struct {
+	Id                       string  `json:"id"`
+	CreationTime             string  `json:"creationTime"`
+	State                    string  `json:"state"`
+	Errors                   []Error `json:"errors"`
+	Progress                 float64 `json:"progress"`
+	StartTime                string  `json:"startTime"`
+	Type                     string  `json:"type"`
+	StorageLocation          string  `json:"storageLocation"`
+	CassandraDirectory       string  `json:"cassandraDirectory"`
+	CassandraConfigDirectory string  `json:"cassandraConfigDirectory"`
+	CommitlogDownloadDir     string  `json:"commitlogDownloadDir"`
+	TimestampEnd             int64   `json:"timestampEnd"`
+	K8sNamespace             string  `json:"k8sNamespace"`
+	K8sSecretName            string  `json:"k8sSecretName"`
+	ConcurrentConnections    int64   `json:"concurrentConnections"`
+	Insecure                 bool    `json:"insecure"`
+	SkipBucketVerification   bool    `json:"skipBucketVerification"`
+}
+
+func (c *client) CommitLogRestore(ctx context.Context, restoreRequest CommitLogRestoreRequest) error {
+	restoreRequest.Type = "commitlog-restore"
+	body, err := json.Marshal(restoreRequest)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.addr+"/operations", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("commitlog restore request failed: code: %d, body: %s", resp.StatusCode, string(b))
+	}
+
+	return nil
+}
+
+func (c *client) CommitLogRestores(ctx context.Context) ([]CommitLogRestore, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.addr+"/operations?type=commitlog-restore", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("commitlog restores request failed: code: %d, body: %s", resp.StatusCode, string(b))
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var restores []CommitLogRestore
+	err = json.Unmarshal(b, &restores)
+	if err != nil {
+		return nil, err
+	}
+
+	return restores, nil
+}
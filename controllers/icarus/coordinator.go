@@ -0,0 +1,33 @@
+package icarus
+
+import (
+	"fmt"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	v1 "k8s.io/api/core/v1"
+)
+
+const ContainerName = "icarus"
+
+// SelectCoordinator picks the pod which Icarus sidecar should coordinate the global requests.
+// The current coordinator is kept while it's ready as only the coordinator has the global request info.
+func SelectCoordinator(readyPods []v1.Pod, currentCoordinator string) (v1.Pod, bool) {
+	if len(readyPods) == 0 {
+		return v1.Pod{}, false
+	}
+
+	for _, pod := range readyPods {
+		if pod.Name == currentCoordinator {
+			return pod, true
+		}
+	}
+
+	return readyPods[0], true
+}
+
+// PodURL returns the address of the Icarus sidecar of the pod
+func PodURL(cc *v1alpha1.CassandraCluster, pod v1.Pod) string {
+	svc := names.DC(cc.Name, pod.Labels[v1alpha1.CassandraClusterDC])
+	return fmt.Sprintf("http://%s.%s.%s.svc.cluster.local:%d", pod.Name, svc, cc.Namespace, v1alpha1.IcarusPort)
+}
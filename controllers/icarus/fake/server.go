@@ -0,0 +1,144 @@
+// Package fake provides an in-memory Icarus HTTP server for tests
+package fake
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+)
+
+// Server implements the operations API of the Icarus sidecar. The operations are stored in memory and never progress
+// on their own, tests change their state with SetBackupState and SetRestoreState.
+type Server struct {
+	*httptest.Server
+	mu                sync.Mutex
+	lastID            int
+	backups           []icarus.Backup
+	restores          []icarus.Restore
+	commitLogRestores []icarus.CommitLogRestore
+}
+
+func NewServer() *Server {
+	s := &Server{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/operations", s.operations)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// SetBackupState sets the state of the backup operation and marks it as finished if it's not running anymore
+func (s *Server) SetBackupState(id, state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.backups {
+		if s.backups[i].ID == id {
+			s.backups[i].State = state
+			if state == icarus.StateCompleted {
+				s.backups[i].Progress = 1
+				s.backups[i].CompletionTime = time.Now().UTC().Format(time.RFC3339)
+			}
+		}
+	}
+}
+
+// SetRestoreState sets the state of the restore operation and marks it as finished if it's not running anymore
+func (s *Server) SetRestoreState(id, state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.restores {
+		if s.restores[i].Id == id {
+			s.restores[i].State = state
+			if state == icarus.StateCompleted {
+				s.restores[i].Progress = 1
+				s.restores[i].CompletionTime = time.Now().UTC().Format(time.RFC3339)
+			}
+		}
+	}
+}
+
+func (s *Server) operations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		switch r.URL.Query().Get("type") {
+		case "backup":
+			writeJSON(w, http.StatusOK, s.backups)
+		case "restore":
+			writeJSON(w, http.StatusOK, s.restores)
+		case "commitlog-restore":
+			writeJSON(w, http.StatusOK, s.commitLogRestores)
+		default:
+			http.Error(w, "unknown operation type", http.StatusBadRequest)
+		}
+	case http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.createOperation(w, body)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) createOperation(w http.ResponseWriter, body []byte) {
+	operation := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(body, &operation); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.lastID++
+	id := strconv.Itoa(s.lastID)
+	now := time.Now().UTC().Format(time.RFC3339)
+	switch operation.Type {
+	case "backup":
+		backup := icarus.Backup{}
+		if err := json.Unmarshal(body, &backup); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		backup.ID, backup.State, backup.CreationTime, backup.StartTime = id, icarus.StateRunning, now, now
+		s.backups = append(s.backups, backup)
+		writeJSON(w, http.StatusCreated, backup)
+	case "restore":
+		restore := icarus.Restore{}
+		if err := json.Unmarshal(body, &restore); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		restore.Id, restore.State, restore.CreationTime, restore.StartTime = id, icarus.StateRunning, now, now
+		s.restores = append(s.restores, restore)
+		writeJSON(w, http.StatusCreated, restore)
+	case "commitlog-restore":
+		restore := icarus.CommitLogRestore{}
+		if err := json.Unmarshal(body, &restore); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		restore.Id, restore.State, restore.CreationTime, restore.StartTime = id, icarus.StateRunning, now, now
+		s.commitLogRestores = append(s.commitLogRestores, restore)
+		writeJSON(w, http.StatusCreated, restore)
+	default:
+		http.Error(w, "unknown operation type "+operation.Type, http.StatusBadRequest)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -17,6 +17,8 @@ type Icarus interface {
 	Backups(ctx context.Context) ([]Backup, error)
 	Restore(ctx context.Context, req RestoreRequest) error
 	Restores(ctx context.Context) ([]Restore, error)
+	CommitLogRestore(ctx context.Context, req CommitLogRestoreRequest) error
+	CommitLogRestores(ctx context.Context) ([]CommitLogRestore, error)
 }
 
 type client struct {
@@ -0,0 +1,70 @@
+package icarus_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/icarus/fake"
+)
+
+func TestBackup(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := fake.NewServer()
+	defer server.Close()
+
+	client := icarus.New(server.URL)
+	backup, err := client.Backup(ctx, icarus.BackupRequest{
+		Type:            "backup",
+		StorageLocation: "s3://bucket/cluster/dc1",
+		SnapshotTag:     "snapshot-1",
+		GlobalRequest:   true,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backup.ID).ToNot(BeEmpty())
+	g.Expect(backup.State).To(Equal(icarus.StateRunning))
+	g.Expect(backup.SnapshotTag).To(Equal("snapshot-1"))
+
+	server.SetBackupState(backup.ID, icarus.StateCompleted)
+
+	backups, err := client.Backups(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups).To(HaveLen(1))
+	g.Expect(backups[0].ID).To(Equal(backup.ID))
+	g.Expect(backups[0].State).To(Equal(icarus.StateCompleted))
+	g.Expect(backups[0].Progress).To(BeEquivalentTo(1))
+	g.Expect(backups[0].StorageLocation).To(Equal("s3://bucket/cluster/dc1"))
+}
+
+func TestRestore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := fake.NewServer()
+	defer server.Close()
+
+	client := icarus.New(server.URL)
+	g.Expect(client.Restore(ctx, icarus.RestoreRequest{
+		Type:            "restore",
+		StorageLocation: "s3://bucket/cluster/dc1",
+		SnapshotTag:     "snapshot-1",
+		GlobalRequest:   true,
+	})).To(Succeed())
+
+	restores, err := client.Restores(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restores).To(HaveLen(1))
+	g.Expect(restores[0].State).To(Equal(icarus.StateRunning))
+
+	server.SetRestoreState(restores[0].Id, icarus.StateFailed)
+
+	restores, err = client.Restores(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restores[0].State).To(Equal(icarus.StateFailed))
+
+	backups, err := client.Backups(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups).To(BeEmpty())
+}
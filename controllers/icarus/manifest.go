@@ -0,0 +1,86 @@
+package icarus
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Icarus uploads the manifest of every node as <cluster>/<dc>/<node>/manifests/<snapshotTag>-<schemaVersion>-<timestamp>.json
+var manifestKeyRegexp = regexp.MustCompile(`^([^/]+)/([^/]+)/([^/]+)/manifests/(.+)-([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})-(\d+)\.json$`)
+
+// ManifestKey is the location of a node's backup manifest relative to the storage location
+type ManifestKey struct {
+	Key           string
+	Cluster       string
+	DC            string
+	Node          string
+	SnapshotTag   string
+	SchemaVersion string
+	Timestamp     time.Time
+}
+
+// Manifest is the part of the Icarus backup manifest used by the operator
+type Manifest struct {
+	Snapshot struct {
+		Keyspaces map[string]ManifestKeyspace `json:"keyspaces"`
+	} `json:"snapshot"`
+}
+
+type ManifestKeyspace struct {
+	Tables map[string]ManifestTable `json:"tables"`
+}
+
+type ManifestTable struct {
+	Entries []ManifestEntry `json:"entries"`
+}
+
+type ManifestEntry struct {
+	ObjectKey string `json:"objectKey"`
+	Size      int64  `json:"size"`
+}
+
+// ParseManifestKey parses the key of a manifest. The key has to be relative to the storage location.
+func ParseManifestKey(key string) (ManifestKey, bool) {
+	matches := manifestKeyRegexp.FindStringSubmatch(key)
+	if matches == nil {
+		return ManifestKey{}, false
+	}
+
+	timestamp, err := strconv.ParseInt(matches[6], 10, 64)
+	if err != nil {
+		return ManifestKey{}, false
+	}
+
+	return ManifestKey{
+		Cluster:       matches[1],
+		DC:            matches[2],
+		Node:          matches[3],
+		SnapshotTag:   matches[4],
+		SchemaVersion: matches[5],
+		Timestamp:     time.UnixMilli(timestamp).UTC(),
+	}, true
+}
+
+// SSTables returns the number of SSTables of the table in the backup
+func (t ManifestTable) SSTables() int {
+	sstables := 0
+	for _, entry := range t.Entries {
+		if strings.HasSuffix(entry.ObjectKey, "-Data.db") {
+			sstables++
+		}
+	}
+
+	return sstables
+}
+
+// Size returns the size of all files of the table in the backup
+func (t ManifestTable) Size() int64 {
+	var size int64
+	for _, entry := range t.Entries {
+		size += entry.Size
+	}
+
+	return size
+}
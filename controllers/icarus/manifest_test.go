@@ -0,0 +1,48 @@
+package icarus
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+const schemaVersion = "8d2a4c2e-2b4f-3b1a-9c5e-4a7f3e2d1c0b"
+
+func TestParseManifestKey(t *testing.T) {
+	g := NewWithT(t)
+
+	key, ok := ParseManifestKey("cluster/dc1/node-1/manifests/my-backup-tag-" + schemaVersion + "-1630497600000.json")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(key).To(Equal(ManifestKey{
+		Cluster:       "cluster",
+		DC:            "dc1",
+		Node:          "node-1",
+		SnapshotTag:   "my-backup-tag",
+		SchemaVersion: schemaVersion,
+		Timestamp:     time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC),
+	}))
+
+	_, ok = ParseManifestKey("cluster/dc1/node-1/data/ks/table/md-1-big-Data.db")
+	g.Expect(ok).To(BeFalse())
+
+	_, ok = ParseManifestKey("cluster/dc1/node-1/manifests/tag-1630497600000.json")
+	g.Expect(ok).To(BeFalse())
+
+	_, ok = ParseManifestKey("path/cluster/dc1/node-1/manifests/tag-" + schemaVersion + "-1630497600000.json")
+	g.Expect(ok).To(BeFalse(), "keys should be relative to the storage location")
+}
+
+func TestManifestTableSSTables(t *testing.T) {
+	g := NewWithT(t)
+
+	table := ManifestTable{Entries: []ManifestEntry{
+		{ObjectKey: "cluster/dc1/node-1/data/ks/t1-1a2b/1-1234/nb-1-big-Data.db", Size: 100},
+		{ObjectKey: "cluster/dc1/node-1/data/ks/t1-1a2b/1-1234/nb-1-big-Index.db", Size: 10},
+		{ObjectKey: "cluster/dc1/node-1/data/ks/t1-1a2b/2-5678/nb-2-big-Data.db", Size: 200},
+		{ObjectKey: "cluster/dc1/node-1/data/ks/t1-1a2b/schema.cql", Size: 1},
+	}}
+	g.Expect(table.SSTables()).To(Equal(2))
+	g.Expect(table.Size()).To(BeEquivalentTo(311))
+	g.Expect(ManifestTable{}.SSTables()).To(Equal(0))
+}
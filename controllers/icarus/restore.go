@@ -35,6 +35,8 @@ type RestoreRequest struct {
 	DC                        string            `json:"dc,omitempty"`
 	SchemaVersion             string            `json:"schemaVersion,omitempty"`
 	ExactSchemaVersion        bool              `json:"exactSchemaVersion,omitempty"`
+	// Identifies the restore on the nodes for the engines that prepare the restore and apply it on start (Medusa). Not sent to Icarus
+	RestoreKey string `json:"-"`
 }
 
 type Restore struct {
@@ -44,6 +46,7 @@ type Restore struct {
 	Errors                    []Error           `json:"errors"`
 	Progress                  float64           `json:"progress"`
 	StartTime                 string            `json:"startTime"`
+	CompletionTime            string            `json:"completionTime"`
 	Type                      string            `json:"type"`
 	StorageLocation           string            `json:"storageLocation"`
 	ConcurrentConnections     int64             `json:"concurrentConnections"`
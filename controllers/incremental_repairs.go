@@ -0,0 +1,420 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/builtinrepair"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+// the keyspaces whose SSTables are marked as repaired by the maintenance-mode container on the next start of a pod
+// are stored in the maintenance configmap under the pod name with this suffix
+const markRepairedKeySuffix = ".mark-repaired"
+
+// migrationRepairRunner runs the full repairs that precede the migration of a keyspace to incremental repairs
+type migrationRepairRunner interface {
+	StartFullRepair(ctx context.Context, keyspace, cause string) (string, error)
+	// RepairRunState returns the state of the repair run or an empty string if the run is not found
+	RepairRunState(ctx context.Context, keyspace, runID string) (string, error)
+	// IncrementalScheduleExists returns true if the keyspace already has an incremental repair schedule
+	IncrementalScheduleExists(ctx context.Context, keyspace string) (bool, error)
+}
+
+type reaperMigrationRepairs struct {
+	cc     *dbv1alpha1.CassandraCluster
+	client reaper.ReaperClient
+}
+
+func (m *reaperMigrationRepairs) StartFullRepair(ctx context.Context, keyspace, cause string) (string, error) {
+	run, err := m.client.CreateRepairRun(ctx, dbv1alpha1.CassandraRepairSpec{
+		CassandraCluster:  m.cc.Name,
+		Keyspace:          keyspace,
+		RepairParallelism: m.cc.Spec.Reaper.RepairParallelism,
+	}, cause)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create a full repair run of keyspace %s", keyspace)
+	}
+
+	if err = m.client.SetRepairRunState(ctx, run.ID, reaper.RepairStateRunning); err != nil {
+		return "", errors.Wrapf(err, "failed to start repair run %s", run.ID)
+	}
+
+	return run.ID, nil
+}
+
+func (m *reaperMigrationRepairs) RepairRunState(ctx context.Context, keyspace, runID string) (string, error) {
+	runs, err := m.client.RepairRuns(ctx, keyspace)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get repair runs")
+	}
+
+	for _, run := range runs {
+		if run.ID == runID {
+			return run.State, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (m *reaperMigrationRepairs) IncrementalScheduleExists(ctx context.Context, keyspace string) (bool, error) {
+	schedules, err := m.client.RepairSchedules(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get repair schedules")
+	}
+
+	for _, schedule := range schedules {
+		if schedule.KeyspaceName == keyspace && schedule.IncrementalRepair {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// builtinMigrationRepairs adds the full repairs to the builtin repairs state. The state is saved by reconcileBuiltinRepairs.
+type builtinMigrationRepairs struct {
+	cc    *dbv1alpha1.CassandraCluster
+	state *builtinrepair.State
+	now   time.Time
+}
+
+func (m *builtinMigrationRepairs) StartFullRepair(_ context.Context, keyspace, cause string) (string, error) {
+	run := m.state.AddRun(builtinrepair.Run{
+		Cause:       cause,
+		Keyspace:    keyspace,
+		Parallelism: m.cc.Spec.Reaper.RepairParallelism,
+		ThreadCount: m.cc.Spec.Reaper.RepairThreadCount,
+	}, m.now)
+
+	return run.ID, nil
+}
+
+func (m *builtinMigrationRepairs) RepairRunState(_ context.Context, _, runID string) (string, error) {
+	for _, run := range m.state.Runs {
+		if run.ID == runID {
+			return run.State, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (m *builtinMigrationRepairs) IncrementalScheduleExists(_ context.Context, keyspace string) (bool, error) {
+	for _, schedule := range m.state.Schedules {
+		if schedule.Keyspace == keyspace && schedule.Incremental {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// reconcileIncrementalRepairMigrations migrates the keyspaces of the incremental repair schedules from full repairs.
+// An incremental repair of a keyspace with unrepaired SSTables anticompacts all of them, so the keyspace is fully
+// repaired first and then its SSTables are marked as repaired node by node while Cassandra is stopped.
+// The incremental repair schedules of a keyspace are created once its migration is completed. The keyspaces that already
+// have incremental repair schedules, e.g. created before the migrations were introduced, are not migrated.
+func (r *CassandraClusterReconciler) reconcileIncrementalRepairMigrations(ctx context.Context, cc *dbv1alpha1.CassandraCluster, repairs migrationRepairRunner) error {
+	keyspaces := incrementalRepairKeyspaces(cc)
+	migrations := make([]dbv1alpha1.IncrementalRepairMigration, 0, len(keyspaces))
+	for _, migration := range cc.Status.IncrementalRepairMigrations {
+		if util.Contains(keyspaces, migration.Keyspace) {
+			migrations = append(migrations, migration)
+			continue
+		}
+
+		// the keyspace is migrated again if the incremental repairs are turned on later, as new SSTables are not repaired
+		r.Log.Infof("Incremental repairs are turned off for keyspace %s, removing its migration state", migration.Keyspace)
+		if len(migration.CurrentPod) > 0 {
+			if err := r.updateMarkRepairedKeyspace(ctx, cc, migration.CurrentPod, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, keyspace := range keyspaces {
+		if findIncrementalRepairMigration(migrations, keyspace) != nil {
+			continue
+		}
+
+		migration := dbv1alpha1.IncrementalRepairMigration{Keyspace: keyspace}
+		scheduled, err := repairs.IncrementalScheduleExists(ctx, keyspace)
+		if err != nil {
+			return err
+		}
+
+		if scheduled {
+			r.Log.Infof("Keyspace %s is already repaired incrementally, not migrating it", keyspace)
+			now := metav1.Now()
+			migration.Phase = dbv1alpha1.IncrementalRepairMigrationCompleted
+			migration.StartTime = now
+			migration.CompletionTime = &now
+		}
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Keyspace < migrations[j].Keyspace
+	})
+
+	// the migrations are updated in place, so that the status persisted by markSSTablesRepaired includes them
+	cc.Status.IncrementalRepairMigrations = nil
+	if len(migrations) > 0 {
+		cc.Status.IncrementalRepairMigrations = migrations
+	}
+
+	// the SSTables of one keyspace at a time are marked as repaired, so that the nodes are restarted only once per keyspace
+	marking := false
+	for i := range migrations {
+		migration := &migrations[i]
+		switch migration.Phase {
+		case "":
+			if err := r.startIncrementalRepairMigration(ctx, cc, migration, repairs); err != nil {
+				return err
+			}
+		case dbv1alpha1.IncrementalRepairMigrationFullRepair:
+			if err := r.checkIncrementalRepairMigrationFullRepair(ctx, cc, migration, repairs); err != nil {
+				return err
+			}
+		case dbv1alpha1.IncrementalRepairMigrationMarkingRepaired:
+			if marking {
+				continue
+			}
+			marking = true
+			if err := r.markSSTablesRepaired(ctx, cc, migration); err != nil {
+				return errors.Wrapf(err, "failed to mark SSTables of keyspace %s as repaired", migration.Keyspace)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *CassandraClusterReconciler) startIncrementalRepairMigration(ctx context.Context, cc *dbv1alpha1.CassandraCluster, migration *dbv1alpha1.IncrementalRepairMigration, repairs migrationRepairRunner) error {
+	if cc.Status.RepairsPause != nil {
+		r.Log.Infof("Repairs are paused for %s, not starting the migration of keyspace %s to incremental repairs", cc.Status.RepairsPause.Reason, migration.Keyspace)
+		return nil
+	}
+
+	runID, err := repairs.StartFullRepair(ctx, migration.Keyspace, repairCauseIncrementalRepair)
+	if err != nil {
+		return err
+	}
+
+	if migration.StartTime.IsZero() {
+		migration.StartTime = metav1.Now()
+	}
+	migration.Phase = dbv1alpha1.IncrementalRepairMigrationFullRepair
+	migration.RepairRunID = runID
+
+	msg := fmt.Sprintf("Migrating keyspace %s to incremental repairs. Started full repair run %s", migration.Keyspace, runID)
+	r.Log.Info(msg)
+	r.Events.Normal(cc, events.EventIncrementalRepairMigration, msg)
+	return nil
+}
+
+func (r *CassandraClusterReconciler) checkIncrementalRepairMigrationFullRepair(ctx context.Context, cc *dbv1alpha1.CassandraCluster, migration *dbv1alpha1.IncrementalRepairMigration, repairs migrationRepairRunner) error {
+	state, err := repairs.RepairRunState(ctx, migration.Keyspace, migration.RepairRunID)
+	if err != nil {
+		return err
+	}
+
+	switch state {
+	case reaper.RepairStateDone:
+		migration.Phase = dbv1alpha1.IncrementalRepairMigrationMarkingRepaired
+		msg := fmt.Sprintf("Full repair run %s of keyspace %s completed. Marking its SSTables as repaired node by node", migration.RepairRunID, migration.Keyspace)
+		r.Log.Info(msg)
+		r.Events.Normal(cc, events.EventIncrementalRepairMigration, msg)
+	case reaper.RepairStateError, reaper.RepairStateAborted, reaper.RepairStateDeleted, "":
+		msg := fmt.Sprintf("Full repair run %s of keyspace %s didn't complete. Starting a new one", migration.RepairRunID, migration.Keyspace)
+		r.Log.Warn(msg)
+		r.Events.Warning(cc, events.EventIncrementalRepairMigrationFailed, msg)
+		migration.Phase = ""
+		migration.RepairRunID = ""
+	}
+
+	return nil
+}
+
+// markSSTablesRepaired restarts the pods one by one with the keyspace set in the maintenance configmap,
+// so that the maintenance-mode container marks the SSTables of the keyspace as repaired before Cassandra starts.
+// A pod is restarted only if all the pods are ready and no disruptive operation is in progress.
+// The status is persisted after each step, so that a pod is not restarted twice if the reconcile fails later on.
+func (r *CassandraClusterReconciler) markSSTablesRepaired(ctx context.Context, cc *dbv1alpha1.CassandraCluster, migration *dbv1alpha1.IncrementalRepairMigration) error {
+	pods, err := r.getCassandraPods(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	if len(migration.CurrentPod) > 0 {
+		done, err := r.podMarkedRepaired(ctx, cc, migration, pods.Items)
+		if err != nil || !done {
+			return err
+		}
+
+		if err = r.updateMarkRepairedKeyspace(ctx, cc, migration.CurrentPod, ""); err != nil {
+			return err
+		}
+
+		r.Log.Infof("SSTables of keyspace %s are marked as repaired on pod %s", migration.Keyspace, migration.CurrentPod)
+		migration.MarkedPods = append(migration.MarkedPods, migration.CurrentPod)
+		migration.CurrentPod = ""
+		migration.CurrentPodUID = ""
+		if err = r.persistStatus(ctx, cc); err != nil {
+			return err
+		}
+	}
+
+	var nextPod *v1.Pod
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+	for i := range pods.Items {
+		if !util.Contains(migration.MarkedPods, pods.Items[i].Name) {
+			nextPod = &pods.Items[i]
+			break
+		}
+	}
+
+	if nextPod == nil {
+		migration.Phase = dbv1alpha1.IncrementalRepairMigrationCompleted
+		migration.CompletionTime = &metav1.Time{Time: time.Now()}
+		msg := fmt.Sprintf("Keyspace %s is migrated to incremental repairs", migration.Keyspace)
+		r.Log.Info(msg)
+		r.Events.Normal(cc, events.EventIncrementalRepairMigration, msg)
+		return r.persistStatus(ctx, cc)
+	}
+
+	if cc.Status.RepairsPause != nil {
+		r.Log.Infof("Waiting for %s to finish before marking SSTables of keyspace %s as repaired", cc.Status.RepairsPause.Reason, migration.Keyspace)
+		return nil
+	}
+
+	for _, pod := range pods.Items {
+		if !podReady(pod) {
+			r.Log.Infof("Waiting for pod %s to become ready before marking SSTables of keyspace %s as repaired", pod.Name, migration.Keyspace)
+			return nil
+		}
+	}
+
+	if err = r.updateMarkRepairedKeyspace(ctx, cc, nextPod.Name, migration.Keyspace); err != nil {
+		return err
+	}
+
+	r.Log.Infof("Restarting pod %s to mark SSTables of keyspace %s as repaired", nextPod.Name, migration.Keyspace)
+	migration.CurrentPod = nextPod.Name
+	migration.CurrentPodUID = string(nextPod.UID)
+	if err = r.persistStatus(ctx, cc); err != nil {
+		return err
+	}
+
+	return r.patchPodStatusPhase(ctx, cc.Namespace, nextPod.Name, v1.PodFailed)
+}
+
+// podMarkedRepaired returns true once the pod has been restarted, the maintenance-mode container has succeeded and the pod is ready
+func (r *CassandraClusterReconciler) podMarkedRepaired(ctx context.Context, cc *dbv1alpha1.CassandraCluster, migration *dbv1alpha1.IncrementalRepairMigration, pods []v1.Pod) (bool, error) {
+	for _, pod := range pods {
+		if pod.Name != migration.CurrentPod {
+			continue
+		}
+
+		if string(pod.UID) == migration.CurrentPodUID {
+			if pod.DeletionTimestamp == nil && pod.Status.Phase != v1.PodFailed {
+				r.Log.Infof("Pod %s is not restarted yet. Restarting it to mark SSTables of keyspace %s as repaired", pod.Name, migration.Keyspace)
+				return false, r.patchPodStatusPhase(ctx, cc.Namespace, pod.Name, v1.PodFailed)
+			}
+			return false, nil
+		}
+
+		for _, container := range pod.Status.InitContainerStatuses {
+			if container.Name != "maintenance-mode" {
+				continue
+			}
+
+			if container.State.Terminated != nil && container.State.Terminated.ExitCode == 0 {
+				return podReady(pod), nil
+			}
+
+			if container.LastTerminationState.Terminated != nil && container.LastTerminationState.Terminated.ExitCode != 0 {
+				r.Log.Warnf("Failed to mark SSTables of keyspace %s as repaired on pod %s. Check the logs of its maintenance-mode container", migration.Keyspace, pod.Name)
+			}
+		}
+
+		return false, nil
+	}
+
+	r.Log.Infof("Waiting for pod %s to be recreated", migration.CurrentPod)
+	return false, nil
+}
+
+// updateMarkRepairedKeyspace sets the keyspace whose SSTables are marked as repaired on the next start of the pod.
+// The key of the pod is removed if the keyspace is empty.
+func (r *CassandraClusterReconciler) updateMarkRepairedKeyspace(ctx context.Context, cc *dbv1alpha1.CassandraCluster, podName, keyspace string) error {
+	configMap, err := r.getConfigMap(ctx, names.MaintenanceConfigMap(cc.Name), cc.Namespace)
+	if err != nil {
+		return err
+	}
+
+	data := configMap.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+
+	if len(keyspace) > 0 {
+		data[podName+markRepairedKeySuffix] = keyspace
+	} else {
+		delete(data, podName+markRepairedKeySuffix)
+	}
+
+	return r.patchConfigMap(ctx, cc.Namespace, configMap.Name, data)
+}
+
+// incrementalRepairKeyspaces returns the sorted keyspaces of the enabled incremental repair schedules
+func incrementalRepairKeyspaces(cc *dbv1alpha1.CassandraCluster) []string {
+	if !cc.Spec.Reaper.RepairSchedules.Enabled {
+		return nil
+	}
+
+	var keyspaces []string
+	for _, repair := range cc.Spec.Reaper.RepairSchedules.Repairs {
+		if repair.IncrementalRepair && !util.Contains(keyspaces, repair.Keyspace) {
+			keyspaces = append(keyspaces, repair.Keyspace)
+		}
+	}
+
+	sort.Strings(keyspaces)
+	return keyspaces
+}
+
+// incrementalRepairMigrationPending returns true if the repair schedule is incremental
+// and the migration of its keyspace to incremental repairs is not completed yet
+func incrementalRepairMigrationPending(cc *dbv1alpha1.CassandraCluster, repair dbv1alpha1.RepairSchedule) bool {
+	if !repair.IncrementalRepair {
+		return false
+	}
+
+	migration := findIncrementalRepairMigration(cc.Status.IncrementalRepairMigrations, repair.Keyspace)
+	return migration == nil || migration.Phase != dbv1alpha1.IncrementalRepairMigrationCompleted
+}
+
+func findIncrementalRepairMigration(migrations []dbv1alpha1.IncrementalRepairMigration, keyspace string) *dbv1alpha1.IncrementalRepairMigration {
+	for i := range migrations {
+		if migrations[i].Keyspace == keyspace {
+			return &migrations[i]
+		}
+	}
+
+	return nil
+}
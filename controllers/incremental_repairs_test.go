@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/builtinrepair"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+)
+
+func TestReconcileIncrementalRepairMigrations(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	ctx := context.Background()
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			Reaper: &v1alpha1.Reaper{
+				RepairSchedules: v1alpha1.RepairSchedules{
+					Enabled: true,
+					Repairs: []v1alpha1.RepairSchedule{
+						{Keyspace: "incremental_keyspace", IncrementalRepair: true},
+						{Keyspace: "full_keyspace"},
+					},
+				},
+			},
+		},
+	}
+
+	podNames := []string{"test-cluster-cassandra-dc1-0", "test-cluster-cassandra-dc1-1"}
+	reconciler := createBasicMockedReconciler()
+	reconciler.Client = fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(
+		cc.DeepCopy(),
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: names.MaintenanceConfigMap(cc.Name), Namespace: cc.Namespace}},
+		migrationTestPod(cc, podNames[0], "uid-1", false),
+		migrationTestPod(cc, podNames[1], "uid-2", false),
+	).Build()
+
+	state := &builtinrepair.State{}
+	repairs := &builtinMigrationRepairs{cc: cc, state: state, now: time.Now()}
+	reconcile := func() v1alpha1.IncrementalRepairMigration {
+		asserts.Expect(reconciler.reconcileIncrementalRepairMigrations(ctx, cc, repairs)).To(Succeed())
+		asserts.Expect(cc.Status.IncrementalRepairMigrations).To(HaveLen(1))
+		return cc.Status.IncrementalRepairMigrations[0]
+	}
+	maintenanceData := func() map[string]string {
+		cm := &v1.ConfigMap{}
+		asserts.Expect(reconciler.Get(ctx, types.NamespacedName{Name: names.MaintenanceConfigMap(cc.Name), Namespace: cc.Namespace}, cm)).To(Succeed())
+		return cm.Data
+	}
+	restartPod := func(name, uid string) {
+		asserts.Expect(reconciler.Delete(ctx, &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cc.Namespace}})).To(Succeed())
+		asserts.Expect(reconciler.Create(ctx, migrationTestPod(cc, name, uid, true))).To(Succeed())
+	}
+
+	// full repair
+	migration := reconcile()
+	asserts.Expect(migration.Keyspace).To(Equal("incremental_keyspace"))
+	asserts.Expect(migration.Phase).To(Equal(v1alpha1.IncrementalRepairMigrationFullRepair))
+	asserts.Expect(state.Runs).To(HaveLen(1))
+	asserts.Expect(state.Runs[0].ID).To(Equal(migration.RepairRunID))
+	asserts.Expect(state.Runs[0].Keyspace).To(Equal("incremental_keyspace"))
+	asserts.Expect(state.Runs[0].Incremental).To(BeFalse())
+	asserts.Expect(incrementalRepairMigrationPending(cc, cc.Spec.Reaper.RepairSchedules.Repairs[0])).To(BeTrue())
+	asserts.Expect(incrementalRepairMigrationPending(cc, cc.Spec.Reaper.RepairSchedules.Repairs[1])).To(BeFalse())
+
+	asserts.Expect(reconcile().Phase).To(Equal(v1alpha1.IncrementalRepairMigrationFullRepair))
+
+	// a failed full repair is started again
+	state.Runs[0].State = reaper.RepairStateError
+	asserts.Expect(reconcile().Phase).To(BeEmpty())
+	migration = reconcile()
+	asserts.Expect(migration.Phase).To(Equal(v1alpha1.IncrementalRepairMigrationFullRepair))
+	asserts.Expect(state.Runs).To(HaveLen(2))
+	asserts.Expect(migration.RepairRunID).To(Equal(state.Runs[1].ID))
+
+	state.Runs[1].State = reaper.RepairStateDone
+	asserts.Expect(reconcile().Phase).To(Equal(v1alpha1.IncrementalRepairMigrationMarkingRepaired))
+
+	// the SSTables are marked as repaired one node at a time
+	migration = reconcile()
+	asserts.Expect(migration.CurrentPod).To(Equal(podNames[0]))
+	asserts.Expect(maintenanceData()).To(Equal(map[string]string{podNames[0] + markRepairedKeySuffix: "incremental_keyspace"}))
+	pod := &v1.Pod{}
+	asserts.Expect(reconciler.Get(ctx, types.NamespacedName{Name: podNames[0], Namespace: cc.Namespace}, pod)).To(Succeed())
+	asserts.Expect(pod.Status.Phase).To(Equal(v1.PodFailed))
+	persisted := &v1alpha1.CassandraCluster{}
+	asserts.Expect(reconciler.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, persisted)).To(Succeed())
+	asserts.Expect(persisted.Status.IncrementalRepairMigrations).To(HaveLen(1))
+	asserts.Expect(persisted.Status.IncrementalRepairMigrations[0].CurrentPod).To(Equal(podNames[0]), "the restarted pod is persisted before the restart")
+	asserts.Expect(persisted.Status.IncrementalRepairMigrations[0].CurrentPodUID).To(Equal("uid-1"))
+
+	asserts.Expect(reconcile().CurrentPod).To(Equal(podNames[0])) // not restarted yet
+
+	restartPod(podNames[0], "uid-3")
+	migration = reconcile()
+	asserts.Expect(migration.MarkedPods).To(Equal([]string{podNames[0]}))
+	asserts.Expect(reconciler.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, persisted)).To(Succeed())
+	asserts.Expect(persisted.Status.IncrementalRepairMigrations[0].MarkedPods).To(Equal([]string{podNames[0]}))
+	asserts.Expect(migration.CurrentPod).To(Equal(podNames[1]))
+	asserts.Expect(maintenanceData()).To(Equal(map[string]string{podNames[1] + markRepairedKeySuffix: "incremental_keyspace"}))
+
+	// the migration is completed once the SSTables are marked as repaired on all nodes
+	restartPod(podNames[1], "uid-4")
+	migration = reconcile()
+	asserts.Expect(migration.Phase).To(Equal(v1alpha1.IncrementalRepairMigrationCompleted))
+	asserts.Expect(migration.MarkedPods).To(Equal(podNames))
+	asserts.Expect(migration.CompletionTime).ToNot(BeNil())
+	asserts.Expect(maintenanceData()).To(BeEmpty())
+	asserts.Expect(incrementalRepairMigrationPending(cc, cc.Spec.Reaper.RepairSchedules.Repairs[0])).To(BeFalse())
+
+	// the migration state is removed once the incremental repairs are turned off
+	cc.Spec.Reaper.RepairSchedules.Repairs[0].IncrementalRepair = false
+	asserts.Expect(reconciler.reconcileIncrementalRepairMigrations(ctx, cc, repairs)).To(Succeed())
+	asserts.Expect(cc.Status.IncrementalRepairMigrations).To(BeNil())
+}
+
+func TestIncrementalRepairMigrationWaitsForRepairsPause(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			Reaper: &v1alpha1.Reaper{
+				RepairSchedules: v1alpha1.RepairSchedules{
+					Enabled: true,
+					Repairs: []v1alpha1.RepairSchedule{{Keyspace: "incremental_keyspace", IncrementalRepair: true}},
+				},
+			},
+		},
+		Status: v1alpha1.CassandraClusterStatus{
+			RepairsPause: &v1alpha1.RepairsPause{Reason: repairsPauseReasonScaling},
+			IncrementalRepairMigrations: []v1alpha1.IncrementalRepairMigration{
+				{Keyspace: "other_keyspace", Phase: v1alpha1.IncrementalRepairMigrationCompleted},
+			},
+		},
+	}
+
+	reconciler := createBasicMockedReconciler()
+	state := &builtinrepair.State{}
+	err := reconciler.reconcileIncrementalRepairMigrations(context.Background(), cc, &builtinMigrationRepairs{cc: cc, state: state, now: time.Now()})
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(state.Runs).To(BeEmpty())
+	asserts.Expect(cc.Status.IncrementalRepairMigrations).To(Equal([]v1alpha1.IncrementalRepairMigration{
+		{Keyspace: "incremental_keyspace"},
+	}))
+}
+
+func TestIncrementalRepairMigrationOfScheduledKeyspace(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			Reaper: &v1alpha1.Reaper{
+				RepairSchedules: v1alpha1.RepairSchedules{
+					Enabled: true,
+					Repairs: []v1alpha1.RepairSchedule{
+						{Keyspace: "scheduled_keyspace", IncrementalRepair: true},
+						{Keyspace: "new_keyspace", IncrementalRepair: true},
+					},
+				},
+			},
+		},
+	}
+
+	reconciler := createBasicMockedReconciler()
+	state := &builtinrepair.State{Schedules: []builtinrepair.Schedule{
+		{ID: "1", Keyspace: "scheduled_keyspace", Incremental: true},
+		{ID: "2", Keyspace: "new_keyspace"},
+	}}
+	err := reconciler.reconcileIncrementalRepairMigrations(context.Background(), cc, &builtinMigrationRepairs{cc: cc, state: state, now: time.Now()})
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(cc.Status.IncrementalRepairMigrations).To(HaveLen(2))
+	asserts.Expect(cc.Status.IncrementalRepairMigrations[0].Keyspace).To(Equal("new_keyspace"))
+	asserts.Expect(cc.Status.IncrementalRepairMigrations[0].Phase).To(Equal(v1alpha1.IncrementalRepairMigrationFullRepair))
+	asserts.Expect(cc.Status.IncrementalRepairMigrations[1].Keyspace).To(Equal("scheduled_keyspace"))
+	asserts.Expect(cc.Status.IncrementalRepairMigrations[1].Phase).To(Equal(v1alpha1.IncrementalRepairMigrationCompleted))
+	asserts.Expect(cc.Status.IncrementalRepairMigrations[1].CompletionTime).ToNot(BeNil())
+	asserts.Expect(state.Runs).To(HaveLen(1), "only the keyspace without incremental repair schedules is fully repaired")
+	asserts.Expect(state.Runs[0].Keyspace).To(Equal("new_keyspace"))
+}
+
+func migrationTestPod(cc *v1alpha1.CassandraCluster, name, uid string, restarted bool) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cc.Namespace,
+			UID:       types.UID(uid),
+			Labels:    labels.ComponentLabels(cc, v1alpha1.CassandraClusterComponentCassandra),
+		},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{Name: "cassandra", Ready: true}},
+		},
+	}
+
+	if restarted {
+		pod.Status.InitContainerStatuses = []v1.ContainerStatus{
+			{Name: "maintenance-mode", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0}}},
+		}
+	}
+
+	return pod
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/cql"
-	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/names"
 	"github.com/pkg/errors"
 )
 
@@ -22,7 +22,7 @@ const (
 	keyspaceSystemTraces      = "system_traces"
 )
 
-func (r *CassandraClusterReconciler) reconcileKeyspaces(ctx context.Context, cc *dbv1alpha1.CassandraCluster, cqlClient cql.CqlClient, reaperClient reaper.ReaperClient, allDCs []dbv1alpha1.DC) error {
+func (r *CassandraClusterReconciler) reconcileKeyspaces(ctx context.Context, cc *dbv1alpha1.CassandraCluster, cqlClient cql.CqlClient, repairs repairRunner, allDCs []dbv1alpha1.DC) error {
 	keyspacesToReconcile := desiredKeyspacesToReconcile(cc)
 
 	currentKeyspaces, err := cqlClient.GetKeyspacesInfo()
@@ -39,6 +39,11 @@ func (r *CassandraClusterReconciler) reconcileKeyspaces(ctx context.Context, cc
 
 		desiredOptions := desiredReplicationOptions(cc, string(systemKeyspace), allDCs)
 		if !cmp.Equal(keyspaceInfo.Replication, desiredOptions) {
+			err = r.ensureSafetySnapshot(ctx, cc, safetySnapshotReplication, fmt.Sprintf("%s/%v", systemKeyspace, desiredOptions), []string{string(systemKeyspace)})
+			if err != nil {
+				return err
+			}
+
 			r.Log.Infof("Updating keyspace %q with replication options %v", systemKeyspace, desiredOptions)
 			err = cqlClient.UpdateRF(string(systemKeyspace), desiredOptions)
 			if err != nil {
@@ -47,7 +52,7 @@ func (r *CassandraClusterReconciler) reconcileKeyspaces(ctx context.Context, cc
 			r.Log.Infof("Done updating keyspace %q", systemKeyspace)
 
 			r.Log.Infof("Repairing keyspace %s", string(systemKeyspace))
-			err := reaperClient.RunRepair(ctx, string(systemKeyspace), repairCauseKeyspacesInit)
+			err := repairs.RunRepair(ctx, string(systemKeyspace), repairCauseKeyspacesInit)
 			if err != nil {
 				return errors.Wrapf(err, "failed to run repair on %q keyspace", systemKeyspace)
 			}
@@ -95,7 +100,11 @@ func desiredKeyspacesToReconcile(cc *dbv1alpha1.CassandraCluster) []dbv1alpha1.K
 		keyspaceSystemAuth,
 		keyspaceSystemDistributed,
 		keyspaceSystemTraces,
-		dbv1alpha1.KeyspaceName(cc.Spec.Reaper.Keyspace),
+	}
+
+	// a shared Reaper keeps its state on its storage cluster
+	if len(cc.Spec.ReaperRef) == 0 {
+		keyspacesToReconcile = append(keyspacesToReconcile, dbv1alpha1.KeyspaceName(cc.Spec.Reaper.Keyspace))
 	}
 
 	if len(cc.Spec.SystemKeyspaces.Keyspaces) == 0 {
@@ -142,8 +151,17 @@ func (r *CassandraClusterReconciler) reconcileSystemAuthKeyspace(ctx context.Con
 			return errors.Wrapf(err, "failed to alter %s keyspace", keyspaceSystemAuth)
 		}
 
+		if builtinRepairEnabled(cc) {
+			r.Log.Infof("Running repair for keyspace system_auth")
+			repairs := &builtinRepairRunner{r: r, cc: cc}
+			if err = repairs.RunRepair(ctx, keyspaceSystemAuth, repairCauseKeyspacesInit); err != nil {
+				return errors.Wrap(err, "Can't run repair for system_auth keyspace")
+			}
+			return nil
+		}
+
 		// reaper may be already running (in case of adding a new DC) so try to run a repair for the updated keyspace
-		reaperClient := r.ReaperClient(reaperServiceURL(cc), cc.Name, cc.Spec.Reaper.RepairThreadCount)
+		reaperClient := r.ReaperClient(names.ReaperServiceURL(cc), cc.Name, cc.Spec.Reaper.RepairThreadCount)
 		if isRunning, err := reaperClient.IsRunning(ctx); err == nil && isRunning {
 			r.Log.Infof("Running repair for keyspace system_auth")
 			err := reaperClient.RunRepair(ctx, keyspaceSystemAuth, repairCauseKeyspacesInit)
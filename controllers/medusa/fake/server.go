@@ -0,0 +1,160 @@
+// Package fake provides an in-memory Medusa gRPC server for tests
+package fake
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+)
+
+// Server implements the Medusa gRPC service. A single server can act as all nodes of the cluster:
+// every AsyncBackup request for the same backup name adds a node to the backup.
+type Server struct {
+	medusa.UnimplementedMedusaServer
+	grpcServer       *grpc.Server
+	listener         net.Listener
+	mu               sync.Mutex
+	backups          map[string]*medusa.BackupSummary
+	order            []string
+	requests         map[string]int
+	preparedRestores []*medusa.PrepareRestoreRequest
+	// Errors makes the calls of a method fail with the error. Should be set before the calls are made.
+	Errors map[string]error
+}
+
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	s := &Server{
+		grpcServer: grpc.NewServer(),
+		listener:   listener,
+		backups:    make(map[string]*medusa.BackupSummary),
+		requests:   make(map[string]int),
+		Errors:     make(map[string]error),
+	}
+	medusa.RegisterMedusaServer(s.grpcServer, s)
+	go func() {
+		_ = s.grpcServer.Serve(listener)
+	}()
+
+	return s
+}
+
+// Addr returns the host:port address of the server
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *Server) Close() {
+	s.grpcServer.Stop()
+}
+
+// Requests returns the number of received calls of the method
+func (s *Server) Requests(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[method]
+}
+
+// PreparedRestores returns the received PrepareRestore requests
+func (s *Server) PreparedRestores() []*medusa.PrepareRestoreRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*medusa.PrepareRestoreRequest(nil), s.preparedRestores...)
+}
+
+// SetBackupStatus sets the status of the backup. Successful backups are finished on all nodes.
+func (s *Server) SetBackupStatus(name string, status medusa.StatusType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	backup, found := s.backups[name]
+	if !found {
+		return
+	}
+
+	backup.Status = status
+	if status == medusa.StatusType_SUCCESS {
+		backup.FinishedNodes = backup.TotalNodes
+		backup.FinishTime = time.Now().Unix()
+	}
+}
+
+// AddBackup adds a backup as if it was found in the storage bucket
+func (s *Server) AddBackup(backup *medusa.BackupSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.backups[backup.BackupName]; !found {
+		s.order = append(s.order, backup.BackupName)
+	}
+	s.backups[backup.BackupName] = proto.Clone(backup).(*medusa.BackupSummary)
+}
+
+func (s *Server) AsyncBackup(_ context.Context, req *medusa.BackupRequest) (*medusa.AsyncBackupResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.request("AsyncBackup"); err != nil {
+		return nil, err
+	}
+
+	backup, found := s.backups[req.Name]
+	if !found {
+		backup = &medusa.BackupSummary{
+			BackupName: req.Name,
+			BackupType: req.Mode,
+			StartTime:  time.Now().Unix(),
+			Status:     medusa.StatusType_IN_PROGRESS,
+		}
+		s.backups[req.Name] = backup
+		s.order = append(s.order, req.Name)
+	}
+
+	backup.TotalNodes++
+	return &medusa.AsyncBackupResponse{BackupName: req.Name, Status: backup.Status.String()}, nil
+}
+
+func (s *Server) GetBackups(context.Context, *medusa.GetBackupsRequest) (*medusa.GetBackupsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.request("GetBackups"); err != nil {
+		return nil, err
+	}
+
+	resp := &medusa.GetBackupsResponse{}
+	for _, name := range s.order {
+		resp.Backups = append(resp.Backups, proto.Clone(s.backups[name]).(*medusa.BackupSummary))
+	}
+	return resp, nil
+}
+
+func (s *Server) PrepareRestore(_ context.Context, req *medusa.PrepareRestoreRequest) (*medusa.PrepareRestoreResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.request("PrepareRestore"); err != nil {
+		return nil, err
+	}
+
+	s.preparedRestores = append(s.preparedRestores, proto.Clone(req).(*medusa.PrepareRestoreRequest))
+	return &medusa.PrepareRestoreResponse{}, nil
+}
+
+// request counts the call of the method and returns the error configured for it. Should be called with the lock held.
+func (s *Server) request(method string) error {
+	s.requests[method]++
+	return s.Errors[method]
+}
@@ -0,0 +1,89 @@
+package medusa
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
+)
+
+const ContainerName = "medusa"
+
+const (
+	BackupModeDifferential = "differential"
+	BackupModeFull         = "full"
+)
+
+// Medusa is the client of the gRPC server of a Medusa sidecar
+type Medusa interface {
+	AsyncBackup(ctx context.Context, name, mode string) error
+	GetBackups(ctx context.Context) ([]*BackupSummary, error)
+	PrepareRestore(ctx context.Context, backupName, datacenter, restoreKey string) error
+}
+
+type client struct {
+	addr string
+}
+
+// New returns the client of the Medusa sidecar listening on addr (host:port)
+func New(addr string) Medusa {
+	return &client{addr: addr}
+}
+
+// AsyncBackup starts a backup of the node. The backup runs in the background.
+func (c *client) AsyncBackup(ctx context.Context, name, mode string) error {
+	return c.invoke(ctx, func(medusaClient MedusaClient) error {
+		_, err := medusaClient.AsyncBackup(ctx, &BackupRequest{Name: name, Mode: mode})
+		return err
+	})
+}
+
+// GetBackups returns the backups found in the storage bucket
+func (c *client) GetBackups(ctx context.Context) ([]*BackupSummary, error) {
+	var backups []*BackupSummary
+	err := c.invoke(ctx, func(medusaClient MedusaClient) error {
+		resp, err := medusaClient.GetBackups(ctx, &GetBackupsRequest{})
+		backups = resp.GetBackups()
+		return err
+	})
+
+	return backups, err
+}
+
+// PrepareRestore stores on the node which backed up nodes it restores. The restore is applied when the node starts in restore mode.
+func (c *client) PrepareRestore(ctx context.Context, backupName, datacenter, restoreKey string) error {
+	return c.invoke(ctx, func(medusaClient MedusaClient) error {
+		_, err := medusaClient.PrepareRestore(ctx, &PrepareRestoreRequest{BackupName: backupName, Datacenter: datacenter, RestoreKey: restoreKey})
+		return err
+	})
+}
+
+// invoke makes the call on a new connection. The clients are short-lived, so the connection isn't kept between calls.
+func (c *client) invoke(ctx context.Context, call func(medusaClient MedusaClient) error) error {
+	conn, err := grpc.DialContext(ctx, c.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return errors.Wrapf(err, "can't connect to medusa at %s", c.addr)
+	}
+	defer conn.Close()
+
+	return call(NewMedusaClient(conn))
+}
+
+// IsAlreadyExists returns true if the error is the gRPC ALREADY_EXISTS status
+func IsAlreadyExists(err error) bool {
+	return status.Code(errors.Cause(err)) == codes.AlreadyExists
+}
+
+// PodAddress returns the address of the Medusa sidecar of the pod
+func PodAddress(cc *v1alpha1.CassandraCluster, pod v1.Pod) string {
+	svc := names.DC(cc.Name, pod.Labels[v1alpha1.CassandraClusterDC])
+	return fmt.Sprintf("%s.%s.%s.svc.cluster.local:%d", pod.Name, svc, cc.Namespace, v1alpha1.MedusaPort)
+}
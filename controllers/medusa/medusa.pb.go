@@ -0,0 +1,809 @@
+// The subset of the Medusa gRPC service (https://github.com/thelastpickle/cassandra-medusa/blob/master/medusa/service/grpc/medusa.proto)
+// used by the operator. Regenerate the Go code with `make medusa-grpc` after changing the file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.21.12
+// source: controllers/medusa/medusa.proto
+
+package medusa
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StatusType int32
+
+const (
+	StatusType_IN_PROGRESS StatusType = 0
+	StatusType_SUCCESS     StatusType = 1
+	StatusType_FAILED      StatusType = 2
+	StatusType_UNKNOWN     StatusType = 3
+)
+
+// Enum value maps for StatusType.
+var (
+	StatusType_name = map[int32]string{
+		0: "IN_PROGRESS",
+		1: "SUCCESS",
+		2: "FAILED",
+		3: "UNKNOWN",
+	}
+	StatusType_value = map[string]int32{
+		"IN_PROGRESS": 0,
+		"SUCCESS":     1,
+		"FAILED":      2,
+		"UNKNOWN":     3,
+	}
+)
+
+func (x StatusType) Enum() *StatusType {
+	p := new(StatusType)
+	*p = x
+	return p
+}
+
+func (x StatusType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatusType) Descriptor() protoreflect.EnumDescriptor {
+	return file_controllers_medusa_medusa_proto_enumTypes[0].Descriptor()
+}
+
+func (StatusType) Type() protoreflect.EnumType {
+	return &file_controllers_medusa_medusa_proto_enumTypes[0]
+}
+
+func (x StatusType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatusType.Descriptor instead.
+func (StatusType) EnumDescriptor() ([]byte, []int) {
+	return file_controllers_medusa_medusa_proto_rawDescGZIP(), []int{0}
+}
+
+type BackupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+}
+
+func (x *BackupRequest) Reset() {
+	*x = BackupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_medusa_medusa_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupRequest) ProtoMessage() {}
+
+func (x *BackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_medusa_medusa_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupRequest.ProtoReflect.Descriptor instead.
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return file_controllers_medusa_medusa_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BackupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BackupRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type AsyncBackupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BackupName string `protobuf:"bytes,1,opt,name=backupName,proto3" json:"backupName,omitempty"`
+	Status     string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *AsyncBackupResponse) Reset() {
+	*x = AsyncBackupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_medusa_medusa_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AsyncBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AsyncBackupResponse) ProtoMessage() {}
+
+func (x *AsyncBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_medusa_medusa_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AsyncBackupResponse.ProtoReflect.Descriptor instead.
+func (*AsyncBackupResponse) Descriptor() ([]byte, []int) {
+	return file_controllers_medusa_medusa_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AsyncBackupResponse) GetBackupName() string {
+	if x != nil {
+		return x.BackupName
+	}
+	return ""
+}
+
+func (x *AsyncBackupResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetBackupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetBackupsRequest) Reset() {
+	*x = GetBackupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_medusa_medusa_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupsRequest) ProtoMessage() {}
+
+func (x *GetBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_medusa_medusa_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupsRequest.ProtoReflect.Descriptor instead.
+func (*GetBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_controllers_medusa_medusa_proto_rawDescGZIP(), []int{2}
+}
+
+type GetBackupsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Backups []*BackupSummary `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
+}
+
+func (x *GetBackupsResponse) Reset() {
+	*x = GetBackupsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_medusa_medusa_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBackupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupsResponse) ProtoMessage() {}
+
+func (x *GetBackupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_medusa_medusa_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupsResponse.ProtoReflect.Descriptor instead.
+func (*GetBackupsResponse) Descriptor() ([]byte, []int) {
+	return file_controllers_medusa_medusa_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetBackupsResponse) GetBackups() []*BackupSummary {
+	if x != nil {
+		return x.Backups
+	}
+	return nil
+}
+
+type BackupSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BackupName    string        `protobuf:"bytes,1,opt,name=backupName,proto3" json:"backupName,omitempty"`
+	StartTime     int64         `protobuf:"varint,2,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	FinishTime    int64         `protobuf:"varint,3,opt,name=finishTime,proto3" json:"finishTime,omitempty"`
+	TotalNodes    int32         `protobuf:"varint,4,opt,name=totalNodes,proto3" json:"totalNodes,omitempty"`
+	FinishedNodes int32         `protobuf:"varint,5,opt,name=finishedNodes,proto3" json:"finishedNodes,omitempty"`
+	Nodes         []*BackupNode `protobuf:"bytes,6,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Status        StatusType    `protobuf:"varint,7,opt,name=status,proto3,enum=StatusType" json:"status,omitempty"`
+	BackupType    string        `protobuf:"bytes,8,opt,name=backupType,proto3" json:"backupType,omitempty"`
+	TotalSize     int64         `protobuf:"varint,9,opt,name=totalSize,proto3" json:"totalSize,omitempty"`
+	TotalObjects  int64         `protobuf:"varint,10,opt,name=totalObjects,proto3" json:"totalObjects,omitempty"`
+}
+
+func (x *BackupSummary) Reset() {
+	*x = BackupSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_medusa_medusa_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupSummary) ProtoMessage() {}
+
+func (x *BackupSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_medusa_medusa_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupSummary.ProtoReflect.Descriptor instead.
+func (*BackupSummary) Descriptor() ([]byte, []int) {
+	return file_controllers_medusa_medusa_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BackupSummary) GetBackupName() string {
+	if x != nil {
+		return x.BackupName
+	}
+	return ""
+}
+
+func (x *BackupSummary) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *BackupSummary) GetFinishTime() int64 {
+	if x != nil {
+		return x.FinishTime
+	}
+	return 0
+}
+
+func (x *BackupSummary) GetTotalNodes() int32 {
+	if x != nil {
+		return x.TotalNodes
+	}
+	return 0
+}
+
+func (x *BackupSummary) GetFinishedNodes() int32 {
+	if x != nil {
+		return x.FinishedNodes
+	}
+	return 0
+}
+
+func (x *BackupSummary) GetNodes() []*BackupNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *BackupSummary) GetStatus() StatusType {
+	if x != nil {
+		return x.Status
+	}
+	return StatusType_IN_PROGRESS
+}
+
+func (x *BackupSummary) GetBackupType() string {
+	if x != nil {
+		return x.BackupType
+	}
+	return ""
+}
+
+func (x *BackupSummary) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *BackupSummary) GetTotalObjects() int64 {
+	if x != nil {
+		return x.TotalObjects
+	}
+	return 0
+}
+
+type BackupNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host       string  `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Tokens     []int64 `protobuf:"varint,2,rep,packed,name=tokens,proto3" json:"tokens,omitempty"`
+	Datacenter string  `protobuf:"bytes,3,opt,name=datacenter,proto3" json:"datacenter,omitempty"`
+	Rack       string  `protobuf:"bytes,4,opt,name=rack,proto3" json:"rack,omitempty"`
+}
+
+func (x *BackupNode) Reset() {
+	*x = BackupNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_medusa_medusa_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupNode) ProtoMessage() {}
+
+func (x *BackupNode) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_medusa_medusa_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupNode.ProtoReflect.Descriptor instead.
+func (*BackupNode) Descriptor() ([]byte, []int) {
+	return file_controllers_medusa_medusa_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BackupNode) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *BackupNode) GetTokens() []int64 {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *BackupNode) GetDatacenter() string {
+	if x != nil {
+		return x.Datacenter
+	}
+	return ""
+}
+
+func (x *BackupNode) GetRack() string {
+	if x != nil {
+		return x.Rack
+	}
+	return ""
+}
+
+// Computes the mapping of the backed up nodes to the nodes of the datacenter and stores it on the node under the restore key.
+// The restore is applied by Medusa in restore mode when the node starts with the restore key and the backup name set.
+type PrepareRestoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BackupName string `protobuf:"bytes,1,opt,name=backupName,proto3" json:"backupName,omitempty"`
+	Datacenter string `protobuf:"bytes,2,opt,name=datacenter,proto3" json:"datacenter,omitempty"`
+	RestoreKey string `protobuf:"bytes,3,opt,name=restoreKey,proto3" json:"restoreKey,omitempty"`
+}
+
+func (x *PrepareRestoreRequest) Reset() {
+	*x = PrepareRestoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_medusa_medusa_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrepareRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareRestoreRequest) ProtoMessage() {}
+
+func (x *PrepareRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_medusa_medusa_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareRestoreRequest.ProtoReflect.Descriptor instead.
+func (*PrepareRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_controllers_medusa_medusa_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PrepareRestoreRequest) GetBackupName() string {
+	if x != nil {
+		return x.BackupName
+	}
+	return ""
+}
+
+func (x *PrepareRestoreRequest) GetDatacenter() string {
+	if x != nil {
+		return x.Datacenter
+	}
+	return ""
+}
+
+func (x *PrepareRestoreRequest) GetRestoreKey() string {
+	if x != nil {
+		return x.RestoreKey
+	}
+	return ""
+}
+
+type PrepareRestoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PrepareRestoreResponse) Reset() {
+	*x = PrepareRestoreResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controllers_medusa_medusa_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrepareRestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareRestoreResponse) ProtoMessage() {}
+
+func (x *PrepareRestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controllers_medusa_medusa_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareRestoreResponse.ProtoReflect.Descriptor instead.
+func (*PrepareRestoreResponse) Descriptor() ([]byte, []int) {
+	return file_controllers_medusa_medusa_proto_rawDescGZIP(), []int{7}
+}
+
+var File_controllers_medusa_medusa_proto protoreflect.FileDescriptor
+
+var file_controllers_medusa_medusa_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x2f, 0x6d, 0x65,
+	0x64, 0x75, 0x73, 0x61, 0x2f, 0x6d, 0x65, 0x64, 0x75, 0x73, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x37, 0x0a, 0x0d, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x4d, 0x0a, 0x13, 0x41, 0x73,
+	0x79, 0x6e, 0x63, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3e,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x53, 0x75,
+	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x22, 0xdd,
+	0x02, 0x0a, 0x0d, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x54, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x22, 0x6c,
+	0x0a, 0x0a, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03,
+	0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61,
+	0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x61,
+	0x74, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x63, 0x6b,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x63, 0x6b, 0x22, 0x77, 0x0a, 0x15,
+	0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x75,
+	0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x63, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x63,
+	0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x4b, 0x65, 0x79, 0x22, 0x18, 0x0a, 0x16, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a,
+	0x43, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a,
+	0x0b, 0x49, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x00, 0x12, 0x0b,
+	0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x46,
+	0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x03, 0x32, 0xb7, 0x01, 0x0a, 0x06, 0x4d, 0x65, 0x64, 0x75, 0x73, 0x61, 0x12,
+	0x33, 0x0a, 0x0b, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x0e,
+	0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75,
+	0x70, 0x73, 0x12, 0x12, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x50,
+	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x2e,
+	0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x36,
+	0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x62, 0x6d,
+	0x2f, 0x63, 0x61, 0x73, 0x73, 0x61, 0x6e, 0x64, 0x72, 0x61, 0x2d, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x2f,
+	0x6d, 0x65, 0x64, 0x75, 0x73, 0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_controllers_medusa_medusa_proto_rawDescOnce sync.Once
+	file_controllers_medusa_medusa_proto_rawDescData = file_controllers_medusa_medusa_proto_rawDesc
+)
+
+func file_controllers_medusa_medusa_proto_rawDescGZIP() []byte {
+	file_controllers_medusa_medusa_proto_rawDescOnce.Do(func() {
+		file_controllers_medusa_medusa_proto_rawDescData = protoimpl.X.CompressGZIP(file_controllers_medusa_medusa_proto_rawDescData)
+	})
+	return file_controllers_medusa_medusa_proto_rawDescData
+}
+
+var file_controllers_medusa_medusa_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_controllers_medusa_medusa_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_controllers_medusa_medusa_proto_goTypes = []interface{}{
+	(StatusType)(0),                // 0: StatusType
+	(*BackupRequest)(nil),          // 1: BackupRequest
+	(*AsyncBackupResponse)(nil),    // 2: AsyncBackupResponse
+	(*GetBackupsRequest)(nil),      // 3: GetBackupsRequest
+	(*GetBackupsResponse)(nil),     // 4: GetBackupsResponse
+	(*BackupSummary)(nil),          // 5: BackupSummary
+	(*BackupNode)(nil),             // 6: BackupNode
+	(*PrepareRestoreRequest)(nil),  // 7: PrepareRestoreRequest
+	(*PrepareRestoreResponse)(nil), // 8: PrepareRestoreResponse
+}
+var file_controllers_medusa_medusa_proto_depIdxs = []int32{
+	5, // 0: GetBackupsResponse.backups:type_name -> BackupSummary
+	6, // 1: BackupSummary.nodes:type_name -> BackupNode
+	0, // 2: BackupSummary.status:type_name -> StatusType
+	1, // 3: Medusa.AsyncBackup:input_type -> BackupRequest
+	3, // 4: Medusa.GetBackups:input_type -> GetBackupsRequest
+	7, // 5: Medusa.PrepareRestore:input_type -> PrepareRestoreRequest
+	2, // 6: Medusa.AsyncBackup:output_type -> AsyncBackupResponse
+	4, // 7: Medusa.GetBackups:output_type -> GetBackupsResponse
+	8, // 8: Medusa.PrepareRestore:output_type -> PrepareRestoreResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_controllers_medusa_medusa_proto_init() }
+func file_controllers_medusa_medusa_proto_init() {
+	if File_controllers_medusa_medusa_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_controllers_medusa_medusa_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controllers_medusa_medusa_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AsyncBackupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controllers_medusa_medusa_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBackupsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controllers_medusa_medusa_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBackupsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controllers_medusa_medusa_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controllers_medusa_medusa_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupNode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controllers_medusa_medusa_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareRestoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controllers_medusa_medusa_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareRestoreResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_controllers_medusa_medusa_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_controllers_medusa_medusa_proto_goTypes,
+		DependencyIndexes: file_controllers_medusa_medusa_proto_depIdxs,
+		EnumInfos:         file_controllers_medusa_medusa_proto_enumTypes,
+		MessageInfos:      file_controllers_medusa_medusa_proto_msgTypes,
+	}.Build()
+	File_controllers_medusa_medusa_proto = out.File
+	file_controllers_medusa_medusa_proto_rawDesc = nil
+	file_controllers_medusa_medusa_proto_goTypes = nil
+	file_controllers_medusa_medusa_proto_depIdxs = nil
+}
@@ -0,0 +1,186 @@
+// The subset of the Medusa gRPC service (https://github.com/thelastpickle/cassandra-medusa/blob/master/medusa/service/grpc/medusa.proto)
+// used by the operator. Regenerate the Go code with `make medusa-grpc` after changing the file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.12
+// source: controllers/medusa/medusa.proto
+
+package medusa
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Medusa_AsyncBackup_FullMethodName    = "/Medusa/AsyncBackup"
+	Medusa_GetBackups_FullMethodName     = "/Medusa/GetBackups"
+	Medusa_PrepareRestore_FullMethodName = "/Medusa/PrepareRestore"
+)
+
+// MedusaClient is the client API for Medusa service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MedusaClient interface {
+	AsyncBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*AsyncBackupResponse, error)
+	GetBackups(ctx context.Context, in *GetBackupsRequest, opts ...grpc.CallOption) (*GetBackupsResponse, error)
+	PrepareRestore(ctx context.Context, in *PrepareRestoreRequest, opts ...grpc.CallOption) (*PrepareRestoreResponse, error)
+}
+
+type medusaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMedusaClient(cc grpc.ClientConnInterface) MedusaClient {
+	return &medusaClient{cc}
+}
+
+func (c *medusaClient) AsyncBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*AsyncBackupResponse, error) {
+	out := new(AsyncBackupResponse)
+	err := c.cc.Invoke(ctx, Medusa_AsyncBackup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medusaClient) GetBackups(ctx context.Context, in *GetBackupsRequest, opts ...grpc.CallOption) (*GetBackupsResponse, error) {
+	out := new(GetBackupsResponse)
+	err := c.cc.Invoke(ctx, Medusa_GetBackups_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medusaClient) PrepareRestore(ctx context.Context, in *PrepareRestoreRequest, opts ...grpc.CallOption) (*PrepareRestoreResponse, error) {
+	out := new(PrepareRestoreResponse)
+	err := c.cc.Invoke(ctx, Medusa_PrepareRestore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MedusaServer is the server API for Medusa service.
+// All implementations must embed UnimplementedMedusaServer
+// for forward compatibility
+type MedusaServer interface {
+	AsyncBackup(context.Context, *BackupRequest) (*AsyncBackupResponse, error)
+	GetBackups(context.Context, *GetBackupsRequest) (*GetBackupsResponse, error)
+	PrepareRestore(context.Context, *PrepareRestoreRequest) (*PrepareRestoreResponse, error)
+	mustEmbedUnimplementedMedusaServer()
+}
+
+// UnimplementedMedusaServer must be embedded to have forward compatible implementations.
+type UnimplementedMedusaServer struct {
+}
+
+func (UnimplementedMedusaServer) AsyncBackup(context.Context, *BackupRequest) (*AsyncBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AsyncBackup not implemented")
+}
+func (UnimplementedMedusaServer) GetBackups(context.Context, *GetBackupsRequest) (*GetBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackups not implemented")
+}
+func (UnimplementedMedusaServer) PrepareRestore(context.Context, *PrepareRestoreRequest) (*PrepareRestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareRestore not implemented")
+}
+func (UnimplementedMedusaServer) mustEmbedUnimplementedMedusaServer() {}
+
+// UnsafeMedusaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MedusaServer will
+// result in compilation errors.
+type UnsafeMedusaServer interface {
+	mustEmbedUnimplementedMedusaServer()
+}
+
+func RegisterMedusaServer(s grpc.ServiceRegistrar, srv MedusaServer) {
+	s.RegisterService(&Medusa_ServiceDesc, srv)
+}
+
+func _Medusa_AsyncBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedusaServer).AsyncBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Medusa_AsyncBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedusaServer).AsyncBackup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Medusa_GetBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedusaServer).GetBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Medusa_GetBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedusaServer).GetBackups(ctx, req.(*GetBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Medusa_PrepareRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedusaServer).PrepareRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Medusa_PrepareRestore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedusaServer).PrepareRestore(ctx, req.(*PrepareRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Medusa_ServiceDesc is the grpc.ServiceDesc for Medusa service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Medusa_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Medusa",
+	HandlerType: (*MedusaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AsyncBackup",
+			Handler:    _Medusa_AsyncBackup_Handler,
+		},
+		{
+			MethodName: "GetBackups",
+			Handler:    _Medusa_GetBackups_Handler,
+		},
+		{
+			MethodName: "PrepareRestore",
+			Handler:    _Medusa_PrepareRestore_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "controllers/medusa/medusa.proto",
+}
@@ -0,0 +1,110 @@
+package medusa_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/medusa/fake"
+)
+
+func TestAsyncBackupAndGetBackups(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := fake.NewServer()
+	defer server.Close()
+
+	client := medusa.New(server.Addr())
+	backups, err := client.GetBackups(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups).To(BeEmpty())
+
+	g.Expect(client.AsyncBackup(ctx, "backup-1", medusa.BackupModeDifferential)).To(Succeed())
+	g.Expect(client.AsyncBackup(ctx, "backup-1", medusa.BackupModeDifferential)).To(Succeed())
+	g.Expect(server.Requests("AsyncBackup")).To(Equal(2))
+
+	backups, err = client.GetBackups(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups).To(HaveLen(1))
+	g.Expect(backups[0].BackupName).To(Equal("backup-1"))
+	g.Expect(backups[0].BackupType).To(Equal(medusa.BackupModeDifferential))
+	g.Expect(backups[0].Status).To(Equal(medusa.StatusType_IN_PROGRESS))
+	g.Expect(backups[0].TotalNodes).To(BeEquivalentTo(2))
+	g.Expect(backups[0].FinishedNodes).To(BeEquivalentTo(0))
+	g.Expect(backups[0].StartTime).ToNot(BeZero())
+
+	server.SetBackupStatus("backup-1", medusa.StatusType_SUCCESS)
+	server.AddBackup(&medusa.BackupSummary{
+		BackupName:    "backup-0",
+		StartTime:     1630497600,
+		FinishTime:    1630501200,
+		TotalNodes:    3,
+		FinishedNodes: 3,
+		Nodes:         []*medusa.BackupNode{{Host: "node-1", Tokens: []int64{-100, 100}, Datacenter: "dc1", Rack: "rack1"}},
+		Status:        medusa.StatusType_SUCCESS,
+		BackupType:    medusa.BackupModeFull,
+		TotalSize:     1 << 40,
+		TotalObjects:  1234,
+	})
+
+	backups, err = client.GetBackups(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backups).To(HaveLen(2))
+	g.Expect(backups[0].Status).To(Equal(medusa.StatusType_SUCCESS))
+	g.Expect(backups[0].FinishedNodes).To(BeEquivalentTo(2))
+	g.Expect(proto.Equal(backups[1], &medusa.BackupSummary{
+		BackupName:    "backup-0",
+		StartTime:     1630497600,
+		FinishTime:    1630501200,
+		TotalNodes:    3,
+		FinishedNodes: 3,
+		Nodes:         []*medusa.BackupNode{{Host: "node-1", Tokens: []int64{-100, 100}, Datacenter: "dc1", Rack: "rack1"}},
+		Status:        medusa.StatusType_SUCCESS,
+		BackupType:    medusa.BackupModeFull,
+		TotalSize:     1 << 40,
+		TotalObjects:  1234,
+	})).To(BeTrue())
+}
+
+func TestPrepareRestore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := fake.NewServer()
+	defer server.Close()
+
+	client := medusa.New(server.Addr())
+	g.Expect(client.PrepareRestore(ctx, "backup-1", "dc1", "restore-key")).To(Succeed())
+	g.Expect(server.PreparedRestores()).To(HaveLen(1))
+	g.Expect(proto.Equal(server.PreparedRestores()[0], &medusa.PrepareRestoreRequest{
+		BackupName: "backup-1",
+		Datacenter: "dc1",
+		RestoreKey: "restore-key",
+	})).To(BeTrue())
+}
+
+func TestStatusError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := fake.NewServer()
+	defer server.Close()
+	server.Errors["AsyncBackup"] = status.Error(codes.AlreadyExists, "backup backup-1 already in progress")
+	server.Errors["GetBackups"] = status.Error(codes.Internal, "can't list the bucket: 100% failed")
+
+	client := medusa.New(server.Addr())
+	err := client.AsyncBackup(ctx, "backup-1", medusa.BackupModeDifferential)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(medusa.IsAlreadyExists(err)).To(BeTrue())
+
+	_, err = client.GetBackups(ctx)
+	g.Expect(status.Code(err)).To(Equal(codes.Internal))
+	g.Expect(status.Convert(err).Message()).To(Equal("can't list the bucket: 100% failed"))
+	g.Expect(medusa.IsAlreadyExists(err)).To(BeFalse())
+
+	err = medusa.New("127.0.0.1:1").PrepareRestore(ctx, "backup-1", "dc1", "restore-key")
+	g.Expect(status.Code(err)).To(Equal(codes.Unavailable))
+}
@@ -0,0 +1,53 @@
+package medusa
+
+import (
+	"strings"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+const (
+	// RestoreContainerName is the init container that applies the prepared restore before Cassandra starts
+	RestoreContainerName = "medusa-restore"
+	// RestoreKeyEnvVar is the env var of the restore container with the key of the prepared restore
+	RestoreKeyEnvVar = "RESTORE_KEY"
+	// BackupNameEnvVar is the env var of the restore container with the name of the restored backup
+	BackupNameEnvVar = "BACKUP_NAME"
+)
+
+// RestoreKey identifies the restore on the nodes. Every CassandraRestore gets its own key,
+// so restoring the same backup again restarts the nodes again.
+func RestoreKey(cr *v1alpha1.CassandraRestore) string {
+	return string(cr.UID)
+}
+
+// RestoreBackupName returns the name of the Medusa backup restored by the CassandraRestore
+func RestoreBackupName(cr *v1alpha1.CassandraRestore) string {
+	if len(cr.Spec.SnapshotTag) != 0 {
+		return cr.Spec.SnapshotTag
+	}
+
+	return cr.Spec.CassandraBackup
+}
+
+// RestoreDCs returns the DCs restored by the CassandraRestore: the DCs from .spec.dc or all DCs of the cluster
+func RestoreDCs(cc *v1alpha1.CassandraCluster, cr *v1alpha1.CassandraRestore) []string {
+	var dcs []string
+	for _, dc := range cc.Spec.DCs {
+		if len(cr.Spec.DC) == 0 || containsDC(cr.Spec.DC, dc.Name) {
+			dcs = append(dcs, dc.Name)
+		}
+	}
+
+	return dcs
+}
+
+func containsDC(dcList, dcName string) bool {
+	for _, dc := range strings.Split(dcList, ",") {
+		if strings.TrimSpace(dc) == dcName {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/storage"
+)
+
+const (
+	medusaConfigDir  = "/etc/medusa" // medusa.ini is read from the default location
+	medusaSecretsDir = "/etc/medusa-secrets"
+)
+
+func medusaEnabled(cc *v1alpha1.CassandraCluster) bool {
+	return cc.Spec.BackupEngine == v1alpha1.BackupEngineMedusa && cc.Spec.Medusa != nil
+}
+
+func (r *CassandraClusterReconciler) reconcileMedusaConfigMap(ctx context.Context, cc *v1alpha1.CassandraCluster) error {
+	if !medusaEnabled(cc) {
+		return nil
+	}
+
+	storageCredentials := &v1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: cc.Spec.Medusa.SecretName, Namespace: cc.Namespace}, storageCredentials)
+	if err != nil {
+		return errors.Wrapf(err, "can't get medusa storage credentials secret %s", cc.Spec.Medusa.SecretName)
+	}
+
+	medusaIni, err := medusaConfig(cc, storageCredentials)
+	if err != nil {
+		return err
+	}
+
+	desiredCM := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.MedusaConfigMap(cc.Name),
+			Namespace: cc.Namespace,
+			Labels:    labels.CombinedComponentLabels(cc, v1alpha1.CassandraClusterComponentCassandra),
+		},
+		Data: map[string]string{
+			"medusa.ini": medusaIni,
+		},
+	}
+	if err = controllerutil.SetControllerReference(cc, desiredCM, r.Scheme); err != nil {
+		return errors.Wrap(err, "Cannot set controller reference")
+	}
+	if err = r.reconcileConfigMap(ctx, desiredCM); err != nil {
+		return err
+	}
+	return nil
+}
+
+// medusaConfig renders medusa.ini. The storage credentials are passed to the container as env vars or files,
+// only the region and endpoint are read from the secret.
+func medusaConfig(cc *v1alpha1.CassandraCluster, storageCredentials *v1.Secret) (string, error) {
+	location, err := storage.ParseLocation(cc.Spec.Medusa.StorageLocation)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid medusa storage location")
+	}
+
+	prefix := location.Path
+	if len(prefix) == 0 {
+		prefix = cc.Name
+	}
+
+	storageConfig := []string{
+		"storage_provider = " + medusaStorageProvider(location.Provider),
+		"bucket_name = " + location.Bucket,
+		"prefix = " + prefix,
+	}
+
+	switch location.Provider {
+	case v1alpha1.StorageProviderGCP:
+		storageConfig = append(storageConfig, "key_file = "+medusaSecretsDir+"/gcp")
+	default:
+		if region := string(storageCredentials.Data["awsregion"]); len(region) != 0 {
+			storageConfig = append(storageConfig, "region = "+region)
+		}
+
+		if endpoint := string(storageCredentials.Data["awsendpoint"]); len(endpoint) != 0 {
+			endpointURL, err := url.Parse(endpoint)
+			if err != nil || len(endpointURL.Hostname()) == 0 {
+				return "", errors.Errorf("invalid 'awsendpoint' %q in secret %s", endpoint, storageCredentials.Name)
+			}
+
+			storageConfig = append(storageConfig, "host = "+endpointURL.Hostname())
+			if len(endpointURL.Port()) != 0 {
+				storageConfig = append(storageConfig, "port = "+endpointURL.Port())
+			}
+			storageConfig = append(storageConfig, fmt.Sprintf("secure = %t", endpointURL.Scheme != "http"))
+		}
+	}
+
+	return fmt.Sprintf(`[cassandra]
+config_file = %s/cassandra.yaml
+use_sudo = False
+
+[storage]
+%s
+
+[grpc]
+enabled = 1
+
+[logging]
+level = INFO
+`, cassandraDCConfigVolumeMount().MountPath, strings.Join(storageConfig, "\n")), nil
+}
+
+func medusaStorageProvider(provider v1alpha1.StorageProvider) string {
+	switch provider {
+	case v1alpha1.StorageProviderS3:
+		return "s3"
+	case v1alpha1.StorageProviderGCP:
+		return "google_storage"
+	default:
+		return "s3_compatible"
+	}
+}
+
+func medusaConfigVolume(cc *v1alpha1.CassandraCluster) v1.Volume {
+	return v1.Volume{
+		Name: "medusa-config",
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: names.MedusaConfigMap(cc.Name),
+				},
+				DefaultMode: proto.Int32(v1.ConfigMapVolumeSourceDefaultMode),
+			},
+		},
+	}
+}
+
+func medusaConfigVolumeMount() v1.VolumeMount {
+	return v1.VolumeMount{
+		Name:      "medusa-config",
+		MountPath: medusaConfigDir,
+	}
+}
+
+func medusaSecretsVolume(cc *v1alpha1.CassandraCluster) v1.Volume {
+	return v1.Volume{
+		Name: "medusa-secrets",
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName:  cc.Spec.Medusa.SecretName,
+				DefaultMode: proto.Int32(v1.SecretVolumeSourceDefaultMode),
+			},
+		},
+	}
+}
+
+func medusaSecretsVolumeMount() v1.VolumeMount {
+	return v1.VolumeMount{
+		Name:      "medusa-secrets",
+		MountPath: medusaSecretsDir,
+	}
+}
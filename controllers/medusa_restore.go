@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+// reconcileMedusaRestore records the latest Medusa restore prepared on the nodes in the status.
+// The statefulsets of the restored DCs get the medusa-restore init container which applies the restore when the nodes are restarted.
+func (r *CassandraClusterReconciler) reconcileMedusaRestore(ctx context.Context, cc *v1alpha1.CassandraCluster) error {
+	if !medusaEnabled(cc) {
+		cc.Status.MedusaRestore = nil
+		return nil
+	}
+
+	restores := &v1alpha1.CassandraRestoreList{}
+	if err := r.List(ctx, restores, client.InNamespace(cc.Namespace)); err != nil {
+		return errors.Wrap(err, "can't get restores")
+	}
+
+	var latestRestore *v1alpha1.CassandraRestore
+	for i, restore := range restores.Items {
+		// the restore controller persists the restore key once the restore is prepared on all nodes
+		if restore.Spec.CassandraCluster != cc.Name || restore.Status.State != icarus.StateRunning ||
+			!util.Contains(restore.Status.OperationIDs, medusa.RestoreKey(&restore)) {
+			continue
+		}
+
+		if latestRestore == nil || latestRestore.CreationTimestamp.Before(&restore.CreationTimestamp) {
+			latestRestore = &restores.Items[i]
+		}
+	}
+
+	if latestRestore == nil || (cc.Status.MedusaRestore != nil && cc.Status.MedusaRestore.RestoreKey == medusa.RestoreKey(latestRestore)) {
+		return nil
+	}
+
+	cc.Status.MedusaRestore = &v1alpha1.MedusaRestore{
+		Restore:    latestRestore.Name,
+		RestoreKey: medusa.RestoreKey(latestRestore),
+		BackupName: medusa.RestoreBackupName(latestRestore),
+		DCs:        medusa.RestoreDCs(cc, latestRestore),
+	}
+
+	msg := fmt.Sprintf("Restarting the nodes of DCs %s to restore Medusa backup %s of restore %s",
+		strings.Join(cc.Status.MedusaRestore.DCs, ", "), cc.Status.MedusaRestore.BackupName, latestRestore.Name)
+	r.Log.Info(msg)
+	r.Events.Normal(cc, events.EventMedusaRestoreStarted, msg)
+	return nil
+}
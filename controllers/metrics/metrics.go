@@ -0,0 +1,280 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "cassandra_operator"
+
+	unknownErrorSource = "unknown"
+)
+
+var (
+	backupLastSuccessTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix timestamp of the last successful backup of the cluster",
+	}, []string{"namespace", "cluster"})
+
+	backupLastDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "last_duration_seconds",
+		Help:      "Duration of the last successful backup of the cluster",
+	}, []string{"namespace", "cluster"})
+
+	backupProgress = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "progress_ratio",
+		Help:      "Progress of the backup from 0 to 1",
+	}, []string{"namespace", "cluster", "backup"})
+
+	backupFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "backup",
+		Name:      "failures_total",
+		Help:      "Number of failed backups of the cluster by error source",
+	}, []string{"namespace", "cluster", "source"})
+
+	restoreState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "restore",
+		Name:      "state",
+		Help:      "State of the restore. Set to 1 for the current state and 0 for the others",
+	}, []string{"namespace", "cluster", "restore", "state"})
+
+	restoreProgress = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "restore",
+		Name:      "progress_ratio",
+		Help:      "Progress of the restore from 0 to 1",
+	}, []string{"namespace", "cluster", "restore"})
+
+	restoreFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "restore",
+		Name:      "failures_total",
+		Help:      "Number of failed restores of the cluster by error source",
+	}, []string{"namespace", "cluster", "source"})
+
+	repairGCGraceSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "repair",
+		Name:      "gc_grace_seconds",
+		Help:      "gc_grace_seconds of the table",
+	}, []string{"namespace", "cluster", "keyspace", "table"})
+
+	repairLastCompleteTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "repair",
+		Name:      "last_complete_timestamp_seconds",
+		Help:      "Unix timestamp of the end of the last repair run that repaired the table on all nodes. Not set if the table has never been repaired",
+	}, []string{"namespace", "cluster", "keyspace", "table"})
+
+	// clusters of the backups and restores with metrics, used to remove the metrics of deleted resources
+	backupClusters  = make(map[types.NamespacedName]string)
+	restoreClusters = make(map[types.NamespacedName]string)
+	// completion time of the last successful backup per cluster
+	lastSuccessfulBackups = make(map[string]time.Time)
+	operationsLock        sync.Mutex
+
+	// tables with repair coverage metrics per cluster, used to remove the metrics of dropped tables and deleted clusters
+	repairCoverageTables     = make(map[types.NamespacedName][]TableRepairCoverage)
+	repairCoverageTablesLock sync.Mutex
+
+	operationStates = []string{icarus.StatePending, icarus.StateRunning, icarus.StateCompleted, icarus.StateCancelled, icarus.StateFailed}
+)
+
+func init() {
+	// exposed on the operator metrics endpoint along with the controller-runtime metrics
+	crmetrics.Registry.MustRegister(
+		backupLastSuccessTimestamp,
+		backupLastDuration,
+		backupProgress,
+		backupFailures,
+		restoreState,
+		restoreProgress,
+		restoreFailures,
+		repairGCGraceSeconds,
+		repairLastCompleteTimestamp,
+	)
+}
+
+// TableRepairCoverage is the last complete repair of a table
+type TableRepairCoverage struct {
+	Keyspace       string
+	Table          string
+	GCGraceSeconds int32
+	// End time of the last repair run that repaired the table on all nodes. Nil if the table has never been repaired.
+	LastCompleteRepair *time.Time
+	// One of the v1alpha1.RepairCoverageState* states
+	State string
+}
+
+// ObserveBackup counts the failures of the backup. Must be called before the status of the CassandraBackup is updated
+// so that state transitions can be detected.
+func ObserveBackup(cb *v1alpha1.CassandraBackup, icarusBackup icarus.Backup) {
+	if cb.Status.State != icarusBackup.State && icarusBackup.State == icarus.StateFailed {
+		for _, source := range errorSources(icarusBackup.Errors) {
+			backupFailures.WithLabelValues(cb.Namespace, cb.Spec.CassandraCluster, source).Inc()
+		}
+	}
+}
+
+// UpdateBackupMetrics sets the backup gauges from the status of the CassandraBackup.
+// The last success gauges of the cluster are set from its most recently completed backup.
+func UpdateBackupMetrics(cb *v1alpha1.CassandraBackup) {
+	operationsLock.Lock()
+	defer operationsLock.Unlock()
+
+	backupClusters[types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}] = cb.Spec.CassandraCluster
+	backupProgress.WithLabelValues(cb.Namespace, cb.Spec.CassandraCluster, cb.Name).Set(float64(cb.Status.Progress) / 100)
+
+	if cb.Status.State != icarus.StateCompleted || cb.Status.CompletionTime == nil {
+		return
+	}
+
+	clusterKey := cb.Namespace + "/" + cb.Spec.CassandraCluster
+	if !cb.Status.CompletionTime.Time.After(lastSuccessfulBackups[clusterKey]) {
+		return
+	}
+
+	lastSuccessfulBackups[clusterKey] = cb.Status.CompletionTime.Time
+	backupLastSuccessTimestamp.WithLabelValues(cb.Namespace, cb.Spec.CassandraCluster).Set(float64(cb.Status.CompletionTime.Unix()))
+	if cb.Status.StartTime != nil {
+		backupLastDuration.WithLabelValues(cb.Namespace, cb.Spec.CassandraCluster).Set(cb.Status.CompletionTime.Sub(cb.Status.StartTime.Time).Seconds())
+	} else {
+		backupLastDuration.DeleteLabelValues(cb.Namespace, cb.Spec.CassandraCluster)
+	}
+}
+
+// ForgetBackup removes the metrics of a deleted CassandraBackup
+func ForgetBackup(backup types.NamespacedName) {
+	operationsLock.Lock()
+	defer operationsLock.Unlock()
+
+	cluster, found := backupClusters[backup]
+	if !found {
+		return
+	}
+
+	backupProgress.DeleteLabelValues(backup.Namespace, cluster, backup.Name)
+	delete(backupClusters, backup)
+}
+
+// ObserveRestore counts the failures of the restore. Must be called before the status of the CassandraRestore is updated
+// so that state transitions can be detected.
+func ObserveRestore(cr *v1alpha1.CassandraRestore, icarusRestore icarus.Restore) {
+	if cr.Status.State != icarusRestore.State && icarusRestore.State == icarus.StateFailed {
+		for _, source := range errorSources(icarusRestore.Errors) {
+			restoreFailures.WithLabelValues(cr.Namespace, cr.Spec.CassandraCluster, source).Inc()
+		}
+	}
+}
+
+// UpdateRestoreMetrics sets the restore gauges from the status of the CassandraRestore
+func UpdateRestoreMetrics(cr *v1alpha1.CassandraRestore) {
+	operationsLock.Lock()
+	defer operationsLock.Unlock()
+
+	restoreClusters[types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}] = cr.Spec.CassandraCluster
+	restoreProgress.WithLabelValues(cr.Namespace, cr.Spec.CassandraCluster, cr.Name).Set(float64(cr.Status.Progress) / 100)
+	for _, state := range operationStates {
+		value := 0.0
+		if state == cr.Status.State {
+			value = 1
+		}
+		restoreState.WithLabelValues(cr.Namespace, cr.Spec.CassandraCluster, cr.Name, state).Set(value)
+	}
+}
+
+// ForgetRestore removes the metrics of a deleted CassandraRestore
+func ForgetRestore(restore types.NamespacedName) {
+	operationsLock.Lock()
+	defer operationsLock.Unlock()
+
+	cluster, found := restoreClusters[restore]
+	if !found {
+		return
+	}
+
+	restoreProgress.DeleteLabelValues(restore.Namespace, cluster, restore.Name)
+	for _, state := range operationStates {
+		restoreState.DeleteLabelValues(restore.Namespace, cluster, restore.Name, state)
+	}
+	delete(restoreClusters, restore)
+}
+
+// ObserveRepairCoverage updates the repair coverage metrics of the cluster's tables
+func ObserveRepairCoverage(cc *v1alpha1.CassandraCluster, coverage []TableRepairCoverage) {
+	repairCoverageTablesLock.Lock()
+	defer repairCoverageTablesLock.Unlock()
+
+	cluster := types.NamespacedName{Namespace: cc.Namespace, Name: cc.Name}
+	current := make(map[string]bool, len(coverage))
+	for _, table := range coverage {
+		current[table.Keyspace+"."+table.Table] = true
+		repairGCGraceSeconds.WithLabelValues(cc.Namespace, cc.Name, table.Keyspace, table.Table).Set(float64(table.GCGraceSeconds))
+		if table.LastCompleteRepair != nil {
+			repairLastCompleteTimestamp.WithLabelValues(cc.Namespace, cc.Name, table.Keyspace, table.Table).Set(float64(table.LastCompleteRepair.Unix()))
+		} else {
+			repairLastCompleteTimestamp.DeleteLabelValues(cc.Namespace, cc.Name, table.Keyspace, table.Table)
+		}
+	}
+
+	for _, table := range repairCoverageTables[cluster] {
+		if !current[table.Keyspace+"."+table.Table] {
+			deleteTableRepairCoverage(cluster, table)
+		}
+	}
+
+	repairCoverageTables[cluster] = coverage
+}
+
+// ForgetRepairCoverage removes the repair coverage metrics of a deleted CassandraCluster
+func ForgetRepairCoverage(cluster types.NamespacedName) {
+	repairCoverageTablesLock.Lock()
+	defer repairCoverageTablesLock.Unlock()
+
+	for _, table := range repairCoverageTables[cluster] {
+		deleteTableRepairCoverage(cluster, table)
+	}
+	delete(repairCoverageTables, cluster)
+}
+
+func deleteTableRepairCoverage(cluster types.NamespacedName, table TableRepairCoverage) {
+	repairGCGraceSeconds.DeleteLabelValues(cluster.Namespace, cluster.Name, table.Keyspace, table.Table)
+	repairLastCompleteTimestamp.DeleteLabelValues(cluster.Namespace, cluster.Name, table.Keyspace, table.Table)
+}
+
+func errorSources(errors []icarus.Error) []string {
+	if len(errors) == 0 {
+		return []string{unknownErrorSource}
+	}
+
+	var sources []string
+	seen := make(map[string]bool)
+	for _, err := range errors {
+		source := err.Source
+		if len(source) == 0 {
+			source = unknownErrorSource
+		}
+
+		if !seen[source] {
+			seen[source] = true
+			sources = append(sources, source)
+		}
+	}
+
+	return sources
+}
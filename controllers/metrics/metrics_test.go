@@ -0,0 +1,142 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestObserveBackup(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cb := &v1alpha1.CassandraBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ns"},
+		Spec:       v1alpha1.CassandraBackupSpec{CassandraCluster: "cluster"},
+		Status:     v1alpha1.CassandraBackupStatus{State: icarus.StateRunning},
+	}
+
+	failedBackup := icarus.Backup{
+		State: icarus.StateFailed,
+		Errors: []icarus.Error{
+			{Source: "node1", Message: "upload failed"},
+			{Source: "node1", Message: "upload failed again"},
+			{Source: "node2", Message: "upload failed"},
+		},
+	}
+	ObserveBackup(cb, failedBackup)
+	g.Expect(testutil.ToFloat64(backupFailures.WithLabelValues("ns", "cluster", "node1"))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(backupFailures.WithLabelValues("ns", "cluster", "node2"))).To(Equal(1.0))
+
+	cb.Status.State = icarus.StateFailed
+	ObserveBackup(cb, failedBackup)
+	g.Expect(testutil.ToFloat64(backupFailures.WithLabelValues("ns", "cluster", "node1"))).To(Equal(1.0), "failures should be counted only on state transition")
+}
+
+func TestUpdateBackupMetrics(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cb := &v1alpha1.CassandraBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ns"},
+		Spec:       v1alpha1.CassandraBackupSpec{CassandraCluster: "update-cluster"},
+		Status:     v1alpha1.CassandraBackupStatus{State: icarus.StateRunning, Progress: 40},
+	}
+
+	UpdateBackupMetrics(cb)
+	g.Expect(testutil.ToFloat64(backupProgress.WithLabelValues("ns", "update-cluster", "backup"))).To(Equal(0.4))
+	g.Expect(testutil.CollectAndCount(backupLastSuccessTimestamp)).To(Equal(0))
+
+	startTime := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	cb.Status = v1alpha1.CassandraBackupStatus{
+		State:          icarus.StateCompleted,
+		Progress:       100,
+		StartTime:      &metav1.Time{Time: startTime},
+		CompletionTime: &metav1.Time{Time: startTime.Add(time.Minute)},
+	}
+	UpdateBackupMetrics(cb)
+	g.Expect(testutil.ToFloat64(backupLastSuccessTimestamp.WithLabelValues("ns", "update-cluster"))).To(Equal(float64(startTime.Add(time.Minute).Unix())))
+	g.Expect(testutil.ToFloat64(backupLastDuration.WithLabelValues("ns", "update-cluster"))).To(Equal(60.0))
+
+	olderBackup := cb.DeepCopy()
+	olderBackup.Name = "older-backup"
+	olderBackup.Status.StartTime = &metav1.Time{Time: startTime.Add(-time.Hour)}
+	olderBackup.Status.CompletionTime = &metav1.Time{Time: startTime.Add(-time.Hour + time.Second)}
+	UpdateBackupMetrics(olderBackup)
+	g.Expect(testutil.ToFloat64(backupLastSuccessTimestamp.WithLabelValues("ns", "update-cluster"))).To(Equal(float64(startTime.Add(time.Minute).Unix())), "an older backup should not override the last success")
+	g.Expect(testutil.ToFloat64(backupLastDuration.WithLabelValues("ns", "update-cluster"))).To(Equal(60.0))
+
+	ForgetBackup(types.NamespacedName{Namespace: "ns", Name: "backup"})
+	g.Expect(testutil.CollectAndCount(backupProgress)).To(Equal(1), "only the progress of the older backup should be left")
+	g.Expect(testutil.ToFloat64(backupLastSuccessTimestamp.WithLabelValues("ns", "update-cluster"))).To(Equal(float64(startTime.Add(time.Minute).Unix())))
+	ForgetBackup(types.NamespacedName{Namespace: "ns", Name: "older-backup"})
+	g.Expect(testutil.CollectAndCount(backupProgress)).To(Equal(0))
+}
+
+func TestObserveRestore(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cr := &v1alpha1.CassandraRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "ns"},
+		Spec:       v1alpha1.CassandraRestoreSpec{CassandraCluster: "cluster"},
+		Status:     v1alpha1.CassandraRestoreStatus{State: icarus.StateRunning},
+	}
+
+	ObserveRestore(cr, icarus.Restore{State: icarus.StateFailed})
+	g.Expect(testutil.ToFloat64(restoreFailures.WithLabelValues("ns", "cluster", unknownErrorSource))).To(Equal(1.0))
+
+	cr.Status.State = icarus.StateFailed
+	ObserveRestore(cr, icarus.Restore{State: icarus.StateFailed})
+	g.Expect(testutil.ToFloat64(restoreFailures.WithLabelValues("ns", "cluster", unknownErrorSource))).To(Equal(1.0), "failures should be counted only on state transition")
+}
+
+func TestUpdateRestoreMetrics(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cr := &v1alpha1.CassandraRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "ns"},
+		Spec:       v1alpha1.CassandraRestoreSpec{CassandraCluster: "cluster"},
+		Status:     v1alpha1.CassandraRestoreStatus{State: icarus.StateRunning, Progress: 50},
+	}
+
+	UpdateRestoreMetrics(cr)
+	g.Expect(testutil.ToFloat64(restoreProgress.WithLabelValues("ns", "cluster", "restore"))).To(Equal(0.5))
+	g.Expect(testutil.ToFloat64(restoreState.WithLabelValues("ns", "cluster", "restore", icarus.StateRunning))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(restoreState.WithLabelValues("ns", "cluster", "restore", icarus.StateFailed))).To(Equal(0.0))
+
+	cr.Status.State = icarus.StateFailed
+	UpdateRestoreMetrics(cr)
+	g.Expect(testutil.ToFloat64(restoreState.WithLabelValues("ns", "cluster", "restore", icarus.StateRunning))).To(Equal(0.0))
+	g.Expect(testutil.ToFloat64(restoreState.WithLabelValues("ns", "cluster", "restore", icarus.StateFailed))).To(Equal(1.0))
+
+	ForgetRestore(types.NamespacedName{Namespace: "ns", Name: "restore"})
+	g.Expect(testutil.CollectAndCount(restoreProgress)).To(Equal(0))
+	g.Expect(testutil.CollectAndCount(restoreState)).To(Equal(0))
+}
+
+func TestObserveRepairCoverage(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "ns"}}
+	lastRepair := time.Date(2021, 9, 20, 12, 0, 0, 0, time.UTC)
+
+	ObserveRepairCoverage(cc, []TableRepairCoverage{
+		{Keyspace: "ks", Table: "table1", GCGraceSeconds: 864000, LastCompleteRepair: &lastRepair},
+		{Keyspace: "ks", Table: "table2", GCGraceSeconds: 3600},
+	})
+	g.Expect(testutil.ToFloat64(repairGCGraceSeconds.WithLabelValues("ns", "cluster", "ks", "table1"))).To(Equal(864000.0))
+	g.Expect(testutil.ToFloat64(repairLastCompleteTimestamp.WithLabelValues("ns", "cluster", "ks", "table1"))).To(Equal(float64(lastRepair.Unix())))
+	g.Expect(testutil.CollectAndCount(repairGCGraceSeconds)).To(Equal(2))
+	g.Expect(testutil.CollectAndCount(repairLastCompleteTimestamp)).To(Equal(1), "not set for never repaired tables")
+
+	// table1 dropped
+	ObserveRepairCoverage(cc, []TableRepairCoverage{
+		{Keyspace: "ks", Table: "table2", GCGraceSeconds: 3600},
+	})
+	g.Expect(testutil.CollectAndCount(repairGCGraceSeconds)).To(Equal(1))
+	g.Expect(testutil.CollectAndCount(repairLastCompleteTimestamp)).To(Equal(0))
+
+	// cluster deleted
+	ForgetRepairCoverage(types.NamespacedName{Namespace: "ns", Name: "cluster"})
+	g.Expect(testutil.CollectAndCount(repairGCGraceSeconds)).To(Equal(0))
+	g.Expect(repairCoverageTables).To(BeEmpty())
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	reflect "reflect"
 
+	gocql "github.com/gocql/gocql"
 	gomock "github.com/golang/mock/gomock"
 	cql "github.com/ibm/cassandra-operator/controllers/cql"
 )
@@ -104,6 +105,21 @@ func (mr *MockCqlClientMockRecorder) GetRoles() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockCqlClient)(nil).GetRoles))
 }
 
+// GetTablesInfo mocks base method.
+func (m *MockCqlClient) GetTablesInfo() ([]cql.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTablesInfo")
+	ret0, _ := ret[0].([]cql.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTablesInfo indicates an expected call of GetTablesInfo.
+func (mr *MockCqlClientMockRecorder) GetTablesInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTablesInfo", reflect.TypeOf((*MockCqlClient)(nil).GetTablesInfo))
+}
+
 // Query mocks base method.
 func (m *MockCqlClient) Query(stmt string, values ...interface{}) error {
 	m.ctrl.T.Helper()
@@ -123,6 +139,21 @@ func (mr *MockCqlClientMockRecorder) Query(stmt interface{}, values ...interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockCqlClient)(nil).Query), varargs...)
 }
 
+// ReadSample mocks base method.
+func (m *MockCqlClient) ReadSample(keyspace, table string, consistency gocql.Consistency) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSample", keyspace, table, consistency)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSample indicates an expected call of ReadSample.
+func (mr *MockCqlClientMockRecorder) ReadSample(keyspace, table, consistency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSample", reflect.TypeOf((*MockCqlClient)(nil).ReadSample), keyspace, table, consistency)
+}
+
 // UpdateRF mocks base method.
 func (m *MockCqlClient) UpdateRF(keyspaceName string, strategyOptions map[string]string) error {
 	m.ctrl.T.Helper()
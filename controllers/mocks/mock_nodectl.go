@@ -49,6 +49,20 @@ func (mr *MockNodectlMockRecorder) Assassinate(ctx, execNodeIP, assassinateNodeI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assassinate", reflect.TypeOf((*MockNodectl)(nil).Assassinate), ctx, execNodeIP, assassinateNodeIP)
 }
 
+// ClearSnapshot mocks base method.
+func (m *MockNodectl) ClearSnapshot(ctx context.Context, nodeIP, tag string, keyspaces []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearSnapshot", ctx, nodeIP, tag, keyspaces)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearSnapshot indicates an expected call of ClearSnapshot.
+func (mr *MockNodectlMockRecorder) ClearSnapshot(ctx, nodeIP, tag, keyspaces interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearSnapshot", reflect.TypeOf((*MockNodectl)(nil).ClearSnapshot), ctx, nodeIP, tag, keyspaces)
+}
+
 // ClusterView mocks base method.
 func (m *MockNodectl) ClusterView(ctx context.Context, nodeIP string) (nodectl.ClusterView, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +107,96 @@ func (mr *MockNodectlMockRecorder) OperationMode(ctx, nodeIP interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OperationMode", reflect.TypeOf((*MockNodectl)(nil).OperationMode), ctx, nodeIP)
 }
 
+// RepairAsync mocks base method.
+func (m *MockNodectl) RepairAsync(ctx context.Context, nodeIP, keyspace string, options map[string]string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairAsync", ctx, nodeIP, keyspace, options)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairAsync indicates an expected call of RepairAsync.
+func (mr *MockNodectlMockRecorder) RepairAsync(ctx, nodeIP, keyspace, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairAsync", reflect.TypeOf((*MockNodectl)(nil).RepairAsync), ctx, nodeIP, keyspace, options)
+}
+
+// RepairStatus mocks base method.
+func (m *MockNodectl) RepairStatus(ctx context.Context, nodeIP string, command int) (string, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairStatus", ctx, nodeIP, command)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RepairStatus indicates an expected call of RepairStatus.
+func (mr *MockNodectlMockRecorder) RepairStatus(ctx, nodeIP, command interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairStatus", reflect.TypeOf((*MockNodectl)(nil).RepairStatus), ctx, nodeIP, command)
+}
+
+// SnapshotsSize mocks base method.
+func (m *MockNodectl) SnapshotsSize(ctx context.Context, nodeIP string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotsSize", ctx, nodeIP)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotsSize indicates an expected call of SnapshotsSize.
+func (mr *MockNodectlMockRecorder) SnapshotsSize(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotsSize", reflect.TypeOf((*MockNodectl)(nil).SnapshotsSize), ctx, nodeIP)
+}
+
+// TableStats mocks base method.
+func (m *MockNodectl) TableStats(ctx context.Context, nodeIP, keyspace, table string) (nodectl.TableStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TableStats", ctx, nodeIP, keyspace, table)
+	ret0, _ := ret[0].(nodectl.TableStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TableStats indicates an expected call of TableStats.
+func (mr *MockNodectlMockRecorder) TableStats(ctx, nodeIP, keyspace, table interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TableStats", reflect.TypeOf((*MockNodectl)(nil).TableStats), ctx, nodeIP, keyspace, table)
+}
+
+// TakeSnapshot mocks base method.
+func (m *MockNodectl) TakeSnapshot(ctx context.Context, nodeIP, tag string, keyspaces []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeSnapshot", ctx, nodeIP, tag, keyspaces)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeSnapshot indicates an expected call of TakeSnapshot.
+func (mr *MockNodectlMockRecorder) TakeSnapshot(ctx, nodeIP, tag, keyspaces interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeSnapshot", reflect.TypeOf((*MockNodectl)(nil).TakeSnapshot), ctx, nodeIP, tag, keyspaces)
+}
+
+// TokenRing mocks base method.
+func (m *MockNodectl) TokenRing(ctx context.Context, nodeIP string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenRing", ctx, nodeIP)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokenRing indicates an expected call of TokenRing.
+func (mr *MockNodectlMockRecorder) TokenRing(ctx, nodeIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenRing", reflect.TypeOf((*MockNodectl)(nil).TokenRing), ctx, nodeIP)
+}
+
 // Version mocks base method.
 func (m *MockNodectl) Version(ctx context.Context, nodeIP string) (int, int, int, error) {
 	m.ctrl.T.Helper()
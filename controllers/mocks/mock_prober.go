@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	v1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	prober "github.com/ibm/cassandra-operator/controllers/prober"
 )
 
 // MockProberClient is a mock of ProberClient interface.
@@ -35,6 +36,21 @@ func (m *MockProberClient) EXPECT() *MockProberClientMockRecorder {
 	return m.recorder
 }
 
+// GetClusterView mocks base method.
+func (m *MockProberClient) GetClusterView(ctx context.Context) ([]prober.NodeView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusterView", ctx)
+	ret0, _ := ret[0].([]prober.NodeView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClusterView indicates an expected call of GetClusterView.
+func (mr *MockProberClientMockRecorder) GetClusterView(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterView", reflect.TypeOf((*MockProberClient)(nil).GetClusterView), ctx)
+}
+
 // GetDCs mocks base method.
 func (m *MockProberClient) GetDCs(ctx context.Context, host string) ([]v1alpha1.DC, error) {
 	m.ctrl.T.Helper()
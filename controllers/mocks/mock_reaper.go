@@ -50,6 +50,20 @@ func (mr *MockReaperClientMockRecorder) AddCluster(ctx, seed interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCluster", reflect.TypeOf((*MockReaperClient)(nil).AddCluster), ctx, seed)
 }
 
+// AddClusterWithCredentials mocks base method.
+func (m *MockReaperClient) AddClusterWithCredentials(ctx context.Context, seed, jmxUsername, jmxPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddClusterWithCredentials", ctx, seed, jmxUsername, jmxPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddClusterWithCredentials indicates an expected call of AddClusterWithCredentials.
+func (mr *MockReaperClientMockRecorder) AddClusterWithCredentials(ctx, seed, jmxUsername, jmxPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddClusterWithCredentials", reflect.TypeOf((*MockReaperClient)(nil).AddClusterWithCredentials), ctx, seed, jmxUsername, jmxPassword)
+}
+
 // ClusterExists mocks base method.
 func (m *MockReaperClient) ClusterExists(ctx context.Context) (bool, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +94,21 @@ func (mr *MockReaperClientMockRecorder) Clusters(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clusters", reflect.TypeOf((*MockReaperClient)(nil).Clusters), ctx)
 }
 
+// CreateRepairRun mocks base method.
+func (m *MockReaperClient) CreateRepairRun(ctx context.Context, repair v1alpha1.CassandraRepairSpec, cause string) (reaper.RepairRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRepairRun", ctx, repair, cause)
+	ret0, _ := ret[0].(reaper.RepairRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRepairRun indicates an expected call of CreateRepairRun.
+func (mr *MockReaperClientMockRecorder) CreateRepairRun(ctx, repair, cause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepairRun", reflect.TypeOf((*MockReaperClient)(nil).CreateRepairRun), ctx, repair, cause)
+}
+
 // CreateRepairSchedule mocks base method.
 func (m *MockReaperClient) CreateRepairSchedule(ctx context.Context, repair v1alpha1.RepairSchedule) error {
 	m.ctrl.T.Helper()
@@ -108,6 +137,20 @@ func (mr *MockReaperClientMockRecorder) DeleteCluster(ctx interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCluster", reflect.TypeOf((*MockReaperClient)(nil).DeleteCluster), ctx)
 }
 
+// DeleteRepairRun mocks base method.
+func (m *MockReaperClient) DeleteRepairRun(ctx context.Context, repairRun reaper.RepairRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRepairRun", ctx, repairRun)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRepairRun indicates an expected call of DeleteRepairRun.
+func (mr *MockReaperClientMockRecorder) DeleteRepairRun(ctx, repairRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRepairRun", reflect.TypeOf((*MockReaperClient)(nil).DeleteRepairRun), ctx, repairRun)
+}
+
 // DeleteRepairSchedule mocks base method.
 func (m *MockReaperClient) DeleteRepairSchedule(ctx context.Context, repairScheduleID string) error {
 	m.ctrl.T.Helper()
@@ -137,6 +180,21 @@ func (mr *MockReaperClientMockRecorder) IsRunning(ctx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRunning", reflect.TypeOf((*MockReaperClient)(nil).IsRunning), ctx)
 }
 
+// RepairRuns mocks base method.
+func (m *MockReaperClient) RepairRuns(ctx context.Context, keyspace string) ([]reaper.RepairRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairRuns", ctx, keyspace)
+	ret0, _ := ret[0].([]reaper.RepairRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairRuns indicates an expected call of RepairRuns.
+func (mr *MockReaperClientMockRecorder) RepairRuns(ctx, keyspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairRuns", reflect.TypeOf((*MockReaperClient)(nil).RepairRuns), ctx, keyspace)
+}
+
 // RepairSchedules mocks base method.
 func (m *MockReaperClient) RepairSchedules(ctx context.Context) ([]reaper.RepairSchedule, error) {
 	m.ctrl.T.Helper()
@@ -166,6 +224,20 @@ func (mr *MockReaperClientMockRecorder) RunRepair(ctx, keyspace, cause interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRepair", reflect.TypeOf((*MockReaperClient)(nil).RunRepair), ctx, keyspace, cause)
 }
 
+// SetRepairRunState mocks base method.
+func (m *MockReaperClient) SetRepairRunState(ctx context.Context, runID, state string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRepairRunState", ctx, runID, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRepairRunState indicates an expected call of SetRepairRunState.
+func (mr *MockReaperClientMockRecorder) SetRepairRunState(ctx, runID, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRepairRunState", reflect.TypeOf((*MockReaperClient)(nil).SetRepairRunState), ctx, runID, state)
+}
+
 // SetRepairScheduleState mocks base method.
 func (m *MockReaperClient) SetRepairScheduleState(ctx context.Context, repairScheduleID string, active bool) error {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package names
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
@@ -31,6 +32,14 @@ func ProberServiceAccount(clusterName string) string {
 	return clusterName + "-cassandra-prober-serviceaccount"
 }
 
+func ProberStateConfigMap(clusterName string) string {
+	return clusterName + "-cassandra-prober-state"
+}
+
+func ProberLease(clusterName string) string {
+	return clusterName + "-cassandra-prober-leader"
+}
+
 func ProberIngress(clusterName string) string {
 	return clusterName + "-cassandra-prober"
 }
@@ -59,6 +68,42 @@ func ReaperService(clusterName string) string {
 	return clusterName + "-reaper"
 }
 
+// ClusterReaperService returns the service of the Reaper that repairs the cluster, either its own or a shared one
+func ClusterReaperService(cc *dbv1alpha1.CassandraCluster) string {
+	if len(cc.Spec.ReaperRef) != 0 {
+		return SharedReaperService(cc.Spec.ReaperRef)
+	}
+	return ReaperService(cc.Name)
+}
+
+// ReaperServiceURL is the URL of the Reaper instance that manages the repairs of the cluster
+func ReaperServiceURL(cc *dbv1alpha1.CassandraCluster) *url.URL {
+	reaperURL, _ := url.Parse(fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", ClusterReaperService(cc), cc.Namespace, dbv1alpha1.ReaperAppPort))
+	return reaperURL
+}
+
+// JolokiaURL is the URL of the Jolokia proxy of the cluster's prober
+func JolokiaURL(cc *dbv1alpha1.CassandraCluster) *url.URL {
+	jURL, _ := url.Parse(fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/jolokia", ProberService(cc.Name), cc.Namespace, dbv1alpha1.JolokiaContainerPort))
+	return jURL
+}
+
+func SharedReaperDeployment(reaperName string) string {
+	return reaperName + "-cassandra-reaper"
+}
+
+func SharedReaperService(reaperName string) string {
+	return reaperName + "-cassandra-reaper"
+}
+
+func SharedReaperShiroConfigMap(reaperName string) string {
+	return reaperName + "-cassandra-reaper-shiro-configmap"
+}
+
+func SharedReaperJMXCredentialsSecret(reaperName string) string {
+	return reaperName + "-cassandra-reaper-jmx-credentials"
+}
+
 func ShiroConfigMap(clusterName string) string {
 	return clusterName + "-shiro-configmap"
 }
@@ -71,6 +116,10 @@ func CollectdConfigMap(clusterName string) string {
 	return clusterName + "-collectd-configmap"
 }
 
+func MedusaConfigMap(clusterName string) string {
+	return clusterName + "-medusa-configmap"
+}
+
 func MaintenanceConfigMap(clusterName string) string {
 	return clusterName + "-maintenance-configmap"
 }
@@ -91,6 +140,10 @@ func PodsConfigConfigmap(clusterName string) string {
 	return clusterName + "-pods-config"
 }
 
+func RestoreLoaderJob(restoreName, sourceDCName string) string {
+	return restoreName + "-loader-" + sourceDCName
+}
+
 func ActiveAdminSecret(clusterName string) string {
 	return clusterName + "-auth-active-admin"
 }
@@ -103,6 +156,10 @@ func PodIPsConfigMap(clusterName string) string {
 	return clusterName + "-pod-ips"
 }
 
+func SafetySnapshot(clusterName, operation, checksum string) string {
+	return clusterName + "-safety-" + operation + "-" + checksum
+}
+
 func OperatorCollectdCM() string {
 	return cassandraOperator + "-collectd-configmap"
 }
@@ -198,3 +255,7 @@ func ProberNetworkPolicyName(clusterName string) string {
 func ReaperNetworkPolicyName(clusterName string) string {
 	return clusterName + "-reaper-policies"
 }
+
+func BuiltinRepairConfigMap(clusterName string) string {
+	return clusterName + "-builtin-repair-state"
+}
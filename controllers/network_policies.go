@@ -129,6 +129,15 @@ func (r *CassandraClusterReconciler) reconcileProberNetworkPolicies(ctx context.
 						nwPolicyPeer(dbv1alpha1.CassandraOperatorPodLabels, r.Cfg.Namespace),
 					},
 				},
+				// Allow prober replicas to forward state updates to the leader
+				{
+					Ports: []nwv1.NetworkPolicyPort{
+						nwPolicyPort(dbv1alpha1.ProberContainerPort),
+					},
+					From: []nwv1.NetworkPolicyPeer{
+						nwPolicyPeer(map[string]string{dbv1alpha1.CassandraClusterComponent: dbv1alpha1.CassandraClusterComponentProber}, cc.Namespace),
+					},
+				},
 			},
 			PolicyTypes: []nwv1.PolicyType{"Ingress"},
 		},
@@ -13,7 +13,7 @@ func (n *client) Assassinate(ctx context.Context, execNodeIP, assassinateNodeIP
 		Type:      jmxRequestTypeExec,
 		Mbean:     mbeanCassandraNetGossiper,
 		Operation: "assassinateEndpoint",
-		Arguments: []string{assassinateNodeIP},
+		Arguments: []interface{}{assassinateNodeIP},
 	}
 
 	resp, err := n.jolokia.Post(ctx, req, execNodeIP)
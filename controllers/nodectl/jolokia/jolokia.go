@@ -23,11 +23,11 @@ type Client struct {
 }
 
 type JMXRequest struct {
-	Type       string   `json:"type"`
-	Mbean      string   `json:"mbean"`
-	Attributes []string `json:"attribute,omitempty"`
-	Operation  string   `json:"operation,omitempty"`
-	Arguments  []string `json:"arguments,omitempty"` //args are identified based on the order they are passed
+	Type       string        `json:"type"`
+	Mbean      string        `json:"mbean"`
+	Attributes []string      `json:"attribute,omitempty"`
+	Operation  string        `json:"operation,omitempty"`
+	Arguments  []interface{} `json:"arguments,omitempty"` //args are identified based on the order they are passed
 }
 
 type JMXResponse struct {
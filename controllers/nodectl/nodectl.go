@@ -21,6 +21,13 @@ type Nodectl interface {
 	Version(ctx context.Context, nodeIP string) (major, minor, patch int, err error)
 	ClusterView(ctx context.Context, nodeIP string) (ClusterView, error)
 	OperationMode(ctx context.Context, nodeIP string) (OperationMode, error)
+	TakeSnapshot(ctx context.Context, nodeIP, tag string, keyspaces []string) error
+	ClearSnapshot(ctx context.Context, nodeIP, tag string, keyspaces []string) error
+	SnapshotsSize(ctx context.Context, nodeIP string) (int64, error)
+	TableStats(ctx context.Context, nodeIP, keyspace, table string) (TableStats, error)
+	TokenRing(ctx context.Context, nodeIP string) (map[string]string, error)
+	RepairAsync(ctx context.Context, nodeIP, keyspace string, options map[string]string) (int, error)
+	RepairStatus(ctx context.Context, nodeIP string, command int) (string, []string, error)
 }
 
 func NewClient(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) Nodectl {
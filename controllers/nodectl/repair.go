@@ -0,0 +1,91 @@
+package nodectl
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/controllers/nodectl/jolokia"
+)
+
+const (
+	RepairStatusInProgress = "IN_PROGRESS"
+	RepairStatusCompleted  = "COMPLETED"
+	RepairStatusFailed     = "FAILED"
+	// the repair command is not known by the node, e.g. if it has been restarted
+	RepairStatusUnknown = "UNKNOWN"
+)
+
+// TokenRing returns the tokens of the ring mapped to the addresses of the nodes that own them
+func (n *client) TokenRing(ctx context.Context, nodeIP string) (map[string]string, error) {
+	req := jolokia.JMXRequest{
+		Type:       jmxRequestTypeRead,
+		Mbean:      mbeanCassandraDBStorageService,
+		Attributes: []string{"TokenToEndpointMap"},
+	}
+
+	resp, err := n.jolokia.Post(ctx, req, nodeIP)
+	if err != nil {
+		return nil, err
+	}
+
+	var value struct {
+		TokenToEndpointMap map[string]string `json:"TokenToEndpointMap"`
+	}
+	if err = json.Unmarshal(resp.Value, &value); err != nil {
+		return nil, errors.Wrapf(err, "can't unmarshal token ring, raw body: %s", string(resp.Value))
+	}
+
+	return value.TokenToEndpointMap, nil
+}
+
+// RepairAsync starts a repair of the keyspace coordinated by the node and returns the repair command number.
+// The options are the same as the ones used by `nodetool repair`, e.g. `ranges`, `columnFamilies` or `parallelism`.
+// Returns 0 if there is nothing to repair.
+func (n *client) RepairAsync(ctx context.Context, nodeIP, keyspace string, options map[string]string) (int, error) {
+	req := jolokia.JMXRequest{
+		Type:      jmxRequestTypeExec,
+		Mbean:     mbeanCassandraDBStorageService,
+		Operation: "repairAsync(java.lang.String,java.util.Map)",
+		Arguments: []interface{}{keyspace, options},
+	}
+
+	resp, err := n.jolokia.Post(ctx, req, nodeIP)
+	if err != nil {
+		return 0, err
+	}
+
+	var command int
+	if err = json.Unmarshal(resp.Value, &command); err != nil {
+		return 0, errors.Wrapf(err, "can't unmarshal repair command, raw body: %s", string(resp.Value))
+	}
+
+	return command, nil
+}
+
+// RepairStatus returns the status of the repair command started on the node and the messages of the repair
+func (n *client) RepairStatus(ctx context.Context, nodeIP string, command int) (string, []string, error) {
+	req := jolokia.JMXRequest{
+		Type:      jmxRequestTypeExec,
+		Mbean:     mbeanCassandraDBStorageService,
+		Operation: "getParentRepairStatus(int)",
+		Arguments: []interface{}{command},
+	}
+
+	resp, err := n.jolokia.Post(ctx, req, nodeIP)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var status []string
+	if err = json.Unmarshal(resp.Value, &status); err != nil {
+		return "", nil, errors.Wrapf(err, "can't unmarshal repair status, raw body: %s", string(resp.Value))
+	}
+
+	if len(status) == 0 {
+		return RepairStatusUnknown, nil, nil
+	}
+
+	return status[0], status[1:], nil
+}
@@ -0,0 +1,67 @@
+package nodectl
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/controllers/nodectl/jolokia"
+)
+
+// TakeSnapshot takes a snapshot with the given tag on the node. All keyspaces are snapshotted if none are specified.
+func (n *client) TakeSnapshot(ctx context.Context, nodeIP, tag string, keyspaces []string) error {
+	if keyspaces == nil {
+		keyspaces = []string{}
+	}
+
+	req := jolokia.JMXRequest{
+		Type:      jmxRequestTypeExec,
+		Mbean:     mbeanCassandraDBStorageService,
+		Operation: "takeSnapshot(java.lang.String,[Ljava.lang.String;)",
+		Arguments: []interface{}{tag, keyspaces},
+	}
+
+	_, err := n.jolokia.Post(ctx, req, nodeIP)
+	return err
+}
+
+// ClearSnapshot removes the snapshot with the given tag from the node. It's not an error if the snapshot doesn't exist.
+func (n *client) ClearSnapshot(ctx context.Context, nodeIP, tag string, keyspaces []string) error {
+	if keyspaces == nil {
+		keyspaces = []string{}
+	}
+
+	req := jolokia.JMXRequest{
+		Type:      jmxRequestTypeExec,
+		Mbean:     mbeanCassandraDBStorageService,
+		Operation: "clearSnapshot(java.lang.String,[Ljava.lang.String;)",
+		Arguments: []interface{}{tag, keyspaces},
+	}
+
+	_, err := n.jolokia.Post(ctx, req, nodeIP)
+	return err
+}
+
+// SnapshotsSize returns the disk space in bytes used by all snapshots on the node.
+// Files still shared with the live data are not counted, same as `nodetool listsnapshots` does.
+func (n *client) SnapshotsSize(ctx context.Context, nodeIP string) (int64, error) {
+	req := jolokia.JMXRequest{
+		Type:      jmxRequestTypeExec,
+		Mbean:     mbeanCassandraDBStorageService,
+		Operation: "trueSnapshotsSize",
+	}
+
+	resp, err := n.jolokia.Post(ctx, req, nodeIP)
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	err = json.Unmarshal(resp.Value, &size)
+	if err != nil {
+		return 0, errors.Wrapf(err, "can't unmarshal snapshots size, raw body: %s", string(resp.Value))
+	}
+
+	return size, nil
+}
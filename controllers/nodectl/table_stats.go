@@ -0,0 +1,64 @@
+package nodectl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/ibm/cassandra-operator/controllers/nodectl/jolokia"
+)
+
+type TableStats struct {
+	LiveSSTables        int64
+	EstimatedPartitions int64
+	LiveDiskSpaceUsed   int64
+}
+
+// TableStats returns the number of live SSTables, the estimated number of partitions and the size of the live SSTables of the table on the node
+func (n *client) TableStats(ctx context.Context, nodeIP, keyspace, table string) (TableStats, error) {
+	liveSSTables, err := n.tableMetric(ctx, nodeIP, keyspace, table, "LiveSSTableCount", "Value")
+	if err != nil {
+		return TableStats{}, err
+	}
+
+	estimatedPartitions, err := n.tableMetric(ctx, nodeIP, keyspace, table, "EstimatedPartitionCount", "Value")
+	if err != nil {
+		return TableStats{}, err
+	}
+
+	// counter metric
+	liveDiskSpaceUsed, err := n.tableMetric(ctx, nodeIP, keyspace, table, "LiveDiskSpaceUsed", "Count")
+	if err != nil {
+		return TableStats{}, err
+	}
+
+	return TableStats{LiveSSTables: liveSSTables, EstimatedPartitions: estimatedPartitions, LiveDiskSpaceUsed: liveDiskSpaceUsed}, nil
+}
+
+func (n *client) tableMetric(ctx context.Context, nodeIP, keyspace, table, metric, attribute string) (int64, error) {
+	req := jolokia.JMXRequest{
+		Type:       jmxRequestTypeRead,
+		Mbean:      fmt.Sprintf("org.apache.cassandra.metrics:type=Table,keyspace=%s,scope=%s,name=%s", keyspace, table, metric),
+		Attributes: []string{attribute},
+	}
+
+	resp, err := n.jolokia.Post(ctx, req, nodeIP)
+	if err != nil {
+		return 0, err
+	}
+
+	metricResponse := make(map[string]int64)
+	err = json.Unmarshal(resp.Value, &metricResponse)
+	if err != nil {
+		return 0, errors.Wrapf(err, "can't unmarshal metric %s of table %s.%s, raw body: %s", metric, keyspace, table, string(resp.Value))
+	}
+
+	value, exists := metricResponse[attribute]
+	if !exists {
+		return 0, errors.Errorf("couldn't find metric %s of table %s.%s, raw response: %s", metric, keyspace, table, string(resp.Value))
+	}
+
+	return value, nil
+}
@@ -34,6 +34,10 @@ func (r *CassandraClusterReconciler) reconcileProber(ctx context.Context, cc *db
 		return errors.Wrap(err, "Error reconciling prober rolebinding")
 	}
 
+	if err := r.reconcileProberStateConfigMap(ctx, cc); err != nil {
+		return errors.Wrap(err, "failed to reconcile prober state configmap")
+	}
+
 	if err := r.reconcileProberDeployment(ctx, cc); err != nil {
 		return errors.Wrap(err, "failed to reconcile prober deployment")
 	}
@@ -74,7 +78,7 @@ func (r *CassandraClusterReconciler) reconcileProberDeployment(ctx context.Conte
 			Labels:    labels.CombinedComponentLabels(cc, dbv1alpha1.CassandraClusterComponentProber),
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: proto.Int32(1),
+			Replicas: proto.Int32(cc.Spec.Prober.Replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: proberLabels,
 			},
@@ -167,6 +171,32 @@ func (r *CassandraClusterReconciler) reconcileProberDeployment(ctx context.Conte
 	return nil
 }
 
+// reconcileProberStateConfigMap creates the ConfigMap the prober persists its state in. The data is managed by the prober.
+func (r *CassandraClusterReconciler) reconcileProberStateConfigMap(ctx context.Context, cc *dbv1alpha1.CassandraCluster) error {
+	desiredCM := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.ProberStateConfigMap(cc.Name),
+			Namespace: cc.Namespace,
+			Labels:    labels.CombinedComponentLabels(cc, dbv1alpha1.CassandraClusterComponentProber),
+		},
+	}
+	if err := controllerutil.SetControllerReference(cc, desiredCM, r.Scheme); err != nil {
+		return errors.Wrap(err, "Cannot set controller reference")
+	}
+
+	actualCM := &v1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: desiredCM.Name, Namespace: desiredCM.Namespace}, actualCM)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Infof("Creating %s", desiredCM.Name)
+			return r.Create(ctx, desiredCM)
+		}
+		return errors.Wrapf(err, "Could not get %s", desiredCM.Name)
+	}
+
+	return nil
+}
+
 func (r *CassandraClusterReconciler) reconcileProberService(ctx context.Context, cc *dbv1alpha1.CassandraCluster) error {
 	desiredService := &v1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -245,6 +275,10 @@ func proberContainer(cc *dbv1alpha1.CassandraCluster) v1.Container {
 		Resources:       cc.Spec.Prober.Resources,
 		Env: []v1.EnvVar{
 			{Name: "POD_NAMESPACE", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.namespace"}}},
+			{Name: "POD_NAME", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"}}},
+			{Name: "POD_IP", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "status.podIP"}}},
+			{Name: "STATE_CONFIGMAP_NAME", Value: names.ProberStateConfigMap(cc.Name)},
+			{Name: "LEADER_ELECTION_LEASE_NAME", Value: names.ProberLease(cc.Name)},
 			{Name: "LOGLEVEL", Value: cc.Spec.Prober.LogLevel},
 			{Name: "LOGFORMAT", Value: cc.Spec.Prober.LogFormat},
 			{Name: "JOLOKIA_PORT", Value: strconv.Itoa(dbv1alpha1.JolokiaContainerPort)},
@@ -334,8 +368,3 @@ func proberURL(cc *dbv1alpha1.CassandraCluster) *url.URL {
 	proberUrl, _ := url.Parse(fmt.Sprintf("http://%s.%s.svc.cluster.local", names.ProberService(cc.Name), cc.Namespace))
 	return proberUrl
 }
-
-func jolokiaURL(cc *dbv1alpha1.CassandraCluster) *url.URL {
-	jURL, _ := url.Parse(fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/jolokia", names.ProberService(cc.Name), cc.Namespace, dbv1alpha1.JolokiaContainerPort))
-	return jURL
-}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/pkg/errors"
@@ -28,6 +29,22 @@ type ProberClient interface {
 	UpdateRegionIPs(ctx context.Context, ips []string) error
 	GetReaperIPs(ctx context.Context, host string) ([]string, error)
 	UpdateReaperIPs(ctx context.Context, ips []string) error
+	GetClusterView(ctx context.Context) ([]NodeView, error)
+}
+
+// NodeView is the state of a Cassandra node as gossiped by the cluster and how the other nodes see it
+type NodeView struct {
+	IP             string  `json:"ip"`
+	DC             string  `json:"dc"`
+	Rack           string  `json:"rack"`
+	HostID         string  `json:"hostId"`
+	Status         string  `json:"status"`
+	Load           float64 `json:"load"`
+	SchemaVersion  string  `json:"schemaVersion"`
+	ReleaseVersion string  `json:"releaseVersion"`
+	// PeerViews maps the IP of each node to its view (UP or DOWN) of the node. `?` if the node doesn't know the node.
+	PeerViews    map[string]string `json:"peerViews"`
+	LastObserved *time.Time        `json:"lastObserved,omitempty"`
 }
 
 type proberClient struct {
@@ -340,3 +357,34 @@ func (p *proberClient) GetReaperIPs(ctx context.Context, host string) ([]string,
 
 	return ips, nil
 }
+
+func (p *proberClient) GetClusterView(ctx context.Context) ([]NodeView, error) {
+	req, err := p.newRequestWithAuth(ctx, http.MethodGet, p.url("/cluster-view"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "GET request to prober's `/cluster-view` endpoint failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("response status %q (code %v) is not %q",
+			http.StatusText(resp.StatusCode), resp.StatusCode, http.StatusText(http.StatusOK))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to read response body")
+	}
+
+	var view []NodeView
+	if err := json.Unmarshal(body, &view); err != nil {
+		return nil, errors.Wrap(err, "Error unmarshalling response body")
+	}
+
+	return view, nil
+}
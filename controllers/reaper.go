@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"github.com/gogo/protobuf/proto"
-	"github.com/ibm/cassandra-operator/api/v1alpha1"
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/compare"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/events"
 	"github.com/ibm/cassandra-operator/controllers/labels"
 	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/prober"
 	"github.com/ibm/cassandra-operator/controllers/reaper"
 	"github.com/ibm/cassandra-operator/controllers/util"
 	"github.com/pkg/errors"
@@ -19,7 +21,6 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"net"
-	"net/url"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -344,6 +345,145 @@ func reaperVolumes(cc *dbv1alpha1.CassandraCluster) []v1.Volume {
 	return volume
 }
 
+// reconcileReaperRepairs deploys Reaper and manages the cluster's repairs in it
+func (r *CassandraClusterReconciler) reconcileReaperRepairs(ctx context.Context, cc *dbv1alpha1.CassandraCluster, cqlClient cql.CqlClient, proberClient prober.ProberClient, podList *v1.PodList, nodeList *v1.NodeList, allDCs []dbv1alpha1.DC) (ctrl.Result, reaper.ReaperClient, error) {
+	waitForFirstRegionReaper, err := r.waitForFirstRegionReaper(ctx, cc, proberClient)
+	if err != nil {
+		return ctrl.Result{}, nil, errors.Wrap(err, "Error checking for first region reaper readiness")
+	}
+
+	if waitForFirstRegionReaper {
+		r.Log.Infof("Reaper is not ready in the first region. Trying again in %s...", r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil, nil
+	}
+
+	if len(cc.Spec.ReaperRef) != 0 {
+		if err = r.removeReaper(ctx, cc); err != nil {
+			return ctrl.Result{}, nil, errors.Wrap(err, "Failed to remove reaper")
+		}
+
+		ready, err := r.sharedReaperReady(ctx, cc)
+		if err != nil {
+			return ctrl.Result{}, nil, err
+		}
+
+		if !ready {
+			return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil, nil
+		}
+	} else {
+		// the keyspace of a shared Reaper is managed by the CassandraReaper on its storage cluster
+		if err = r.reconcileReaperKeyspace(cc, cqlClient, allDCs); err != nil {
+			return ctrl.Result{}, nil, errors.Wrap(err, "Error reconciling reaper keyspace")
+		}
+
+		if res, err := r.reconcileReaper(ctx, cc, podList, nodeList); needsRequeue(res, err) {
+			return res, nil, err
+		}
+	}
+
+	reaperClient := r.ReaperClient(names.ReaperServiceURL(cc), cc.Name, cc.Spec.Reaper.RepairThreadCount)
+	isRunning, err := reaperClient.IsRunning(ctx)
+	if err != nil {
+		if updErr := proberClient.UpdateReaperStatus(ctx, false); updErr != nil {
+			return ctrl.Result{}, nil, updErr
+		}
+		r.Log.Warnf("Reaper ping request failed: %s. Trying again in %s...", err.Error(), r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil, nil
+	}
+	if !isRunning {
+		if err = proberClient.UpdateReaperStatus(ctx, false); err != nil {
+			return ctrl.Result{}, nil, err
+		}
+		r.Log.Infof("Reaper is not ready. Trying again in %s...", r.Cfg.RetryDelay)
+		return ctrl.Result{RequeueAfter: r.Cfg.RetryDelay}, nil, nil
+	}
+
+	if err = r.reaperInitialization(ctx, cc, reaperClient); err != nil {
+		return ctrl.Result{}, nil, errors.Wrap(err, "Failed to initialize reaper")
+	}
+
+	if err = proberClient.UpdateReaperStatus(ctx, true); err != nil {
+		return ctrl.Result{}, nil, err
+	}
+
+	if err = r.reconcileIncrementalRepairMigrations(ctx, cc, &reaperMigrationRepairs{cc: cc, client: reaperClient}); err != nil {
+		return ctrl.Result{}, nil, errors.Wrap(err, "Failed to reconcile incremental repair migrations")
+	}
+
+	if err = r.reconcileRepairSchedules(ctx, cc, reaperClient); err != nil {
+		return ctrl.Result{}, nil, errors.Wrap(err, "Failed to reconcile repair schedules")
+	}
+
+	if err = r.reconcileRepairSchedulesStatus(ctx, cc, reaperClient); err != nil {
+		return ctrl.Result{}, nil, errors.Wrap(err, "Failed to reconcile repair schedules status")
+	}
+
+	repairRuns, err := reaperClient.RepairRuns(ctx, "")
+	if err != nil {
+		return ctrl.Result{}, nil, errors.Wrap(err, "failed to get repair runs")
+	}
+
+	if err = r.reconcileRepairCoverage(cc, cqlClient, repairRuns); err != nil {
+		return ctrl.Result{}, nil, errors.Wrap(err, "Failed to reconcile repair coverage")
+	}
+
+	return ctrl.Result{}, reaperClient, nil
+}
+
+// sharedReaperReady returns true once the CassandraReaper referenced by the cluster has loaded the cluster's JMX credentials
+func (r *CassandraClusterReconciler) sharedReaperReady(ctx context.Context, cc *dbv1alpha1.CassandraCluster) (bool, error) {
+	sharedReaper := &dbv1alpha1.CassandraReaper{}
+	err := r.Get(ctx, types.NamespacedName{Name: cc.Spec.ReaperRef, Namespace: cc.Namespace}, sharedReaper)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			errMsg := fmt.Sprintf("CassandraReaper %q not found. Trying again in %s...", cc.Spec.ReaperRef, r.Cfg.RetryDelay)
+			r.Log.Warn(errMsg)
+			r.Events.Warning(cc, events.EventCassandraReaperNotFound, errMsg)
+			return false, nil
+		}
+		return false, errors.Wrap(err, "Failed to get CassandraReaper")
+	}
+
+	if !sharedReaper.Status.Ready || !util.Contains(sharedReaper.Status.Clusters, cc.Name) {
+		r.Log.Infof("Waiting for CassandraReaper %s to be ready to repair the cluster. Trying again in %s...", sharedReaper.Name, r.Cfg.RetryDelay)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// removeReaper removes the Reaper deployments if the repairs are run by the builtin repair engine or a shared Reaper
+func (r *CassandraClusterReconciler) removeReaper(ctx context.Context, cc *dbv1alpha1.CassandraCluster) error {
+	for _, dc := range cc.Spec.DCs {
+		reaperDeployment := &appsv1.Deployment{}
+		err := r.Get(ctx, types.NamespacedName{Name: names.ReaperDeployment(cc.Name, dc.Name), Namespace: cc.Namespace}, reaperDeployment)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrap(err, "Failed to get reaper deployment")
+		}
+
+		r.Log.Infof("Removing reaper deployment %s", reaperDeployment.Name)
+		if err = r.Delete(ctx, reaperDeployment); err != nil {
+			return errors.Wrap(err, "Failed to delete reaper deployment")
+		}
+	}
+
+	reaperService := &v1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: names.ReaperService(cc.Name), Namespace: cc.Namespace}, reaperService)
+	if err == nil {
+		r.Log.Infof("Removing reaper service")
+		if err = r.Delete(ctx, reaperService); err != nil {
+			return errors.Wrap(err, "Failed to delete reaper service")
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "Failed to get reaper service")
+	}
+
+	return r.removeServiceMonitors(ctx, cc, dbv1alpha1.CassandraClusterComponentReaper)
+}
+
 func (r *CassandraClusterReconciler) reaperInitialization(ctx context.Context, cc *dbv1alpha1.CassandraCluster, reaperClient reaper.ReaperClient) error {
 	seed := getSeedHostname(cc, cc.Spec.DCs[0].Name, 0, true)
 	clusterExists, err := reaperClient.ClusterExists(ctx)
@@ -361,14 +501,16 @@ func (r *CassandraClusterReconciler) reaperInitialization(ctx context.Context, c
 	}
 	if !clusterExists {
 		r.Log.Infof("Cluster %s does not exist in reaper. Adding cluster seed...", cc.Name)
-		if err = reaperClient.AddCluster(ctx, seed); err != nil {
+		if err = r.addReaperCluster(ctx, cc, reaperClient, seed); err != nil {
 			return err
 		}
 
-		r.Log.Infof("starting a repair for %s keyspace", cc.Spec.Reaper.Keyspace)
-		err = reaperClient.RunRepair(ctx, cc.Spec.Reaper.Keyspace, repairCauseReaperInit)
-		if err != nil {
-			return errors.Wrapf(err, "failed to run repair on %s keyspace", cc.Spec.Reaper.Keyspace)
+		if len(cc.Spec.ReaperRef) == 0 {
+			r.Log.Infof("starting a repair for %s keyspace", cc.Spec.Reaper.Keyspace)
+			err = reaperClient.RunRepair(ctx, cc.Spec.Reaper.Keyspace, repairCauseReaperInit)
+			if err != nil {
+				return errors.Wrapf(err, "failed to run repair on %s keyspace", cc.Spec.Reaper.Keyspace)
+			}
 		}
 
 		// we had to modify system_auth keyspace before we had reaper in order to bootstrap the cluster. So run the missing repair now.
@@ -381,9 +523,21 @@ func (r *CassandraClusterReconciler) reaperInitialization(ctx context.Context, c
 	return nil
 }
 
-func reaperServiceURL(cc *dbv1alpha1.CassandraCluster) *url.URL {
-	reaperURL, _ := url.Parse(fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", names.ReaperService(cc.Name), cc.Namespace, v1alpha1.ReaperAppPort))
-	return reaperURL
+// addReaperCluster adds the cluster to Reaper. A shared Reaper gets the JMX credentials of the cluster with it
+// as it loads the credentials Secret on startup only.
+func (r *CassandraClusterReconciler) addReaperCluster(ctx context.Context, cc *dbv1alpha1.CassandraCluster, reaperClient reaper.ReaperClient, seed string) error {
+	if len(cc.Spec.ReaperRef) == 0 {
+		return reaperClient.AddCluster(ctx, seed)
+	}
+
+	adminSecret := &v1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: names.AdminAuthConfigSecret(cc.Name), Namespace: cc.Namespace}, adminSecret)
+	if err != nil {
+		return errors.Wrap(err, "can't get admin role secret")
+	}
+
+	return reaperClient.AddClusterWithCredentials(ctx, seed, string(adminSecret.Data[dbv1alpha1.CassandraOperatorAdminRole]),
+		string(adminSecret.Data[dbv1alpha1.CassandraOperatorAdminPassword]))
 }
 
 func (r *CassandraClusterReconciler) reInitReaperIfNeeded(ctx context.Context, cc *dbv1alpha1.CassandraCluster, reaperClient reaper.ReaperClient, seed string) error {
@@ -430,7 +584,7 @@ func (r *CassandraClusterReconciler) reInitReaperIfNeeded(ctx context.Context, c
 	}
 
 	r.Log.Info("Cluster is removed from reaper. Re-adding it.")
-	if err = reaperClient.AddCluster(ctx, seed); err != nil {
+	if err = r.addReaperCluster(ctx, cc, reaperClient, seed); err != nil {
 		return err
 	}
 
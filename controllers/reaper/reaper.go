@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -14,8 +16,16 @@ import (
 )
 
 const (
-	RepairStateRunning = "RUNNING"
-	RepairStatePaused  = "PAUSED"
+	RepairStateNotStarted = "NOT_STARTED"
+	RepairStateRunning    = "RUNNING"
+	RepairStatePaused     = "PAUSED"
+	RepairStateDone       = "DONE"
+	RepairStateError      = "ERROR"
+	RepairStateAborted    = "ABORTED"
+	RepairStateDeleted    = "DELETED"
+
+	RepairScheduleStateActive = "ACTIVE"
+	RepairScheduleStatePaused = "PAUSED"
 
 	OwnerCassandraOperator = "cassandra-operator"
 )
@@ -33,11 +43,16 @@ type ReaperClient interface {
 	Clusters(ctx context.Context) ([]string, error)
 	DeleteCluster(ctx context.Context) error
 	AddCluster(ctx context.Context, seed string) error
+	AddClusterWithCredentials(ctx context.Context, seed, jmxUsername, jmxPassword string) error
 	CreateRepairSchedule(ctx context.Context, repair dbv1alpha1.RepairSchedule) error
 	RepairSchedules(ctx context.Context) ([]RepairSchedule, error)
 	DeleteRepairSchedule(ctx context.Context, repairScheduleID string) error
 	SetRepairScheduleState(ctx context.Context, repairScheduleID string, active bool) error
 	RunRepair(ctx context.Context, keyspace, cause string) error
+	CreateRepairRun(ctx context.Context, repair dbv1alpha1.CassandraRepairSpec, cause string) (RepairRun, error)
+	RepairRuns(ctx context.Context, keyspace string) ([]RepairRun, error)
+	SetRepairRunState(ctx context.Context, runID, state string) error
+	DeleteRepairRun(ctx context.Context, repairRun RepairRun) error
 }
 
 var (
@@ -130,6 +145,36 @@ func (r *reaperClient) AddCluster(ctx context.Context, seed string) error {
 	return nil
 }
 
+// AddClusterWithCredentials adds the cluster or updates its seed and JMX credentials. Reaper uses the credentials stored
+// with the cluster instead of the ones it loaded on startup.
+func (r *reaperClient) AddClusterWithCredentials(ctx context.Context, seed, jmxUsername, jmxPassword string) error {
+	form := url.Values{}
+	form.Add("seedHost", seed)
+	form.Add("jmxPort", strconv.Itoa(dbv1alpha1.JmxPort))
+	form.Add("jmxUsername", jmxUsername)
+	form.Add("jmxPassword", jmxPassword)
+	route := r.url("/cluster/auth/" + r.clusterName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, route, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	b, _ := io.ReadAll(resp.Body)
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		if resp.StatusCode == http.StatusNotFound {
+			return ClusterNotFound
+		}
+		return &requestFailedWithStatus{code: resp.StatusCode, message: string(b)}
+	}
+	return nil
+}
+
 func (r *reaperClient) Clusters(ctx context.Context) ([]string, error) {
 	route := r.url("/cluster")
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, route, nil)
@@ -159,13 +204,13 @@ func (r *reaperClient) Clusters(ctx context.Context) ([]string, error) {
 
 func (r *reaperClient) DeleteCluster(ctx context.Context) error {
 	// ensure no repair are running
-	repairRuns, err := r.getRepairRuns(ctx, "")
+	repairRuns, err := r.RepairRuns(ctx, "")
 	if err != nil {
 		return err
 	}
 
 	for _, repairRun := range repairRuns {
-		err = r.deleteRepairRun(ctx, repairRun)
+		err = r.DeleteRepairRun(ctx, repairRun)
 		if err != nil {
 			return errors.Wrapf(err, "can't delete repair run %s for keyspace %s", repairRun.ID, repairRun.KeyspaceName)
 		}
@@ -203,9 +248,11 @@ func (r *reaperClient) DeleteCluster(ctx context.Context) error {
 	return nil
 }
 
-func (r *reaperClient) deleteRepairRun(ctx context.Context, repairRun RepairRun) error {
+// DeleteRepairRun aborts the repair run and removes it from Reaper. A running repair is paused first
+// as Reaper doesn't delete running repairs.
+func (r *reaperClient) DeleteRepairRun(ctx context.Context, repairRun RepairRun) error {
 	if repairRun.State == RepairStateRunning {
-		if err := r.setRepairState(ctx, repairRun.ID, RepairStatePaused); err != nil {
+		if err := r.SetRepairRunState(ctx, repairRun.ID, RepairStatePaused); err != nil {
 			return errors.Wrapf(err, "can't pause repair run %s for keyspace %s", repairRun.ID, repairRun.KeyspaceName)
 		}
 	}
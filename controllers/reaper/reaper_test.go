@@ -242,6 +242,35 @@ func TestAddCluster(t *testing.T) {
 	})
 }
 
+func TestAddClusterWithCredentials(t *testing.T) {
+	asserts := NewWithT(t)
+	var form url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		asserts.Expect(r.Method).To(Equal(http.MethodPut))
+		asserts.Expect(r.URL.Path).To(Equal("/cluster/auth/test-cluster"))
+		asserts.Expect(r.ParseForm()).To(Succeed())
+		form = r.PostForm
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	reaperUrl, err := url.Parse(ts.URL)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	rc := NewReaperClient(reaperUrl, "test-cluster", defaultClient, 1)
+	err = rc.AddClusterWithCredentials(context.Background(), "test-cluster-cassandra-dc1-0", "admin", "password")
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(form).To(Equal(url.Values{
+		"seedHost":    []string{"test-cluster-cassandra-dc1-0"},
+		"jmxPort":     []string{"7199"},
+		"jmxUsername": []string{"admin"},
+		"jmxPassword": []string{"password"},
+	}))
+
+	ts.Config.Handler = handleResponseError(testError, http.StatusBadRequest)
+	err = rc.AddClusterWithCredentials(context.Background(), "test-cluster-cassandra-dc1-0", "admin", "password")
+	asserts.Expect(err).To(BeEquivalentTo(&requestFailedWithStatus{code: http.StatusBadRequest, message: "test error message\n"}))
+}
+
 func handleResponseStatus(code int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(code)
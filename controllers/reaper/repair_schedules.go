@@ -26,6 +26,12 @@ type RepairSchedule struct {
 	IncrementalRepair   bool     `json:"incremental_repair"`
 	Tables              []string `json:"tables"`
 	RepairThreadCount   int32    `json:"repair_thread_count"`
+	NextActivation      string   `json:"next_activation"`
+}
+
+// ScheduledRunCause returns the cause Reaper sets on the repair runs started by the repair schedule
+func ScheduledRunCause(repairScheduleID string) string {
+	return fmt.Sprintf("scheduled run (schedule id %s)", repairScheduleID)
 }
 
 func (r *reaperClient) CreateRepairSchedule(ctx context.Context, repair dbv1alpha1.RepairSchedule) error {
@@ -121,9 +127,9 @@ func (r *reaperClient) SetRepairScheduleState(ctx context.Context, repairSchedul
 		return err
 	}
 
-	state := "ACTIVE"
+	state := RepairScheduleStateActive
 	if !active {
-		state = "PAUSED"
+		state = RepairScheduleStatePaused
 	}
 
 	req.Header.Set("Accept", "application/json")
@@ -7,54 +7,91 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/go-querystring/query"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
 )
 
 type RepairRun struct {
-	ID           string `json:"id"`
-	State        string `json:"state"`
-	Duration     string `json:"duration"`
-	ClusterName  string `json:"cluster_name"`
-	KeyspaceName string `json:"keyspace_name"`
-	Owner        string `json:"owner"`
-	Cause        string `json:"cause"`
+	ID                string   `json:"id"`
+	State             string   `json:"state"`
+	Duration          string   `json:"duration"`
+	ClusterName       string   `json:"cluster_name"`
+	KeyspaceName      string   `json:"keyspace_name"`
+	ColumnFamilies    []string `json:"column_families"`
+	Owner             string   `json:"owner"`
+	Cause             string   `json:"cause"`
+	Intensity         float64  `json:"intensity"`
+	IncrementalRepair bool     `json:"incremental_repair"`
+	RepairParallelism string   `json:"repair_parallelism"`
+	TotalSegments     int32    `json:"total_segments"`
+	SegmentsRepaired  int32    `json:"segments_repaired"`
+	LastEvent         string   `json:"last_event"`
+	Nodes             []string `json:"nodes"`
+	Datacenters       []string `json:"datacenters"`
+	RepairThreadCount int32    `json:"repair_thread_count"`
+	CreationTime      string   `json:"creation_time"`
+	StartTime         string   `json:"start_time"`
+	EndTime           string   `json:"end_time"`
 }
 
 // RunRepair Creates and starts a repair run
 func (r *reaperClient) RunRepair(ctx context.Context, keyspace, cause string) error {
-	existingRepairRuns, err := r.getRepairRuns(ctx, keyspace)
+	existingRepairRuns, err := r.RepairRuns(ctx, keyspace)
 	if err != nil {
 		return err
 	}
 
 	if len(existingRepairRuns) > 0 {
 		for _, run := range existingRepairRuns {
-			if run.KeyspaceName == keyspace && (run.State == "RUNNING" || run.State == "NOT_STARTED") {
+			if run.KeyspaceName == keyspace && (run.State == RepairStateRunning || run.State == RepairStateNotStarted) {
 				return nil // don't start a repair if there's one running or scheduled
 			}
 		}
 	}
 
-	repairRun, err := r.createRepairRun(ctx, keyspace, cause)
+	urlParams := url.Values{}
+	urlParams.Add("keyspace", keyspace)
+	urlParams.Add("repairThreadCount", fmt.Sprint(r.repairThreadCount))
+	repairRun, err := r.createRepairRun(ctx, urlParams, cause)
 	if err != nil {
 		return err
 	}
 
-	return r.setRepairState(ctx, repairRun.ID, RepairStateRunning)
+	return r.SetRepairRunState(ctx, repairRun.ID, RepairStateRunning)
 }
 
-func (r *reaperClient) createRepairRun(ctx context.Context, keyspace, cause string) (RepairRun, error) {
+// CreateRepairRun creates a repair run that is not started yet. The cause identifies the run in the list of repair runs.
+func (r *reaperClient) CreateRepairRun(ctx context.Context, repair dbv1alpha1.CassandraRepairSpec, cause string) (RepairRun, error) {
+	urlParams, err := query.Values(repair)
+	if err != nil {
+		return RepairRun{}, err
+	}
+
+	if repair.RepairThreadCount == 0 {
+		urlParams.Add("repairThreadCount", fmt.Sprint(r.repairThreadCount))
+	}
+
+	// Reaper falls back to its own incrementalRepair setting if the parameter is not set
+	urlParams.Set("incrementalRepair", strconv.FormatBool(repair.IncrementalRepair))
+
+	return r.createRepairRun(ctx, urlParams, cause)
+}
+
+func (r *reaperClient) createRepairRun(ctx context.Context, urlParams url.Values, cause string) (RepairRun, error) {
 	route := r.url("/repair_run")
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, route, nil)
 	if err != nil {
 		return RepairRun{}, err
 	}
 	req.Header.Set("Accept", "application/json")
-	urlParams := url.Values{}
 	urlParams.Add("clusterName", r.clusterName)
-	urlParams.Add("keyspace", keyspace)
 	urlParams.Add("owner", OwnerCassandraOperator)
 	urlParams.Add("cause", cause)
-	urlParams.Add("repairThreadCount", fmt.Sprint(r.repairThreadCount))
 
 	req.URL.RawQuery = urlParams.Encode()
 	req = req.WithContext(ctx)
@@ -81,7 +118,8 @@ func (r *reaperClient) createRepairRun(ctx context.Context, keyspace, cause stri
 	return createdRepair, nil
 }
 
-func (r *reaperClient) getRepairRuns(ctx context.Context, keyspace string) ([]RepairRun, error) {
+// RepairRuns returns the repair runs of the cluster. All keyspaces are included if the keyspace is empty.
+func (r *reaperClient) RepairRuns(ctx context.Context, keyspace string) ([]RepairRun, error) {
 	route := r.url("/repair_run")
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, route, nil)
 	if err != nil {
@@ -119,7 +157,8 @@ func (r *reaperClient) getRepairRuns(ctx context.Context, keyspace string) ([]Re
 	return repairRuns, nil
 }
 
-func (r *reaperClient) setRepairState(ctx context.Context, runID, state string) error {
+// SetRepairRunState starts, pauses or aborts the repair run
+func (r *reaperClient) SetRepairRunState(ctx context.Context, runID, state string) error {
 	route := r.url(fmt.Sprintf("/repair_run/%s/state/%s", runID, state))
 	req, err := http.NewRequestWithContext(ctx, http.MethodPut, route, nil)
 	if err != nil {
@@ -139,3 +178,21 @@ func (r *reaperClient) setRepairState(ctx context.Context, runID, state string)
 
 	return nil
 }
+
+// ParseTime parses the ISO 8601 times returned by Reaper. Returns nil if the time is not set.
+func ParseTime(value string) *metav1.Time {
+	if len(value) == 0 {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		// the schedule trigger times are returned without the timezone
+		t, err = time.ParseInLocation(dbv1alpha1.ISOFormat, value, time.UTC)
+		if err != nil {
+			return nil
+		}
+	}
+
+	return &metav1.Time{Time: t.Local()} // metav1.Time is unmarshalled in local time
+}
@@ -0,0 +1,114 @@
+package reaper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+)
+
+func TestCreateRepairRun(t *testing.T) {
+	asserts := NewWithT(t)
+	clusterName := "test-cluster"
+	repair := v1alpha1.CassandraRepairSpec{
+		CassandraCluster:  clusterName,
+		Keyspace:          "test_keyspace",
+		Tables:            []string{"table1", "table2"},
+		Datacenters:       []string{"dc1"},
+		RepairParallelism: "PARALLEL",
+		Intensity:         "0.5",
+		IncrementalRepair: true,
+	}
+
+	t.Run("sends the repair parameters and returns the created repair run", func(t *testing.T) {
+		var query url.Values
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			asserts.Expect(r.Method).To(Equal(http.MethodPost))
+			asserts.Expect(r.URL.Path).To(Equal("/repair_run"))
+			query = r.URL.Query()
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"run-1","state":"NOT_STARTED","keyspace_name":"test_keyspace","total_segments":12,"segments_repaired":0}`)
+		}))
+		defer ts.Close()
+		reaperUrl, err := url.Parse(ts.URL)
+		asserts.Expect(err).To(BeNil())
+
+		rc := NewReaperClient(reaperUrl, clusterName, defaultClient, 2)
+		repairRun, err := rc.CreateRepairRun(context.Background(), repair, "test cause")
+		asserts.Expect(err).To(BeNil())
+		asserts.Expect(repairRun).To(Equal(RepairRun{ID: "run-1", State: RepairStateNotStarted, KeyspaceName: "test_keyspace", TotalSegments: 12}))
+		asserts.Expect(query).To(Equal(url.Values{
+			"clusterName":       {clusterName},
+			"keyspace":          {"test_keyspace"},
+			"tables":            {"table1,table2"},
+			"datacenters":       {"dc1"},
+			"repairParallelism": {"PARALLEL"},
+			"intensity":         {"0.5"},
+			"incrementalRepair": {"true"},
+			"repairThreadCount": {"2"},
+			"owner":             {OwnerCassandraOperator},
+			"cause":             {"test cause"},
+		}))
+	})
+
+	t.Run("sends a full repair explicitly", func(t *testing.T) {
+		var query url.Values
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"run-1","state":"NOT_STARTED"}`)
+		}))
+		defer ts.Close()
+		reaperUrl, err := url.Parse(ts.URL)
+		asserts.Expect(err).To(BeNil())
+
+		rc := NewReaperClient(reaperUrl, clusterName, defaultClient, 2)
+		_, err = rc.CreateRepairRun(context.Background(), v1alpha1.CassandraRepairSpec{Keyspace: "test_keyspace"}, "test cause")
+		asserts.Expect(err).To(BeNil())
+		asserts.Expect(query.Get("incrementalRepair")).To(Equal("false"))
+	})
+
+	t.Run("returns error if response status code >= 300", func(t *testing.T) {
+		ts := httptest.NewServer(handleResponseError(testError, http.StatusBadRequest))
+		defer ts.Close()
+		reaperUrl, err := url.Parse(ts.URL)
+		asserts.Expect(err).To(BeNil())
+
+		rc := NewReaperClient(reaperUrl, clusterName, defaultClient, 2)
+		_, err = rc.CreateRepairRun(context.Background(), repair, "test cause")
+		asserts.Expect(err).To(BeEquivalentTo(&requestFailedWithStatus{code: http.StatusBadRequest, message: "test error message\n"}))
+	})
+}
+
+func TestDeleteRepairRun(t *testing.T) {
+	asserts := NewWithT(t)
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	reaperUrl, err := url.Parse(ts.URL)
+	asserts.Expect(err).To(BeNil())
+	rc := NewReaperClient(reaperUrl, "test-cluster", defaultClient, 1)
+
+	t.Run("pauses a running repair run before deleting it", func(t *testing.T) {
+		requests = nil
+		err = rc.DeleteRepairRun(context.Background(), RepairRun{ID: "run-1", State: RepairStateRunning, Owner: OwnerCassandraOperator})
+		asserts.Expect(err).To(BeNil())
+		asserts.Expect(requests).To(Equal([]string{"PUT /repair_run/run-1/state/PAUSED", "DELETE /repair_run/run-1"}))
+	})
+
+	t.Run("deletes a repair run that is not running", func(t *testing.T) {
+		requests = nil
+		err = rc.DeleteRepairRun(context.Background(), RepairRun{ID: "run-2", State: RepairStateNotStarted, Owner: OwnerCassandraOperator})
+		asserts.Expect(err).To(BeNil())
+		asserts.Expect(requests).To(Equal([]string{"DELETE /repair_run/run-2"}))
+	})
+}
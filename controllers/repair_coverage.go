@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/metrics"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/util"
+)
+
+// keyspaces with data local to each node which are not repaired
+var localKeyspaces = []string{"system", "system_schema", "system_views", "system_virtual_schema"}
+
+// reconcileRepairCoverage checks that every table has been fully repaired within its gc_grace_seconds,
+// so that deleted data can't come back. Raises a warning when a table gets close to its gc_grace_seconds without a repair.
+// The status keeps a summary per keyspace, the state of every table is exposed in the metrics.
+func (r *CassandraClusterReconciler) reconcileRepairCoverage(cc *dbv1alpha1.CassandraCluster, cqlClient cql.CqlClient, repairRuns []reaper.RepairRun) error {
+	keyspaces, err := cqlClient.GetKeyspacesInfo()
+	if err != nil {
+		return errors.Wrap(err, "can't get keyspace info")
+	}
+
+	tables, err := cqlClient.GetTablesInfo()
+	if err != nil {
+		return errors.Wrap(err, "can't get tables info")
+	}
+
+	now := time.Now()
+	coverage := repairCoverage(cc, keyspaces, tables, repairRuns, now)
+	summaries := repairCoverageSummaries(coverage)
+
+	previousSummaries := make(map[string]dbv1alpha1.KeyspaceRepairCoverage, len(cc.Status.RepairCoverage))
+	for _, summary := range cc.Status.RepairCoverage {
+		previousSummaries[summary.Keyspace] = summary
+	}
+
+	// the tables are reported again if more tables of the keyspace get the Warning or Overdue state
+	keyspacesAtRisk := make(map[string]bool)
+	for _, summary := range summaries {
+		previous := previousSummaries[summary.Keyspace]
+		if summary.Overdue > previous.Overdue || summary.Warning+summary.Overdue > previous.Warning+previous.Overdue {
+			keyspacesAtRisk[summary.Keyspace] = true
+		}
+	}
+
+	var atRisk []string
+	for _, table := range coverage {
+		if !keyspacesAtRisk[table.Keyspace] ||
+			(table.State != dbv1alpha1.RepairCoverageStateWarning && table.State != dbv1alpha1.RepairCoverageStateOverdue) {
+			continue
+		}
+
+		atRisk = append(atRisk, fmt.Sprintf("%s.%s (%s, last repaired %s ago, gc_grace_seconds %d)",
+			table.Keyspace, table.Table, table.State, now.Sub(*table.LastCompleteRepair).Truncate(time.Minute), table.GCGraceSeconds))
+	}
+
+	if len(atRisk) > 0 {
+		msg := fmt.Sprintf("Tables not fully repaired within %d%% of their gc_grace_seconds, deleted data may come back: %s",
+			cc.Spec.Reaper.GCGraceWarningPercent, strings.Join(atRisk, ", "))
+		r.Log.Warn(msg)
+		r.Events.Warning(cc, events.EventRepairCoverageWarning, msg)
+	}
+
+	metrics.ObserveRepairCoverage(cc, coverage)
+	cc.Status.RepairCoverage = summaries
+	return nil
+}
+
+// repairCoverage returns the last complete repair of the replicated tables with a non-zero gc_grace_seconds
+func repairCoverage(cc *dbv1alpha1.CassandraCluster, keyspaces []cql.Keyspace, tables []cql.Table, repairRuns []reaper.RepairRun, now time.Time) []metrics.TableRepairCoverage {
+	replicatedKeyspaces := make(map[string]bool, len(keyspaces))
+	for _, keyspace := range keyspaces {
+		if !util.Contains(localKeyspaces, keyspace.Name) && !strings.HasSuffix(keyspace.Replication["class"], "LocalStrategy") {
+			replicatedKeyspaces[keyspace.Name] = true
+		}
+	}
+
+	coverage := make([]metrics.TableRepairCoverage, 0, len(tables))
+	for _, table := range tables {
+		if !replicatedKeyspaces[table.Keyspace] || table.GCGraceSeconds <= 0 {
+			continue
+		}
+
+		tableCoverage := metrics.TableRepairCoverage{
+			Keyspace:       table.Keyspace,
+			Table:          table.Name,
+			GCGraceSeconds: table.GCGraceSeconds,
+			State:          dbv1alpha1.RepairCoverageStateNeverRepaired,
+		}
+
+		for _, repairRun := range repairRuns {
+			if !completeRepairOf(cc, repairRun, table) {
+				continue
+			}
+
+			endTime := reaper.ParseTime(repairRun.EndTime)
+			if endTime != nil && (tableCoverage.LastCompleteRepair == nil || endTime.After(*tableCoverage.LastCompleteRepair)) {
+				tableCoverage.LastCompleteRepair = &endTime.Time
+			}
+		}
+
+		if tableCoverage.LastCompleteRepair != nil {
+			sinceLastRepair := now.Sub(*tableCoverage.LastCompleteRepair)
+			gcGrace := time.Duration(table.GCGraceSeconds) * time.Second
+			switch {
+			case sinceLastRepair > gcGrace:
+				tableCoverage.State = dbv1alpha1.RepairCoverageStateOverdue
+			case sinceLastRepair > gcGrace*time.Duration(cc.Spec.Reaper.GCGraceWarningPercent)/100:
+				tableCoverage.State = dbv1alpha1.RepairCoverageStateWarning
+			default:
+				tableCoverage.State = dbv1alpha1.RepairCoverageStateRepaired
+			}
+		}
+
+		coverage = append(coverage, tableCoverage)
+	}
+
+	sort.Slice(coverage, func(i, j int) bool {
+		if coverage[i].Keyspace != coverage[j].Keyspace {
+			return coverage[i].Keyspace < coverage[j].Keyspace
+		}
+		return coverage[i].Table < coverage[j].Table
+	})
+
+	return coverage
+}
+
+// repairCoverageStateRanks orders the states of the tables from the best to the worst
+var repairCoverageStateRanks = map[string]int{
+	dbv1alpha1.RepairCoverageStateRepaired:      0,
+	dbv1alpha1.RepairCoverageStateNeverRepaired: 1,
+	dbv1alpha1.RepairCoverageStateWarning:       2,
+	dbv1alpha1.RepairCoverageStateOverdue:       3,
+}
+
+// repairCoverageSummaries summarizes the coverage of the tables per keyspace. Expects the coverage sorted by keyspace.
+func repairCoverageSummaries(coverage []metrics.TableRepairCoverage) []dbv1alpha1.KeyspaceRepairCoverage {
+	var summaries []dbv1alpha1.KeyspaceRepairCoverage
+	for _, table := range coverage {
+		if len(summaries) == 0 || summaries[len(summaries)-1].Keyspace != table.Keyspace {
+			summaries = append(summaries, dbv1alpha1.KeyspaceRepairCoverage{Keyspace: table.Keyspace, State: dbv1alpha1.RepairCoverageStateRepaired})
+		}
+
+		summary := &summaries[len(summaries)-1]
+		summary.Tables++
+		switch table.State {
+		case dbv1alpha1.RepairCoverageStateWarning:
+			summary.Warning++
+		case dbv1alpha1.RepairCoverageStateOverdue:
+			summary.Overdue++
+		case dbv1alpha1.RepairCoverageStateNeverRepaired:
+			summary.NeverRepaired++
+		}
+
+		if repairCoverageStateRanks[table.State] > repairCoverageStateRanks[summary.State] {
+			summary.State = table.State
+		}
+
+		if table.LastCompleteRepair != nil && (summary.OldestCompleteRepair == nil || table.LastCompleteRepair.Before(summary.OldestCompleteRepair.Time)) {
+			summary.OldestCompleteRepair = &metav1.Time{Time: *table.LastCompleteRepair}
+		}
+	}
+
+	return summaries
+}
+
+// completeRepairOf returns true if the repair run has successfully repaired the table on all nodes of the cluster
+func completeRepairOf(cc *dbv1alpha1.CassandraCluster, repairRun reaper.RepairRun, table cql.Table) bool {
+	if repairRun.State != reaper.RepairStateDone || repairRun.KeyspaceName != table.Keyspace || len(repairRun.Nodes) > 0 {
+		return false
+	}
+
+	if len(repairRun.ColumnFamilies) > 0 && !util.Contains(repairRun.ColumnFamilies, table.Name) {
+		return false
+	}
+
+	if len(repairRun.Datacenters) > 0 {
+		for _, dc := range cc.Spec.DCs {
+			if !util.Contains(repairRun.Datacenters, dc.Name) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/metrics"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRepairCoverage(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	now := time.Date(2021, 9, 20, 12, 0, 0, 0, time.UTC)
+	cc := &v1alpha1.CassandraCluster{
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs:    []v1alpha1.DC{{Name: "dc1"}, {Name: "dc2"}},
+			Reaper: &v1alpha1.Reaper{GCGraceWarningPercent: 90},
+		},
+	}
+	keyspaces := []cql.Keyspace{
+		{Name: "system", Replication: map[string]string{"class": "org.apache.cassandra.locator.LocalStrategy"}},
+		{Name: "local_ks", Replication: map[string]string{"class": "org.apache.cassandra.locator.LocalStrategy"}},
+		{Name: "ks", Replication: map[string]string{"class": cql.ReplicationClassNetworkTopologyStrategy, "dc1": "3", "dc2": "3"}},
+	}
+	tenDays := int32(10 * 24 * 3600)
+	tables := []cql.Table{
+		{Keyspace: "system", Name: "peers", GCGraceSeconds: tenDays},
+		{Keyspace: "local_ks", Name: "table", GCGraceSeconds: tenDays},
+		{Keyspace: "ks", Name: "repaired", GCGraceSeconds: tenDays},
+		{Keyspace: "ks", Name: "warning", GCGraceSeconds: tenDays},
+		{Keyspace: "ks", Name: "overdue", GCGraceSeconds: tenDays},
+		{Keyspace: "ks", Name: "never", GCGraceSeconds: tenDays},
+		{Keyspace: "ks", Name: "no_gc_grace", GCGraceSeconds: 0},
+	}
+	repairRuns := []reaper.RepairRun{
+		// 12 days ago
+		{ID: "1", State: reaper.RepairStateDone, KeyspaceName: "ks", ColumnFamilies: []string{"overdue", "warning"}, EndTime: "2021-09-08T12:00:00Z"},
+		// 9.5 days ago
+		{ID: "2", State: reaper.RepairStateDone, KeyspaceName: "ks", ColumnFamilies: []string{"warning", "repaired"}, Datacenters: []string{"dc1", "dc2"}, EndTime: "2021-09-11T00:00:00Z"},
+		// a day ago
+		{ID: "3", State: reaper.RepairStateDone, KeyspaceName: "ks", ColumnFamilies: []string{"repaired"}, EndTime: "2021-09-19T12:00:00Z"},
+		// not complete repairs
+		{ID: "4", State: reaper.RepairStateDone, KeyspaceName: "ks", Datacenters: []string{"dc1"}, EndTime: "2021-09-20T00:00:00Z"},
+		{ID: "5", State: reaper.RepairStateDone, KeyspaceName: "ks", Nodes: []string{"10.0.0.1"}, EndTime: "2021-09-20T00:00:00Z"},
+		{ID: "6", State: reaper.RepairStateError, KeyspaceName: "ks", EndTime: "2021-09-20T00:00:00Z"},
+		{ID: "7", State: reaper.RepairStateRunning, KeyspaceName: "ks", ColumnFamilies: []string{"never"}},
+	}
+
+	parseTime := func(value string) *time.Time {
+		t, err := time.Parse(time.RFC3339, value)
+		asserts.Expect(err).ToNot(HaveOccurred())
+		t = t.Local()
+		return &t
+	}
+
+	coverage := repairCoverage(cc, keyspaces, tables, repairRuns, now)
+	asserts.Expect(coverage).To(Equal([]metrics.TableRepairCoverage{
+		{Keyspace: "ks", Table: "never", GCGraceSeconds: tenDays, State: v1alpha1.RepairCoverageStateNeverRepaired},
+		{Keyspace: "ks", Table: "overdue", GCGraceSeconds: tenDays, LastCompleteRepair: parseTime("2021-09-08T12:00:00Z"), State: v1alpha1.RepairCoverageStateOverdue},
+		{Keyspace: "ks", Table: "repaired", GCGraceSeconds: tenDays, LastCompleteRepair: parseTime("2021-09-19T12:00:00Z"), State: v1alpha1.RepairCoverageStateRepaired},
+		{Keyspace: "ks", Table: "warning", GCGraceSeconds: tenDays, LastCompleteRepair: parseTime("2021-09-11T00:00:00Z"), State: v1alpha1.RepairCoverageStateWarning},
+	}))
+
+	asserts.Expect(repairCoverageSummaries(coverage)).To(Equal([]v1alpha1.KeyspaceRepairCoverage{
+		{
+			Keyspace:             "ks",
+			Tables:               4,
+			State:                v1alpha1.RepairCoverageStateOverdue,
+			Warning:              1,
+			Overdue:              1,
+			NeverRepaired:        1,
+			OldestCompleteRepair: &metav1.Time{Time: *parseTime("2021-09-08T12:00:00Z")},
+		},
+	}))
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cron"
 	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/util"
 	"github.com/pkg/errors"
 )
 
@@ -45,11 +47,16 @@ func (r *CassandraClusterReconciler) reconcileRepairSchedules(ctx context.Contex
 	}
 
 	for _, desiredRepair := range cc.Spec.Reaper.RepairSchedules.Repairs {
+		if incrementalRepairMigrationPending(cc, desiredRepair) {
+			r.Log.Debugf("Keyspace %s is not migrated to incremental repairs yet, not updating its repair schedule", desiredRepair.Keyspace)
+			continue
+		}
+
 		found := false
 		for _, existingSchedule := range existingOperatorRepairSchedules {
 			if sameRepair(existingSchedule, desiredRepair) {
 				found = true
-				if !repairSchedulesEqual(existingSchedule, r.toReaperRepair(desiredRepair)) {
+				if !repairSchedulesEqual(existingSchedule, r.toReaperRepair(desiredRepair)) || cronScheduleChanged(existingSchedule, desiredRepair) {
 					r.Log.Infof("Updating repair schedule for keyspace %s", desiredRepair.Keyspace)
 					err = removeRepairSchedule(ctx, reaperClient, existingSchedule.ID)
 					if err != nil {
@@ -74,9 +81,241 @@ func (r *CassandraClusterReconciler) reconcileRepairSchedules(ctx context.Contex
 		}
 	}
 
+	return r.reconcileRepairWindows(ctx, cc, reaperClient)
+}
+
+// reconcileRepairWindows pauses the repair schedules outside of their allowed windows and during their blackout windows
+// and resumes them otherwise. The schedules stay paused while the repairs are paused for a disruptive operation.
+// The operator manages the state of the schedules it has created.
+func (r *CassandraClusterReconciler) reconcileRepairWindows(ctx context.Context, cc *dbv1alpha1.CassandraCluster, reaperClient reaper.ReaperClient) error {
+	existingRepairSchedules, err := reaperClient.RepairSchedules(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get repair schedules")
+	}
+	existingOperatorRepairSchedules := filterOperatorRepairSchedules(existingRepairSchedules)
+
+	// forget the schedules that were removed or resumed by the user
+	var pausedSchedules []string
+	for _, existingSchedule := range existingOperatorRepairSchedules {
+		if existingSchedule.State != reaper.RepairScheduleStateActive && util.Contains(cc.Status.PausedRepairSchedules, existingSchedule.ID) {
+			pausedSchedules = append(pausedSchedules, existingSchedule.ID)
+		}
+	}
+	if err = r.setPausedRepairSchedules(ctx, cc, pausedSchedules); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, desiredRepair := range cc.Spec.Reaper.RepairSchedules.Repairs {
+		for _, existingSchedule := range existingOperatorRepairSchedules {
+			if !sameRepair(existingSchedule, desiredRepair) {
+				continue
+			}
+
+			active := repairAllowed(desiredRepair, now) && cc.Status.RepairsPause == nil
+			if active == (existingSchedule.State == reaper.RepairScheduleStateActive) {
+				break
+			}
+
+			if active && !util.Contains(cc.Status.PausedRepairSchedules, existingSchedule.ID) {
+				r.Log.Debugf("Repair schedule for keyspace %s is paused by the user, not resuming it", desiredRepair.Keyspace)
+				break
+			}
+
+			if err = r.setRepairScheduleState(ctx, cc, reaperClient, existingSchedule, active); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
 	return nil
 }
 
+// setRepairScheduleState pauses or resumes the repair schedule and records the schedules paused by the operator in the status
+func (r *CassandraClusterReconciler) setRepairScheduleState(ctx context.Context, cc *dbv1alpha1.CassandraCluster, reaperClient reaper.ReaperClient,
+	schedule reaper.RepairSchedule, active bool) error {
+	pausedSchedules := make([]string, 0, len(cc.Status.PausedRepairSchedules)+1)
+	for _, id := range cc.Status.PausedRepairSchedules {
+		if id != schedule.ID {
+			pausedSchedules = append(pausedSchedules, id)
+		}
+	}
+
+	if active {
+		r.Log.Infof("Resuming repair schedule for keyspace %s", schedule.KeyspaceName)
+	} else {
+		r.Log.Infof("Pausing repair schedule for keyspace %s", schedule.KeyspaceName)
+		pausedSchedules = append(pausedSchedules, schedule.ID)
+		// recorded before the schedule is paused, so that it's resumed even if the reconcile fails after pausing it
+		if err := r.setPausedRepairSchedules(ctx, cc, pausedSchedules); err != nil {
+			return err
+		}
+	}
+
+	if err := reaperClient.SetRepairScheduleState(ctx, schedule.ID, active); err != nil {
+		return errors.Wrapf(err, "failed to set state of repair schedule %s", schedule.ID)
+	}
+
+	if active {
+		return r.setPausedRepairSchedules(ctx, cc, pausedSchedules)
+	}
+
+	return nil
+}
+
+// setPausedRepairSchedules persists the repair schedules paused by the operator if they have changed
+func (r *CassandraClusterReconciler) setPausedRepairSchedules(ctx context.Context, cc *dbv1alpha1.CassandraCluster, pausedSchedules []string) error {
+	if len(pausedSchedules) == 0 {
+		pausedSchedules = nil
+	}
+
+	if reflect.DeepEqual(cc.Status.PausedRepairSchedules, pausedSchedules) {
+		return nil
+	}
+
+	cc.Status.PausedRepairSchedules = pausedSchedules
+	return r.persistStatus(ctx, cc)
+}
+
+// repairAllowed returns true if the time is within the allowed windows of the repair schedule and not within its blackout windows
+func repairAllowed(repair dbv1alpha1.RepairSchedule, now time.Time) bool {
+	allowed := len(repair.AllowedWindows) == 0
+	for _, w := range repair.AllowedWindows {
+		window, err := cron.ParseWindow(w.Days, w.Start, w.End)
+		if err == nil && window.Contains(now) {
+			allowed = true
+			break
+		}
+	}
+
+	for _, w := range repair.BlackoutWindows {
+		window, err := cron.ParseWindow(w.Days, w.Start, w.End)
+		if err == nil && window.Contains(now) {
+			return false
+		}
+	}
+
+	return allowed
+}
+
+// cronSchedule returns the first trigger time and the days between the runs of a repair schedule defined by a cron expression
+func cronSchedule(repair dbv1alpha1.RepairSchedule, now time.Time) (time.Time, int32, error) {
+	schedule, err := cron.Parse(repair.Cron)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	daysBetween, err := cron.DaysBetween(schedule, now)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return schedule.Next(now), daysBetween, nil
+}
+
+// cronScheduleChanged returns true if the next activation of the Reaper schedule doesn't match the cron expression
+func cronScheduleChanged(existingRepair reaper.RepairSchedule, desiredRepair dbv1alpha1.RepairSchedule) bool {
+	if len(desiredRepair.Cron) == 0 {
+		return false
+	}
+
+	schedule, err := cron.Parse(desiredRepair.Cron)
+	nextActivation := reaper.ParseTime(existingRepair.NextActivation)
+	if err != nil || nextActivation == nil {
+		return false
+	}
+
+	activation := nextActivation.UTC().Truncate(time.Minute)
+	return !schedule.Next(activation.Add(-time.Minute)).Equal(activation)
+}
+
+// reconcileRepairSchedulesStatus shows the state of the repair schedules and their latest repair runs in the cluster status
+func (r *CassandraClusterReconciler) reconcileRepairSchedulesStatus(ctx context.Context, cc *dbv1alpha1.CassandraCluster, reaperClient reaper.ReaperClient) error {
+	if !cc.Spec.Reaper.RepairSchedules.Enabled || len(cc.Spec.Reaper.RepairSchedules.Repairs) == 0 {
+		cc.Status.RepairSchedules = nil
+		return nil
+	}
+
+	existingRepairSchedules, err := reaperClient.RepairSchedules(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get repair schedules")
+	}
+
+	repairRuns, err := reaperClient.RepairRuns(ctx, "")
+	if err != nil {
+		return errors.Wrap(err, "failed to get repair runs")
+	}
+
+	cc.Status.RepairSchedules = repairSchedulesStatus(cc.Spec.Reaper.RepairSchedules.Repairs, filterOperatorRepairSchedules(existingRepairSchedules), repairRuns)
+	return nil
+}
+
+func repairSchedulesStatus(desiredRepairs []dbv1alpha1.RepairSchedule, existingRepairSchedules []reaper.RepairSchedule, repairRuns []reaper.RepairRun) []dbv1alpha1.RepairScheduleStatus {
+	statuses := make([]dbv1alpha1.RepairScheduleStatus, 0, len(desiredRepairs))
+	for _, desiredRepair := range desiredRepairs {
+		status := dbv1alpha1.RepairScheduleStatus{
+			Keyspace: desiredRepair.Keyspace,
+			Tables:   append([]string(nil), desiredRepair.Tables...),
+		}
+
+		for _, existingSchedule := range existingRepairSchedules {
+			if !sameRepair(existingSchedule, desiredRepair) {
+				continue
+			}
+
+			status.ID = existingSchedule.ID
+			status.State = existingSchedule.State
+			status.NextActivation = reaper.ParseTime(existingSchedule.NextActivation)
+			status.LastRun = lastScheduledRun(existingSchedule.ID, repairRuns)
+			break
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// lastScheduledRun returns the latest repair run started by the repair schedule
+func lastScheduledRun(repairScheduleID string, repairRuns []reaper.RepairRun) *dbv1alpha1.ScheduledRepairRun {
+	var lastRun *reaper.RepairRun
+	var lastRunCreationTime time.Time
+	for i, repairRun := range repairRuns {
+		if repairRun.Cause != reaper.ScheduledRunCause(repairScheduleID) {
+			continue
+		}
+
+		var creationTime time.Time
+		if t := reaper.ParseTime(repairRun.CreationTime); t != nil {
+			creationTime = t.Time
+		}
+
+		if lastRun == nil || creationTime.After(lastRunCreationTime) {
+			lastRun = &repairRuns[i]
+			lastRunCreationTime = creationTime
+		}
+	}
+
+	if lastRun == nil {
+		return nil
+	}
+
+	var repairedPercent int32
+	if lastRun.TotalSegments > 0 {
+		repairedPercent = lastRun.SegmentsRepaired * 100 / lastRun.TotalSegments
+	}
+
+	return &dbv1alpha1.ScheduledRepairRun{
+		ID:              lastRun.ID,
+		State:           lastRun.State,
+		StartTime:       reaper.ParseTime(lastRun.StartTime),
+		EndTime:         reaper.ParseTime(lastRun.EndTime),
+		Duration:        lastRun.Duration,
+		RepairedPercent: repairedPercent,
+	}
+}
+
 func repairsToDelete(existingRepairs []reaper.RepairSchedule, desiredRepairs []dbv1alpha1.RepairSchedule) []reaper.RepairSchedule {
 	repairs := make([]reaper.RepairSchedule, 0)
 	for _, existingRepair := range existingRepairs {
@@ -109,7 +348,14 @@ func removeRepairSchedule(ctx context.Context, reaperClient reaper.ReaperClient,
 }
 
 func (r *CassandraClusterReconciler) createRepairSchedule(ctx context.Context, reaperClient reaper.ReaperClient, desiredRepairSchedule dbv1alpha1.RepairSchedule) error {
-	if err := rescheduleTimestamp(&desiredRepairSchedule); err != nil {
+	if len(desiredRepairSchedule.Cron) > 0 {
+		triggerTime, daysBetween, err := cronSchedule(desiredRepairSchedule, time.Now())
+		if err != nil {
+			return errors.Wrapf(err, "invalid cron of the repair schedule for keyspace %s", desiredRepairSchedule.Keyspace)
+		}
+		desiredRepairSchedule.ScheduleTriggerTime = triggerTime.Format(dbv1alpha1.ISOFormat)
+		desiredRepairSchedule.ScheduleDaysBetween = daysBetween
+	} else if err := rescheduleTimestamp(&desiredRepairSchedule); err != nil {
 		desiredRepairSchedule.ScheduleTriggerTime = ""
 		r.Log.Warnf("failed to reschedule scheduledTriggerTime: %s", err.Error())
 	}
@@ -133,7 +379,7 @@ func filterOperatorRepairSchedules(existingRepairSchedules []reaper.RepairSchedu
 }
 
 func repairSchedulesEqual(x, y reaper.RepairSchedule) bool {
-	return cmp.Equal(x, y, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(reaper.RepairSchedule{}, "ID", "State"))
+	return cmp.Equal(x, y, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(reaper.RepairSchedule{}, "ID", "State", "NextActivation"))
 }
 
 func sameRepair(reaperRepair reaper.RepairSchedule, crRepair dbv1alpha1.RepairSchedule) bool {
@@ -155,11 +401,19 @@ func (r *CassandraClusterReconciler) toReaperRepair(repair dbv1alpha1.RepairSche
 		}
 	}
 
+	scheduleDaysBetween := repair.ScheduleDaysBetween
+	if len(repair.Cron) > 0 {
+		_, scheduleDaysBetween, err = cronSchedule(repair, time.Now())
+		if err != nil {
+			r.Log.Warnf("can't parse repair schedule cron %q: %s", repair.Cron, err.Error())
+		}
+	}
+
 	return reaper.RepairSchedule{
 		KeyspaceName:        repair.Keyspace,
 		Owner:               reaper.OwnerCassandraOperator,
 		Tables:              repair.Tables,
-		ScheduleDaysBetween: repair.ScheduleDaysBetween,
+		ScheduleDaysBetween: scheduleDaysBetween,
 		Datacenters:         repair.Datacenters,
 		IncrementalRepair:   repair.IncrementalRepair,
 		RepairThreadCount:   repair.RepairThreadCount,
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRepairSchedulesStatus(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	desiredRepairs := []v1alpha1.RepairSchedule{
+		{Keyspace: "system_auth"},
+		{Keyspace: "ks", Tables: []string{"table1"}},
+		{Keyspace: "not_created"},
+	}
+	existingRepairSchedules := []reaper.RepairSchedule{
+		{ID: "schedule-1", KeyspaceName: "system_auth", State: "ACTIVE", NextActivation: "2021-09-08T04:00:00Z"},
+		{ID: "schedule-2", KeyspaceName: "ks", Tables: []string{"table1"}, State: "PAUSED"},
+	}
+	repairRuns := []reaper.RepairRun{
+		{
+			ID:               "run-1",
+			State:            reaper.RepairStateDone,
+			Cause:            reaper.ScheduledRunCause("schedule-1"),
+			CreationTime:     "2021-08-25T04:00:00Z",
+			StartTime:        "2021-08-25T04:00:00Z",
+			EndTime:          "2021-08-25T05:00:00Z",
+			Duration:         "1 hour",
+			TotalSegments:    10,
+			SegmentsRepaired: 10,
+		},
+		{
+			ID:               "run-2",
+			State:            reaper.RepairStateRunning,
+			Cause:            reaper.ScheduledRunCause("schedule-1"),
+			CreationTime:     "2021-09-01T04:00:00Z",
+			StartTime:        "2021-09-01T04:00:00Z",
+			TotalSegments:    8,
+			SegmentsRepaired: 2,
+		},
+		{
+			ID:           "run-3",
+			State:        reaper.RepairStateRunning,
+			Cause:        "manual run",
+			CreationTime: "2021-09-02T04:00:00Z",
+		},
+	}
+
+	parseTime := func(value string) *metav1.Time {
+		t, err := time.Parse(time.RFC3339, value)
+		asserts.Expect(err).ToNot(HaveOccurred())
+		return &metav1.Time{Time: t.Local()}
+	}
+
+	asserts.Expect(repairSchedulesStatus(desiredRepairs, existingRepairSchedules, repairRuns)).To(Equal([]v1alpha1.RepairScheduleStatus{
+		{
+			Keyspace:       "system_auth",
+			ID:             "schedule-1",
+			State:          "ACTIVE",
+			NextActivation: parseTime("2021-09-08T04:00:00Z"),
+			LastRun: &v1alpha1.ScheduledRepairRun{
+				ID:              "run-2",
+				State:           reaper.RepairStateRunning,
+				StartTime:       parseTime("2021-09-01T04:00:00Z"),
+				RepairedPercent: 25,
+			},
+		},
+		{
+			Keyspace: "ks",
+			Tables:   []string{"table1"},
+			ID:       "schedule-2",
+			State:    "PAUSED",
+		},
+		{
+			Keyspace: "not_created",
+		},
+	}))
+}
+
+func TestRepairAllowed(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	monday := time.Date(2021, 9, 6, 0, 0, 0, 0, time.UTC)
+	repair := v1alpha1.RepairSchedule{
+		Keyspace:        "ks",
+		AllowedWindows:  []v1alpha1.RepairWindow{{Start: "22:00", End: "06:00"}},
+		BlackoutWindows: []v1alpha1.RepairWindow{{Days: []string{"SUN"}, Start: "00:00", End: "00:00"}},
+	}
+
+	asserts.Expect(repairAllowed(v1alpha1.RepairSchedule{Keyspace: "ks"}, monday.Add(12*time.Hour))).To(BeTrue())
+	asserts.Expect(repairAllowed(repair, monday.Add(23*time.Hour))).To(BeTrue())
+	asserts.Expect(repairAllowed(repair, monday.Add(12*time.Hour))).To(BeFalse())
+	asserts.Expect(repairAllowed(repair, monday.Add(-time.Hour))).To(BeFalse(), "Sunday is blacked out")
+}
+
+func TestReconcileRepairWindows(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			Reaper: &v1alpha1.Reaper{
+				RepairSchedules: v1alpha1.RepairSchedules{
+					Enabled: true,
+					Repairs: []v1alpha1.RepairSchedule{
+						{Keyspace: "ks1"},
+						{Keyspace: "ks2"},
+						{Keyspace: "ks3", BlackoutWindows: []v1alpha1.RepairWindow{{Start: "00:00", End: "00:00"}}},
+					},
+				},
+			},
+		},
+		Status: v1alpha1.CassandraClusterStatus{
+			PausedRepairSchedules: []string{"schedule-1", "schedule-removed"},
+		},
+	}
+
+	reconciler, mCtrl, m := createMockedReconciler(t)
+	defer mCtrl.Finish()
+	reconciler.Client = fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(cc.DeepCopy()).Build()
+
+	m.reaper.EXPECT().RepairSchedules(gomock.Any()).Return([]reaper.RepairSchedule{
+		{ID: "schedule-1", KeyspaceName: "ks1", Owner: reaper.OwnerCassandraOperator, State: reaper.RepairScheduleStatePaused},
+		{ID: "schedule-2", KeyspaceName: "ks2", Owner: reaper.OwnerCassandraOperator, State: reaper.RepairScheduleStatePaused},
+		{ID: "schedule-3", KeyspaceName: "ks3", Owner: reaper.OwnerCassandraOperator, State: reaper.RepairScheduleStateActive},
+	}, nil)
+	// schedule-2 is paused by the user
+	m.reaper.EXPECT().SetRepairScheduleState(gomock.Any(), "schedule-1", true).Return(nil)
+	m.reaper.EXPECT().SetRepairScheduleState(gomock.Any(), "schedule-3", false).Return(nil)
+
+	err := reconciler.reconcileRepairWindows(context.Background(), cc, m.reaper)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(cc.Status.PausedRepairSchedules).To(Equal([]string{"schedule-3"}))
+
+	persistedCC := &v1alpha1.CassandraCluster{}
+	asserts.Expect(reconciler.Get(context.Background(), client.ObjectKeyFromObject(cc), persistedCC)).To(Succeed())
+	asserts.Expect(persistedCC.Status.PausedRepairSchedules).To(Equal([]string{"schedule-3"}))
+}
+
+func TestCronScheduleChanged(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	desired := v1alpha1.RepairSchedule{Keyspace: "ks", Cron: "0 1 * * SAT"}
+
+	asserts.Expect(cronScheduleChanged(reaper.RepairSchedule{NextActivation: "2021-09-11T01:00:00Z"}, desired)).To(BeFalse())
+	asserts.Expect(cronScheduleChanged(reaper.RepairSchedule{NextActivation: "2021-09-10T01:00:00Z"}, desired)).To(BeTrue())
+	asserts.Expect(cronScheduleChanged(reaper.RepairSchedule{}, desired)).To(BeFalse())
+	asserts.Expect(cronScheduleChanged(reaper.RepairSchedule{NextActivation: "2021-09-10T01:00:00Z"}, v1alpha1.RepairSchedule{Keyspace: "ks"})).To(BeFalse())
+
+	triggerTime, daysBetween, err := cronSchedule(desired, time.Date(2021, 9, 6, 0, 0, 0, 0, time.UTC))
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(triggerTime).To(Equal(time.Date(2021, 9, 11, 1, 0, 0, 0, time.UTC)))
+	asserts.Expect(daysBetween).To(Equal(int32(7)))
+}
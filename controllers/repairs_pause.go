@@ -0,0 +1,240 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/labels"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	repairsPauseReasonScaling        = "scaling"
+	repairsPauseReasonRollingRestart = "rolling restart"
+	repairsPauseReasonMaintenance    = "maintenance"
+	repairsPauseReasonRestore        = "restore"
+)
+
+// repairsPauseReason returns the disruptive operation in progress the repairs should be paused for.
+// Returns an empty string if there's none.
+func (r *CassandraClusterReconciler) repairsPauseReason(ctx context.Context, cc *dbv1alpha1.CassandraCluster) (string, error) {
+	stsList := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, stsList, client.InNamespace(cc.Namespace), client.MatchingLabels(labels.Cassandra(cc))); err != nil {
+		return "", errors.Wrap(err, "can't get statefulsets")
+	}
+
+	dcToReplicas := dcsMap(cc)
+	for _, sts := range stsList.Items {
+		if replicas, found := dcToReplicas[sts.Labels[dbv1alpha1.CassandraClusterDC]]; !found || statefulSetReplicas(sts) != replicas {
+			return repairsPauseReasonScaling, nil
+		}
+	}
+
+	if len(cc.Spec.Maintenance) > 0 {
+		return repairsPauseReasonMaintenance, nil
+	}
+
+	if cc.Status.RestoreFromState == dbv1alpha1.RestoreFromStateRestoring {
+		return repairsPauseReasonRestore, nil
+	}
+
+	restores := &dbv1alpha1.CassandraRestoreList{}
+	if err := r.List(ctx, restores, client.InNamespace(cc.Namespace)); err != nil {
+		return "", errors.Wrap(err, "can't get restores")
+	}
+	for _, restore := range restores.Items {
+		if restore.Spec.CassandraCluster == cc.Name && restoreInProgress(restore) {
+			return repairsPauseReasonRestore, nil
+		}
+	}
+
+	for _, sts := range stsList.Items {
+		if statefulSetRollingOut(sts) {
+			return repairsPauseReasonRollingRestart, nil
+		}
+	}
+
+	return "", nil
+}
+
+func restoreInProgress(restore dbv1alpha1.CassandraRestore) bool {
+	switch restore.Status.State {
+	case icarus.StateCompleted, icarus.StateFailed, icarus.StateCancelled:
+		return false
+	}
+
+	return restore.DeletionTimestamp == nil
+}
+
+// statefulSetRollingOut returns true if the statefulset's pods are being restarted or are not ready yet
+func statefulSetRollingOut(sts appsv1.StatefulSet) bool {
+	return sts.Status.CurrentRevision != sts.Status.UpdateRevision ||
+		sts.Status.ReadyReplicas < statefulSetReplicas(sts)
+}
+
+func statefulSetReplicas(sts appsv1.StatefulSet) int32 {
+	if sts.Spec.Replicas == nil {
+		return 1
+	}
+
+	return *sts.Spec.Replicas
+}
+
+// ensureRepairsPaused pauses the repairs before a disruptive operation is started. Reaper errors hold the operation back
+// until the repairs can be paused. Nothing is paused if Reaper is not running.
+func (r *CassandraClusterReconciler) ensureRepairsPaused(ctx context.Context, cc *dbv1alpha1.CassandraCluster, reason string) error {
+	if cc.Status.RepairsPause != nil {
+		return nil
+	}
+
+	return errors.Wrapf(r.reconcileRepairsPause(ctx, cc, reason), "failed to pause repairs before %s", reason)
+}
+
+// reconcileRepairsPause pauses the operator's repair schedules and the running repair runs while a disruptive operation
+// is in progress and resumes them once it's finished. The paused repair runs are recorded in the cluster status,
+// so that only the repair runs paused by the operator are resumed. The status is persisted right away.
+func (r *CassandraClusterReconciler) reconcileRepairsPause(ctx context.Context, cc *dbv1alpha1.CassandraCluster, reason string) error {
+	if len(reason) == 0 && cc.Status.RepairsPause == nil {
+		return nil
+	}
+
+	if builtinRepairEnabled(cc) { // the builtin repairs don't start new segments while paused
+		if len(reason) > 0 {
+			r.markRepairsPaused(cc, reason)
+		} else {
+			r.markRepairsResumed(cc)
+		}
+		return r.persistStatus(ctx, cc)
+	}
+
+	reaperClient := r.ReaperClient(names.ReaperServiceURL(cc), cc.Name, cc.Spec.Reaper.RepairThreadCount)
+	isRunning, err := reaperClient.IsRunning(ctx)
+	if err != nil || !isRunning {
+		r.Log.Debugf("Reaper is not running, not reconciling repairs pause")
+		return nil
+	}
+
+	clusterExists, err := reaperClient.ClusterExists(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to check if the cluster exists in reaper")
+	}
+
+	if len(reason) > 0 {
+		if !clusterExists { // nothing to pause yet
+			return nil
+		}
+		return r.pauseRepairs(ctx, cc, reaperClient, reason)
+	}
+
+	if clusterExists {
+		if err = r.resumeRepairRuns(ctx, reaperClient, cc.Status.RepairsPause.RepairRunIDs); err != nil {
+			return err
+		}
+	}
+
+	r.markRepairsResumed(cc)
+	if err = r.persistStatus(ctx, cc); err != nil {
+		return err
+	}
+
+	if !clusterExists || !cc.Spec.Reaper.RepairSchedules.Enabled {
+		return nil
+	}
+
+	// the schedules are resumed according to their repair windows
+	return r.reconcileRepairWindows(ctx, cc, reaperClient)
+}
+
+func (r *CassandraClusterReconciler) markRepairsPaused(cc *dbv1alpha1.CassandraCluster, reason string) {
+	if cc.Status.RepairsPause == nil {
+		msg := fmt.Sprintf("Repairs paused while %s is in progress", reason)
+		r.Log.Info(msg)
+		r.Events.Normal(cc, events.EventRepairsPaused, msg)
+		cc.Status.RepairsPause = &dbv1alpha1.RepairsPause{Since: metav1.Now()}
+	}
+	cc.Status.RepairsPause.Reason = reason
+}
+
+func (r *CassandraClusterReconciler) markRepairsResumed(cc *dbv1alpha1.CassandraCluster) {
+	r.Log.Infof("Repairs resumed")
+	r.Events.Normal(cc, events.EventRepairsResumed, fmt.Sprintf("Repairs resumed after %s", cc.Status.RepairsPause.Reason))
+	cc.Status.RepairsPause = nil
+}
+
+func (r *CassandraClusterReconciler) pauseRepairs(ctx context.Context, cc *dbv1alpha1.CassandraCluster, reaperClient reaper.ReaperClient, reason string) error {
+	r.markRepairsPaused(cc, reason)
+	if err := r.persistStatus(ctx, cc); err != nil {
+		return err
+	}
+
+	repairSchedules, err := reaperClient.RepairSchedules(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get repair schedules")
+	}
+
+	for _, schedule := range filterOperatorRepairSchedules(repairSchedules) {
+		if schedule.State != reaper.RepairScheduleStateActive {
+			continue
+		}
+
+		if err = r.setRepairScheduleState(ctx, cc, reaperClient, schedule, false); err != nil {
+			return err
+		}
+	}
+
+	repairRuns, err := reaperClient.RepairRuns(ctx, "")
+	if err != nil {
+		return errors.Wrap(err, "failed to get repair runs")
+	}
+
+	for _, repairRun := range repairRuns {
+		if repairRun.State != reaper.RepairStateRunning {
+			continue
+		}
+
+		// recorded before the repair run is paused, so that it's resumed even if the reconcile fails after pausing it
+		if !util.Contains(cc.Status.RepairsPause.RepairRunIDs, repairRun.ID) {
+			cc.Status.RepairsPause.RepairRunIDs = append(cc.Status.RepairsPause.RepairRunIDs, repairRun.ID)
+			if err = r.persistStatus(ctx, cc); err != nil {
+				return err
+			}
+		}
+
+		r.Log.Infof("Pausing repair run %s of keyspace %s", repairRun.ID, repairRun.KeyspaceName)
+		if err = reaperClient.SetRepairRunState(ctx, repairRun.ID, reaper.RepairStatePaused); err != nil {
+			return errors.Wrapf(err, "failed to pause repair run %s", repairRun.ID)
+		}
+	}
+
+	return nil
+}
+
+func (r *CassandraClusterReconciler) resumeRepairRuns(ctx context.Context, reaperClient reaper.ReaperClient, repairRunIDs []string) error {
+	repairRuns, err := reaperClient.RepairRuns(ctx, "")
+	if err != nil {
+		return errors.Wrap(err, "failed to get repair runs")
+	}
+
+	for _, repairRun := range repairRuns {
+		if repairRun.State != reaper.RepairStatePaused || !util.Contains(repairRunIDs, repairRun.ID) {
+			continue
+		}
+
+		r.Log.Infof("Resuming repair run %s of keyspace %s", repairRun.ID, repairRun.KeyspaceName)
+		if err = reaperClient.SetRepairRunState(ctx, repairRun.ID, reaper.RepairStateRunning); err != nil {
+			return errors.Wrapf(err, "failed to resume repair run %s", repairRun.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/mock/gomock"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStatefulSetRollingOut(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	sts := appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{Replicas: proto.Int32(3)},
+		Status: appsv1.StatefulSetStatus{
+			ReadyReplicas:   3,
+			CurrentRevision: "rev-1",
+			UpdateRevision:  "rev-1",
+		},
+	}
+	asserts.Expect(statefulSetRollingOut(sts)).To(BeFalse())
+
+	restarting := sts.DeepCopy()
+	restarting.Status.UpdateRevision = "rev-2"
+	asserts.Expect(statefulSetRollingOut(*restarting)).To(BeTrue())
+
+	notReady := sts.DeepCopy()
+	notReady.Status.ReadyReplicas = 2
+	asserts.Expect(statefulSetRollingOut(*notReady)).To(BeTrue())
+}
+
+func TestReconcileRepairsPause(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			Reaper: &v1alpha1.Reaper{},
+		},
+	}
+
+	reconciler, mCtrl, m := createMockedReconciler(t)
+	defer mCtrl.Finish()
+	reconciler.Client = fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(cc.DeepCopy()).Build()
+
+	m.reaper.EXPECT().IsRunning(gomock.Any()).Return(true, nil).AnyTimes()
+	m.reaper.EXPECT().ClusterExists(gomock.Any()).Return(true, nil).AnyTimes()
+
+	// pause
+	m.reaper.EXPECT().RepairSchedules(gomock.Any()).Return([]reaper.RepairSchedule{
+		{ID: "schedule-1", Owner: reaper.OwnerCassandraOperator, State: reaper.RepairScheduleStateActive},
+		{ID: "schedule-2", Owner: reaper.OwnerCassandraOperator, State: reaper.RepairScheduleStatePaused},
+		{ID: "schedule-3", Owner: "user", State: reaper.RepairScheduleStateActive},
+	}, nil)
+	m.reaper.EXPECT().SetRepairScheduleState(gomock.Any(), "schedule-1", false).Return(nil)
+	m.reaper.EXPECT().RepairRuns(gomock.Any(), "").Return([]reaper.RepairRun{
+		{ID: "run-1", State: reaper.RepairStateRunning},
+		{ID: "run-2", State: reaper.RepairStatePaused},
+		{ID: "run-3", State: reaper.RepairStateDone},
+	}, nil)
+	m.reaper.EXPECT().SetRepairRunState(gomock.Any(), "run-1", reaper.RepairStatePaused).Return(nil)
+
+	err := reconciler.reconcileRepairsPause(context.Background(), cc, repairsPauseReasonScaling)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(cc.Status.RepairsPause).ToNot(BeNil())
+	asserts.Expect(cc.Status.RepairsPause.Reason).To(Equal(repairsPauseReasonScaling))
+	asserts.Expect(cc.Status.RepairsPause.RepairRunIDs).To(Equal([]string{"run-1"}))
+	asserts.Expect(cc.Status.PausedRepairSchedules).To(Equal([]string{"schedule-1"}))
+
+	persistedCC := &v1alpha1.CassandraCluster{}
+	asserts.Expect(reconciler.Get(context.Background(), client.ObjectKeyFromObject(cc), persistedCC)).To(Succeed())
+	asserts.Expect(persistedCC.Status.PausedRepairSchedules).To(Equal([]string{"schedule-1"}))
+	asserts.Expect(persistedCC.Status.RepairsPause).ToNot(BeNil())
+	asserts.Expect(persistedCC.Status.RepairsPause.RepairRunIDs).To(Equal([]string{"run-1"}))
+
+	// resume only the repair runs paused by the operator
+	m.reaper.EXPECT().RepairRuns(gomock.Any(), "").Return([]reaper.RepairRun{
+		{ID: "run-1", State: reaper.RepairStatePaused},
+		{ID: "run-2", State: reaper.RepairStatePaused},
+	}, nil)
+	m.reaper.EXPECT().SetRepairRunState(gomock.Any(), "run-1", reaper.RepairStateRunning).Return(nil)
+
+	err = reconciler.reconcileRepairsPause(context.Background(), cc, "")
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(cc.Status.RepairsPause).To(BeNil())
+
+	asserts.Expect(reconciler.Get(context.Background(), client.ObjectKeyFromObject(cc), persistedCC)).To(Succeed())
+	asserts.Expect(persistedCC.Status.RepairsPause).To(BeNil())
+}
+
+func TestEnsureRepairsPaused(t *testing.T) {
+	asserts := NewGomegaWithT(t)
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: v1alpha1.CassandraClusterSpec{
+			Reaper: &v1alpha1.Reaper{},
+		},
+	}
+
+	reconciler, mCtrl, m := createMockedReconciler(t)
+	defer mCtrl.Finish()
+	reconciler.Client = fake.NewClientBuilder().WithScheme(baseScheme).WithObjects(cc.DeepCopy()).Build()
+
+	m.reaper.EXPECT().IsRunning(gomock.Any()).Return(true, nil).AnyTimes()
+	m.reaper.EXPECT().ClusterExists(gomock.Any()).Return(true, nil).AnyTimes()
+
+	m.reaper.EXPECT().RepairSchedules(gomock.Any()).Return(nil, nil)
+	m.reaper.EXPECT().RepairRuns(gomock.Any(), "").Return(nil, nil)
+	err := reconciler.ensureRepairsPaused(context.Background(), cc, repairsPauseReasonRollingRestart)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(cc.Status.RepairsPause).ToNot(BeNil())
+	asserts.Expect(cc.Status.RepairsPause.Reason).To(Equal(repairsPauseReasonRollingRestart))
+
+	// already paused
+	err = reconciler.ensureRepairsPaused(context.Background(), cc, repairsPauseReasonScaling)
+	asserts.Expect(err).ToNot(HaveOccurred())
+	asserts.Expect(cc.Status.RepairsPause.Reason).To(Equal(repairsPauseReasonRollingRestart))
+
+	// the operation is held back if the repairs can't be paused
+	cc.Status.RepairsPause = nil
+	m.reaper.EXPECT().RepairSchedules(gomock.Any()).Return(nil, errors.New("reaper error"))
+	err = reconciler.ensureRepairsPaused(context.Background(), cc, repairsPauseReasonScaling)
+	asserts.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	v1 "k8s.io/api/core/v1"
+)
+
+// completeRestoreFromBackup marks the bootstrap from a backup as completed. Should be called only when all pods are ready,
+// i.e. the restore init containers have finished on all nodes.
+func (r *CassandraClusterReconciler) completeRestoreFromBackup(cc *v1alpha1.CassandraCluster) {
+	if cc.Spec.RestoreFrom == nil || cc.Status.RestoreFromState == v1alpha1.RestoreFromStateCompleted {
+		return
+	}
+
+	msg := fmt.Sprintf("Cluster has been restored from backup %s of cluster %s", cc.Spec.RestoreFrom.SnapshotTag, cc.Spec.RestoreFrom.SourceCluster)
+	r.Log.Info(msg)
+	r.Events.Normal(cc, events.EventRestoreFromBackupCompleted, msg)
+	cc.Status.RestoreFromState = v1alpha1.RestoreFromStateCompleted
+}
+
+// restoreStorageLocation returns the location of the backed up node the pod is restored from.
+// The pod is mapped to the node with the same DC name and ordinal in the source cluster.
+// The node's host ID is resolved by its hostname from the topology file uploaded with the backup.
+func restoreStorageLocation(cc *v1alpha1.CassandraCluster, pod v1.Pod) string {
+	dcName := pod.Labels[v1alpha1.CassandraClusterDC]
+	ordinal := strings.TrimPrefix(pod.Name, names.DC(cc.Name, dcName)+"-")
+	storageLocation := strings.TrimSuffix(cc.Spec.RestoreFrom.StorageLocation, "/")
+
+	return fmt.Sprintf("%s/%s/%s/%s-%s", storageLocation, cc.Spec.RestoreFrom.SourceCluster, dcName, names.DC(cc.Spec.RestoreFrom.SourceCluster, dcName), ordinal)
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/events"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/util"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	safetySnapshotCQLConfigMap  = "cql"
+	safetySnapshotReplication   = "rf"
+	safetySnapshotDecommission  = "decommission"
+	safetySnapshotUpgrade       = "upgrade"
+	safetySnapshotChecksumChars = 10
+)
+
+var (
+	// errSafetySnapshotInProgress is returned while the operation waits for its safety snapshot to be taken
+	errSafetySnapshotInProgress = errors.New("safety snapshot in progress")
+	// errSafetySnapshotFailed is returned while the safety snapshot of the operation is failed and the failure policy is Fail
+	errSafetySnapshotFailed = errors.New("safety snapshot failed")
+)
+
+// ensureSafetySnapshot makes sure a snapshot is taken on every node before a risky operation is started.
+// The operation is identified by its type and the checksum of its input, so the snapshot is taken only once per operation.
+// Returns errSafetySnapshotInProgress until the snapshot is taken. Does nothing if safety snapshots are not enabled.
+// If the snapshot fails, returns errSafetySnapshotFailed until the failed CassandraSnapshot is deleted, unless the failure policy is Ignore.
+func (r *CassandraClusterReconciler) ensureSafetySnapshot(ctx context.Context, cc *dbv1alpha1.CassandraCluster, operation, operationInput string, keyspaces []string) error {
+	if cc.Spec.SafetySnapshots == nil {
+		return nil
+	}
+
+	name := names.SafetySnapshot(cc.Name, operation, util.Sha1(operationInput)[:safetySnapshotChecksumChars])
+	cs := &dbv1alpha1.CassandraSnapshot{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: cc.Namespace}, cs)
+	if err != nil && apierrors.IsNotFound(err) {
+		cs = &dbv1alpha1.CassandraSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: cc.Namespace,
+			},
+			Spec: dbv1alpha1.CassandraSnapshotSpec{
+				CassandraCluster: cc.Name,
+				Keyspaces:        keyspaces,
+				RetentionSeconds: cc.Spec.SafetySnapshots.RetentionSeconds,
+				TimeoutSeconds:   cc.Spec.SafetySnapshots.TimeoutSeconds,
+			},
+		}
+		if err = controllerutil.SetControllerReference(cc, cs, r.Scheme); err != nil {
+			return errors.Wrap(err, "cannot set controller reference")
+		}
+
+		r.Log.Infof("Creating safety snapshot %s", name)
+		if err = r.Create(ctx, cs); err != nil {
+			return errors.Wrapf(err, "failed to create safety snapshot %s", name)
+		}
+
+		keyspacesMsg := "all keyspaces"
+		if len(keyspaces) > 0 {
+			keyspacesMsg = "keyspaces " + strings.Join(keyspaces, ", ")
+		}
+		msg := fmt.Sprintf("Taking snapshot with tag %q of %s before %s", name, keyspacesMsg, safetySnapshotOperationDescription(operation))
+		r.Events.Normal(cc, events.EventSafetySnapshotStarted, msg)
+		return errSafetySnapshotInProgress
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get safety snapshot %s", name)
+	}
+
+	if cs.Status.State == dbv1alpha1.SnapshotStateFailed {
+		if cc.Spec.SafetySnapshots.FailurePolicy == dbv1alpha1.SafetySnapshotFailurePolicyIgnore {
+			r.Log.Warnf("Safety snapshot %s failed. Proceeding with %s without the snapshot as the failure policy is %s",
+				name, safetySnapshotOperationDescription(operation), dbv1alpha1.SafetySnapshotFailurePolicyIgnore)
+			return nil
+		}
+
+		msg := fmt.Sprintf("Safety snapshot %q failed. Not %s. Delete the CassandraSnapshot to take the snapshot again "+
+			"or set the safety snapshots failure policy to %s to proceed without it", name, safetySnapshotOperationDescription(operation), dbv1alpha1.SafetySnapshotFailurePolicyIgnore)
+		r.Log.Warn(msg)
+		r.Events.Warning(cc, events.EventSafetySnapshotFailed, msg)
+		return errSafetySnapshotFailed
+	}
+
+	if cs.Status.State != dbv1alpha1.SnapshotStateCompleted && cs.Status.State != dbv1alpha1.SnapshotStateCleared {
+		r.Log.Infof("Waiting for safety snapshot %s to be taken before %s", name, safetySnapshotOperationDescription(operation))
+		return errSafetySnapshotInProgress
+	}
+
+	return nil
+}
+
+func safetySnapshotOperationDescription(operation string) string {
+	switch operation {
+	case safetySnapshotCQLConfigMap:
+		return "executing CQL ConfigMap scripts"
+	case safetySnapshotReplication:
+		return "changing keyspace replication"
+	case safetySnapshotDecommission:
+		return "decommissioning DCs"
+	case safetySnapshotUpgrade:
+		return "upgrading Cassandra"
+	}
+
+	return operation
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/util"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureSafetySnapshot(t *testing.T) {
+	asserts := NewWithT(t)
+	ctx := context.Background()
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+	}
+
+	reconciler := createBasicMockedReconciler()
+	reconciler.Scheme = baseScheme
+	reconciler.Client = fake.NewClientBuilder().WithScheme(baseScheme).Build()
+
+	// disabled
+	asserts.Expect(reconciler.ensureSafetySnapshot(ctx, cc, safetySnapshotUpgrade, "cassandra:4.0.1", nil)).To(Succeed())
+	snapshots := &v1alpha1.CassandraSnapshotList{}
+	asserts.Expect(reconciler.List(ctx, snapshots)).To(Succeed())
+	asserts.Expect(snapshots.Items).To(BeEmpty())
+
+	cc.Spec.SafetySnapshots = &v1alpha1.SafetySnapshots{RetentionSeconds: 3600, TimeoutSeconds: 600, FailurePolicy: v1alpha1.SafetySnapshotFailurePolicyFail}
+	err := reconciler.ensureSafetySnapshot(ctx, cc, safetySnapshotReplication, "system_auth/map[dc1:3]", []string{"system_auth"})
+	asserts.Expect(err).To(Equal(errSafetySnapshotInProgress))
+
+	name := names.SafetySnapshot(cc.Name, safetySnapshotReplication, util.Sha1("system_auth/map[dc1:3]")[:safetySnapshotChecksumChars])
+	cs := &v1alpha1.CassandraSnapshot{}
+	asserts.Expect(reconciler.Get(ctx, types.NamespacedName{Name: name, Namespace: cc.Namespace}, cs)).To(Succeed())
+	asserts.Expect(cs.Spec.CassandraCluster).To(Equal(cc.Name))
+	asserts.Expect(cs.Spec.Keyspaces).To(Equal([]string{"system_auth"}))
+	asserts.Expect(cs.Spec.RetentionSeconds).To(BeEquivalentTo(3600))
+	asserts.Expect(cs.Spec.TimeoutSeconds).To(BeEquivalentTo(600))
+	asserts.Expect(cs.OwnerReferences).To(HaveLen(1))
+
+	// still running
+	asserts.Expect(reconciler.ensureSafetySnapshot(ctx, cc, safetySnapshotReplication, "system_auth/map[dc1:3]", []string{"system_auth"})).To(Equal(errSafetySnapshotInProgress))
+
+	cs.Status.State = v1alpha1.SnapshotStateCompleted
+	asserts.Expect(reconciler.Status().Update(ctx, cs)).To(Succeed())
+	asserts.Expect(reconciler.ensureSafetySnapshot(ctx, cc, safetySnapshotReplication, "system_auth/map[dc1:3]", []string{"system_auth"})).To(Succeed())
+
+	// another operation requires a new snapshot
+	asserts.Expect(reconciler.ensureSafetySnapshot(ctx, cc, safetySnapshotReplication, "system_auth/map[dc1:5]", []string{"system_auth"})).To(Equal(errSafetySnapshotInProgress))
+	asserts.Expect(reconciler.List(ctx, snapshots)).To(Succeed())
+	asserts.Expect(snapshots.Items).To(HaveLen(2))
+
+	// failed snapshot holds the operation back unless the failure policy is Ignore
+	name = names.SafetySnapshot(cc.Name, safetySnapshotReplication, util.Sha1("system_auth/map[dc1:5]")[:safetySnapshotChecksumChars])
+	asserts.Expect(reconciler.Get(ctx, types.NamespacedName{Name: name, Namespace: cc.Namespace}, cs)).To(Succeed())
+	cs.Status.State = v1alpha1.SnapshotStateFailed
+	asserts.Expect(reconciler.Status().Update(ctx, cs)).To(Succeed())
+	asserts.Expect(reconciler.ensureSafetySnapshot(ctx, cc, safetySnapshotReplication, "system_auth/map[dc1:5]", []string{"system_auth"})).To(Equal(errSafetySnapshotFailed))
+
+	cc.Spec.SafetySnapshots.FailurePolicy = v1alpha1.SafetySnapshotFailurePolicyIgnore
+	asserts.Expect(reconciler.ensureSafetySnapshot(ctx, cc, safetySnapshotReplication, "system_auth/map[dc1:5]", []string{"system_auth"})).To(Succeed())
+}
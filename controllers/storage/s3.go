@@ -0,0 +1,285 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+
+	amzDateFormat   = "20060102T150405Z"
+	amzScopeFormat  = "20060102"
+	signedS3Headers = "host;x-amz-content-sha256;x-amz-date"
+)
+
+// s3Client talks to S3 compatible storages using path-style requests signed with AWS Signature Version 4
+type s3Client struct {
+	endpoint        *url.URL
+	bucket          string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	httpClient      *http.Client
+}
+
+func newS3Client(location Location, secret *v1.Secret, insecure bool, httpClient *http.Client) (*s3Client, error) {
+	accessKeyID := strings.TrimSpace(string(secret.Data["awsaccesskeyid"]))
+	secretAccessKey := strings.TrimSpace(string(secret.Data["awssecretaccesskey"]))
+	if len(accessKeyID) == 0 || len(secretAccessKey) == 0 {
+		// Icarus falls back to the AWS env vars of its container, which the operator doesn't have access to
+		return nil, ErrNoCredentials
+	}
+
+	region := strings.TrimSpace(string(secret.Data["awsregion"]))
+	if len(region) == 0 {
+		region = defaultS3Region
+	}
+
+	endpoint := strings.TrimSpace(string(secret.Data["awsendpoint"]))
+	if len(endpoint) == 0 {
+		endpoint = fmt.Sprintf("s3.%s.amazonaws.com", region)
+	}
+
+	if !strings.Contains(endpoint, "://") {
+		scheme := "https"
+		if insecure {
+			scheme = "http"
+		}
+		endpoint = scheme + "://" + endpoint
+	}
+
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid endpoint %q", endpoint)
+	}
+
+	return &s3Client{
+		endpoint:        endpointURL,
+		bucket:          location.Bucket,
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		httpClient:      httpClient,
+	}, nil
+}
+
+func (c *s3Client) BucketExists(ctx context.Context) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, "", nil, nil)
+	if err != nil {
+		return false, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, responseError(resp)
+	}
+}
+
+func (c *s3Client) PutObject(ctx context.Context, key string, data []byte) error {
+	resp, err := c.do(ctx, http.MethodPut, key, nil, data)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+
+	return nil
+}
+
+func (c *s3Client) GetObject(ctx context.Context, key string) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (c *s3Client) DeleteObject(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+
+	return nil
+}
+
+// listBucketResult is the response of the ListObjectsV2 request
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+}
+
+func (c *s3Client) ListObjects(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	continuationToken := ""
+	for {
+		query := url.Values{"list-type": []string{"2"}, "prefix": []string{prefix}}
+		if len(continuationToken) != 0 {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		resp, err := c.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, responseError(resp)
+		}
+
+		result := &listBucketResult{}
+		if err = xml.NewDecoder(resp.Body).Decode(result); err != nil {
+			return nil, errors.Wrap(err, "failed to decode the list of objects")
+		}
+
+		for _, content := range result.Contents {
+			objects = append(objects, Object{Key: content.Key, Size: content.Size, LastModified: content.LastModified})
+		}
+
+		if !result.IsTruncated || len(result.NextContinuationToken) == 0 {
+			return objects, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+func (c *s3Client) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	reqURL := *c.endpoint
+	reqURL.Path = strings.TrimSuffix(reqURL.Path, "/") + "/" + c.bucket
+	if len(key) != 0 {
+		reqURL.Path += "/" + key
+	}
+	// send the path encoded the same way as in the canonical request
+	reqURL.RawPath = uriEncode(reqURL.Path)
+	// the canonical query string requires sorted parameters and spaces encoded as %20
+	reqURL.RawQuery = strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	c.sign(req, body, time.Now().UTC())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// read the body so that the connection can be reused
+	b, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+
+	return resp, nil
+}
+
+// sign adds the AWS Signature Version 4 authorization header to the request
+func (c *s3Client) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format(amzDateFormat)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedS3Headers,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", now.Format(amzScopeFormat), c.region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+c.secretAccessKey), now.Format(amzScopeFormat))
+	signingKey = hmacSHA256(signingKey, c.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKeyID, scope, signedS3Headers, signature))
+}
+
+// uriEncode percent-encodes every character of the path except the unreserved ones and '/', as required for the canonical URI.
+// url.URL.EscapedPath leaves characters like '+', '=' or ':' unescaped, which results in a signature mismatch.
+func uriEncode(path string) string {
+	var encoded strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			encoded.WriteByte(c)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		}
+	}
+
+	return encoded.String()
+}
+
+func responseError(resp *http.Response) error {
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized {
+		return errors.Wrapf(ErrAccessDenied, "code: %d, body: %s", resp.StatusCode, string(b))
+	}
+
+	return fmt.Errorf("request failed: code: %d, body: %s", resp.StatusCode, string(b))
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
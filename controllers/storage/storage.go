@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	ReasonVerified               = "Verified"
+	ReasonInvalidStorageLocation = "InvalidStorageLocation"
+	ReasonInvalidCredentials     = "InvalidCredentials"
+	ReasonBucketNotFound         = "BucketNotFound"
+	ReasonBucketNotWritable      = "BucketNotWritable"
+	ReasonStorageUnreachable     = "StorageUnreachable"
+	ReasonNotVerified            = "NotVerified"
+)
+
+var (
+	ErrAccessDenied        = errors.New("access denied")
+	ErrNoCredentials       = errors.New("no credentials set in the secret")
+	ErrUnsupportedProvider = errors.New("connectivity checks are not supported for the storage provider")
+)
+
+// StorageClient is used to verify that the storage location can be used for backups before Icarus is asked to upload to it
+// and to discover the backups that already exist in the storage location
+type StorageClient interface {
+	BucketExists(ctx context.Context) (bool, error)
+	PutObject(ctx context.Context, key string, data []byte) error
+	GetObject(ctx context.Context, key string) ([]byte, error)
+	DeleteObject(ctx context.Context, key string) error
+	ListObjects(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Object is an object stored in the bucket
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Location is a parsed storage location in format 'protocol://bucket/path'
+type Location struct {
+	Provider v1alpha1.StorageProvider
+	Bucket   string
+	Path     string
+}
+
+// VerificationError describes why the storage location can't be used. The reason is used for the StorageVerified condition
+type VerificationError struct {
+	Reason  string
+	Message string
+}
+
+func (e *VerificationError) Error() string {
+	return e.Message
+}
+
+func ParseLocation(storageLocation string) (Location, error) {
+	index := strings.Index(storageLocation, "://")
+	if index < 0 {
+		return Location{}, errors.New("storage location should be in format 'protocol://bucket/location'")
+	}
+
+	location := Location{Provider: v1alpha1.StorageProvider(storageLocation[:index])}
+	switch location.Provider {
+	case v1alpha1.StorageProviderS3, v1alpha1.StorageProviderMinio, v1alpha1.StorageProviderCeph,
+		v1alpha1.StorageProviderOracle, v1alpha1.StorageProviderGCP, v1alpha1.StorageProviderAzure:
+	default:
+		return Location{}, fmt.Errorf("storage provider %q is not supported", location.Provider)
+	}
+
+	bucketAndPath := strings.SplitN(storageLocation[index+3:], "/", 2)
+	location.Bucket = bucketAndPath[0]
+	if len(bucketAndPath) == 2 {
+		location.Path = strings.Trim(bucketAndPath[1], "/")
+	}
+
+	if err := v1alpha1.ValidateBucketName(location.Provider, location.Bucket); err != nil {
+		return Location{}, err
+	}
+
+	return location, nil
+}
+
+// NewStorageClient creates a client for the storage location using the credentials from the secret.
+// Only S3 compatible storage providers are supported.
+func NewStorageClient(location Location, secret *v1.Secret, insecure bool, httpClient *http.Client) (StorageClient, error) {
+	switch location.Provider {
+	case v1alpha1.StorageProviderS3, v1alpha1.StorageProviderMinio, v1alpha1.StorageProviderCeph, v1alpha1.StorageProviderOracle:
+		return newS3Client(location, secret, insecure, httpClient)
+	default:
+		return nil, ErrUnsupportedProvider
+	}
+}
+
+// Verify checks that the bucket exists and that objects can be written to it.
+// Returns a *VerificationError if the storage can't be used.
+func Verify(ctx context.Context, client StorageClient, location Location, objectKey string, skipBucketVerification bool) error {
+	if !skipBucketVerification {
+		exists, err := client.BucketExists(ctx)
+		if err != nil {
+			return verificationError(err, fmt.Sprintf("failed to check if bucket %s exists", location.Bucket))
+		}
+
+		if !exists {
+			return &VerificationError{Reason: ReasonBucketNotFound, Message: fmt.Sprintf("bucket %s doesn't exist", location.Bucket)}
+		}
+	}
+
+	if len(location.Path) != 0 {
+		objectKey = location.Path + "/" + objectKey
+	}
+
+	if err := client.PutObject(ctx, objectKey, []byte("cassandra-operator storage verification")); err != nil {
+		verr := verificationError(err, fmt.Sprintf("failed to write to bucket %s", location.Bucket))
+		if verr.Reason == ReasonInvalidCredentials {
+			verr.Reason = ReasonBucketNotWritable
+		}
+		return verr
+	}
+
+	if err := client.DeleteObject(ctx, objectKey); err != nil {
+		return verificationError(err, fmt.Sprintf("failed to delete the verification object from bucket %s", location.Bucket))
+	}
+
+	return nil
+}
+
+func verificationError(err error, message string) *VerificationError {
+	if errors.Is(err, ErrAccessDenied) {
+		return &VerificationError{Reason: ReasonInvalidCredentials, Message: fmt.Sprintf("%s: %s", message, err.Error())}
+	}
+
+	return &VerificationError{Reason: ReasonStorageUnreachable, Message: fmt.Sprintf("%s: %s", message, err.Error())}
+}
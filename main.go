@@ -24,10 +24,12 @@ import (
 	"time"
 
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
 
 	"github.com/go-logr/zapr"
 	"github.com/gocql/gocql"
 	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -42,8 +44,14 @@ import (
 
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers"
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/builtinrepair"
 	"github.com/ibm/cassandra-operator/controllers/cassandrabackup"
+	"github.com/ibm/cassandra-operator/controllers/cassandrabackupcatalog"
+	"github.com/ibm/cassandra-operator/controllers/cassandrareaper"
+	"github.com/ibm/cassandra-operator/controllers/cassandrarepair"
 	"github.com/ibm/cassandra-operator/controllers/cassandrarestore"
+	"github.com/ibm/cassandra-operator/controllers/cassandrasnapshot"
 	operatorCfg "github.com/ibm/cassandra-operator/controllers/config"
 	"github.com/ibm/cassandra-operator/controllers/cql"
 	"github.com/ibm/cassandra-operator/controllers/events"
@@ -53,6 +61,7 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/nodectl"
 	"github.com/ibm/cassandra-operator/controllers/prober"
 	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 	"github.com/ibm/cassandra-operator/controllers/webhooks"
 )
 
@@ -124,6 +133,20 @@ func main() {
 
 	eventRecorder := events.NewEventRecorder(mgr.GetEventRecorderFor(events.EventRecorderNameCassandraCluster))
 	reconcileChan := make(chan event.GenericEvent)
+	backupReconcileChan := make(chan event.GenericEvent)
+	restoreReconcileChan := make(chan event.GenericEvent)
+	operationsPoller := backupengine.NewPoller(backupReconcileChan, restoreReconcileChan,
+		operatorConfig.OperationsPollInterval, operatorConfig.OperationsMaxPollInterval, logr)
+	if err = mgr.Add(operationsPoller); err != nil {
+		logr.With(zap.Error(err)).Error("unable to add the backup operations poller")
+		os.Exit(1)
+	}
+
+	builtinRepairWatcher := builtinrepair.NewSegmentWatcher(reconcileChan, builtinrepair.SegmentCheckInterval, logr)
+	if err = mgr.Add(builtinRepairWatcher); err != nil {
+		logr.With(zap.Error(err)).Error("unable to add the builtin repair segment watcher")
+		os.Exit(1)
+	}
 
 	cassandraReconciler := &controllers.CassandraClusterReconciler{
 		Client: mgr.GetClient(),
@@ -141,7 +164,8 @@ func main() {
 		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
 			return reaper.NewReaperClient(url, clusterName, httpClient, defaultRepairThreadCount)
 		},
-		Jobs: jobs.NewJobManager(reconcileChan, logr),
+		Jobs:           jobs.NewJobManager(reconcileChan, logr),
+		BuiltinRepairs: builtinRepairWatcher,
 	}
 	err = controllers.SetupCassandraReconciler(cassandraReconciler, mgr, logr, reconcileChan)
 	if err != nil {
@@ -158,13 +182,85 @@ func main() {
 		IcarusClient: func(coordinatorPodURL string) icarus.Icarus {
 			return icarus.New(coordinatorPodURL)
 		},
+		MedusaClient: func(addr string) medusa.Medusa {
+			return medusa.New(addr)
+		},
+		StorageClient: func(location storage.Location, secret *v1.Secret, insecure bool) (storage.StorageClient, error) {
+			return storage.NewStorageClient(location, secret, insecure, httpClient)
+		},
+		Poller: operationsPoller,
 	}
-	err = cassandrabackup.SetupCassandraBackupReconciler(cassandraBackupReconciler, mgr)
+	err = cassandrabackup.SetupCassandraBackupReconciler(cassandraBackupReconciler, mgr, backupReconcileChan)
 	if err != nil {
 		logr.With(zap.Error(err)).Error("unable to create controller", "controller", "CassandraBackup")
 		os.Exit(1)
 	}
 
+	cassandraBackupCatalogReconciler := &cassandrabackupcatalog.CassandraBackupCatalogReconciler{
+		Client: mgr.GetClient(),
+		Log:    logr,
+		Scheme: mgr.GetScheme(),
+		Cfg:    *operatorConfig,
+		Events: eventRecorder,
+		StorageClient: func(location storage.Location, secret *v1.Secret, insecure bool) (storage.StorageClient, error) {
+			return storage.NewStorageClient(location, secret, insecure, httpClient)
+		},
+	}
+	err = cassandrabackupcatalog.SetupCassandraBackupCatalogReconciler(cassandraBackupCatalogReconciler, mgr)
+	if err != nil {
+		logr.With(zap.Error(err)).Error("unable to create controller", "controller", "CassandraBackupCatalog")
+		os.Exit(1)
+	}
+
+	cassandraSnapshotReconciler := &cassandrasnapshot.CassandraSnapshotReconciler{
+		Client: mgr.GetClient(),
+		Log:    logr,
+		Scheme: mgr.GetScheme(),
+		Cfg:    *operatorConfig,
+		Events: eventRecorder,
+		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
+			return nodectl.NewClient(jolokiaAddr, jmxUser, jmxPassword, logr)
+		},
+	}
+	err = cassandrasnapshot.SetupCassandraSnapshotReconciler(cassandraSnapshotReconciler, mgr)
+	if err != nil {
+		logr.With(zap.Error(err)).Error("unable to create controller", "controller", "CassandraSnapshot")
+		os.Exit(1)
+	}
+
+	cassandraRepairReconciler := &cassandrarepair.CassandraRepairReconciler{
+		Client: mgr.GetClient(),
+		Log:    logr,
+		Scheme: mgr.GetScheme(),
+		Cfg:    *operatorConfig,
+		Events: eventRecorder,
+		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
+			return reaper.NewReaperClient(url, clusterName, httpClient, defaultRepairThreadCount)
+		},
+	}
+	err = cassandrarepair.SetupCassandraRepairReconciler(cassandraRepairReconciler, mgr)
+	if err != nil {
+		logr.With(zap.Error(err)).Error("unable to create controller", "controller", "CassandraRepair")
+		os.Exit(1)
+	}
+
+	cassandraReaperReconciler := &cassandrareaper.CassandraReaperReconciler{
+		Client: mgr.GetClient(),
+		Log:    logr,
+		Scheme: mgr.GetScheme(),
+		Cfg:    *operatorConfig,
+		Events: eventRecorder,
+		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
+			return reaper.NewReaperClient(url, clusterName, httpClient, defaultRepairThreadCount)
+		},
+		CqlClient: func(cluster *gocql.ClusterConfig) (cql.CqlClient, error) { return cql.NewCQLClient(cluster) },
+	}
+	err = cassandrareaper.SetupCassandraReaperReconciler(cassandraReaperReconciler, mgr)
+	if err != nil {
+		logr.With(zap.Error(err)).Error("unable to create controller", "controller", "CassandraReaper")
+		os.Exit(1)
+	}
+
 	cassandraRestoreReconciler := &cassandrarestore.CassandraRestoreReconciler{
 		Client: mgr.GetClient(),
 		Log:    logr,
@@ -174,8 +270,22 @@ func main() {
 		IcarusClient: func(coordinatorPodURL string) icarus.Icarus {
 			return icarus.New(coordinatorPodURL)
 		},
+		MedusaClient: func(addr string) medusa.Medusa {
+			return medusa.New(addr)
+		},
+		StorageClient: func(location storage.Location, secret *v1.Secret, insecure bool) (storage.StorageClient, error) {
+			return storage.NewStorageClient(location, secret, insecure, httpClient)
+		},
+		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
+			return nodectl.NewClient(jolokiaAddr, jmxUser, jmxPassword, logr)
+		},
+		CqlClient: func(cluster *gocql.ClusterConfig) (cql.CqlClient, error) { return cql.NewCQLClient(cluster) },
+		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
+			return reaper.NewReaperClient(url, clusterName, httpClient, defaultRepairThreadCount)
+		},
+		Poller: operationsPoller,
 	}
-	err = cassandrarestore.SetupCassandraRestoreReconciler(cassandraRestoreReconciler, mgr)
+	err = cassandrarestore.SetupCassandraRestoreReconciler(cassandraRestoreReconciler, mgr, restoreReconcileChan)
 	if err != nil {
 		logr.With(zap.Error(err)).Error("unable to create controller", "controller", "CassandraRestore")
 		os.Exit(1)
@@ -212,6 +322,8 @@ func main() {
 			os.Exit(1)
 		}
 		dbv1alpha1.SetWebhookLogger(logr)
+		dbv1alpha1.SetWebhookClient(mgr.GetAPIReader())
+		dbv1alpha1.SetWebhookSchemaReader(cassandraRestoreReconciler.SchemaTables)
 	} else {
 		logr.Infof("deleting webhooks assests if they exist")
 		if err = webhooks.DeleteWebhookAssets(kubeClient, operatorConfig); err != nil {
@@ -13,10 +13,18 @@ type Config struct {
 	AdminRoleSecretName     string        `env:"ADMIN_SECRET_NAME,required"`      // Active Admin Secret
 	BaseAdminRoleSecretName string        `env:"BASE_ADMIN_SECRET_NAME,required"` // User's Admin Secret
 	JmxPollingInterval      time.Duration `env:"JMX_POLLING_INTERVAL" envDefault:"10s"`
+	JmxPollingWorkers       int           `env:"JMX_POLLING_WORKERS" envDefault:"10"` // Number of nodes polled concurrently
+	JmxRequestTimeout       time.Duration `env:"JMX_REQUEST_TIMEOUT" envDefault:"5s"`
 	JmxPort                 int           `env:"JMX_PORT" envDefault:"7199"`
 	JolokiaPort             int           `env:"JOLOKIA_PORT" envDefault:"8080"`
 	LogLevel                zapcore.Level `env:"LOGLEVEL" envDefault:"info"`
 	LogFormat               string        `env:"LOGFORMAT" envDefault:"json"`
+	PodName                 string        `env:"POD_NAME"`
+	PodIP                   string        `env:"POD_IP"`
+	// ConfigMap the state set by the operator is persisted in. The state is kept only in memory if not set.
+	StateConfigMapName string `env:"STATE_CONFIGMAP_NAME"`
+	// Lease used to elect the replica that writes the state
+	LeaderElectionLeaseName string `env:"LEADER_ELECTION_LEASE_NAME"`
 }
 
 func LevelParser(v string) (interface{}, error) {
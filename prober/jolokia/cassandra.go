@@ -27,6 +27,9 @@ type CassandraNodeState struct {
 // EndpointState of useful properties of a node's state
 type EndpointState struct {
 	Status, DC, Rack, Internal_IP, RPC_Address string
+	Host_ID, Schema, Release_Version           string
+	// Load is the size of the data on disk in bytes
+	Load float64
 }
 
 // AllEndpointStates implements UnmarshalText to transform the Cassandra MBean to a Go struct.
@@ -7,32 +7,36 @@ import (
 	"github.com/google/go-cmp/cmp"
 )
 
+const (
+	hostID1 = "d629438b-7158-4558-8675-80dc705ddc8e"
+	hostID2 = "3e0d7191-84af-40cf-9e7f-0ce11c925e7f"
+	hostID3 = "070ef8d2-7f54-4fd4-b34d-dfd8c2690588"
+	schema1 = "69ea6896-bc4b-3690-8d18-50ee71f33237"
+	schema2 = "fed73249-15e3-378a-946f-7847dc4ed28d"
+)
+
 var (
 	allEndpointsValue = AllEndpointStates{
-		"/10.244.0.5": EndpointState{
-			Status:      "NORMAL",
-			DC:          "dc1",
-			Rack:        "rack1",
-			Internal_IP: "10.244.0.5",
-			RPC_Address: "10.244.0.5",
-		},
-		"/10.244.0.6": EndpointState{
-			Status:      "NORMAL",
-			DC:          "dc1",
-			Rack:        "rack1",
-			Internal_IP: "10.244.0.6",
-			RPC_Address: "10.244.0.6",
-		},
-		"/10.244.0.7": EndpointState{
-			Status:      "NORMAL",
-			DC:          "dc2",
-			Rack:        "rack1",
-			Internal_IP: "10.244.0.7",
-			RPC_Address: "10.244.0.7",
-		},
+		"/10.244.0.5": endpointStateValue("10.244.0.5", "dc1", hostID1, schema1, 165615),
+		"/10.244.0.6": endpointStateValue("10.244.0.6", "dc1", hostID2, schema1, 134596),
+		"/10.244.0.7": endpointStateValue("10.244.0.7", "dc2", hostID3, schema1, 140333),
 	}
 )
 
+func endpointStateValue(ip, dc, hostID, schema string, load float64) EndpointState {
+	return EndpointState{
+		Status:          "NORMAL",
+		DC:              dc,
+		Rack:            "rack1",
+		Internal_IP:     ip,
+		RPC_Address:     ip,
+		Host_ID:         hostID,
+		Schema:          schema,
+		Release_Version: "3.11.11",
+		Load:            load,
+	}
+}
+
 func TestAllEndpointStates_UnmarshaText(t *testing.T) {
 	tests := []struct {
 		name string
@@ -61,10 +65,16 @@ func TestAllEndpointStates_UnmarshaText(t *testing.T) {
 }
 
 func TestCassResponse_UnmarshalText(t *testing.T) {
+	simpleStates := map[string]string{
+		"/10.244.0.5": "UP",
+		"/10.244.0.6": "UP",
+		"/10.244.0.7": "UP",
+	}
 	tests := []struct {
-		name string
-		e    CassandraNodeState
-		data string
+		name     string
+		e        CassandraNodeState
+		data     string
+		expected CassandraNodeState
 	}{
 		{
 			name: "unmarshalls prettified SimpleStates and unescaped AllEndpointStates",
@@ -76,28 +86,36 @@ func TestCassResponse_UnmarshalText(t *testing.T) {
 						},
 						"AllEndpointStates": "/10.244.0.6\n  generation:1615430009\n  heartbeat:24904\n  STATUS:17:NORMAL,-1068096267908218392\n  LOAD:24878:237311.0\n  SCHEMA:13:fed73249-15e3-378a-946f-7847dc4ed28d\n  DC:9:dc1\n  RACK:11:rack1\n  RELEASE_VERSION:5:3.11.11\n  INTERNAL_IP:7:10.244.0.6\n  RPC_ADDRESS:4:10.244.0.6\n  NET_VERSION:2:11\n  HOST_ID:3:d629438b-7158-4558-8675-80dc705ddc8e\n  RPC_READY:29:true\n  TOKENS:16:<hidden>\n/10.244.0.7\n  generation:1615429985\n  heartbeat:24927\n  STATUS:18:NORMAL,-2918089050085335913\n  LOAD:24877:265004.0\n  SCHEMA:13:fed73249-15e3-378a-946f-7847dc4ed28d\n  DC:9:dc2\n  RACK:11:rack1\n  RELEASE_VERSION:5:3.11.11\n  INTERNAL_IP:7:10.244.0.7\n  RPC_ADDRESS:4:10.244.0.7\n  NET_VERSION:2:11\n  HOST_ID:3:3e0d7191-84af-40cf-9e7f-0ce11c925e7f\n  RPC_READY:31:true\n  TOKENS:17:<hidden>\n/10.244.0.5\n  generation:1615429985\n  heartbeat:24927\n  STATUS:17:NORMAL,-139581499681091162\n  LOAD:24878:254587.0\n  SCHEMA:13:fed73249-15e3-378a-946f-7847dc4ed28d\n  DC:9:dc1\n  RACK:11:rack1\n  RELEASE_VERSION:5:3.11.11\n  INTERNAL_IP:7:10.244.0.5\n  RPC_ADDRESS:4:10.244.0.5\n  NET_VERSION:2:11\n  HOST_ID:3:070ef8d2-7f54-4fd4-b34d-dfd8c2690588\n  RPC_READY:32:true\n  TOKENS:16:<hidden>\n"
 					}`,
+			expected: CassandraNodeState{
+				SimpleStates: simpleStates,
+				AllEndpointStates: AllEndpointStates{
+					"/10.244.0.5": endpointStateValue("10.244.0.5", "dc1", hostID3, schema2, 254587),
+					"/10.244.0.6": endpointStateValue("10.244.0.6", "dc1", hostID1, schema2, 237311),
+					"/10.244.0.7": endpointStateValue("10.244.0.7", "dc2", hostID2, schema2, 265004),
+				},
+			},
 		},
 		{
 			name: "unmarshalls minified and unescaped backlashes and newlines",
 			data: `{"SimpleStates":{"\/10.244.0.6":"UP","\/10.244.0.7":"UP","\/10.244.0.5":"UP"},"AllEndpointStates":"\/10.244.0.5\n  generation:1615484112\n  heartbeat:147677\n  STATUS:17:NORMAL,-1068096267908218392\n  LOAD:147670:284363.0\n  SCHEMA:13:fed73249-15e3-378a-946f-7847dc4ed28d\n  DC:9:dc1\n  RACK:11:rack1\n  RELEASE_VERSION:5:3.11.11\n  INTERNAL_IP:7:10.244.0.5\n  RPC_ADDRESS:4:10.244.0.5\n  NET_VERSION:2:11\n  HOST_ID:3:d629438b-7158-4558-8675-80dc705ddc8e\n  RPC_READY:29:true\n  TOKENS:16:<hidden>\n\/10.244.0.6\n  generation:1615484110\n  heartbeat:147680\n  STATUS:17:NORMAL,-2918089050085335913\n  LOAD:147672:274238.0\n  SCHEMA:13:fed73249-15e3-378a-946f-7847dc4ed28d\n  DC:9:dc1\n  RACK:11:rack1\n  RELEASE_VERSION:5:3.11.11\n  INTERNAL_IP:7:10.244.0.6\n  RPC_ADDRESS:4:10.244.0.6\n  NET_VERSION:2:11\n  HOST_ID:3:3e0d7191-84af-40cf-9e7f-0ce11c925e7f\n  RPC_READY:29:true\n  TOKENS:16:<hidden>\n\/10.244.0.7\n  generation:1615484111\n  heartbeat:147680\n  STATUS:17:NORMAL,-139581499681091162\n  LOAD:147672:285552.0\n  SCHEMA:13:fed73249-15e3-378a-946f-7847dc4ed28d\n  DC:9:dc2\n  RACK:11:rack1\n  RELEASE_VERSION:5:3.11.11\n  INTERNAL_IP:7:10.244.0.7\n  RPC_ADDRESS:4:10.244.0.7\n  NET_VERSION:2:11\n  HOST_ID:3:070ef8d2-7f54-4fd4-b34d-dfd8c2690588\n  RPC_READY:29:true\n  TOKENS:16:<hidden>\n"}`,
+			expected: CassandraNodeState{
+				SimpleStates: simpleStates,
+				AllEndpointStates: AllEndpointStates{
+					"/10.244.0.5": endpointStateValue("10.244.0.5", "dc1", hostID1, schema2, 284363),
+					"/10.244.0.6": endpointStateValue("10.244.0.6", "dc1", hostID2, schema2, 274238),
+					"/10.244.0.7": endpointStateValue("10.244.0.7", "dc2", hostID3, schema2, 285552),
+				},
+			},
 		},
 	}
 
-	cassResponseValue := CassandraNodeState{
-		SimpleStates: map[string]string{
-			"/10.244.0.5": "UP",
-			"/10.244.0.6": "UP",
-			"/10.244.0.7": "UP",
-		},
-		AllEndpointStates: allEndpointsValue,
-	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if err := json.Unmarshal([]byte(tt.data), &tt.e); err != nil {
 				t.Error("UnmarshalJSON() error = ", err)
 			}
-			if !cmp.Equal(tt.e, cassResponseValue) {
-				t.Error("Unmarshalled value is not equal to expected", cmp.Diff(cassResponseValue, tt.e))
+			if !cmp.Equal(tt.e, tt.expected) {
+				t.Error("Unmarshalled value is not equal to expected", cmp.Diff(tt.expected, tt.e))
 			}
 		})
 	}
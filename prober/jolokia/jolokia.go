@@ -2,6 +2,7 @@ package jolokia
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,7 +24,7 @@ var (
 )
 
 type Jolokia interface {
-	CassandraNodeState(ip string) (CassandraResponse, error)
+	CassandraNodeState(ctx context.Context, ip string) (CassandraResponse, error)
 	SetAuth(username, password string)
 }
 
@@ -70,8 +71,14 @@ func jmxUrl(ip string, port int) string {
 	return fmt.Sprintf("service:jmx:rmi:///jndi/rmi:/%s:%d/jmxrmi", ip, port)
 }
 
-func (j *Client) CassandraNodeState(ip string) (CassandraResponse, error) {
-	resp, err := j.Client.Post(j.url, runtime.ContentTypeJSON, bytes.NewReader(j.cassandraNodeStateRequest(ip)))
+func (j *Client) CassandraNodeState(ctx context.Context, ip string) (CassandraResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, j.url, bytes.NewReader(j.cassandraNodeStateRequest(ip)))
+	if err != nil {
+		return CassandraResponse{}, err
+	}
+	req.Header.Set("Content-Type", runtime.ContentTypeJSON)
+
+	resp, err := j.Client.Do(req)
 	if err != nil {
 		return CassandraResponse{}, err
 	}
@@ -65,7 +65,7 @@ func main() {
 		logr.Error(err, "unable to get base admin secret")
 	}
 
-	jolokiaClient := jolokia.NewClient(cfg.JolokiaPort, cfg.JmxPort, cfg.JmxPollingInterval, logr,
+	jolokiaClient := jolokia.NewClient(cfg.JolokiaPort, cfg.JmxPort, cfg.JmxRequestTimeout, logr,
 		string(authSecret.Data["admin-role"]),
 		string(authSecret.Data["admin-password"]),
 	)
@@ -31,6 +31,8 @@ func (p *Prober) ping(w http.ResponseWriter, _ *http.Request, _ httprouter.Param
 }
 
 func (p *Prober) getRegionReady(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
 	p.write(w, []byte(strconv.FormatBool(p.state.regionReady)))
 }
 
@@ -44,11 +46,13 @@ func (p *Prober) putRegionReady(w http.ResponseWriter, r *http.Request, _ httpro
 		p.log.Error(err, "can't parse region readiness state")
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
-		p.state.regionReady = ready
+		p.updateState(w, r, body, func(s *state) { s.regionReady = ready })
 	}
 }
 
 func (p *Prober) getReaperReady(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
 	p.write(w, []byte(strconv.FormatBool(p.state.reaperReady)))
 }
 
@@ -62,12 +66,14 @@ func (p *Prober) putReaperReady(w http.ResponseWriter, r *http.Request, _ httpro
 		p.log.Error(err, "can't parse reaper readiness state")
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
-		p.state.reaperReady = ready
+		p.updateState(w, r, body, func(s *state) { s.reaperReady = ready })
 	}
 }
 
 func (p *Prober) getSeeds(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	p.stateMu.RLock()
 	response, _ := json.Marshal(p.state.seeds)
+	p.stateMu.RUnlock()
 	p.write(w, response)
 }
 
@@ -79,12 +85,14 @@ func (p *Prober) putSeeds(w http.ResponseWriter, r *http.Request, _ httprouter.P
 	} else if json.Unmarshal(body, &s) != nil {
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
-		p.state.seeds = s
+		p.updateState(w, r, body, func(st *state) { st.seeds = s })
 	}
 }
 
 func (p *Prober) getDCs(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	p.stateMu.RLock()
 	response, _ := json.Marshal(p.state.dcs)
+	p.stateMu.RUnlock()
 	p.write(w, response)
 }
 
@@ -96,10 +104,15 @@ func (p *Prober) putDCs(w http.ResponseWriter, r *http.Request, _ httprouter.Par
 	} else if json.Unmarshal(body, &dcs) != nil {
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
-		p.state.dcs = dcs
+		p.updateState(w, r, body, func(s *state) { s.dcs = dcs })
 	}
 }
 
+func (p *Prober) getClusterView(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	response, _ := json.Marshal(p.clusterView())
+	p.write(w, response)
+}
+
 func (p *Prober) write(writer io.Writer, data []byte) {
 	written, err := writer.Write(data)
 	if err != nil {
@@ -108,7 +121,9 @@ func (p *Prober) write(writer io.Writer, data []byte) {
 }
 
 func (p *Prober) getRegionIPs(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	p.stateMu.RLock()
 	response, _ := json.Marshal(p.state.regionIPs)
+	p.stateMu.RUnlock()
 	p.write(w, response)
 }
 
@@ -122,12 +137,14 @@ func (p *Prober) putRegionIPs(w http.ResponseWriter, r *http.Request, _ httprout
 		p.log.Error(err)
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
-		p.state.regionIPs = ips
+		p.updateState(w, r, body, func(s *state) { s.regionIPs = ips })
 	}
 }
 
 func (p *Prober) getReaperIPs(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	p.stateMu.RLock()
 	response, _ := json.Marshal(p.state.reaperIPs)
+	p.stateMu.RUnlock()
 	p.write(w, response)
 }
 
@@ -141,6 +158,6 @@ func (p *Prober) putReaperIPs(w http.ResponseWriter, r *http.Request, _ httprout
 		p.log.Error(err)
 		w.WriteHeader(http.StatusBadRequest)
 	} else {
-		p.state.reaperIPs = ips
+		p.updateState(w, r, body, func(s *state) { s.reaperIPs = ips })
 	}
 }
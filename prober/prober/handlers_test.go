@@ -495,3 +495,67 @@ func TestPutDCs(t *testing.T) {
 type failingReader string
 
 func (f failingReader) Read(_ []byte) (n int, err error) { return 0, errors.New(string(f)) }
+
+func TestGetClusterView(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	node1 := endpointState("10.134.3.4", "NORMAL")
+	node1.Host_ID = "d629438b-7158-4558-8675-80dc705ddc8e"
+	node1.Schema = "69ea6896-bc4b-3690-8d18-50ee71f33237"
+	node1.Release_Version = "3.11.11"
+	node1.Load = 134596
+	testProber := &Prober{
+		auth: UserAuth{
+			User:     "cassandra",
+			Password: "cassandra",
+		},
+		log: zap.NewNop().Sugar(),
+		state: state{
+			nodes: map[string]nodeState{
+				"/10.134.3.4": {
+					SimpleStates:  map[string]string{"/10.134.3.4": "UP", "/10.134.3.5": "DOWN"},
+					EndpointState: node1,
+				},
+				"/10.134.3.5": {
+					SimpleStates:  map[string]string{"/10.134.3.5": "UP"},
+					EndpointState: endpointState("10.134.3.5", "NORMAL"),
+				},
+			},
+		},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/cluster-view", nil)
+	recorder := httptest.NewRecorder()
+	router := httprouter.New()
+	setupRoutes(router, testProber)
+	router.ServeHTTP(recorder, request)
+	asserts.Expect(recorder.Code).To(gomega.Equal(http.StatusUnauthorized))
+
+	request.SetBasicAuth("cassandra", "cassandra")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	asserts.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+	asserts.Expect(recorder.Body.String()).To(gomega.MatchJSON(`[
+		{
+			"ip": "/10.134.3.4",
+			"dc": "dc1",
+			"rack": "rack1",
+			"hostId": "d629438b-7158-4558-8675-80dc705ddc8e",
+			"status": "NORMAL",
+			"load": 134596,
+			"schemaVersion": "69ea6896-bc4b-3690-8d18-50ee71f33237",
+			"releaseVersion": "3.11.11",
+			"peerViews": {"/10.134.3.4": "UP", "/10.134.3.5": "?"}
+		},
+		{
+			"ip": "/10.134.3.5",
+			"dc": "dc1",
+			"rack": "rack1",
+			"hostId": "",
+			"status": "NORMAL",
+			"load": 0,
+			"schemaVersion": "",
+			"releaseVersion": "",
+			"peerViews": {"/10.134.3.4": "DOWN", "/10.134.3.5": "UP"}
+		}
+	]`))
+}
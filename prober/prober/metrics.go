@@ -5,6 +5,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"net/http"
+	"strings"
 )
 
 var (
@@ -19,6 +20,21 @@ var (
 	}, []string{"path"})
 )
 
+var (
+	nodeGossipStatusDesc = prometheus.NewDesc("cassandra_node_gossip_status",
+		"Gossip status of the node (e.g. NORMAL, LEAVING). Set to 1 for the current status", []string{"ip", "dc", "rack", "status"}, nil)
+	nodePeerUpDesc = prometheus.NewDesc("cassandra_node_peer_up",
+		"Whether the node is seen as UP (1) or DOWN (0) by the peer", []string{"ip", "peer"}, nil)
+	nodeLoadBytesDesc = prometheus.NewDesc("cassandra_node_load_bytes",
+		"Size of the data on disk of the node in bytes", []string{"ip", "dc", "rack"}, nil)
+	nodeSchemaVersionDesc = prometheus.NewDesc("cassandra_node_schema_version",
+		"Schema version of the node. Set to 1 for the current version", []string{"ip", "schema_version"}, nil)
+	schemaAgreementDesc = prometheus.NewDesc("cassandra_schema_agreement",
+		"Whether all nodes have the same schema version (1) or not (0). Not exported until a schema version is observed", nil, nil)
+	nodesNotReadyDesc = prometheus.NewDesc("cassandra_nodes_not_ready",
+		"Number of nodes that are not seen as ready by all healthy peers", nil, nil)
+)
+
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
@@ -35,7 +51,7 @@ func (rw *responseWriter) WriteHeader(code int) {
 
 func prometheusMiddleware(next httprouter.Handle) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-		path := r.URL.Path
+		path := routePath(r.URL.Path, ps)
 		timer := prometheus.NewTimer(prometheus.ObserverFunc(func(v float64) {
 			millis := v * 1000 // make milliseconds
 			responseTimeMillis.WithLabelValues(path).Observe(millis)
@@ -50,3 +66,71 @@ func prometheusMiddleware(next httprouter.Handle) httprouter.Handle {
 		}
 	}
 }
+
+// routePath returns the route template of the request path, e.g. `/healthz/:broadcastip`, to keep the cardinality of the path label low
+func routePath(path string, ps httprouter.Params) string {
+	for _, param := range ps {
+		path = strings.Replace(path, "/"+param.Value, "/:"+param.Key, 1)
+	}
+
+	return path
+}
+
+// nodeStatesCollector exports the states of the nodes observed by the polls. The metrics are built from the node states
+// on every scrape, so the nodes that are not observed anymore disappear without resetting the metrics between two polls.
+type nodeStatesCollector struct {
+	prober *Prober
+}
+
+func (c nodeStatesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- nodeGossipStatusDesc
+	ch <- nodePeerUpDesc
+	ch <- nodeLoadBytesDesc
+	ch <- nodeSchemaVersionDesc
+	ch <- schemaAgreementDesc
+	ch <- nodesNotReadyDesc
+}
+
+func (c nodeStatesCollector) Collect(ch chan<- prometheus.Metric) {
+	p := c.prober
+	p.nodesMu.RLock()
+	defer p.nodesMu.RUnlock()
+
+	schemaVersions := make(map[string]bool)
+	notReady := 0
+	for ip, node := range p.state.nodes {
+		nodeIP := strings.TrimPrefix(ip, "/")
+		if len(node.Status) > 0 {
+			ch <- prometheus.MustNewConstMetric(nodeGossipStatusDesc, prometheus.GaugeValue, 1, nodeIP, node.DC, node.Rack, node.Status)
+		}
+		ch <- prometheus.MustNewConstMetric(nodeLoadBytesDesc, prometheus.GaugeValue, node.Load, nodeIP, node.DC, node.Rack)
+		if len(node.Schema) > 0 {
+			ch <- prometheus.MustNewConstMetric(nodeSchemaVersionDesc, prometheus.GaugeValue, 1, nodeIP, node.Schema)
+			schemaVersions[node.Schema] = true
+		}
+
+		for peerIP, peer := range p.state.nodes {
+			if simpleState, exists := peer.SimpleStates[ip]; exists {
+				up := 0.0
+				if strings.ToLower(simpleState) == "up" {
+					up = 1
+				}
+				ch <- prometheus.MustNewConstMetric(nodePeerUpDesc, prometheus.GaugeValue, up, nodeIP, strings.TrimPrefix(peerIP, "/"))
+			}
+		}
+
+		if !p.nodeReadiness(ip).ready() {
+			notReady++
+		}
+	}
+
+	// the agreement is unknown until the schema version of a node is observed
+	if len(schemaVersions) > 0 {
+		agreement := 0.0
+		if len(schemaVersions) == 1 {
+			agreement = 1
+		}
+		ch <- prometheus.MustNewConstMetric(schemaAgreementDesc, prometheus.GaugeValue, agreement)
+	}
+	ch <- prometheus.MustNewConstMetric(nodesNotReadyDesc, prometheus.GaugeValue, float64(notReady))
+}
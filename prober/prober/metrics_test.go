@@ -0,0 +1,121 @@
+package prober
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+
+	"github.com/ibm/cassandra-operator/prober/config"
+)
+
+func TestPrometheusMiddlewarePathLabel(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	testProber := &Prober{
+		log: zap.NewNop().Sugar(),
+		state: state{
+			nodes:  map[string]nodeState{},
+			podIPs: map[string]string{},
+		},
+	}
+	router := httprouter.New()
+	setupRoutes(router, testProber)
+
+	before := testutil.ToFloat64(totalRequests.WithLabelValues("/healthz/:broadcastip", "fail"))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz/10.134.3.4", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz/10.134.3.5", nil))
+	asserts.Expect(testutil.ToFloat64(totalRequests.WithLabelValues("/healthz/:broadcastip", "fail"))).To(gomega.Equal(before + 2))
+	asserts.Expect(routePath("/healthz/10.134.3.4", httprouter.Params{{Key: "broadcastip", Value: "10.134.3.4"}})).To(gomega.Equal("/healthz/:broadcastip"))
+}
+
+func TestNodeStatesCollector(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	node1 := endpointState("10.134.3.4", "NORMAL")
+	node1.Schema = "69ea6896-bc4b-3690-8d18-50ee71f33237"
+	node1.Load = 134596
+	node2 := endpointState("10.134.3.5", "NORMAL")
+	node2.Schema = "69ea6896-bc4b-3690-8d18-50ee71f33237"
+	node2.Load = 140333
+	testProber := &Prober{
+		cfg: config.Config{JmxPollingInterval: 10 * time.Second},
+		log: zap.NewNop().Sugar(),
+		state: state{
+			nodes: map[string]nodeState{
+				"/10.134.3.4": {
+					SimpleStates:  map[string]string{"/10.134.3.4": "UP", "/10.134.3.5": "UP"},
+					EndpointState: node1,
+					LastObserved:  time.Now(),
+				},
+				"/10.134.3.5": {
+					SimpleStates:  map[string]string{"/10.134.3.4": "DOWN", "/10.134.3.5": "UP"},
+					EndpointState: node2,
+					LastObserved:  time.Now(),
+				},
+			},
+		},
+	}
+	collector := nodeStatesCollector{prober: testProber}
+
+	asserts.Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP cassandra_node_gossip_status Gossip status of the node (e.g. NORMAL, LEAVING). Set to 1 for the current status
+# TYPE cassandra_node_gossip_status gauge
+cassandra_node_gossip_status{dc="dc1",ip="10.134.3.4",rack="rack1",status="NORMAL"} 1
+cassandra_node_gossip_status{dc="dc1",ip="10.134.3.5",rack="rack1",status="NORMAL"} 1
+# HELP cassandra_node_peer_up Whether the node is seen as UP (1) or DOWN (0) by the peer
+# TYPE cassandra_node_peer_up gauge
+cassandra_node_peer_up{ip="10.134.3.4",peer="10.134.3.4"} 1
+cassandra_node_peer_up{ip="10.134.3.4",peer="10.134.3.5"} 0
+cassandra_node_peer_up{ip="10.134.3.5",peer="10.134.3.4"} 1
+cassandra_node_peer_up{ip="10.134.3.5",peer="10.134.3.5"} 1
+# HELP cassandra_node_load_bytes Size of the data on disk of the node in bytes
+# TYPE cassandra_node_load_bytes gauge
+cassandra_node_load_bytes{dc="dc1",ip="10.134.3.4",rack="rack1"} 134596
+cassandra_node_load_bytes{dc="dc1",ip="10.134.3.5",rack="rack1"} 140333
+# HELP cassandra_node_schema_version Schema version of the node. Set to 1 for the current version
+# TYPE cassandra_node_schema_version gauge
+cassandra_node_schema_version{ip="10.134.3.4",schema_version="69ea6896-bc4b-3690-8d18-50ee71f33237"} 1
+cassandra_node_schema_version{ip="10.134.3.5",schema_version="69ea6896-bc4b-3690-8d18-50ee71f33237"} 1
+# HELP cassandra_schema_agreement Whether all nodes have the same schema version (1) or not (0). Not exported until a schema version is observed
+# TYPE cassandra_schema_agreement gauge
+cassandra_schema_agreement 1
+# HELP cassandra_nodes_not_ready Number of nodes that are not seen as ready by all healthy peers
+# TYPE cassandra_nodes_not_ready gauge
+cassandra_nodes_not_ready 1
+`))).To(gomega.Succeed())
+
+	// the schema disagreement is reported and the metrics of the previous state are not exported anymore
+	node2.Schema = "fed73249-15e3-378a-946f-7847dc4ed28d"
+	node2.Status = "LEAVING"
+	testProber.state.nodes["/10.134.3.5"] = nodeState{
+		SimpleStates:  map[string]string{"/10.134.3.4": "UP", "/10.134.3.5": "UP"},
+		EndpointState: node2,
+		LastObserved:  time.Now(),
+	}
+	asserts.Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP cassandra_node_gossip_status Gossip status of the node (e.g. NORMAL, LEAVING). Set to 1 for the current status
+# TYPE cassandra_node_gossip_status gauge
+cassandra_node_gossip_status{dc="dc1",ip="10.134.3.4",rack="rack1",status="NORMAL"} 1
+cassandra_node_gossip_status{dc="dc1",ip="10.134.3.5",rack="rack1",status="LEAVING"} 1
+# HELP cassandra_node_schema_version Schema version of the node. Set to 1 for the current version
+# TYPE cassandra_node_schema_version gauge
+cassandra_node_schema_version{ip="10.134.3.4",schema_version="69ea6896-bc4b-3690-8d18-50ee71f33237"} 1
+cassandra_node_schema_version{ip="10.134.3.5",schema_version="fed73249-15e3-378a-946f-7847dc4ed28d"} 1
+# HELP cassandra_schema_agreement Whether all nodes have the same schema version (1) or not (0). Not exported until a schema version is observed
+# TYPE cassandra_schema_agreement gauge
+cassandra_schema_agreement 0
+`), "cassandra_node_gossip_status", "cassandra_node_schema_version", "cassandra_schema_agreement")).To(gomega.Succeed())
+
+	// the schema agreement is not reported without the schema versions of the nodes
+	testProber.state.nodes = map[string]nodeState{"/10.134.3.4": {}}
+	asserts.Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP cassandra_nodes_not_ready Number of nodes that are not seen as ready by all healthy peers
+# TYPE cassandra_nodes_not_ready gauge
+cassandra_nodes_not_ready 1
+`), "cassandra_schema_agreement", "cassandra_nodes_not_ready")).To(gomega.Succeed())
+}
@@ -1,24 +1,35 @@
 package prober
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/ibm/cassandra-operator/prober/jolokia"
 )
 
+// the view of a node is ignored if it wasn't observed during that many polling intervals
+const staleNodeStateIntervals = 3
+
 type nodeState struct {
 	SimpleStates map[string]string
 	jolokia.EndpointState
+	// the last time the state was successfully polled from the node
+	LastObserved time.Time
 }
 
 func (p *Prober) processReadinessProbe(podIP string, broadcastIP string) (bool, map[string]string) {
 	broadcastIP = fmt.Sprintf("/%s", broadcastIP)
+	p.nodesMu.Lock()
+	defer p.nodesMu.Unlock()
 	if _, ok := p.state.nodes[broadcastIP]; !ok {
 		p.log.Infow("new ip from readiness probe", "remoteIP", podIP)
 		p.state.nodes[broadcastIP] = nodeState{}
@@ -28,49 +39,145 @@ func (p *Prober) processReadinessProbe(podIP string, broadcastIP string) (bool,
 	return p.isNodeReady(broadcastIP)
 }
 
-// isNodeReady checks if all nodes (including the one being checked) see the node as ready
+// nodeReadiness is the view of a node by its peers
+type nodeReadiness struct {
+	peersUnreadyView []string // nodes that see the questioned node as not ready
+	ignoredPeerNodes []string // nodes that are not ready, so we don't take their view into account
+	stalePeerNodes   []string // nodes that haven't been observed recently, so we don't take their view into account
+	clusterView      map[string]string
+}
+
+// ready checks if all nodes whose view is taken into account see the node as ready
+func (r nodeReadiness) ready() bool {
+	return len(r.ignoredPeerNodes)+len(r.stalePeerNodes) < len(r.clusterView) && len(r.peersUnreadyView) == 0
+}
+
+// isNodeReady checks if all nodes (including the one being checked) see the node as ready. The caller must hold nodesMu.
 func (p *Prober) isNodeReady(ip string) (bool, map[string]string) {
-	var peersUnreadyView []string // nodes that see the questioned node as not ready
-	var ignoredPeerNodes []string // nodes that are not ready, so we don't take their view into account
-	var nodeClusterView = make(map[string]string)
+	readiness := p.nodeReadiness(ip)
+
+	if len(readiness.ignoredPeerNodes) > 0 {
+		p.log.Infof("ignoring the following node(s) view as they are not ready: %v", readiness.ignoredPeerNodes)
+	}
+
+	if len(readiness.stalePeerNodes) > 0 {
+		p.log.Infof("ignoring the following node(s) view as it's stale: %v", readiness.stalePeerNodes)
+	}
+
+	if len(readiness.ignoredPeerNodes)+len(readiness.stalePeerNodes) == len(p.state.nodes) {
+		p.log.Infof("no nodes are ready")
+		return false, readiness.clusterView
+	}
+
+	if len(readiness.peersUnreadyView) > 0 {
+		p.log.Infof("node %s not seen as ready by %v", ip, readiness.peersUnreadyView)
+		return false, readiness.clusterView
+	}
+
+	p.log.Debugf("all healthy nodes see node %s as ready", ip)
+	return true, readiness.clusterView
+}
+
+// nodeReadiness returns the view of the node by its peers. The caller must hold nodesMu.
+func (p *Prober) nodeReadiness(ip string) nodeReadiness {
+	readiness := nodeReadiness{clusterView: make(map[string]string)}
 	for peerNodeIP, node := range p.state.nodes {
 		simpleState, exists := node.SimpleStates[ip]
 		if !exists {
 			simpleState = "?"
 		}
-		nodeClusterView[peerNodeIP] = simpleState
+		readiness.clusterView[peerNodeIP] = simpleState
 
 		//ignore node's view if its status is not `NORMAL` and the node doesn't see itself as `UP`
 		if strings.ToLower(node.Status) != "normal" || strings.ToLower(node.SimpleStates[peerNodeIP]) != "up" {
-			ignoredPeerNodes = append(ignoredPeerNodes, peerNodeIP)
+			readiness.ignoredPeerNodes = append(readiness.ignoredPeerNodes, peerNodeIP)
+			continue
+		}
+
+		// ignore node's view if the node hasn't been observed recently, it doesn't reflect the current state
+		if p.isStale(node) {
+			readiness.stalePeerNodes = append(readiness.stalePeerNodes, peerNodeIP)
 			continue
 		}
 
 		if strings.ToLower(node.SimpleStates[ip]) != "up" {
-			peersUnreadyView = append(peersUnreadyView, peerNodeIP)
+			readiness.peersUnreadyView = append(readiness.peersUnreadyView, peerNodeIP)
 		}
 	}
 
-	if len(ignoredPeerNodes) > 0 {
-		p.log.Infof("ignoring the following node(s) view as they are not ready: %v", ignoredPeerNodes)
-	}
+	return readiness
+}
 
-	if len(ignoredPeerNodes) == len(p.state.nodes) {
-		p.log.Infof("no nodes are ready")
-		return false, nodeClusterView
+// isStale checks if the node hasn't been observed during the polling intervals preceding the last completed poll.
+// Nodes that have never been observed don't have a view of the cluster, so they are not considered stale.
+func (p *Prober) isStale(node nodeState) bool {
+	if node.LastObserved.IsZero() || p.lastPoll.IsZero() {
+		return false
 	}
 
-	if len(peersUnreadyView) > 0 {
-		p.log.Infof("node %s not seen as ready by %v", ip, peersUnreadyView)
-		return false, nodeClusterView
+	return p.lastPoll.Sub(node.LastObserved) > staleNodeStateIntervals*p.cfg.JmxPollingInterval
+}
+
+// nodeView is the state of a node as gossiped by Cassandra and how the other nodes see it
+type nodeView struct {
+	IP             string  `json:"ip"`
+	DC             string  `json:"dc"`
+	Rack           string  `json:"rack"`
+	HostID         string  `json:"hostId"`
+	Status         string  `json:"status"`
+	Load           float64 `json:"load"`
+	SchemaVersion  string  `json:"schemaVersion"`
+	ReleaseVersion string  `json:"releaseVersion"`
+	// PeerViews maps the IP of each node to its view (UP or DOWN) of the node. `?` if the node doesn't know the node.
+	PeerViews    map[string]string `json:"peerViews"`
+	LastObserved *time.Time        `json:"lastObserved,omitempty"`
+}
+
+func (p *Prober) clusterView() []nodeView {
+	p.nodesMu.RLock()
+	defer p.nodesMu.RUnlock()
+	view := make([]nodeView, 0, len(p.state.nodes))
+	for ip, node := range p.state.nodes {
+		nv := nodeView{
+			IP:             ip,
+			DC:             node.DC,
+			Rack:           node.Rack,
+			HostID:         node.Host_ID,
+			Status:         node.Status,
+			Load:           node.Load,
+			SchemaVersion:  node.Schema,
+			ReleaseVersion: node.Release_Version,
+			PeerViews:      make(map[string]string, len(p.state.nodes)),
+		}
+		if !node.LastObserved.IsZero() {
+			lastObserved := node.LastObserved
+			nv.LastObserved = &lastObserved
+		}
+
+		for peerIP, peer := range p.state.nodes {
+			simpleState, exists := peer.SimpleStates[ip]
+			if !exists {
+				simpleState = "?"
+			}
+			nv.PeerViews[peerIP] = simpleState
+		}
+
+		view = append(view, nv)
 	}
 
-	p.log.Debugf("all healthy nodes see node %s as ready", ip)
-	return true, nodeClusterView
+	sort.Slice(view, func(i, j int) bool {
+		return view[i].IP < view[j].IP
+	})
+
+	return view
 }
 
 func (p *Prober) updateNodeStates() {
-	if len(p.state.nodes) > 0 {
+	p.nodesMu.RLock()
+	discovered := len(p.state.nodes)
+	p.nodesMu.RUnlock()
+
+	if discovered > 0 {
 		p.updateNodesRequest()
 	} else {
 		p.log.Info("0 discovered nodes...")
@@ -79,6 +186,11 @@ func (p *Prober) updateNodeStates() {
 
 func (p *Prober) updateNodesRequest() {
 	responses := p.allNodesStates()
+	observed := time.Now()
+
+	// the nodes are not locked while they are polled, so that the readiness probes are not blocked by slow nodes
+	p.nodesMu.Lock()
+	defer p.nodesMu.Unlock()
 
 	newNodeStates := make(map[string]nodeState)
 	for polledIP, nodeStateResponse := range responses {
@@ -86,6 +198,7 @@ func (p *Prober) updateNodesRequest() {
 
 		if nodeStateResponse.Status == http.StatusOK { // responses[i].Value is not nil
 			cassandraNodeState := nodeStateResponse.Value
+			newNodeState.LastObserved = observed
 			newNodeState.SimpleStates = cassandraNodeState.SimpleStates
 			newNodeState.EndpointState = cassandraNodeState.AllEndpointStates[polledIP]
 			// lookup new nodes from node's peers (`.AllEndpointsStates`)
@@ -101,8 +214,11 @@ func (p *Prober) updateNodesRequest() {
 				}
 			}
 		} else {
+			// the node is kept with its last known endpoint state until it's polled successfully again
 			newNodeState.SimpleStates = make(map[string]string)
 			newNodeState.SimpleStates[polledIP] = strconv.Itoa(nodeStateResponse.Status)
+			newNodeState.EndpointState = p.state.nodes[polledIP].EndpointState
+			newNodeState.LastObserved = p.state.nodes[polledIP].LastObserved
 		}
 		newNodeStates[polledIP] = newNodeState
 	}
@@ -116,37 +232,91 @@ func (p *Prober) updateNodesRequest() {
 		newNodeStates[ip] = newNodeState
 	}
 
-	if !reflect.DeepEqual(newNodeStates, p.state.nodes) {
+	// the nodes registered by readiness probes during the poll are polled next time
+	for ip, node := range p.state.nodes {
+		if _, polled := responses[ip]; !polled {
+			if _, found := newNodeStates[ip]; !found {
+				newNodeStates[ip] = node
+			}
+		}
+	}
+
+	// the observation time and the load change constantly, only the changes of the states are logged
+	ignoreVolatileFields := cmpopts.IgnoreFields(nodeState{}, "LastObserved", "EndpointState.Load")
+	if !cmp.Equal(newNodeStates, p.state.nodes, ignoreVolatileFields) {
 		p.log.Info("Node states updated")
-		p.log.Debug(cmp.Diff(p.state.nodes, newNodeStates))
-		p.state.nodes = newNodeStates
+		p.log.Debug(cmp.Diff(p.state.nodes, newNodeStates, ignoreVolatileFields))
 	}
+	p.state.nodes = newNodeStates
+	p.lastPoll = observed
 }
 
-// allNodesStates returns JMX response for each discovered node, including failed requests
+// allNodesStates returns JMX response for each discovered node, including failed requests.
+// The nodes are polled concurrently by a bounded number of workers.
 func (p *Prober) allNodesStates() map[string]jolokia.CassandraResponse {
-	responses := make(map[string]jolokia.CassandraResponse)
+	p.nodesMu.RLock()
+	podIPs := make(map[string]string, len(p.state.nodes))
 	for nodeIP := range p.state.nodes {
-		response, err := p.jolokia.CassandraNodeState(p.state.podIPs[nodeIP])
-		if err != nil {
-			p.log.Errorf("jolokia request for IP %q failed: %s", nodeIP, err.Error())
-			response = jolokia.CassandraResponse{
-				Response: jolokia.Response{
-					Status: http.StatusInternalServerError,
-					Error:  err.Error(),
-				},
+		podIPs[nodeIP] = p.state.podIPs[nodeIP]
+	}
+	p.nodesMu.RUnlock()
+
+	workers := p.cfg.JmxPollingWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(podIPs) {
+		workers = len(podIPs)
+	}
+
+	responses := make(map[string]jolokia.CassandraResponse, len(podIPs))
+	responsesMu := sync.Mutex{}
+	nodeIPs := make(chan string)
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for nodeIP := range nodeIPs {
+				response := p.nodeState(nodeIP, podIPs[nodeIP])
+				responsesMu.Lock()
+				responses[nodeIP] = response
+				responsesMu.Unlock()
 			}
-			responses[nodeIP] = response
-			continue
-		}
+		}()
+	}
 
-		responses[nodeIP] = response
+	for nodeIP := range podIPs {
+		nodeIPs <- nodeIP
 	}
+	close(nodeIPs)
+	wg.Wait()
 
 	return responses
 }
 
+// nodeState returns JMX response for the node. The request is canceled if the node doesn't respond within the timeout.
+func (p *Prober) nodeState(nodeIP, podIP string) jolokia.CassandraResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), p.cfg.JmxRequestTimeout)
+	defer cancel()
+
+	response, err := p.jolokia.CassandraNodeState(ctx, podIP)
+	if err != nil {
+		p.log.Errorf("jolokia request for IP %q failed: %s", nodeIP, err.Error())
+		return jolokia.CassandraResponse{
+			Response: jolokia.Response{
+				Status: http.StatusInternalServerError,
+				Error:  err.Error(),
+			},
+		}
+	}
+
+	return response
+}
+
 func (p *Prober) ownedDC(dc string) bool {
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
 	for _, ownedDC := range p.state.dcs {
 		if ownedDC.Name == dc {
 			return true
@@ -158,6 +328,14 @@ func (p *Prober) ownedDC(dc string) bool {
 
 func (p *Prober) pollNodeStates() {
 	for range time.Tick(p.cfg.JmxPollingInterval) {
-		go p.updateNodeStates()
+		if !atomic.CompareAndSwapInt32(&p.polling, 0, 1) {
+			p.log.Warn("previous poll of the node states hasn't finished yet, skipping")
+			continue
+		}
+
+		go func() {
+			defer atomic.StoreInt32(&p.polling, 0)
+			p.updateNodeStates()
+		}()
 	}
 }
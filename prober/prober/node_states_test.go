@@ -1,12 +1,20 @@
 package prober
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/julienschmidt/httprouter"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/kubernetes"
 
+	"github.com/ibm/cassandra-operator/prober/config"
 	"github.com/ibm/cassandra-operator/prober/jolokia"
 
 	"github.com/onsi/gomega"
@@ -23,7 +31,7 @@ func (j *jolokiaMock) SetAuth(username, password string) {
 	j.password = password
 }
 
-func (j *jolokiaMock) CassandraNodeState(ip string) (jolokia.CassandraResponse, error) {
+func (j *jolokiaMock) CassandraNodeState(_ context.Context, ip string) (jolokia.CassandraResponse, error) {
 	resp, nodeFound := j.nodeStates[ip]
 	if !nodeFound {
 		return jolokia.CassandraResponse{}, fmt.Errorf("node %s not found", ip)
@@ -236,6 +244,56 @@ func TestUpdateNodeStates(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "node that fails to respond is kept with its previous state",
+			initialState: state{
+				nodes: map[string]nodeState{
+					"/10.12.13.43": {EndpointState: endpointState("10.12.13.43", "UP")},
+					"/10.12.13.44": {EndpointState: endpointState("10.12.13.44", "UP")},
+				},
+				dcs: []dc{
+					{
+						Name:     "dc1",
+						Replicas: 2,
+					},
+				},
+				podIPs: map[string]string{
+					"/10.12.13.43": "172.16.16.43",
+					"/10.12.13.44": "172.16.16.44",
+				},
+			},
+			expectedState: state{
+				nodes: map[string]nodeState{
+					"/10.12.13.43": {
+						SimpleStates:  map[string]string{"/10.12.13.43": "UP", "/10.12.13.44": "DOWN"},
+						EndpointState: endpointState("10.12.13.43", "UP"),
+					},
+					"/10.12.13.44": {
+						SimpleStates:  map[string]string{"/10.12.13.44": "500"},
+						EndpointState: endpointState("10.12.13.44", "UP"),
+					},
+				},
+				dcs: []dc{
+					{
+						Name:     "dc1",
+						Replicas: 2,
+					},
+				},
+				podIPs: map[string]string{
+					"/10.12.13.43": "172.16.16.43",
+					"/10.12.13.44": "172.16.16.44",
+				},
+			},
+			nodeStates: map[string]jolokia.CassandraResponse{
+				"172.16.16.43": {
+					Response: successJMXResponse,
+					Value: cassandraResponse(map[string]string{
+						"10.12.13.43": "UP",
+						"10.12.13.44": "DOWN",
+					}),
+				},
+			},
+		},
 		{
 			name: "node becomes unready",
 			initialState: state{
@@ -350,6 +408,161 @@ func TestUpdateNodeStates(t *testing.T) {
 		}
 
 		testProber.updateNodeStates()
+		for ip, node := range testProber.state.nodes {
+			if response, polled := testCase.nodeStates[testCase.initialState.podIPs[ip]]; polled && response.Status == http.StatusOK {
+				asserts.Expect(node.LastObserved).ToNot(gomega.BeZero(), testCase.name)
+			} else {
+				asserts.Expect(node.LastObserved).To(gomega.BeZero(), testCase.name)
+			}
+			node.LastObserved = time.Time{}
+			testProber.state.nodes[ip] = node
+		}
 		asserts.Expect(testProber.state).To(gomega.Equal(testCase.expectedState), cmp.Diff(testCase.expectedState, testProber.state, cmp.Options{cmp.AllowUnexported(state{})}))
 	}
 }
+
+func TestIsNodeReadyIgnoresStaleViews(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	testProber := &Prober{
+		cfg:      config.Config{JmxPollingInterval: 10 * time.Second},
+		log:      zap.NewNop().Sugar(),
+		lastPoll: time.Now(),
+		state: state{
+			nodes: map[string]nodeState{
+				"/10.12.13.43": {
+					SimpleStates:  map[string]string{"/10.12.13.43": "UP", "/10.12.13.44": "UP"},
+					EndpointState: endpointState("/10.12.13.43", "NORMAL"),
+					LastObserved:  time.Now(),
+				},
+				"/10.12.13.44": {
+					SimpleStates:  map[string]string{"/10.12.13.43": "DOWN", "/10.12.13.44": "UP"},
+					EndpointState: endpointState("/10.12.13.44", "NORMAL"),
+					LastObserved:  time.Now(),
+				},
+			},
+		},
+	}
+
+	ready, _ := testProber.isNodeReady("/10.12.13.43")
+	asserts.Expect(ready).To(gomega.BeFalse())
+
+	// the view of a node that hasn't been observed recently is ignored
+	staleNode := testProber.state.nodes["/10.12.13.44"]
+	staleNode.LastObserved = time.Now().Add(-time.Minute)
+	testProber.state.nodes["/10.12.13.44"] = staleNode
+	ready, _ = testProber.isNodeReady("/10.12.13.43")
+	asserts.Expect(ready).To(gomega.BeTrue())
+
+	// the node isn't ready if no view is recent
+	freshNode := testProber.state.nodes["/10.12.13.43"]
+	freshNode.LastObserved = time.Now().Add(-time.Minute)
+	testProber.state.nodes["/10.12.13.43"] = freshNode
+	ready, _ = testProber.isNodeReady("/10.12.13.43")
+	asserts.Expect(ready).To(gomega.BeFalse())
+
+	// the views observed by the last completed poll are not stale even if the next polls are delayed
+	testProber.lastPoll = freshNode.LastObserved
+	asserts.Expect(testProber.nodeReadiness("/10.12.13.43").stalePeerNodes).To(gomega.BeEmpty())
+	ready, _ = testProber.isNodeReady("/10.12.13.43")
+	asserts.Expect(ready).To(gomega.BeFalse(), "the view of /10.12.13.44 is taken into account again")
+}
+
+// blockingJolokiaMock doesn't respond until the request is canceled
+type blockingJolokiaMock struct {
+	jolokiaMock
+	mu                  sync.Mutex
+	inFlight, maxFlight int
+}
+
+func (j *blockingJolokiaMock) CassandraNodeState(ctx context.Context, ip string) (jolokia.CassandraResponse, error) {
+	j.mu.Lock()
+	j.inFlight++
+	if j.inFlight > j.maxFlight {
+		j.maxFlight = j.inFlight
+	}
+	j.mu.Unlock()
+
+	<-ctx.Done()
+
+	j.mu.Lock()
+	j.inFlight--
+	j.mu.Unlock()
+	return jolokia.CassandraResponse{}, ctx.Err()
+}
+
+func TestAllNodesStatesBoundedConcurrency(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	jolokiaClient := &blockingJolokiaMock{}
+	testProber := &Prober{
+		cfg:     config.Config{JmxPollingWorkers: 2, JmxRequestTimeout: 50 * time.Millisecond},
+		log:     zap.NewNop().Sugar(),
+		jolokia: jolokiaClient,
+		state: state{
+			nodes:  map[string]nodeState{},
+			podIPs: map[string]string{},
+		},
+	}
+	for i := 0; i < 5; i++ {
+		ip := fmt.Sprintf("/10.12.13.%d", i)
+		testProber.state.nodes[ip] = nodeState{}
+		testProber.state.podIPs[ip] = fmt.Sprintf("172.143.32.%d", i)
+	}
+
+	responses := testProber.allNodesStates()
+	asserts.Expect(responses).To(gomega.HaveLen(5))
+	for _, response := range responses {
+		asserts.Expect(response.Status).To(gomega.Equal(http.StatusInternalServerError))
+	}
+	asserts.Expect(jolokiaClient.maxFlight).To(gomega.Equal(2))
+}
+
+// run with -race: the node states are polled while the requests are served
+func TestNodeStatesConcurrentPollsAndRequests(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	testProber := NewProber(
+		config.Config{JmxPollingWorkers: 2, JmxRequestTimeout: time.Second, JmxPollingInterval: time.Second},
+		&jolokiaMock{nodeStates: map[string]jolokia.CassandraResponse{
+			"/192.0.2.1": { // the default RemoteAddr of httptest requests
+				Response: jolokia.Response{Status: http.StatusOK},
+				Value: cassandraResponse(map[string]string{
+					"10.12.13.43": "UP",
+					"10.12.13.44": "UP",
+				}),
+			},
+		}},
+		UserAuth{User: "user", Password: "password"},
+		&kubernetes.Clientset{},
+		zap.NewNop().Sugar(),
+	)
+	testProber.state.dcs = []dc{{Name: "dc1", Replicas: 2}}
+	router := httprouter.New()
+	setupRoutes(router, testProber)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(nodeStatesCollector{prober: testProber})
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			testProber.updateNodeStates()
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		for _, path := range []string{"/healthz/10.12.13.43", "/cluster-view", "/metrics"} {
+			request := httptest.NewRequest(http.MethodGet, path, nil)
+			request.SetBasicAuth("user", "password")
+			router.ServeHTTP(httptest.NewRecorder(), request)
+		}
+		_, err := registry.Gather()
+		asserts.Expect(err).ToNot(gomega.HaveOccurred())
+	}
+	wg.Wait()
+
+	view := testProber.clusterView()
+	asserts.Expect(view).To(gomega.HaveLen(2))
+	asserts.Expect(view[0].IP).To(gomega.Equal("/10.12.13.43"))
+	asserts.Expect(view[0].LastObserved).ToNot(gomega.BeNil())
+	asserts.Expect(view[1].IP).To(gomega.Equal("/10.12.13.44"))
+}
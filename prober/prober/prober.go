@@ -1,9 +1,13 @@
 package prober
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/ibm/cassandra-operator/prober/config"
@@ -17,9 +21,22 @@ type Prober struct {
 	cfg        config.Config
 	jolokia    jolokia.Jolokia
 	auth       UserAuth
-	kubeClient *kubernetes.Clientset
+	kubeClient kubernetes.Interface
 	log        *zap.SugaredLogger
-	state      state
+	httpClient *http.Client
+	// nil if the state is kept only in memory
+	store *stateStore
+	// guards the part of the state set by the operator
+	stateMu sync.RWMutex
+	writeMu sync.Mutex
+	state   state
+	// guards the nodes, their pod IPs and lastPoll, which are updated by the polls and read by the request handlers
+	nodesMu sync.RWMutex
+	// set to 1 while the node states are being polled
+	polling int32
+	// completion time of the last poll of the node states. The staleness of the node views is measured against it,
+	// so that the views don't become stale while the polls are delayed
+	lastPoll time.Time
 }
 
 type state struct {
@@ -43,18 +60,25 @@ type UserAuth struct {
 	Password string
 }
 
-func NewProber(cfg config.Config, jolokiaClient jolokia.Jolokia, auth UserAuth, clientset *kubernetes.Clientset, logr *zap.SugaredLogger) *Prober {
-	return &Prober{
+func NewProber(cfg config.Config, jolokiaClient jolokia.Jolokia, auth UserAuth, clientset kubernetes.Interface, logr *zap.SugaredLogger) *Prober {
+	p := &Prober{
 		cfg:        cfg,
 		jolokia:    jolokiaClient,
 		auth:       auth,
 		kubeClient: clientset,
 		log:        logr,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
 		state: state{
 			nodes:  make(map[string]nodeState),
 			podIPs: make(map[string]string),
 		},
 	}
+
+	if len(cfg.StateConfigMapName) > 0 {
+		p.store = newStateStore(clientset, cfg.PodNamespace, cfg.StateConfigMapName, cfg.LeaderElectionLeaseName, cfg.PodName, cfg.PodIP)
+	}
+
+	return p
 }
 
 func (p *Prober) Run() error {
@@ -67,6 +91,20 @@ func (p *Prober) Run() error {
 	baseSecretCh := p.WatchBaseSecret()
 	defer close(baseSecretCh)
 
+	if p.store != nil {
+		if err := p.loadState(context.Background()); err != nil {
+			return fmt.Errorf("can't load persisted state: %w", err)
+		}
+
+		stateCh := p.WatchState()
+		defer close(stateCh)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go p.runLeaderElection(ctx)
+	}
+
+	prometheus.MustRegister(nodeStatesCollector{prober: p})
 	go p.pollNodeStates()
 
 	p.log.Infow("Cassandra's prober listening", "serverPort", p.cfg.ServerPort)
@@ -89,6 +127,7 @@ func setupRoutes(router *httprouter.Router, prober *Prober) {
 	router.PUT("/region-ips", prober.BasicAuth(prometheusMiddleware(prober.putRegionIPs)))
 	router.GET("/reaper-ips", prober.BasicAuth(prometheusMiddleware(prober.getReaperIPs)))
 	router.PUT("/reaper-ips", prober.BasicAuth(prometheusMiddleware(prober.putReaperIPs)))
+	router.GET("/cluster-view", prober.BasicAuth(prometheusMiddleware(prober.getClusterView)))
 }
 
 func (p *Prober) BasicAuth(h httprouter.Handle) httprouter.Handle {
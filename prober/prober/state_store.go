@@ -0,0 +1,333 @@
+package prober
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	stateKey = "state.json"
+	// set on the requests forwarded to the leader, so that they are not forwarded again
+	forwardedHeader = "X-Prober-Forwarded"
+
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// persistedState is the part of the state set by the operator. It's persisted in a ConfigMap,
+// so that it survives restarts of the prober and is shared between its replicas.
+type persistedState struct {
+	Seeds       []string `json:"seeds,omitempty"`
+	RegionReady bool     `json:"regionReady"`
+	ReaperReady bool     `json:"reaperReady"`
+	DCs         []dc     `json:"dcs,omitempty"`
+	RegionIPs   []string `json:"regionIPs,omitempty"`
+	ReaperIPs   []string `json:"reaperIPs,omitempty"`
+}
+
+func (s *state) persisted() persistedState {
+	return persistedState{
+		Seeds:       s.seeds,
+		RegionReady: s.regionReady,
+		ReaperReady: s.reaperReady,
+		DCs:         s.dcs,
+		RegionIPs:   s.regionIPs,
+		ReaperIPs:   s.reaperIPs,
+	}
+}
+
+func (s *state) load(ps persistedState) {
+	s.seeds = ps.Seeds
+	s.regionReady = ps.RegionReady
+	s.reaperReady = ps.ReaperReady
+	s.dcs = ps.DCs
+	s.regionIPs = ps.RegionIPs
+	s.reaperIPs = ps.ReaperIPs
+}
+
+// stateStore persists the state in a ConfigMap. The state is written only by the leader replica.
+type stateStore struct {
+	kubeClient    kubernetes.Interface
+	namespace     string
+	configMapName string
+	leaseName     string
+	// <pod name>_<pod IP> of the replica. Used as the leader election identity to let the followers find the leader.
+	identity string
+
+	mu      sync.RWMutex
+	leading bool
+	leader  string
+}
+
+func newStateStore(kubeClient kubernetes.Interface, namespace, configMapName, leaseName, podName, podIP string) *stateStore {
+	return &stateStore{
+		kubeClient:    kubeClient,
+		namespace:     namespace,
+		configMapName: configMapName,
+		leaseName:     leaseName,
+		identity:      podName + "_" + podIP,
+	}
+}
+
+func (s *stateStore) load(ctx context.Context) (persistedState, error) {
+	ps := persistedState{}
+	cm, err := s.kubeClient.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.configMapName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ps, nil
+		}
+		return ps, err
+	}
+
+	return parseState(cm)
+}
+
+func (s *stateStore) save(ctx context.Context, ps persistedState) error {
+	data, err := json.Marshal(ps)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := s.kubeClient.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.configMapName, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				cm = &v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: s.configMapName, Namespace: s.namespace},
+					Data:       map[string]string{stateKey: string(data)},
+				}
+				_, err = s.kubeClient.CoreV1().ConfigMaps(s.namespace).Create(ctx, cm, metav1.CreateOptions{})
+			}
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[stateKey] = string(data)
+		_, err = s.kubeClient.CoreV1().ConfigMaps(s.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (s *stateStore) isLeader() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.leading
+}
+
+// leaderAddress returns the IP of the leader replica or an empty string if the leader is not known
+func (s *stateStore) leaderAddress() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if i := strings.LastIndex(s.leader, "_"); i >= 0 {
+		return s.leader[i+1:]
+	}
+
+	return ""
+}
+
+func (s *stateStore) setLeading(leading bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leading = leading
+}
+
+func (s *stateStore) setLeader(identity string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leader = identity
+}
+
+func parseState(cm *v1.ConfigMap) (persistedState, error) {
+	ps := persistedState{}
+	if len(cm.Data[stateKey]) == 0 {
+		return ps, nil
+	}
+
+	err := json.Unmarshal([]byte(cm.Data[stateKey]), &ps)
+	return ps, err
+}
+
+// loadState replaces the in-memory state with the persisted one
+func (p *Prober) loadState(ctx context.Context) error {
+	ps, err := p.store.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.stateMu.Lock()
+	p.state.load(ps)
+	p.stateMu.Unlock()
+	return nil
+}
+
+// WatchState keeps the state of the followers in sync with the state written by the leader
+func (p *Prober) WatchState() chan struct{} {
+	p.log.Info("Watching ConfigMap " + p.cfg.StateConfigMapName + "...")
+
+	watchList := cache.NewListWatchFromClient(
+		p.kubeClient.CoreV1().RESTClient(),
+		"configmaps",
+		p.cfg.PodNamespace,
+		fields.OneTermEqualSelector("metadata.name", p.cfg.StateConfigMapName),
+	)
+
+	_, controller := cache.NewInformer(
+		watchList,
+		&v1.ConfigMap{},
+		time.Minute,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: p.handleStateConfigMap,
+			UpdateFunc: func(_, newObj interface{}) {
+				p.handleStateConfigMap(newObj)
+			},
+		})
+	stopCh := make(chan struct{})
+	go controller.Run(stopCh)
+	return stopCh
+}
+
+func (p *Prober) handleStateConfigMap(obj interface{}) {
+	if p.store.isLeader() { // the leader is the only writer, its in-memory state is the latest
+		return
+	}
+
+	ps, err := parseState(obj.(*v1.ConfigMap))
+	if err != nil {
+		p.log.Errorf("can't parse persisted state: %s", err.Error())
+		return
+	}
+
+	p.stateMu.Lock()
+	p.state.load(ps)
+	p.stateMu.Unlock()
+}
+
+// runLeaderElection elects the replica that writes the state. The replica rejoins the election if it loses the leadership.
+func (p *Prober) runLeaderElection(ctx context.Context) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      p.cfg.LeaderElectionLeaseName,
+			Namespace: p.cfg.PodNamespace,
+		},
+		Client:     p.kubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: p.store.identity},
+	}
+
+	for ctx.Err() == nil {
+		leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			LeaseDuration:   leaseDuration,
+			RenewDeadline:   renewDeadline,
+			RetryPeriod:     retryPeriod,
+			ReleaseOnCancel: true,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(ctx context.Context) {
+					// the state could have been written by the previous leader after the last watch event
+					if err := p.loadState(ctx); err != nil {
+						p.log.Errorf("can't load persisted state: %s", err.Error())
+					}
+					p.store.setLeading(true)
+					p.log.Info("Started leading")
+				},
+				OnStoppedLeading: func() {
+					p.store.setLeading(false)
+					p.log.Info("Stopped leading")
+				},
+				OnNewLeader: func(identity string) {
+					p.store.setLeader(identity)
+					p.log.Infof("New leader elected: %s", identity)
+				},
+			},
+		})
+	}
+}
+
+// updateState applies the update to the state and persists it. If the prober runs with a state store
+// and the replica is not the leader, the request is forwarded to the leader.
+func (p *Prober) updateState(w http.ResponseWriter, r *http.Request, body []byte, update func(s *state)) {
+	if p.store == nil {
+		p.stateMu.Lock()
+		update(&p.state)
+		p.stateMu.Unlock()
+		return
+	}
+
+	if !p.store.isLeader() {
+		p.forwardToLeader(w, r, body)
+		return
+	}
+
+	// the writes are serialized, so that the persisted state is the latest one
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	// the update is applied to a copy and takes effect only once it's persisted,
+	// so that the leader doesn't serve a state that the other replicas never see
+	p.stateMu.RLock()
+	updated := p.state
+	p.stateMu.RUnlock()
+	update(&updated)
+	ps := updated.persisted()
+
+	if err := p.store.save(r.Context(), ps); err != nil {
+		p.log.Errorf("can't persist state: %s", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	p.stateMu.Lock()
+	p.state.load(ps)
+	p.stateMu.Unlock()
+}
+
+func (p *Prober) forwardToLeader(w http.ResponseWriter, r *http.Request, body []byte) {
+	leaderAddress := p.store.leaderAddress()
+	if len(leaderAddress) == 0 || len(r.Header.Get(forwardedHeader)) > 0 {
+		p.log.Warnf("can't update state: no leader elected")
+		http.Error(w, "no leader elected", http.StatusServiceUnavailable)
+		return
+	}
+
+	url := fmt.Sprintf("http://%s:%d%s", leaderAddress, p.cfg.ServerPort, r.URL.Path)
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, url, bytes.NewReader(body))
+	if err != nil {
+		p.log.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	req.Header.Set("Authorization", r.Header.Get("Authorization"))
+	req.Header.Set(forwardedHeader, p.store.identity)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		p.log.Errorf("can't forward request to leader %s: %s", leaderAddress, err.Error())
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	w.WriteHeader(resp.StatusCode)
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		p.log.Errorf("can't write response of leader %s: %s", leaderAddress, err.Error())
+	}
+}
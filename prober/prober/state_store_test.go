@@ -0,0 +1,145 @@
+package prober
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/onsi/gomega"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/ibm/cassandra-operator/prober/config"
+)
+
+func TestStateStore(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	ctx := context.Background()
+	store := newStateStore(fake.NewSimpleClientset(), "default", "test-prober-state", "test-prober-leader", "prober-0", "10.0.0.1")
+
+	ps, err := store.load(ctx)
+	asserts.Expect(err).ToNot(gomega.HaveOccurred())
+	asserts.Expect(ps).To(gomega.Equal(persistedState{}))
+
+	desired := persistedState{
+		Seeds:       []string{"seed1", "seed2"},
+		RegionReady: true,
+		DCs:         []dc{{Name: "dc1", Replicas: 3}},
+		RegionIPs:   []string{"10.0.0.2"},
+	}
+	asserts.Expect(store.save(ctx, desired)).To(gomega.Succeed())
+	ps, err = store.load(ctx)
+	asserts.Expect(err).ToNot(gomega.HaveOccurred())
+	asserts.Expect(ps).To(gomega.Equal(desired))
+
+	desired.ReaperReady = true
+	asserts.Expect(store.save(ctx, desired)).To(gomega.Succeed())
+	ps, err = store.load(ctx)
+	asserts.Expect(err).ToNot(gomega.HaveOccurred())
+	asserts.Expect(ps).To(gomega.Equal(desired))
+
+	asserts.Expect(store.leaderAddress()).To(gomega.BeEmpty())
+	store.setLeader("prober-1_10.0.0.3")
+	asserts.Expect(store.leaderAddress()).To(gomega.Equal("10.0.0.3"))
+}
+
+func TestUpdateStateLeader(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	kubeClient := fake.NewSimpleClientset()
+	leader := testStoreProber(kubeClient, 0)
+	leader.store.setLeading(true)
+
+	recorder := putState(leader, "/seeds", `["seed1","seed2"]`)
+	asserts.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+	asserts.Expect(leader.state.seeds).To(gomega.Equal([]string{"seed1", "seed2"}))
+
+	ps, err := leader.store.load(context.Background())
+	asserts.Expect(err).ToNot(gomega.HaveOccurred())
+	asserts.Expect(ps.Seeds).To(gomega.Equal([]string{"seed1", "seed2"}))
+
+	// the persisted state is loaded after a restart
+	restarted := testStoreProber(kubeClient, 0)
+	asserts.Expect(restarted.loadState(context.Background())).To(gomega.Succeed())
+	asserts.Expect(restarted.state.seeds).To(gomega.Equal([]string{"seed1", "seed2"}))
+
+	// the state is not changed if it can't be persisted
+	kubeClient.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("etcd unavailable")
+	})
+	recorder = putState(leader, "/seeds", `["seed3"]`)
+	asserts.Expect(recorder.Code).To(gomega.Equal(http.StatusInternalServerError))
+	asserts.Expect(leader.state.seeds).To(gomega.Equal([]string{"seed1", "seed2"}))
+}
+
+func TestUpdateStateFollower(t *testing.T) {
+	asserts := gomega.NewWithT(t)
+	kubeClient := fake.NewSimpleClientset()
+	leader := testStoreProber(kubeClient, 0)
+	leader.store.setLeading(true)
+	leaderRouter := httprouter.New()
+	setupRoutes(leaderRouter, leader)
+	leaderServer := httptest.NewServer(leaderRouter)
+	defer leaderServer.Close()
+
+	leaderURL, err := url.Parse(leaderServer.URL)
+	asserts.Expect(err).ToNot(gomega.HaveOccurred())
+	port, err := strconv.Atoi(leaderURL.Port())
+	asserts.Expect(err).ToNot(gomega.HaveOccurred())
+
+	follower := testStoreProber(kubeClient, port)
+
+	// no leader elected
+	recorder := putState(follower, "/region-ready", "true")
+	asserts.Expect(recorder.Code).To(gomega.Equal(http.StatusServiceUnavailable))
+
+	follower.store.setLeader("prober-0_" + leaderURL.Hostname())
+	recorder = putState(follower, "/region-ready", "true")
+	asserts.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+	asserts.Expect(leader.state.regionReady).To(gomega.BeTrue())
+	asserts.Expect(follower.state.regionReady).To(gomega.BeFalse())
+
+	recorder = putState(follower, "/region-ready", "invalid")
+	asserts.Expect(recorder.Code).To(gomega.Equal(http.StatusBadRequest))
+
+	// the follower's state is updated from the persisted state
+	cm, err := kubeClient.CoreV1().ConfigMaps("default").Get(context.Background(), "test-prober-state", metav1.GetOptions{})
+	asserts.Expect(err).ToNot(gomega.HaveOccurred())
+	follower.handleStateConfigMap(cm)
+	asserts.Expect(follower.state.regionReady).To(gomega.BeTrue())
+
+	// the leader ignores the persisted state
+	leader.handleStateConfigMap(&v1.ConfigMap{Data: map[string]string{stateKey: `{"regionReady":false}`}})
+	asserts.Expect(leader.state.regionReady).To(gomega.BeTrue())
+}
+
+func testStoreProber(kubeClient *fake.Clientset, serverPort int) *Prober {
+	return NewProber(config.Config{
+		ServerPort:              serverPort,
+		PodNamespace:            "default",
+		StateConfigMapName:      "test-prober-state",
+		LeaderElectionLeaseName: "test-prober-leader",
+		PodName:                 "prober",
+		PodIP:                   "127.0.0.1",
+	}, &jolokiaMock{}, UserAuth{User: "cassandra", Password: "cassandra"}, kubeClient, zap.NewNop().Sugar())
+}
+
+func putState(p *Prober, path, body string) *httptest.ResponseRecorder {
+	router := httprouter.New()
+	setupRoutes(router, p)
+	request := httptest.NewRequest(http.MethodPut, path, io.NopCloser(bytes.NewReader([]byte(body))))
+	request.SetBasicAuth("cassandra", "cassandra")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
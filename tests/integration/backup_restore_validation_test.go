@@ -0,0 +1,233 @@
+package integration
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+)
+
+var _ = Describe("cassandrabackup and cassandrarestore validation", func() {
+	ccTpl := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			ImagePullSecretName: "pullSecretName",
+			AdminRoleSecretName: "admin-role",
+		},
+	}
+
+	storageSecretTpl := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-credentials", Namespace: cassandraObjectMeta.Namespace},
+		Data: map[string][]byte{
+			"awsaccesskeyid":     []byte("key-id"),
+			"awssecretaccesskey": []byte("access-key"),
+			"awsregion":          []byte("us-east"),
+		},
+	}
+
+	cbTpl := &v1alpha1.CassandraBackup{
+		ObjectMeta: cassandraBackupObjectMeta,
+		Spec: v1alpha1.CassandraBackupSpec{
+			CassandraCluster: cassandraObjectMeta.Name,
+			StorageLocation:  "s3://bucket",
+			SecretName:       storageSecretTpl.Name,
+		},
+	}
+
+	crTpl := &v1alpha1.CassandraRestore{
+		ObjectMeta: cassandraRestoreObjectMeta,
+		Spec: v1alpha1.CassandraRestoreSpec{
+			CassandraCluster: cassandraObjectMeta.Name,
+			StorageLocation:  "s3://bucket",
+			SnapshotTag:      "snapshot",
+			SecretName:       storageSecretTpl.Name,
+		},
+	}
+
+	expectToBeInvalidError := func(err error, msg string) {
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+		Expect(err.(*errors.StatusError).ErrStatus.Reason).To(Equal(metav1.StatusReasonInvalid))
+		Expect(err.Error()).To(ContainSubstring(msg))
+	}
+
+	createClusterAndSecret := func() {
+		cc := ccTpl.DeepCopy()
+		createAdminSecret(cc)
+		Expect(k8sClient.Create(ctx, cc)).To(Succeed())
+		Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+	}
+
+	Context("cassandrabackup", func() {
+		It("should be rejected if the cluster or the secret doesn't exist", func() {
+			expectToBeInvalidError(k8sClient.Create(ctx, cbTpl.DeepCopy()), "CassandraCluster default/test-cluster not found")
+			expectToBeInvalidError(k8sClient.Create(ctx, cbTpl.DeepCopy()), "storage credentials secret default/storage-credentials not found")
+		})
+
+		It("should be rejected with an invalid spec", func() {
+			createClusterAndSecret()
+
+			cb := cbTpl.DeepCopy()
+			cb.Spec.StorageLocation = "s3://B"
+			expectToBeInvalidError(k8sClient.Create(ctx, cb), `bucket name "B" is invalid`)
+
+			cb = cbTpl.DeepCopy()
+			cb.Spec.StorageLocation = "ftp://bucket"
+			expectToBeInvalidError(k8sClient.Create(ctx, cb), "protocol ftp is not supported")
+
+			cb = cbTpl.DeepCopy()
+			cb.Spec.Duration = "0 hours"
+			expectToBeInvalidError(k8sClient.Create(ctx, cb), "duration should be in format")
+
+			cb = cbTpl.DeepCopy()
+			cb.Spec.Entities = "ks1,ks2.t2"
+			expectToBeInvalidError(k8sClient.Create(ctx, cb), "keyspaces and tables can't be used together")
+
+			cb = cbTpl.DeepCopy()
+			cb.Spec.Entities = "ks1.t1.c1"
+			expectToBeInvalidError(k8sClient.Create(ctx, cb), `"ks1.t1.c1" should be in format 'keyspace' or 'keyspace.table'`)
+
+			cb = cbTpl.DeepCopy()
+			cb.Spec.DC = "dc1,dc2"
+			expectToBeInvalidError(k8sClient.Create(ctx, cb), `DC "dc2" not found in CassandraCluster default/test-cluster`)
+
+			cb = cbTpl.DeepCopy()
+			cb.Spec.Entities = "ks1.t1, ks2.t2"
+			cb.Spec.DC = "dc1"
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+		})
+
+		It("should reject the options Medusa doesn't support if the cluster uses Medusa", func() {
+			cc := ccTpl.DeepCopy()
+			cc.Spec.BackupEngine = v1alpha1.BackupEngineMedusa
+			cc.Spec.Medusa = &v1alpha1.Medusa{
+				StorageLocation: "s3://bucket/medusa",
+				SecretName:      storageSecretTpl.Name,
+			}
+			createAdminSecret(cc)
+			Expect(k8sClient.Create(ctx, cc)).To(Succeed())
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+
+			cb := cbTpl.DeepCopy()
+			expectToBeInvalidError(k8sClient.Create(ctx, cb), `.spec.storageLocation should be "s3://bucket/medusa"`)
+
+			cb = cbTpl.DeepCopy()
+			cb.Spec.StorageLocation = cc.Spec.Medusa.StorageLocation
+			cb.Spec.Entities = "ks1"
+			cb.Spec.Retry = v1alpha1.Retry{Enabled: true}
+			err := k8sClient.Create(ctx, cb)
+			expectToBeInvalidError(err, `.spec.entities is not supported: CassandraCluster default/test-cluster uses the "medusa" backup engine`)
+			expectToBeInvalidError(err, `.spec.retry is not supported`)
+
+			cb = cbTpl.DeepCopy()
+			cb.Spec.StorageLocation = cc.Spec.Medusa.StorageLocation
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+		})
+
+		It("should not allow spec changes once the backup has started", func() {
+			createClusterAndSecret()
+			cb := cbTpl.DeepCopy()
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+
+			cb.Status.State = icarus.StateRunning
+			Expect(k8sClient.Status().Update(ctx, cb)).To(Succeed())
+			cb.Spec.Entities = "ks1"
+			expectToBeInvalidError(k8sClient.Update(ctx, cb), ".spec can't be changed once the backup has started")
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cb.Name, Namespace: cb.Namespace}, cb)).To(Succeed())
+			cb.Status.State = icarus.StateFailed
+			Expect(k8sClient.Status().Update(ctx, cb)).To(Succeed())
+			cb.Spec.Entities = "ks1"
+			Expect(k8sClient.Update(ctx, cb)).To(Succeed(), "a failed backup is retried with the changed spec")
+		})
+	})
+
+	Context("cassandrarestore", func() {
+		It("should be rejected with an invalid spec", func() {
+			createClusterAndSecret()
+
+			cr := crTpl.DeepCopy()
+			cr.Spec.CassandraCluster = "unknown-cluster"
+			expectToBeInvalidError(k8sClient.Create(ctx, cr), "CassandraCluster default/unknown-cluster not found")
+
+			cr = crTpl.DeepCopy()
+			cr.Spec.TopologyMapping = []v1alpha1.DCMapping{{Source: "dc1", Target: "dc2"}}
+			expectToBeInvalidError(k8sClient.Create(ctx, cr), `DC "dc2" not found in CassandraCluster default/test-cluster`)
+
+			cr = crTpl.DeepCopy()
+			cr.Spec.Rename = map[string]string{"ks1.t1": "ks1.t2"}
+			expectToBeInvalidError(k8sClient.Create(ctx, cr), ".spec.entities should list the renamed table ks1.t1")
+
+			cr = crTpl.DeepCopy()
+			cr.Spec.Entities = "ks1.t1,ks1.t2"
+			cr.Spec.Rename = map[string]string{"ks1.t1": "ks1.t2"}
+			expectToBeInvalidError(k8sClient.Create(ctx, cr), "the target table ks1.t2 is restored itself")
+
+			cr = crTpl.DeepCopy()
+			cr.Spec.Entities = "ks1.t1,ks2.t1"
+			cr.Spec.Rename = map[string]string{"ks1.t1": "ks3.t1", "ks2.t1": "ks3.t1"}
+			expectToBeInvalidError(k8sClient.Create(ctx, cr), "tables ks1.t1 and ks2.t1 are renamed to the same table ks3.t1")
+
+			cr = crTpl.DeepCopy()
+			cr.Spec.Entities = "ks1"
+			cr.Spec.Rename = map[string]string{"ks1.t1": "ks1"}
+			expectToBeInvalidError(k8sClient.Create(ctx, cr), "should map a table to a table in format 'keyspace.table'")
+
+			cr = crTpl.DeepCopy()
+			cr.Spec.Entities = "ks1"
+			cr.Spec.Rename = map[string]string{"ks1.t1": "ks2.t1"}
+			expectToBeInvalidError(k8sClient.Create(ctx, cr), "the target keyspace ks2 of table ks1.t1 doesn't exist")
+
+			mockCQLClient.tables = []cql.Table{{Keyspace: "ks2", Name: "t2"}}
+			expectToBeInvalidError(k8sClient.Create(ctx, cr), "the target table ks2.t1 of table ks1.t1 doesn't exist")
+
+			mockCQLClient.tables = append(mockCQLClient.tables, cql.Table{Keyspace: "ks2", Name: "t1"})
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+		})
+
+		It("should reject a point-in-time restore of a cluster using Medusa", func() {
+			cc := ccTpl.DeepCopy()
+			cc.Spec.BackupEngine = v1alpha1.BackupEngineMedusa
+			cc.Spec.Medusa = &v1alpha1.Medusa{
+				StorageLocation: "s3://bucket/medusa",
+				SecretName:      storageSecretTpl.Name,
+			}
+			createAdminSecret(cc)
+			Expect(k8sClient.Create(ctx, cc)).To(Succeed())
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+
+			cr := crTpl.DeepCopy()
+			cr.Spec.PointInTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			expectToBeInvalidError(k8sClient.Create(ctx, cr), `.spec.pointInTime is not supported: CassandraCluster default/test-cluster uses the "medusa" backup engine`)
+
+			cr.Spec.PointInTime = nil
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+		})
+
+		It("should not allow spec changes once the restore has started", func() {
+			createClusterAndSecret()
+			cr := crTpl.DeepCopy()
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+			cr.Status.State = icarus.StateRunning
+			Expect(k8sClient.Status().Update(ctx, cr)).To(Succeed())
+			cr.Spec.Entities = "ks1"
+			expectToBeInvalidError(k8sClient.Update(ctx, cr), ".spec can't be changed once the restore has started")
+		})
+	})
+})
@@ -4,9 +4,14 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -87,6 +92,7 @@ var _ = Describe("created cassandrabackup", func() {
 		It("should reflect errors in the status", func() {
 			cc := ccTpl.DeepCopy()
 			cb := cbTpl.DeepCopy()
+			cb.Spec.StorageLocation = cc.Spec.Medusa.StorageLocation
 			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
 			createReadyCluster(cc)
 			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
@@ -138,4 +144,118 @@ var _ = Describe("created cassandrabackup", func() {
 			}))
 		})
 	})
+
+	Context("with storage that can't be used", func() {
+		It("should not send a backup request until the storage is verified", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			mockStorageClient.bucketExists = false
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+
+			Eventually(func() *metav1.Condition {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return meta.FindStatusCondition(cb.Status.Conditions, v1alpha1.BackupConditionStorageVerified)
+			}, mediumTimeout, mediumRetry).ShouldNot(BeNil())
+			condition := meta.FindStatusCondition(cb.Status.Conditions, v1alpha1.BackupConditionStorageVerified)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(storage.ReasonBucketNotFound))
+			Expect(mockIcarusClient.backups).To(BeEmpty())
+
+			mockStorageClient.bucketExists = true
+
+			Eventually(func() []icarus.Backup {
+				return mockIcarusClient.backups
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+			condition = meta.FindStatusCondition(cb.Status.Conditions, v1alpha1.BackupConditionStorageVerified)
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(storage.ReasonVerified))
+			Expect(mockStorageClient.objects).To(ConsistOf(cc.Name + "/.cassandra-operator-verification-" + cb.Name))
+		})
+	})
+
+	Context("with the coordinator pod lost", func() {
+		It("should choose another coordinator and track the backup by operation ID", func() {
+			cc := ccTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Status.OperationID
+			}, mediumTimeout, mediumRetry).Should(Equal("random_id"))
+			coordinator := cb.Status.Coordinator
+			Expect(coordinator).To(Equal(names.DC(cc.Name, cc.Spec.DCs[0].Name) + "-0"))
+
+			coordinatorPod := &v1.Pod{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cc.Namespace, Name: coordinator}, coordinatorPod)).To(Succeed())
+			coordinatorPod.Status.ContainerStatuses[1].Ready = false
+			Expect(k8sClient.Status().Update(ctx, coordinatorPod)).To(Succeed())
+
+			// the global request is gone with the coordinator, only the node requests are left
+			mockIcarusClient.backups[0].GlobalRequest = false
+			mockIcarusClient.backups[0].Progress = 1.0
+			mockIcarusClient.backups[0].State = icarus.StateCompleted
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCompleted))
+			Expect(cb.Status.Coordinator).ToNot(Equal(coordinator))
+			Expect(cb.Status.OperationID).To(Equal("random_id"))
+			Expect(mockIcarusClient.backups).To(HaveLen(1), "the backup should not be restarted")
+		})
+	})
+
+	Context("with the medusa backup engine", func() {
+		It("should send a backup request to every node and track progress", func() {
+			cc := ccTpl.DeepCopy()
+			cc.Spec.BackupEngine = v1alpha1.BackupEngineMedusa
+			cc.Spec.Medusa = &v1alpha1.Medusa{
+				StorageLocation: "s3://bucket/medusa",
+				SecretName:      storageSecretTpl.Name,
+			}
+			cb := cbTpl.DeepCopy()
+			cb.Spec.StorageLocation = cc.Spec.Medusa.StorageLocation
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			createReadyCluster(cc)
+
+			sts := &apps.StatefulSet{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, cc.Spec.DCs[0].Name), Namespace: cc.Namespace}, sts)).To(Succeed())
+			medusaContainer, found := getContainerByName(sts.Spec.Template.Spec, "medusa")
+			Expect(found).To(BeTrue())
+			Expect(medusaContainer.Image).To(Equal(operatorConfig.DefaultMedusaImage))
+			_, found = getContainerByName(sts.Spec.Template.Spec, "icarus")
+			Expect(found).To(BeFalse())
+
+			medusaCM := &v1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.MedusaConfigMap(cc.Name), Namespace: cc.Namespace}, medusaCM)).To(Succeed())
+			Expect(medusaCM.Data["medusa.ini"]).To(ContainSubstring("bucket_name = bucket\nprefix = medusa\nregion = us-east\n"))
+
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateRunning))
+			Expect(cb.Status.OperationID).To(Equal(cb.Name))
+			storageVerified := meta.FindStatusCondition(cb.Status.Conditions, v1alpha1.BackupConditionStorageVerified)
+			Expect(storageVerified).NotTo(BeNil())
+			Expect(storageVerified.Status).To(Equal(metav1.ConditionUnknown))
+			Expect(storageVerified.Reason).To(Equal(storage.ReasonNotVerified))
+			Expect(mockMedusaServer.Requests("AsyncBackup")).To(BeNumerically(">=", int(*cc.Spec.DCs[0].Replicas)))
+			Expect(mockIcarusClient.backups).To(BeEmpty())
+
+			mockMedusaServer.SetBackupStatus(cb.Name, medusa.StatusType_SUCCESS)
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cb.Namespace, Name: cb.Name}, cb)).To(Succeed())
+				return cb.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCompleted))
+			Expect(cb.Status.Progress).To(Equal(100))
+		})
+	})
 })
@@ -0,0 +1,130 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/icarus"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("created cassandrabackupcatalog", func() {
+	const schemaVersion = "8d2a4c2e-2b4f-3b1a-9c5e-4a7f3e2d1c0b"
+
+	ccTpl := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			AdminRoleSecretName: "admin-role",
+			ImagePullSecretName: "pullSecretName",
+		},
+	}
+
+	storageSecretTpl := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-credentials", Namespace: cassandraObjectMeta.Namespace},
+		Data: map[string][]byte{
+			"awsaccesskeyid":     []byte("key-id"),
+			"awssecretaccesskey": []byte("access-key"),
+			"awsregion":          []byte("us-east"),
+			"awsendpoint":        []byte("https://s3.us-east.cloud-object-storage.appdomain.cloud"),
+		},
+	}
+
+	catalogTpl := &v1alpha1.CassandraBackupCatalog{
+		ObjectMeta: cassandraBackupCatalogObjectMeta,
+		Spec: v1alpha1.CassandraBackupCatalogSpec{
+			StorageLocation: "s3://bucket/backups",
+			SecretName:      storageSecretTpl.Name,
+		},
+	}
+
+	storedManifests := map[string][]byte{
+		"backups/deleted-cluster/dc1/node1/manifests/weekly-" + schemaVersion + "-1630497600000.json": []byte(`{"snapshot":{"keyspaces":{"ks":{"tables":{"t1":{"entries":[{"size":100},{"size":20}]}}}}}}`),
+		"backups/deleted-cluster/dc1/node2/manifests/weekly-" + schemaVersion + "-1630497660000.json": []byte(`{"snapshot":{"keyspaces":{"ks":{"tables":{"t1":{"entries":[{"size":30}]}}}}}}`),
+		"backups/deleted-cluster/dc1/node1/data/ks/t1/md-1-big-Data.db":                               []byte("data"),
+	}
+
+	It("should list the backups found in the storage location", func() {
+		mockStorageClient.storedObjects = storedManifests
+		Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+		catalog := catalogTpl.DeepCopy()
+		Expect(k8sClient.Create(ctx, catalog)).To(Succeed())
+
+		Eventually(func() []v1alpha1.CatalogBackup {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: catalog.Namespace, Name: catalog.Name}, catalog)).To(Succeed())
+			return catalog.Status.Backups
+		}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+
+		backup := catalog.Status.Backups[0]
+		Expect(backup.Cluster).To(Equal("deleted-cluster"))
+		Expect(backup.SnapshotTag).To(Equal("weekly"))
+		Expect(backup.SchemaVersion).To(Equal(schemaVersion))
+		Expect(backup.DCs).To(Equal([]v1alpha1.CatalogDC{{Name: "dc1", Nodes: 2}}))
+		Expect(backup.Nodes).To(BeEquivalentTo(2))
+		Expect(backup.SizeBytes).To(BeEquivalentTo(150))
+		Expect(backup.Timestamp.UTC().Unix()).To(BeEquivalentTo(1630497660))
+		Expect(catalog.Status.LastSyncTime).ToNot(BeNil())
+	})
+
+	Context("used by a cassandrarestore", func() {
+		It("should restore the backup from the catalog storage location", func() {
+			mockStorageClient.storedObjects = storedManifests
+			cc := ccTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			catalog := catalogTpl.DeepCopy()
+			Expect(k8sClient.Create(ctx, catalog)).To(Succeed())
+
+			cr := &v1alpha1.CassandraRestore{
+				ObjectMeta: cassandraRestoreObjectMeta,
+				Spec: v1alpha1.CassandraRestoreSpec{
+					CassandraCluster:       cc.Name,
+					CassandraBackupCatalog: catalog.Name,
+					SnapshotTag:            "weekly",
+				},
+			}
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+			Eventually(func() []icarus.Restore {
+				return mockIcarusClient.restores
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Expect(mockIcarusClient.restores[0].StorageLocation).To(Equal("s3://bucket/backups/deleted-cluster/dc1/1"))
+			Expect(mockIcarusClient.restores[0].K8sSecretName).To(Equal(storageSecretTpl.Name))
+		})
+
+		It("should fail if the schema version is not found in the catalog", func() {
+			mockStorageClient.storedObjects = storedManifests
+			cc := ccTpl.DeepCopy()
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, catalogTpl.DeepCopy())).To(Succeed())
+
+			cr := &v1alpha1.CassandraRestore{
+				ObjectMeta: cassandraRestoreObjectMeta,
+				Spec: v1alpha1.CassandraRestoreSpec{
+					CassandraCluster:       cc.Name,
+					CassandraBackupCatalog: catalogTpl.Name,
+					SnapshotTag:            "weekly",
+					SchemaVersion:          "00000000-0000-0000-0000-000000000000",
+				},
+			}
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateFailed))
+			Expect(cr.Status.Errors).To(HaveLen(1))
+			Expect(cr.Status.Errors[0].Message).To(ContainSubstring(schemaVersion))
+			Expect(mockIcarusClient.restores).To(BeEmpty())
+		})
+	})
+})
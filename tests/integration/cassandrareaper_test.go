@@ -0,0 +1,96 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("created cassandrareaper", func() {
+	It("should repair the clusters that reference it with a single Reaper", func() {
+		cc := &v1alpha1.CassandraCluster{
+			ObjectMeta: cassandraObjectMeta,
+			Spec: v1alpha1.CassandraClusterSpec{
+				DCs: []v1alpha1.DC{
+					{
+						Name:     "dc1",
+						Replicas: proto.Int32(3),
+					},
+				},
+				AdminRoleSecretName: "admin-role",
+				ImagePullSecretName: "pullSecretName",
+				ReaperRef:           cassandraReaperObjectMeta.Name,
+			},
+		}
+
+		cr := &v1alpha1.CassandraReaper{
+			ObjectMeta: cassandraReaperObjectMeta,
+			Spec: v1alpha1.CassandraReaperSpec{
+				StorageCassandraCluster: cc.Name,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+		createAdminSecret(cc)
+		Expect(k8sClient.Create(ctx, cc)).To(Succeed())
+		markMocksAsReady(cc)
+		mockReaperClient.clusters = nil
+		waitForDCsToBeCreated(cc)
+		markAllDCsReady(cc)
+		createNodes(nodeIPs)
+		createCassandraPods(cc)
+
+		By("the JMX credentials of the cluster should be passed to the shared Reaper")
+		credentialsSecret := &v1.Secret{}
+		Eventually(func() string {
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: names.SharedReaperJMXCredentialsSecret(cr.Name), Namespace: cr.Namespace}, credentialsSecret)
+			return string(credentialsSecret.Data["credentials"])
+		}, longTimeout, mediumRetry).Should(Equal("admin-role:admin-password@" + cc.Name))
+
+		deploymentName := types.NamespacedName{Name: names.SharedReaperDeployment(cr.Name), Namespace: cr.Namespace}
+		deployment := &apps.Deployment{}
+		waitForResourceToBeCreated(deploymentName, deployment)
+		container := deployment.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).To(Equal(operatorConfig.DefaultReaperImage))
+		Expect(container.Env).To(ContainElement(v1.EnvVar{Name: "REAPER_CASS_CLUSTER_NAME", Value: cc.Name}))
+		Expect(container.Env).To(ContainElement(v1.EnvVar{Name: "REAPER_DATACENTER_AVAILABILITY", Value: "ALL"}))
+		Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(v1alpha1.CassandraClusterComponent, v1alpha1.CassandraClusterComponentReaper))
+
+		By("the cluster should be added to the shared Reaper once it's ready")
+		Consistently(func() []string {
+			return mockReaperClient.clusters
+		}, shortTimeout, shortRetry).Should(BeEmpty())
+
+		Eventually(func() error {
+			Expect(k8sClient.Get(ctx, deploymentName, deployment)).To(Succeed())
+			deployment.Status.ObservedGeneration = deployment.Generation
+			deployment.Status.Replicas = *deployment.Spec.Replicas
+			deployment.Status.UpdatedReplicas = *deployment.Spec.Replicas
+			deployment.Status.ReadyReplicas = *deployment.Spec.Replicas
+			return k8sClient.Status().Update(ctx, deployment)
+		}, mediumTimeout, mediumRetry).Should(Succeed())
+
+		Eventually(func() []string {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr)).To(Succeed())
+			return cr.Status.Clusters
+		}, longTimeout, mediumRetry).Should(Equal([]string{cc.Name}))
+
+		Eventually(func() []string {
+			return mockReaperClient.clusters
+		}, longTimeout, mediumRetry).Should(ConsistOf(cc.Name))
+
+		By("the JMX credentials of the registered cluster should be tracked to reload them without restarting Reaper")
+		Eventually(func() map[string]string {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr)).To(Succeed())
+			return cr.Status.CredentialsChecksums
+		}, longTimeout, mediumRetry).Should(HaveKey(cc.Name))
+
+		By("no Reaper should be deployed for the cluster")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.ReaperDeployment(cc.Name, "dc1"), Namespace: cc.Namespace}, &apps.Deployment{})).ToNot(Succeed())
+	})
+})
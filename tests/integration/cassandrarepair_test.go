@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/reaper"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("created cassandrarepair", func() {
+	ccTpl := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			AdminRoleSecretName: "admin-role",
+			ImagePullSecretName: "pullSecretName",
+		},
+	}
+
+	It("should start a repair run and mirror its progress", func() {
+		cc := ccTpl.DeepCopy()
+		createReadyCluster(cc)
+
+		cr := &v1alpha1.CassandraRepair{
+			ObjectMeta: cassandraRepairObjectMeta,
+			Spec: v1alpha1.CassandraRepairSpec{
+				CassandraCluster:  cc.Name,
+				Keyspace:          "ks",
+				Tables:            []string{"table1"},
+				Datacenters:       []string{"dc1"},
+				RepairParallelism: "PARALLEL",
+				IncrementalRepair: true,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+		Eventually(func() string {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+			return cr.Status.State
+		}, mediumTimeout, mediumRetry).Should(Equal(reaper.RepairStateRunning))
+
+		Expect(mockReaperClient.repairRuns).To(HaveLen(1))
+		repairRun := mockReaperClient.repairRuns[0]
+		Expect(repairRun.KeyspaceName).To(Equal("ks"))
+		Expect(repairRun.ColumnFamilies).To(Equal([]string{"table1"}))
+		Expect(repairRun.Datacenters).To(Equal([]string{"dc1"}))
+		Expect(repairRun.RepairParallelism).To(Equal("PARALLEL"))
+		Expect(repairRun.IncrementalRepair).To(BeTrue())
+		Expect(repairRun.Cause).To(ContainSubstring(cr.Name))
+		Expect(cr.Status.RunID).To(Equal(repairRun.ID))
+		Expect(cr.Status.TotalSegments).To(BeEquivalentTo(10))
+		Expect(cr.Status.StartTime).ToNot(BeNil())
+		Expect(cr.Finalizers).To(ContainElement("db.ibm.com/abort-repair-run"))
+
+		mockReaperClient.repairRuns[0].State = reaper.RepairStateDone
+		mockReaperClient.repairRuns[0].SegmentsRepaired = 10
+		mockReaperClient.repairRuns[0].EndTime = "2021-09-01T12:00:00Z"
+		cr.Labels = map[string]string{"refresh": "true"} // trigger a reconcile instead of waiting for the refresh
+		Expect(k8sClient.Update(ctx, cr)).To(Succeed())
+
+		Eventually(func() string {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+			return cr.Status.State
+		}, mediumTimeout, mediumRetry).Should(Equal(reaper.RepairStateDone))
+		Expect(cr.Status.SegmentsRepaired).To(BeEquivalentTo(10))
+		Expect(cr.Status.EndTime).ToNot(BeNil())
+
+		Expect(k8sClient.Delete(ctx, cr)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)
+			return kerrors.IsNotFound(err)
+		}, mediumTimeout, mediumRetry).Should(BeTrue())
+		Expect(mockReaperClient.deletedRepairRuns).To(BeEmpty(), "finished repair runs are kept in Reaper")
+	})
+
+	It("should abort the repair run when deleted", func() {
+		cc := ccTpl.DeepCopy()
+		createReadyCluster(cc)
+
+		cr := &v1alpha1.CassandraRepair{
+			ObjectMeta: cassandraRepairObjectMeta,
+			Spec: v1alpha1.CassandraRepairSpec{
+				CassandraCluster: cc.Name,
+				Keyspace:         "ks",
+			},
+		}
+		Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+		Eventually(func() string {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+			return cr.Status.State
+		}, mediumTimeout, mediumRetry).Should(Equal(reaper.RepairStateRunning))
+		runID := cr.Status.RunID
+
+		Expect(k8sClient.Delete(ctx, cr)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)
+			return kerrors.IsNotFound(err)
+		}, mediumTimeout, mediumRetry).Should(BeTrue())
+
+		Expect(mockReaperClient.deletedRepairRuns).To(Equal([]string{runID}))
+		Expect(mockReaperClient.repairRuns).To(BeEmpty())
+	})
+})
@@ -1,12 +1,21 @@
 package integration
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/gogo/protobuf/proto"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	"github.com/ibm/cassandra-operator/controllers/nodectl"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -86,6 +95,8 @@ var _ = Describe("created cassandrarestore", func() {
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
 			return cr.Status.Progress
 		}, mediumTimeout, mediumRetry).Should(Equal(53))
+		Expect(cr.Status.Coordinator).ToNot(BeEmpty())
+		Expect(cr.Status.OperationIDs).To(Equal([]string{"random_id"}))
 
 		mockIcarusClient.restores[0].Progress = 1
 		mockIcarusClient.restores[0].State = icarus.StateCompleted
@@ -164,4 +175,337 @@ var _ = Describe("created cassandrarestore", func() {
 			}))
 		})
 	})
+
+	Context("with topology mapping", func() {
+		It("should send a restore request per mapped DC", func() {
+			cc := ccTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			cr.Spec.SourceCluster = "source-cluster"
+			cr.Spec.TopologyMapping = []v1alpha1.DCMapping{
+				{
+					Source: "source-dc1",
+					Target: "dc1",
+				},
+			}
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+			Eventually(func() []icarus.Restore {
+				return mockIcarusClient.restores
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			Expect(mockIcarusClient.restores[0].StorageLocation).To(Equal("s3://bucket/source-cluster/source-dc1/1"))
+			Expect(mockIcarusClient.restores[0].DC).To(Equal("dc1"))
+			Expect(mockIcarusClient.restores[0].ResolveHostIdFromTopology).To(BeTrue())
+
+			mockIcarusClient.restores[0].Progress = 1
+			mockIcarusClient.restores[0].State = icarus.StateCompleted
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCompleted))
+		})
+
+		It("should stream SSTables with a loader job if the number of nodes differs", func() {
+			cc := ccTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			cr.Spec.TopologyMapping = []v1alpha1.DCMapping{
+				{
+					Source:      "source-dc1",
+					Target:      "dc1",
+					SourceNodes: 12,
+				},
+			}
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+			job := &batchv1.Job{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: names.RestoreLoaderJob(cr.Name, "source-dc1")}, job)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+			Expect(job.Spec.Template.Spec.InitContainers[0].Args[0]).To(ContainSubstring("for i in $(seq 0 11)"))
+			Expect(job.Spec.Template.Spec.InitContainers[0].Args[0]).To(ContainSubstring("--storage-location=s3://bucket/" + cb.Spec.CassandraCluster + "/source-dc1/" + cb.Spec.CassandraCluster + "-cassandra-source-dc1-${i}"))
+			Expect(job.Spec.Template.Spec.Containers[0].Args[0]).To(ContainSubstring("sstableloader --nodes " + names.DC(cc.Name, "dc1") + "-0."))
+			Expect(job.Spec.Template.Spec.Containers[0].Args[0]).To(ContainSubstring(fmt.Sprintf("--port %d", v1alpha1.CqlPort)))
+			Expect(job.Spec.Template.Spec.Containers[0].Args[0]).NotTo(ContainSubstring("--conf-path"))
+			Expect(job.Spec.Template.Spec.ImagePullSecrets).To(Equal([]v1.LocalObjectReference{{Name: "pullSecretName"}}))
+			Expect(mockIcarusClient.restores).To(BeEmpty())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateRunning))
+
+			job.Status.Conditions = []batchv1.JobCondition{
+				{
+					Type:   batchv1.JobComplete,
+					Status: v1.ConditionTrue,
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCompleted))
+			Expect(cr.Status.Progress).To(Equal(100))
+		})
+	})
+
+	Context("with point in time", func() {
+		It("should replay commitlogs on every node after the snapshot restore", func() {
+			cc := ccTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				CommitLogArchiving: &v1alpha1.CommitLogArchiving{
+					StorageLocation: "s3://bucket",
+					SecretName:      storageSecretTpl.Name,
+				},
+			}
+			pointInTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+			cr.Spec.PointInTime = &pointInTime
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+			Eventually(func() []icarus.Restore {
+				return mockIcarusClient.restores
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			mockIcarusClient.restores[0].Progress = 1
+			mockIcarusClient.restores[0].State = icarus.StateCompleted
+
+			Eventually(func() []icarus.CommitLogRestore {
+				return mockIcarusClient.commitLogRestores
+			}, mediumTimeout, mediumRetry).Should(HaveLen(6))
+			Expect(mockIcarusClient.commitLogRestores[0].StorageLocation).To(Equal("s3://bucket/" + cb.Spec.CassandraCluster + "/dc1/" + names.DC(cc.Name, "dc1") + "-0"))
+			Expect(mockIcarusClient.commitLogRestores[0].TimestampEnd).To(Equal(pointInTime.UnixMilli()))
+			Expect(mockIcarusClient.commitLogRestores[0].K8sSecretName).To(Equal(storageSecretTpl.Name))
+
+			Consistently(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, shortTimeout, shortRetry).Should(Equal(icarus.StateRunning))
+
+			for i := range mockIcarusClient.commitLogRestores {
+				mockIcarusClient.commitLogRestores[i].Progress = 1
+				mockIcarusClient.commitLogRestores[i].State = icarus.StateCompleted
+			}
+
+			sts := &apps.StatefulSet{}
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cc.Namespace, Name: names.DC(cc.Name, "dc1")}, sts)).To(Succeed())
+				return sts.Spec.Template.Annotations["commitlog-replay"]
+			}, mediumTimeout, mediumRetry).Should(Equal(string(cr.UID)))
+			Expect(cr.Status.State).To(Equal(icarus.StateRunning))
+
+			sts.Status.ObservedGeneration = sts.Generation
+			sts.Status.UpdatedReplicas = *sts.Spec.Replicas
+			sts.Status.ReadyReplicas = *sts.Spec.Replicas
+			Expect(k8sClient.Status().Update(ctx, sts)).To(Succeed())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCompleted))
+		})
+
+		It("should fail if the cluster doesn't archive commitlogs", func() {
+			cc := ccTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			pointInTime := metav1.NewTime(time.Now().Add(-time.Hour))
+			cr.Spec.PointInTime = &pointInTime
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateFailed))
+			Expect(cr.Status.Errors).To(HaveLen(1))
+			Expect(mockIcarusClient.restores).To(BeEmpty())
+		})
+	})
+
+	Context("with verification", func() {
+		const schemaVersion = "8d2a4c2e-2b4f-3b1a-9c5e-4a7f3e2d1c0b"
+
+		completeRestore := func(cc *v1alpha1.CassandraCluster, cb *v1alpha1.CassandraBackup, cr *v1alpha1.CassandraRestore) {
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, cb)).To(Succeed())
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+			Eventually(func() []icarus.Restore {
+				return mockIcarusClient.restores
+			}, mediumTimeout, mediumRetry).Should(HaveLen(1))
+			mockIcarusClient.restores[0].Progress = 1
+			mockIcarusClient.restores[0].State = icarus.StateCompleted
+		}
+
+		BeforeEach(func() {
+			manifest := []byte(`{"snapshot":{"keyspaces":{` +
+				`"ks":{"tables":{"t1":{"entries":[{"objectKey":"data/ks/t1/nb-1-big-Data.db","size":100},{"objectKey":"data/ks/t1/nb-1-big-Index.db","size":10}]}}},` +
+				`"system_schema":{"tables":{"tables":{"entries":[{"objectKey":"data/system_schema/tables/nb-1-big-Data.db","size":5}]}}}}}}`)
+			mockStorageClient.storedObjects = map[string][]byte{
+				cassandraObjectMeta.Name + "/dc1/node1/manifests/" + cassandraBackupObjectMeta.Name + "-" + schemaVersion + "-1630497600000.json": manifest,
+				cassandraObjectMeta.Name + "/dc1/node2/manifests/" + cassandraBackupObjectMeta.Name + "-" + schemaVersion + "-1630497600000.json": manifest,
+			}
+		})
+
+		It("should mark the restore as verified if the restored data matches the backup", func() {
+			cc := ccTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			cr.Spec.Verification = &v1alpha1.RestoreVerification{Repair: true}
+			mockNodectlClient.tableStats = map[string]nodectl.TableStats{"ks.t1": {LiveSSTables: 1, EstimatedPartitions: 10, LiveDiskSpaceUsed: 200}}
+			completeRestore(cc, cb, cr)
+
+			Eventually(func() *metav1.Condition {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return meta.FindStatusCondition(cr.Status.Conditions, v1alpha1.RestoreConditionVerified)
+			}, mediumTimeout, mediumRetry).Should(And(Not(BeNil()), WithTransform(func(c *metav1.Condition) metav1.ConditionStatus {
+				return c.Status
+			}, Equal(metav1.ConditionTrue))))
+
+			Expect(cr.Status.State).To(Equal(icarus.StateCompleted))
+			Expect(cr.Status.Errors).To(BeEmpty())
+			Expect(cr.Status.VerifiedTables).To(HaveLen(1))
+			Expect(cr.Status.VerifiedTables[0].Keyspace).To(Equal("ks"))
+			Expect(cr.Status.VerifiedTables[0].DC).To(Equal("dc1"))
+			Expect(cr.Status.VerifiedTables[0].BackupSSTables).To(BeEquivalentTo(2))
+			Expect(cr.Status.VerifiedTables[0].BackupSizeBytes).To(BeEquivalentTo(220))
+			Expect(mockReaperClient.repairedKeyspaces).To(ContainElement("ks"))
+		})
+
+		It("should record restore errors if the restored table is empty", func() {
+			cc := ccTpl.DeepCopy()
+			cr := crTpl.DeepCopy()
+			cb := cbTpl.DeepCopy()
+			cr.Spec.Verification = &v1alpha1.RestoreVerification{ConsistencyLevel: "ALL"}
+			mockCQLClient.emptyTables = []string{"ks.t1"}
+			completeRestore(cc, cb, cr)
+
+			Eventually(func() *metav1.Condition {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return meta.FindStatusCondition(cr.Status.Conditions, v1alpha1.RestoreConditionVerified)
+			}, mediumTimeout, mediumRetry).Should(And(Not(BeNil()), WithTransform(func(c *metav1.Condition) metav1.ConditionStatus {
+				return c.Status
+			}, Equal(metav1.ConditionFalse))))
+
+			// no live SSTables and an empty sample read
+			Expect(cr.Status.Errors).To(HaveLen(2))
+			Expect(cr.Status.Errors[0].Source).To(Equal("cassandra-operator"))
+			Expect(mockReaperClient.repairedKeyspaces).To(BeEmpty())
+		})
+	})
+
+	Context("with the medusa backup engine", func() {
+		It("should restore the backup in place", func() {
+			cc := ccTpl.DeepCopy()
+			cc.Spec.BackupEngine = v1alpha1.BackupEngineMedusa
+			cc.Spec.Medusa = &v1alpha1.Medusa{
+				StorageLocation: "s3://bucket/medusa",
+				SecretName:      storageSecretTpl.Name,
+			}
+			cr := crTpl.DeepCopy()
+			cr.Spec.CassandraBackup = ""
+			cr.Spec.StorageLocation = "s3://bucket"
+			cr.Spec.SnapshotTag = "snapshot"
+			cr.Spec.SecretName = storageSecretTpl.Name
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+			Eventually(func() []string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.OperationIDs
+			}, mediumTimeout, mediumRetry).Should(Equal([]string{string(cr.UID)}))
+			Expect(cr.Status.State).To(Equal(icarus.StateRunning))
+
+			preparedRestores := mockMedusaServer.PreparedRestores()
+			Expect(preparedRestores).To(HaveLen(int(*cc.Spec.DCs[0].Replicas)))
+			for _, preparedRestore := range preparedRestores {
+				Expect(preparedRestore.BackupName).To(Equal("snapshot"))
+				Expect(preparedRestore.Datacenter).To(Equal(cc.Spec.DCs[0].Name))
+				Expect(preparedRestore.RestoreKey).To(Equal(string(cr.UID)))
+			}
+			Expect(mockIcarusClient.restores).To(BeEmpty())
+
+			sts := &apps.StatefulSet{}
+			Eventually(func() bool {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, cc.Spec.DCs[0].Name), Namespace: cc.Namespace}, sts)).To(Succeed())
+				_, found := getInitContainerByName(sts.Spec.Template.Spec, medusa.RestoreContainerName)
+				return found
+			}, mediumTimeout, mediumRetry).Should(BeTrue())
+
+			restoreContainer, _ := getInitContainerByName(sts.Spec.Template.Spec, medusa.RestoreContainerName)
+			Expect(restoreContainer.Env).To(ContainElements(
+				v1.EnvVar{Name: medusa.RestoreKeyEnvVar, Value: string(cr.UID)},
+				v1.EnvVar{Name: medusa.BackupNameEnvVar, Value: "snapshot"},
+			))
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+			Expect(cc.Status.MedusaRestore).To(Equal(&v1alpha1.MedusaRestore{
+				Restore:    cr.Name,
+				RestoreKey: string(cr.UID),
+				BackupName: "snapshot",
+				DCs:        []string{cc.Spec.DCs[0].Name},
+			}))
+
+			// the rollout of the statefulset restores the nodes
+			Eventually(func() error {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: sts.Name, Namespace: sts.Namespace}, sts)).To(Succeed())
+				sts.Status.ObservedGeneration = sts.Generation
+				sts.Status.Replicas = *sts.Spec.Replicas
+				sts.Status.ReadyReplicas = *sts.Spec.Replicas
+				sts.Status.UpdatedReplicas = *sts.Spec.Replicas
+				sts.Status.CurrentRevision = "restored"
+				sts.Status.UpdateRevision = "restored"
+				return k8sClient.Status().Update(ctx, sts)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateCompleted))
+			Expect(cr.Status.Progress).To(Equal(100))
+		})
+
+		It("should fail a point-in-time restore", func() {
+			cc := ccTpl.DeepCopy()
+			cc.Spec.BackupEngine = v1alpha1.BackupEngineMedusa
+			cc.Spec.Medusa = &v1alpha1.Medusa{
+				StorageLocation: "s3://bucket/medusa",
+				SecretName:      storageSecretTpl.Name,
+			}
+			cr := crTpl.DeepCopy()
+			cr.Spec.CassandraBackup = ""
+			cr.Spec.StorageLocation = "s3://bucket"
+			cr.Spec.SnapshotTag = "snapshot"
+			cr.Spec.SecretName = storageSecretTpl.Name
+			cr.Spec.PointInTime = &metav1.Time{Time: time.Now()}
+			Expect(k8sClient.Create(ctx, storageSecretTpl.DeepCopy())).To(Succeed())
+			createReadyCluster(cc)
+			Expect(k8sClient.Create(ctx, cr)).To(Succeed())
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, cr)).To(Succeed())
+				return cr.Status.State
+			}, mediumTimeout, mediumRetry).Should(Equal(icarus.StateFailed))
+			Expect(cr.Status.Errors).To(HaveLen(1))
+			Expect(cr.Status.Errors[0].Message).To(ContainSubstring("point-in-time restores are not supported"))
+			Expect(mockMedusaServer.PreparedRestores()).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("created cassandrasnapshot", func() {
+	ccTpl := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			AdminRoleSecretName: "admin-role",
+			ImagePullSecretName: "pullSecretName",
+			Cassandra: &v1alpha1.Cassandra{
+				Persistence: v1alpha1.Persistence{
+					Enabled: true,
+					DataVolumeClaimSpec: v1.PersistentVolumeClaimSpec{
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{
+								v1.ResourceStorage: resource.MustParse("1000"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	It("should take the snapshot on every node and report the disk usage", func() {
+		mockNodectlClient.snapshotsSize = 600
+		cc := ccTpl.DeepCopy()
+		createReadyCluster(cc)
+
+		cs := &v1alpha1.CassandraSnapshot{
+			ObjectMeta: cassandraSnapshotObjectMeta,
+			Spec: v1alpha1.CassandraSnapshotSpec{
+				CassandraCluster: cc.Name,
+				SnapshotTag:      "before-migration",
+			},
+		}
+		Expect(k8sClient.Create(ctx, cs)).To(Succeed())
+
+		Eventually(func() string {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cs.Namespace, Name: cs.Name}, cs)).To(Succeed())
+			return cs.Status.State
+		}, mediumTimeout, mediumRetry).Should(Equal(v1alpha1.SnapshotStateCompleted))
+
+		Expect(mockNodectlClient.snapshots).To(HaveLen(3))
+		for _, tags := range mockNodectlClient.snapshots {
+			Expect(tags).To(Equal([]string{"before-migration"}))
+		}
+		Expect(cs.Status.SnapshotTime).ToNot(BeNil())
+		Expect(cs.Status.ExpirationTime).To(BeNil())
+		Expect(cs.Finalizers).To(ContainElement("db.ibm.com/clear-snapshot"))
+
+		Eventually(func() *metav1.Condition {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cs.Namespace, Name: cs.Name}, cs)).To(Succeed())
+			return meta.FindStatusCondition(cs.Status.Conditions, v1alpha1.SnapshotConditionDiskPressure)
+		}, mediumTimeout, mediumRetry).ShouldNot(BeNil())
+		Expect(meta.IsStatusConditionTrue(cs.Status.Conditions, v1alpha1.SnapshotConditionDiskPressure)).To(BeTrue())
+		Expect(cs.Status.Nodes).To(HaveLen(3))
+		for _, node := range cs.Status.Nodes {
+			Expect(node.DC).To(Equal("dc1"))
+			Expect(node.SnapshotTime).ToNot(BeNil())
+			Expect(node.SnapshotsSizeBytes).To(BeEquivalentTo(600))
+			Expect(node.DataVolumeUsagePercent).To(Equal(proto.Int32(60)))
+		}
+	})
+
+	It("should clear the snapshot when deleted", func() {
+		cc := ccTpl.DeepCopy()
+		createReadyCluster(cc)
+
+		cs := &v1alpha1.CassandraSnapshot{
+			ObjectMeta: cassandraSnapshotObjectMeta,
+			Spec: v1alpha1.CassandraSnapshotSpec{
+				CassandraCluster: cc.Name,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cs)).To(Succeed())
+
+		Eventually(func() string {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: cs.Namespace, Name: cs.Name}, cs)).To(Succeed())
+			return cs.Status.State
+		}, mediumTimeout, mediumRetry).Should(Equal(v1alpha1.SnapshotStateCompleted))
+
+		Expect(k8sClient.Delete(ctx, cs)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: cs.Namespace, Name: cs.Name}, cs)
+			return kerrors.IsNotFound(err)
+		}, mediumTimeout, mediumRetry).Should(BeTrue())
+
+		Expect(mockNodectlClient.clearedSnapshots).To(HaveLen(3))
+		for _, tags := range mockNodectlClient.clearedSnapshots {
+			Expect(tags).To(Equal([]string{cs.Name}))
+		}
+	})
+})
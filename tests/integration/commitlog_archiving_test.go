@@ -0,0 +1,58 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("cassandra cluster with commitlog archiving", func() {
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			ImagePullSecretName: "pullSecretName",
+			AdminRoleSecretName: "admin-role",
+			Cassandra: &v1alpha1.Cassandra{
+				CommitLogArchiving: &v1alpha1.CommitLogArchiving{
+					StorageLocation: "s3://bucket",
+					SecretName:      "storage-credentials",
+				},
+			},
+		},
+	}
+
+	Context("when commitLogArchiving is set", func() {
+		It("should archive sealed segments and ship them to the storage location", func() {
+			createReadyCluster(cc)
+
+			cassandraCM := &v1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.ConfigMap(cc.Name), Namespace: cc.Namespace}, cassandraCM)).To(Succeed())
+			Expect(cassandraCM.Data["commitlog_archiving.properties"]).To(Equal("archive_command=/bin/ln %path /var/lib/cassandra/commitlog/archive/%name\n"))
+
+			sts := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, "dc1"), Namespace: cc.Namespace}, sts)).To(Succeed())
+
+			shipperContainer, found := getContainerByName(sts.Spec.Template.Spec, "commitlog-shipper")
+			Expect(found).To(BeTrue())
+			Expect(shipperContainer.Image).To(Equal(operatorConfig.DefaultIcarusImage))
+			Expect(shipperContainer.Args[0]).To(ContainSubstring("esop commitlog-backup --storage-location=s3://bucket/" + cc.Name + "/dc1/${POD_NAME}"))
+			Expect(shipperContainer.Args[0]).To(ContainSubstring("--k8s-secret-name=storage-credentials"))
+			Expect(shipperContainer.Args[0]).To(ContainSubstring("sleep 60"))
+
+			cassandraContainer, found := getContainerByName(sts.Spec.Template.Spec, "cassandra")
+			Expect(found).To(BeTrue())
+			Expect(cassandraContainer.Args[2]).To(ContainSubstring("/var/lib/cassandra/commitlog-restore/commitlog_archiving.properties"))
+		})
+	})
+})
@@ -1,6 +1,8 @@
 package integration
 
 import (
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -8,6 +10,7 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/cql"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("system keyspaces settings", func() {
@@ -443,4 +446,64 @@ var _ = Describe("system keyspaces settings", func() {
 			))
 		})
 	})
+
+	Context("with safety snapshots enabled", func() {
+		It("should be snapshotted before updated", func() {
+			cc := &v1alpha1.CassandraCluster{
+				ObjectMeta: cassandraObjectMeta,
+				Spec: v1alpha1.CassandraClusterSpec{
+					DCs: []v1alpha1.DC{
+						{
+							Name:     "dc1",
+							Replicas: proto.Int32(3),
+						},
+					},
+					ImagePullSecretName: "pull-secret-name",
+					AdminRoleSecretName: "admin-role",
+					SystemKeyspaces: v1alpha1.SystemKeyspaces{
+						Keyspaces: []v1alpha1.KeyspaceName{"system_auth"},
+						DCs: []v1alpha1.SystemKeyspaceDC{{
+							Name: "dc1",
+							RF:   3,
+						}},
+					},
+					SafetySnapshots: &v1alpha1.SafetySnapshots{},
+				},
+			}
+
+			createReadyCluster(cc)
+			mockCQLClient.keyspaces = []cql.Keyspace{{
+				Name: "system_auth",
+				Replication: map[string]string{
+					"class": cql.ReplicationClassSimpleTopologyStrategy,
+				},
+			}}
+
+			Eventually(mockCQLClient.GetKeyspacesInfo, mediumTimeout, mediumRetry).Should(Equal([]cql.Keyspace{{
+				Name: "system_auth",
+				Replication: map[string]string{
+					"class": cql.ReplicationClassNetworkTopologyStrategy,
+					"dc1":   "3",
+				},
+			}},
+			))
+
+			snapshots := &v1alpha1.CassandraSnapshotList{}
+			Expect(k8sClient.List(ctx, snapshots, client.InNamespace(cc.Namespace))).To(Succeed())
+			Expect(snapshots.Items).ToNot(BeEmpty())
+			var rfSnapshot *v1alpha1.CassandraSnapshot
+			for i, snapshot := range snapshots.Items {
+				if strings.HasPrefix(snapshot.Name, cc.Name+"-safety-rf-") && reflect.DeepEqual(snapshot.Spec.Keyspaces, []string{"system_auth"}) {
+					rfSnapshot = &snapshots.Items[i]
+				}
+			}
+			Expect(rfSnapshot).ToNot(BeNil())
+			Expect(rfSnapshot.Status.State).To(Equal(v1alpha1.SnapshotStateCompleted))
+			Expect(rfSnapshot.Spec.RetentionSeconds).To(BeEquivalentTo(86400))
+			Expect(mockNodectlClient.snapshots).To(HaveLen(3))
+			for _, tags := range mockNodectlClient.snapshots {
+				Expect(tags).To(ContainElement(rfSnapshot.Name))
+			}
+		})
+	})
 })
@@ -4,9 +4,11 @@ import (
 	"context"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 
 	"github.com/ibm/cassandra-operator/controllers/nodectl"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/gocql/gocql"
 	dbv1alpha1 "github.com/ibm/cassandra-operator/api/v1alpha1"
 	"github.com/ibm/cassandra-operator/controllers/cql"
+	"github.com/ibm/cassandra-operator/controllers/prober"
 	"github.com/ibm/cassandra-operator/controllers/util"
 	"github.com/pkg/errors"
 )
@@ -34,7 +37,9 @@ type proberMock struct {
 
 type cqlMock struct {
 	keyspaces      []cql.Keyspace
+	tables         []cql.Table
 	cassandraRoles []cql.Role
+	emptyTables    []string // keyspace.table
 	err            error
 }
 
@@ -43,16 +48,20 @@ type nodetoolMock struct {
 }
 
 type reaperMock struct {
-	repairSchedules []reaper.RepairSchedule
-	isRunning       bool
-	clusters        []string
-	clusterName     string
-	err             error
+	repairSchedules   []reaper.RepairSchedule
+	repairedKeyspaces []string
+	repairRuns        []reaper.RepairRun
+	deletedRepairRuns []string
+	isRunning         bool
+	clusters          []string
+	clusterName       string
+	err               error
 }
 
 type icarusMock struct {
-	backups  []icarus.Backup
-	restores []icarus.Restore
+	backups           []icarus.Backup
+	restores          []icarus.Restore
+	commitLogRestores []icarus.CommitLogRestore
 	error
 }
 
@@ -136,6 +145,75 @@ func (i *icarusMock) Restores(ctx context.Context) ([]icarus.Restore, error) {
 	return i.restores, i.error
 }
 
+func (i *icarusMock) CommitLogRestore(ctx context.Context, req icarus.CommitLogRestoreRequest) error {
+	restore := icarus.CommitLogRestore{
+		Id:                       "random_id",
+		CreationTime:             time.Now().Format(time.RFC3339),
+		State:                    icarus.StateRunning,
+		Errors:                   nil,
+		Progress:                 0.0,
+		StartTime:                time.Now().Format(time.RFC3339),
+		Type:                     "commitlog-restore",
+		StorageLocation:          req.StorageLocation,
+		CassandraDirectory:       req.CassandraDirectory,
+		CassandraConfigDirectory: req.CassandraConfigDirectory,
+		CommitlogDownloadDir:     req.CommitlogDownloadDir,
+		TimestampEnd:             req.TimestampEnd,
+		K8sNamespace:             req.K8sNamespace,
+		K8sSecretName:            req.K8sSecretName,
+		ConcurrentConnections:    req.ConcurrentConnections,
+		Insecure:                 req.Insecure,
+		SkipBucketVerification:   req.SkipBucketVerification,
+	}
+	i.commitLogRestores = append(i.commitLogRestores, restore)
+	return i.error
+}
+
+func (i *icarusMock) CommitLogRestores(ctx context.Context) ([]icarus.CommitLogRestore, error) {
+	return i.commitLogRestores, i.error
+}
+
+type storageMock struct {
+	bucketExists  bool
+	putErr        error
+	objects       []string
+	storedObjects map[string][]byte
+}
+
+func (s *storageMock) BucketExists(ctx context.Context) (bool, error) {
+	return s.bucketExists, nil
+}
+
+func (s *storageMock) PutObject(ctx context.Context, key string, data []byte) error {
+	if s.putErr != nil {
+		return s.putErr
+	}
+	s.objects = append(s.objects, key)
+	return nil
+}
+
+func (s *storageMock) GetObject(ctx context.Context, key string) ([]byte, error) {
+	data, found := s.storedObjects[key]
+	if !found {
+		return nil, errors.New("object not found")
+	}
+	return data, nil
+}
+
+func (s *storageMock) DeleteObject(ctx context.Context, key string) error {
+	return nil
+}
+
+func (s *storageMock) ListObjects(ctx context.Context, prefix string) ([]storage.Object, error) {
+	var objects []storage.Object
+	for key, data := range s.storedObjects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, storage.Object{Key: key, Size: int64(len(data))})
+		}
+	}
+	return objects, nil
+}
+
 func (r proberMock) Ready(ctx context.Context) (bool, error) {
 	return r.ready, r.err
 }
@@ -196,6 +274,10 @@ func (r proberMock) UpdateReaperIPs(ctx context.Context, ips []string) error {
 	return r.err
 }
 
+func (r proberMock) GetClusterView(ctx context.Context) ([]prober.NodeView, error) {
+	return nil, r.err
+}
+
 func (c *cqlMock) Query(stmt string, values ...interface{}) error {
 	return c.err
 }
@@ -204,6 +286,10 @@ func (c *cqlMock) GetKeyspacesInfo() ([]cql.Keyspace, error) {
 	return c.keyspaces, c.err
 }
 
+func (c *cqlMock) GetTablesInfo() ([]cql.Table, error) {
+	return c.tables, c.err
+}
+
 func (c *cqlMock) GetRoles() ([]cql.Role, error) {
 	return c.cassandraRoles, c.err
 }
@@ -275,6 +361,16 @@ func (c *cqlMock) UpdateRF(keyspaceName string, rfOptions map[string]string) err
 	return c.err
 }
 
+func (c *cqlMock) ReadSample(keyspace, table string, consistency gocql.Consistency) (bool, error) {
+	for _, emptyTable := range c.emptyTables {
+		if emptyTable == keyspace+"."+table {
+			return false, c.err
+		}
+	}
+
+	return true, c.err
+}
+
 func (c *cqlMock) CloseSession() {}
 
 func (n *nodetoolMock) RepairKeyspace(cc *dbv1alpha1.CassandraCluster, keyspace string) error {
@@ -298,6 +394,9 @@ func (r *reaperMock) AddCluster(ctx context.Context, seed string) error {
 	}
 	return r.err
 }
+func (r *reaperMock) AddClusterWithCredentials(ctx context.Context, seed, jmxUsername, jmxPassword string) error {
+	return r.AddCluster(ctx, seed)
+}
 func (r *reaperMock) Clusters(ctx context.Context) ([]string, error) {
 	return r.clusters, r.err
 }
@@ -367,16 +466,89 @@ func (r *reaperMock) SetRepairScheduleState(ctx context.Context, repairScheduleI
 }
 
 func (r *reaperMock) RunRepair(ctx context.Context, keyspace, cause string) error {
+	r.repairedKeyspaces = append(r.repairedKeyspaces, keyspace)
 	return r.err
 }
 
+func (r *reaperMock) CreateRepairRun(ctx context.Context, repair dbv1alpha1.CassandraRepairSpec, cause string) (reaper.RepairRun, error) {
+	if r.err != nil {
+		return reaper.RepairRun{}, r.err
+	}
+
+	repairRun := reaper.RepairRun{
+		ID:                "run-" + strconv.Itoa(len(r.repairRuns)),
+		State:             reaper.RepairStateNotStarted,
+		ClusterName:       r.clusterName,
+		KeyspaceName:      repair.Keyspace,
+		ColumnFamilies:    repair.Tables,
+		Owner:             reaper.OwnerCassandraOperator,
+		Cause:             cause,
+		IncrementalRepair: repair.IncrementalRepair,
+		RepairParallelism: repair.RepairParallelism,
+		TotalSegments:     10,
+		Nodes:             repair.Nodes,
+		Datacenters:       repair.Datacenters,
+	}
+	r.repairRuns = append(r.repairRuns, repairRun)
+	return repairRun, nil
+}
+
+func (r *reaperMock) RepairRuns(ctx context.Context, keyspace string) ([]reaper.RepairRun, error) {
+	var repairRuns []reaper.RepairRun
+	for _, repairRun := range r.repairRuns {
+		if len(keyspace) == 0 || repairRun.KeyspaceName == keyspace {
+			repairRuns = append(repairRuns, repairRun)
+		}
+	}
+	return repairRuns, r.err
+}
+
+func (r *reaperMock) SetRepairRunState(ctx context.Context, runID, state string) error {
+	for i, repairRun := range r.repairRuns {
+		if repairRun.ID == runID {
+			r.repairRuns[i].State = state
+			if state == reaper.RepairStateRunning && len(repairRun.StartTime) == 0 {
+				r.repairRuns[i].StartTime = time.Now().UTC().Format(time.RFC3339)
+			}
+			return r.err
+		}
+	}
+
+	return errors.New("unable to update repair run state: not found")
+}
+
+func (r *reaperMock) DeleteRepairRun(ctx context.Context, repairRun reaper.RepairRun) error {
+	for i, run := range r.repairRuns {
+		if run.ID == repairRun.ID {
+			r.repairRuns = append(r.repairRuns[:i], r.repairRuns[i+1:]...)
+			r.deletedRepairRuns = append(r.deletedRepairRuns, run.ID)
+			return r.err
+		}
+	}
+
+	return errors.New("unable to delete repair run: not found")
+}
+
 type mockNode struct {
 	clusterView nodectl.ClusterView
 	opMode      nodectl.OperationMode
 }
 
 type nodectlMock struct {
-	nodesState map[string]mockNode
+	nodesState       map[string]mockNode
+	snapshots        map[string][]string // node IP -> snapshot tags
+	clearedSnapshots map[string][]string // node IP -> snapshot tags
+	snapshotsSize    int64
+	snapshotErr      error
+	tableStats       map[string]nodectl.TableStats // keyspace.table -> stats
+	tokenRing        map[string]string             // token -> node IP
+	repairs          []mockRepair
+}
+
+type mockRepair struct {
+	nodeIP   string
+	keyspace string
+	options  map[string]string
 }
 
 func (n *nodectlMock) Decommission(ctx context.Context, nodeIP string) error {
@@ -388,7 +560,7 @@ func (n *nodectlMock) Assassinate(ctx context.Context, execNodeIP string, assass
 }
 
 func (n *nodectlMock) Version(ctx context.Context, nodeIP string) (major, minor, patch int, err error) {
-	return 3, 11, 11, nil
+	return 4, 0, 5, nil
 }
 
 func (n *nodectlMock) ClusterView(ctx context.Context, nodeIP string) (nodectl.ClusterView, error) {
@@ -399,6 +571,46 @@ func (n *nodectlMock) OperationMode(ctx context.Context, nodeIP string) (nodectl
 	return n.nodesState[nodeIP].opMode, nil
 }
 
+func (n *nodectlMock) TakeSnapshot(ctx context.Context, nodeIP, tag string, keyspaces []string) error {
+	if n.snapshotErr != nil {
+		return n.snapshotErr
+	}
+	if n.snapshots == nil {
+		n.snapshots = make(map[string][]string)
+	}
+	n.snapshots[nodeIP] = append(n.snapshots[nodeIP], tag)
+	return nil
+}
+
+func (n *nodectlMock) ClearSnapshot(ctx context.Context, nodeIP, tag string, keyspaces []string) error {
+	if n.clearedSnapshots == nil {
+		n.clearedSnapshots = make(map[string][]string)
+	}
+	n.clearedSnapshots[nodeIP] = append(n.clearedSnapshots[nodeIP], tag)
+	return nil
+}
+
+func (n *nodectlMock) SnapshotsSize(ctx context.Context, nodeIP string) (int64, error) {
+	return n.snapshotsSize, nil
+}
+
+func (n *nodectlMock) TableStats(ctx context.Context, nodeIP, keyspace, table string) (nodectl.TableStats, error) {
+	return n.tableStats[keyspace+"."+table], nil
+}
+
+func (n *nodectlMock) TokenRing(ctx context.Context, nodeIP string) (map[string]string, error) {
+	return n.tokenRing, nil
+}
+
+func (n *nodectlMock) RepairAsync(ctx context.Context, nodeIP, keyspace string, options map[string]string) (int, error) {
+	n.repairs = append(n.repairs, mockRepair{nodeIP: nodeIP, keyspace: keyspace, options: options})
+	return len(n.repairs), nil
+}
+
+func (n *nodectlMock) RepairStatus(ctx context.Context, nodeIP string, command int) (string, []string, error) {
+	return nodectl.RepairStatusCompleted, nil, nil
+}
+
 func markMocksAsReady(cc *dbv1alpha1.CassandraCluster) {
 	for i, externalRegion := range cc.Spec.ExternalRegions.Managed {
 		mockProberClient.readyClusters[externalRegion.Domain] = true
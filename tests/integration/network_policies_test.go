@@ -508,6 +508,24 @@ var _ = Describe("network policies", func() {
 							},
 						},
 					},
+					{
+						Ports: []nwv1.NetworkPolicyPort{
+							{
+								Port:     &intstr.IntOrString{IntVal: dbv1alpha1.ProberContainerPort},
+								Protocol: &protocolTCP,
+							},
+						},
+						From: []nwv1.NetworkPolicyPeer{
+							{
+								PodSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{dbv1alpha1.CassandraClusterComponent: dbv1alpha1.CassandraClusterComponentProber},
+								},
+								NamespaceSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{v1.LabelMetadataName: cc.Namespace},
+								},
+							},
+						},
+					},
 					{
 						Ports: []nwv1.NetworkPolicyPort{
 							{
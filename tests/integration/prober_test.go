@@ -52,6 +52,10 @@ var _ = Describe("prober deployment", func() {
 			Expect(proberContainer.Image).To(Equal(operatorConfig.DefaultProberImage), "default values")
 			Expect(proberContainer.Env).To(Equal([]v1.EnvVar{
 				{Name: "POD_NAMESPACE", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.namespace"}}},
+				{Name: "POD_NAME", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"}}},
+				{Name: "POD_IP", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "status.podIP"}}},
+				{Name: "STATE_CONFIGMAP_NAME", Value: names.ProberStateConfigMap(cc.Name)},
+				{Name: "LEADER_ELECTION_LEASE_NAME", Value: names.ProberLease(cc.Name)},
 				{Name: "LOGLEVEL", Value: "info"},
 				{Name: "LOGFORMAT", Value: "json"},
 				{Name: "JOLOKIA_PORT", Value: "8080"},
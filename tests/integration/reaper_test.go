@@ -1,12 +1,16 @@
 package integration
 
 import (
+	"time"
+
 	"github.com/gogo/protobuf/proto"
 	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/cql"
 	"github.com/ibm/cassandra-operator/controllers/names"
 	"github.com/ibm/cassandra-operator/controllers/reaper"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -203,6 +207,14 @@ var _ = Describe("repair schedules in reaper", func() {
 
 			// add an externally created repair schedule to ensure that the operator doesn't delete/update not owned schedules
 			mockReaperClient.repairSchedules = append(mockReaperClient.repairSchedules, externalRepairSchedule)
+			mockReaperClient.repairRuns = append(mockReaperClient.repairRuns, reaper.RepairRun{
+				ID:               "run-1",
+				State:            reaper.RepairStateRunning,
+				Cause:            reaper.ScheduledRunCause("id-2"),
+				Duration:         "10 minutes",
+				TotalSegments:    4,
+				SegmentsRepaired: 1,
+			})
 
 			Expect(k8sClient.Update(ctx, cc)).To(Succeed())
 			By("CR updated with repair schedules should create them in reaper")
@@ -243,6 +255,30 @@ var _ = Describe("repair schedules in reaper", func() {
 					RepairThreadCount:   4,
 				}}))
 
+			By("The state of the repair schedules should be shown in the status")
+			Eventually(func() []v1alpha1.RepairScheduleStatus {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+				return cc.Status.RepairSchedules
+			}, mediumTimeout, mediumRetry).Should(Equal([]v1alpha1.RepairScheduleStatus{
+				{
+					Keyspace: "system_traces",
+					Tables:   []string{"events"},
+					ID:       "id-1",
+					State:    "ACTIVE",
+				},
+				{
+					Keyspace: "system_auth",
+					ID:       "id-2",
+					State:    "ACTIVE",
+					LastRun: &v1alpha1.ScheduledRepairRun{
+						ID:              "run-1",
+						State:           reaper.RepairStateRunning,
+						Duration:        "10 minutes",
+						RepairedPercent: 25,
+					},
+				},
+			}))
+
 			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc))
 			cc.Spec.Reaper.RepairSchedules.Repairs = []v1alpha1.RepairSchedule{
 				{
@@ -292,6 +328,198 @@ var _ = Describe("repair schedules in reaper", func() {
 				Expect(err).ToNot(HaveOccurred())
 				return repairSchedules
 			}).Should(Equal([]reaper.RepairSchedule{externalRepairSchedule}))
+			Eventually(func() []v1alpha1.RepairScheduleStatus {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+				return cc.Status.RepairSchedules
+			}, mediumTimeout, mediumRetry).Should(BeEmpty())
+		})
+	})
+
+	Context("when a disruptive operation is in progress", func() {
+		It("should pause the repairs and resume them once it's finished", func() {
+			cc := &v1alpha1.CassandraCluster{
+				ObjectMeta: cassandraObjectMeta,
+				Spec: v1alpha1.CassandraClusterSpec{
+					DCs: []v1alpha1.DC{
+						{
+							Name:     "dc1",
+							Replicas: proto.Int32(3),
+						},
+					},
+					ImagePullSecretName: "pull-secret-name",
+					AdminRoleSecretName: "admin-role",
+					Reaper: &v1alpha1.Reaper{
+						RepairSchedules: v1alpha1.RepairSchedules{
+							Enabled: true,
+							Repairs: []v1alpha1.RepairSchedule{
+								{
+									Keyspace:            "system_auth",
+									ScheduleDaysBetween: 7,
+									RepairParallelism:   "PARALLEL",
+								},
+							},
+						},
+					},
+				},
+			}
+
+			createReadyCluster(cc)
+
+			Eventually(func() []reaper.RepairSchedule {
+				repairSchedules, err := mockReaperClient.RepairSchedules(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				return repairSchedules
+			}, mediumTimeout, mediumRetry).Should(ConsistOf(HaveField("State", reaper.RepairScheduleStateActive)))
+
+			mockReaperClient.repairRuns = append(mockReaperClient.repairRuns,
+				reaper.RepairRun{ID: "run-1", KeyspaceName: "system_auth", State: reaper.RepairStateRunning},
+				reaper.RepairRun{ID: "run-2", KeyspaceName: "system_auth", State: reaper.RepairStatePaused}, // paused by the user
+			)
+
+			By("Repairs should be paused during a rolling restart")
+			Eventually(func() error {
+				sts := &apps.StatefulSet{}
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, "dc1"), Namespace: cc.Namespace}, sts)
+				if err != nil {
+					return err
+				}
+				sts.Status.ReadyReplicas = *sts.Spec.Replicas - 1
+				return k8sClient.Status().Update(ctx, sts)
+			}, mediumTimeout, mediumRetry).Should(Succeed())
+
+			Eventually(func() *v1alpha1.RepairsPause {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+				return cc.Status.RepairsPause
+			}, mediumTimeout, mediumRetry).Should(And(
+				HaveField("Reason", "rolling restart"),
+				HaveField("RepairRunIDs", []string{"run-1"}),
+			))
+			Expect(mockReaperClient.repairSchedules).To(ConsistOf(HaveField("State", reaper.RepairScheduleStatePaused)))
+			Expect(mockReaperClient.repairRuns).To(ConsistOf(
+				HaveField("State", reaper.RepairStatePaused),
+				HaveField("State", reaper.RepairStatePaused),
+			))
+
+			By("Repairs paused by the operator should be resumed")
+			markAllDCsReady(cc)
+
+			Eventually(func() *v1alpha1.RepairsPause {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+				return cc.Status.RepairsPause
+			}, mediumTimeout, mediumRetry).Should(BeNil())
+			Expect(mockReaperClient.repairSchedules).To(ConsistOf(HaveField("State", reaper.RepairScheduleStateActive)))
+			Expect(mockReaperClient.repairRuns[0].State).To(Equal(reaper.RepairStateRunning))
+			Expect(mockReaperClient.repairRuns[1].State).To(Equal(reaper.RepairStatePaused))
+		})
+	})
+
+	Context("when tables are repaired", func() {
+		It("should report the repair coverage of the tables", func() {
+			cc := &v1alpha1.CassandraCluster{
+				ObjectMeta: cassandraObjectMeta,
+				Spec: v1alpha1.CassandraClusterSpec{
+					DCs: []v1alpha1.DC{
+						{
+							Name:     "dc1",
+							Replicas: proto.Int32(3),
+						},
+					},
+					ImagePullSecretName: "pull-secret-name",
+					AdminRoleSecretName: "admin-role",
+				},
+			}
+
+			mockCQLClient.keyspaces = []cql.Keyspace{{
+				Name: "system_auth",
+				Replication: map[string]string{
+					"class": cql.ReplicationClassNetworkTopologyStrategy,
+					"dc1":   "3",
+				},
+			}}
+			mockCQLClient.tables = []cql.Table{
+				{Keyspace: "system_auth", Name: "roles", GCGraceSeconds: 864000},
+				{Keyspace: "system_auth", Name: "role_members", GCGraceSeconds: 864000},
+			}
+			lastRepair := time.Now().Add(-9*24*time.Hour - 12*time.Hour).UTC().Truncate(time.Second)
+			mockReaperClient.repairRuns = append(mockReaperClient.repairRuns, reaper.RepairRun{
+				ID:             "run-1",
+				State:          reaper.RepairStateDone,
+				KeyspaceName:   "system_auth",
+				ColumnFamilies: []string{"roles"},
+				EndTime:        lastRepair.Format(time.RFC3339),
+			})
+
+			createReadyCluster(cc)
+
+			Eventually(func() []v1alpha1.KeyspaceRepairCoverage {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+				return cc.Status.RepairCoverage
+			}, mediumTimeout, mediumRetry).Should(ConsistOf(
+				And(
+					HaveField("Keyspace", "system_auth"),
+					HaveField("Tables", int32(2)),
+					HaveField("State", v1alpha1.RepairCoverageStateWarning),
+					HaveField("Warning", int32(1)),
+					HaveField("NeverRepaired", int32(1)),
+					HaveField("OldestCompleteRepair.Time", BeTemporally("==", lastRepair)),
+				),
+			))
+		})
+	})
+
+	Context("when the builtin repair engine is used", func() {
+		It("should repair the token ranges through JMX without deploying Reaper", func() {
+			cc := &v1alpha1.CassandraCluster{
+				ObjectMeta: cassandraObjectMeta,
+				Spec: v1alpha1.CassandraClusterSpec{
+					DCs: []v1alpha1.DC{
+						{
+							Name:     "dc1",
+							Replicas: proto.Int32(3),
+						},
+					},
+					ImagePullSecretName: "pull-secret-name",
+					AdminRoleSecretName: "admin-role",
+					RepairEngine:        v1alpha1.RepairEngineBuiltin,
+					BuiltinRepair: v1alpha1.BuiltinRepair{
+						MaxConcurrentSegments: 3,
+						SegmentCountPerNode:   1,
+					},
+				},
+			}
+
+			mockNodectlClient.tokenRing = map[string]string{
+				"-100": "172.0.0.0",
+				"0":    "172.0.0.1",
+				"100":  "172.0.0.2",
+			}
+
+			createAdminSecret(cc)
+			Expect(k8sClient.Create(ctx, cc)).To(Succeed())
+			markMocksAsReady(cc)
+			waitForDCsToBeCreated(cc)
+			markAllDCsReady(cc)
+			createNodes(nodeIPs)
+			createCassandraPods(cc)
+
+			By("system_auth keyspace should be repaired after the cluster is bootstrapped")
+			Eventually(func() []string {
+				var nodes []string
+				for _, repair := range mockNodectlClient.repairs {
+					if repair.keyspace == "system_auth" {
+						nodes = append(nodes, repair.nodeIP)
+					}
+				}
+				return nodes
+			}, longTimeout, mediumRetry).Should(ConsistOf("172.0.0.0", "172.0.0.1", "172.0.0.2"))
+
+			By("the progress should be persisted")
+			stateCM := &v1.ConfigMap{}
+			waitForResourceToBeCreated(types.NamespacedName{Name: names.BuiltinRepairConfigMap(cc.Name), Namespace: cc.Namespace}, stateCM)
+			Expect(stateCM.Data).To(HaveKeyWithValue("state.json", ContainSubstring(`"keyspace":"system_auth"`)))
+
+			By("Reaper should not be deployed")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.ReaperDeployment(cc.Name, "dc1"), Namespace: cc.Namespace}, &apps.Deployment{})).ToNot(Succeed())
 		})
 	})
 })
@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/ibm/cassandra-operator/api/v1alpha1"
+	"github.com/ibm/cassandra-operator/controllers/names"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("cassandra cluster restored from backup", func() {
+	cc := &v1alpha1.CassandraCluster{
+		ObjectMeta: cassandraObjectMeta,
+		Spec: v1alpha1.CassandraClusterSpec{
+			DCs: []v1alpha1.DC{
+				{
+					Name:     "dc1",
+					Replicas: proto.Int32(3),
+				},
+			},
+			ImagePullSecretName: "pullSecretName",
+			AdminRoleSecretName: "admin-role",
+			RestoreFrom: &v1alpha1.RestoreFrom{
+				StorageLocation: "s3://bucket",
+				SourceCluster:   "source-cluster",
+				SnapshotTag:     "snapshot",
+				SecretName:      "storage-credentials",
+			},
+		},
+	}
+
+	Context("when restoreFrom is set", func() {
+		It("should restore nodes before Cassandra starts and mark the restore completed once the cluster is ready", func() {
+			createReadyCluster(cc)
+
+			sts := &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.DC(cc.Name, "dc1"), Namespace: cc.Namespace}, sts)).To(Succeed())
+			var initContainerNames []string
+			for _, container := range sts.Spec.Template.Spec.InitContainers {
+				initContainerNames = append(initContainerNames, container.Name)
+			}
+			Expect(initContainerNames).To(Equal([]string{"privileged-init", "maintenance-mode", "restore-from-backup", "init"}))
+
+			restoreContainer, found := getInitContainerByName(sts.Spec.Template.Spec, "restore-from-backup")
+			Expect(found).To(BeTrue())
+			Expect(restoreContainer.Image).To(Equal(operatorConfig.DefaultIcarusImage))
+			Expect(restoreContainer.Args[0]).To(ContainSubstring("--snapshot-tag=snapshot"))
+			Expect(restoreContainer.Args[0]).To(ContainSubstring("--k8s-secret-name=storage-credentials"))
+
+			cassandraContainer, found := getContainerByName(sts.Spec.Template.Spec, "cassandra")
+			Expect(found).To(BeTrue())
+			Expect(cassandraContainer.Args[2]).To(ContainSubstring("/var/lib/cassandra/restore/cassandra.yaml"))
+
+			podsConfigCM := &v1.ConfigMap{}
+			Eventually(func() map[string]string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.PodsConfigConfigmap(cc.Name), Namespace: cc.Namespace}, podsConfigCM)).To(Succeed())
+				return podsConfigCM.Data
+			}, mediumTimeout, mediumRetry).ShouldNot(BeEmpty())
+			for entryName, value := range podsConfigCM.Data {
+				Expect(value).To(ContainSubstring("RESTORE_STORAGE_LOCATION=s3://bucket/source-cluster/dc1/source-cluster-cassandra-dc1-"), entryName)
+			}
+
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace}, cc)).To(Succeed())
+				return cc.Status.RestoreFromState
+			}, mediumTimeout, mediumRetry).Should(Equal(v1alpha1.RestoreFromStateCompleted))
+
+			Eventually(func() map[string]string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: names.PodsConfigConfigmap(cc.Name), Namespace: cc.Namespace}, podsConfigCM)).To(Succeed())
+				return podsConfigCM.Data
+			}, mediumTimeout, mediumRetry).Should(HaveEach(ContainSubstring("RESTORE_FROM_BACKUP=false")), "nodes added after the restore should bootstrap normally")
+		})
+	})
+})
@@ -30,9 +30,17 @@ import (
 	"time"
 
 	"github.com/ibm/cassandra-operator/controllers/icarus"
+	"github.com/ibm/cassandra-operator/controllers/medusa"
+	medusafake "github.com/ibm/cassandra-operator/controllers/medusa/fake"
 
+	"github.com/ibm/cassandra-operator/controllers/cassandrabackupcatalog"
+	"github.com/ibm/cassandra-operator/controllers/cassandrareaper"
+	"github.com/ibm/cassandra-operator/controllers/cassandrarepair"
 	"github.com/ibm/cassandra-operator/controllers/cassandrarestore"
+	"github.com/ibm/cassandra-operator/controllers/cassandrasnapshot"
 
+	"github.com/ibm/cassandra-operator/controllers/backupengine"
+	"github.com/ibm/cassandra-operator/controllers/builtinrepair"
 	"github.com/ibm/cassandra-operator/controllers/cassandrabackup"
 
 	"github.com/ibm/cassandra-operator/controllers/nodectl"
@@ -55,6 +63,7 @@ import (
 	"github.com/ibm/cassandra-operator/controllers/names"
 	"github.com/ibm/cassandra-operator/controllers/prober"
 	"github.com/ibm/cassandra-operator/controllers/reaper"
+	"github.com/ibm/cassandra-operator/controllers/storage"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -91,6 +100,8 @@ var mockNodetoolClient = &nodetoolMock{}
 var mockCQLClient = &cqlMock{}
 var mockReaperClient = &reaperMock{}
 var mockIcarusClient = &icarusMock{}
+var mockMedusaServer *medusafake.Server
+var mockStorageClient = &storageMock{bucketExists: true}
 var operatorConfig = config.Config{}
 var ctx = context.Background()
 var logr = zap.NewNop()
@@ -120,6 +131,25 @@ var (
 		Name:      "test-cassandra-restore",
 	}
 
+	cassandraBackupCatalogObjectMeta = metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "test-cassandra-backup-catalog",
+	}
+
+	cassandraSnapshotObjectMeta = metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "test-cassandra-snapshot",
+	}
+
+	cassandraRepairObjectMeta = metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "test-cassandra-repair",
+	}
+	cassandraReaperObjectMeta = metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "test-cassandra-reaper",
+	}
+
 	reaperDeploymentLabels = map[string]string{
 		v1alpha1.CassandraClusterComponent: v1alpha1.CassandraClusterComponentReaper,
 		v1alpha1.CassandraClusterInstance:  cassandraObjectMeta.Name,
@@ -188,16 +218,21 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred())
 	Expect(cfg).ToNot(BeNil())
 
+	mockMedusaServer = medusafake.NewServer()
+
 	err = v1alpha1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 	operatorConfig = config.Config{
-		Namespace:             "default",
-		RetryDelay:            time.Second * 1,
-		DefaultCassandraImage: "cassandra/image",
-		DefaultProberImage:    "prober/image",
-		DefaultJolokiaImage:   "jolokia/image",
-		DefaultReaperImage:    "reaper/image",
-		DefaultIcarusImage:    "icarus/image",
+		Namespace:                 "default",
+		RetryDelay:                time.Second * 1,
+		OperationsPollInterval:    time.Millisecond * 500,
+		OperationsMaxPollInterval: time.Second * 2,
+		DefaultCassandraImage:     "cassandra/image",
+		DefaultProberImage:        "prober/image",
+		DefaultJolokiaImage:       "jolokia/image",
+		DefaultReaperImage:        "reaper/image",
+		DefaultIcarusImage:        "icarus/image",
+		DefaultMedusaImage:        "medusa/image",
 	}
 	sch := scheme.Scheme
 	k8sClient, err = client.New(cfg, client.Options{Scheme: sch})
@@ -229,6 +264,18 @@ var _ = BeforeSuite(func() {
 	err = (&v1alpha1.CassandraRestore{}).SetupWebhookWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 	Expect(mgr).ToNot(BeNil())
+	v1alpha1.SetWebhookClient(k8sClient)
+	v1alpha1.SetWebhookSchemaReader(func(ctx context.Context, cc *v1alpha1.CassandraCluster) (map[string][]string, error) {
+		tables := make(map[string][]string)
+		for _, table := range mockCQLClient.tables {
+			tables[table.Keyspace] = append(tables[table.Keyspace], table.Name)
+		}
+		return tables, mockCQLClient.err
+	})
+
+	reconcileChan := make(chan event.GenericEvent)
+	builtinRepairWatcher := builtinrepair.NewSegmentWatcher(reconcileChan, builtinrepair.SegmentCheckInterval, logr.Sugar())
+	Expect(mgr.Add(builtinRepairWatcher)).To(Succeed())
 
 	cassandraCtrl := &controllers.CassandraClusterReconciler{
 		Log:    logr.Sugar(),
@@ -263,8 +310,15 @@ var _ = BeforeSuite(func() {
 		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
 			return mockNodectlClient
 		},
+		BuiltinRepairs: builtinRepairWatcher,
 	}
 
+	backupReconcileChan := make(chan event.GenericEvent)
+	restoreReconcileChan := make(chan event.GenericEvent)
+	operationsPoller := backupengine.NewPoller(backupReconcileChan, restoreReconcileChan,
+		operatorConfig.OperationsPollInterval, operatorConfig.OperationsMaxPollInterval, logr.Sugar())
+	Expect(mgr.Add(operationsPoller)).To(Succeed())
+
 	cassandraBackupCtrl := &cassandrabackup.CassandraBackupReconciler{
 		Log:    logr.Sugar(),
 		Scheme: sch,
@@ -274,6 +328,13 @@ var _ = BeforeSuite(func() {
 		IcarusClient: func(coordinatorPodURL string) icarus.Icarus {
 			return mockIcarusClient
 		},
+		MedusaClient: func(addr string) medusa.Medusa {
+			return medusa.New(mockMedusaServer.Addr())
+		},
+		StorageClient: func(location storage.Location, secret *v1.Secret, insecure bool) (storage.StorageClient, error) {
+			return mockStorageClient, nil
+		},
+		Poller: operationsPoller,
 	}
 
 	cassandraRestoreCtrl := &cassandrarestore.CassandraRestoreReconciler{
@@ -285,14 +346,85 @@ var _ = BeforeSuite(func() {
 		IcarusClient: func(coordinatorPodURL string) icarus.Icarus {
 			return mockIcarusClient
 		},
+		MedusaClient: func(addr string) medusa.Medusa {
+			return medusa.New(mockMedusaServer.Addr())
+		},
+		StorageClient: func(location storage.Location, secret *v1.Secret, insecure bool) (storage.StorageClient, error) {
+			return mockStorageClient, nil
+		},
+		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
+			return mockNodectlClient
+		},
+		CqlClient: func(clusterConfig *gocql.ClusterConfig) (cql.CqlClient, error) {
+			return mockCQLClient, nil
+		},
+		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
+			return mockReaperClient
+		},
+		Poller: operationsPoller,
+	}
+
+	cassandraBackupCatalogCtrl := &cassandrabackupcatalog.CassandraBackupCatalogReconciler{
+		Log:    logr.Sugar(),
+		Scheme: sch,
+		Client: k8sClient,
+		Cfg:    operatorConfig,
+		Events: events.NewEventRecorder(&record.FakeRecorder{}),
+		StorageClient: func(location storage.Location, secret *v1.Secret, insecure bool) (storage.StorageClient, error) {
+			return mockStorageClient, nil
+		},
+	}
+
+	cassandraSnapshotCtrl := &cassandrasnapshot.CassandraSnapshotReconciler{
+		Log:    logr.Sugar(),
+		Scheme: sch,
+		Client: k8sClient,
+		Cfg:    operatorConfig,
+		Events: events.NewEventRecorder(&record.FakeRecorder{}),
+		NodectlClient: func(jolokiaAddr, jmxUser, jmxPassword string, logr *zap.SugaredLogger) nodectl.Nodectl {
+			return mockNodectlClient
+		},
+	}
+
+	cassandraRepairCtrl := &cassandrarepair.CassandraRepairReconciler{
+		Log:    logr.Sugar(),
+		Scheme: sch,
+		Client: k8sClient,
+		Cfg:    operatorConfig,
+		Events: events.NewEventRecorder(&record.FakeRecorder{}),
+		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
+			return mockReaperClient
+		},
+	}
+
+	cassandraReaperCtrl := &cassandrareaper.CassandraReaperReconciler{
+		Log:    logr.Sugar(),
+		Scheme: sch,
+		Client: k8sClient,
+		Cfg:    operatorConfig,
+		Events: events.NewEventRecorder(&record.FakeRecorder{}),
+		ReaperClient: func(url *url.URL, clusterName string, defaultRepairThreadCount int32) reaper.ReaperClient {
+			return mockReaperClient
+		},
+		CqlClient: func(clusterConfig *gocql.ClusterConfig) (cql.CqlClient, error) {
+			return mockCQLClient, nil
+		},
 	}
 
 	testReconciler := SetupTestReconcile(cassandraCtrl)
-	Expect(controllers.SetupCassandraReconciler(testReconciler, mgr, zap.NewNop().Sugar(), make(chan event.GenericEvent))).To(Succeed())
+	Expect(controllers.SetupCassandraReconciler(testReconciler, mgr, zap.NewNop().Sugar(), reconcileChan)).To(Succeed())
 	testBackupReconciler := SetupTestReconcile(cassandraBackupCtrl)
-	Expect(cassandrabackup.SetupCassandraBackupReconciler(testBackupReconciler, mgr)).To(Succeed())
+	Expect(cassandrabackup.SetupCassandraBackupReconciler(testBackupReconciler, mgr, backupReconcileChan)).To(Succeed())
 	testRestoreReconciler := SetupTestReconcile(cassandraRestoreCtrl)
-	Expect(cassandrarestore.SetupCassandraRestoreReconciler(testRestoreReconciler, mgr)).To(Succeed())
+	Expect(cassandrarestore.SetupCassandraRestoreReconciler(testRestoreReconciler, mgr, restoreReconcileChan)).To(Succeed())
+	testBackupCatalogReconciler := SetupTestReconcile(cassandraBackupCatalogCtrl)
+	Expect(cassandrabackupcatalog.SetupCassandraBackupCatalogReconciler(testBackupCatalogReconciler, mgr)).To(Succeed())
+	testSnapshotReconciler := SetupTestReconcile(cassandraSnapshotCtrl)
+	Expect(cassandrasnapshot.SetupCassandraSnapshotReconciler(testSnapshotReconciler, mgr)).To(Succeed())
+	testRepairReconciler := SetupTestReconcile(cassandraRepairCtrl)
+	Expect(cassandrarepair.SetupCassandraRepairReconciler(testRepairReconciler, mgr)).To(Succeed())
+	testReaperReconciler := SetupTestReconcile(cassandraReaperCtrl)
+	Expect(cassandrareaper.SetupCassandraReaperReconciler(testReaperReconciler, mgr)).To(Succeed())
 
 	mgrStopCh = StartTestManager(mgr)
 })
@@ -302,6 +434,7 @@ var _ = AfterSuite(func() {
 	shutdown = true
 	close(mgrStopCh) //tell the manager to shutdown
 	waitGroup.Wait() //wait for all reconcile loops to be finished
+	mockMedusaServer.Close()
 	//only log the error until https://github.com/kubernetes-sigs/controller-runtime/issues/1571 is resolved
 	err := testEnv.Stop() //stop the test control plane (etcd, kube-apiserver)
 	if err != nil {
@@ -332,12 +465,51 @@ var _ = AfterEach(func() {
 		}
 		Expect(k8sClient.Delete(ctx, restore)).To(Succeed())
 	}
+
+	catalog := &v1alpha1.CassandraBackupCatalog{}
+	err = k8sClient.Get(ctx, types.NamespacedName{Name: cassandraBackupCatalogObjectMeta.Name, Namespace: cassandraBackupCatalogObjectMeta.Namespace}, catalog)
+	if err == nil {
+		Expect(deleteResource(types.NamespacedName{Name: catalog.Spec.SecretName, Namespace: catalog.Namespace}, &v1.Secret{})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, catalog)).To(Succeed())
+	}
+
+	// includes the safety snapshots created by the operator
+	snapshots := &v1alpha1.CassandraSnapshotList{}
+	Expect(k8sClient.List(ctx, snapshots, client.InNamespace(cassandraObjectMeta.Namespace))).To(Succeed())
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		snapshot.Finalizers = nil // the reconciler is stopped and won't clear the snapshot
+		Expect(k8sClient.Update(ctx, snapshot)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, snapshot)).To(Succeed())
+	}
+
+	repair := &v1alpha1.CassandraRepair{}
+	err = k8sClient.Get(ctx, types.NamespacedName{Name: cassandraRepairObjectMeta.Name, Namespace: cassandraRepairObjectMeta.Namespace}, repair)
+	if err == nil {
+		repair.Finalizers = nil // the reconciler is stopped and won't abort the repair run
+		Expect(k8sClient.Update(ctx, repair)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, repair)).To(Succeed())
+	}
+	cassandraReaper := &v1alpha1.CassandraReaper{}
+	err = k8sClient.Get(ctx, types.NamespacedName{Name: cassandraReaperObjectMeta.Name, Namespace: cassandraReaperObjectMeta.Namespace}, cassandraReaper)
+	if err == nil {
+		Expect(k8sClient.Delete(ctx, cassandraReaper)).To(Succeed())
+		// owned resources are not garbage collected by envtest
+		Expect(deleteResource(types.NamespacedName{Name: names.SharedReaperDeployment(cassandraReaper.Name), Namespace: cassandraReaper.Namespace}, &apps.Deployment{})).To(Succeed())
+		Expect(deleteResource(types.NamespacedName{Name: names.SharedReaperService(cassandraReaper.Name), Namespace: cassandraReaper.Namespace}, &v1.Service{})).To(Succeed())
+		Expect(deleteResource(types.NamespacedName{Name: names.SharedReaperShiroConfigMap(cassandraReaper.Name), Namespace: cassandraReaper.Namespace}, &v1.ConfigMap{})).To(Succeed())
+		Expect(deleteResource(types.NamespacedName{Name: names.SharedReaperJMXCredentialsSecret(cassandraReaper.Name), Namespace: cassandraReaper.Namespace}, &v1.Secret{})).To(Succeed())
+	}
+
 	mockProberClient = &proberMock{}
 	mockNodectlClient = &nodectlMock{}
 	mockNodetoolClient = &nodetoolMock{}
 	mockCQLClient = &cqlMock{}
 	mockReaperClient = &reaperMock{}
 	mockIcarusClient = &icarusMock{}
+	mockMedusaServer.Close()
+	mockMedusaServer = medusafake.NewServer()
+	mockStorageClient = &storageMock{bucketExists: true}
 	testFinished = false
 })
 
@@ -633,11 +805,12 @@ func createCassandraPods(cc *v1alpha1.CassandraCluster) {
 				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, actualPod)).To(Succeed())
 				actualPod.Status.PodIP = fmt.Sprintf("172.0.%d.%d", dcID, replicaID)
 				actualPod.Status.HostIP = fmt.Sprintf(nodeIPs[0])
-				actualPod.Status.ContainerStatuses = []v1.ContainerStatus{
-					{
-						Name:  "cassandra",
+				actualPod.Status.ContainerStatuses = nil
+				for _, container := range sts.Spec.Template.Spec.Containers {
+					actualPod.Status.ContainerStatuses = append(actualPod.Status.ContainerStatuses, v1.ContainerStatus{
+						Name:  container.Name,
 						Ready: true,
-					},
+					})
 				}
 				return k8sClient.Status().Update(ctx, actualPod)
 			}, mediumTimeout, mediumRetry).Should(Succeed())
@@ -455,6 +455,46 @@ var _ = Describe("cassandracluster validation", func() {
 			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("[reaper repair schedule `20200327T04:00:00` has invalid format, should be `2000-01-31T00:00:00`, reaper repair schedule `2020-03-27T04:00` has invalid format, should be `2000-01-31T00:00:00`]"))
 		})
 	})
+	Context("with the builtin repair engine and external regions", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.RepairEngine = v1alpha1.RepairEngineBuiltin
+			cc.Spec.ExternalRegions = v1alpha1.ExternalRegions{
+				Unmanaged: []v1alpha1.UnmanagedRegion{
+					{
+						Seeds: []string{"10.10.10.10"},
+						DCs:   []v1alpha1.SystemKeyspaceDC{{Name: "ext-dc", RF: 3}},
+					},
+				},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("[the builtin repair engine can't be used with external regions]"))
+		})
+	})
+	Context("with the builtin repair engine and a Cassandra 3.x image", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.RepairEngine = v1alpha1.RepairEngineBuiltin
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{Image: "registry.example.com/cassandra:3.11.13-0.1.0"}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("[the builtin repair engine requires Cassandra 4.0 or newer, image registry.example.com/cassandra:3.11.13-0.1.0 runs Cassandra 3]"))
+		})
+	})
+	Context("with a shared reaper and hostPort", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.ReaperRef = "shared-reaper"
+			cc.Spec.HostPort = v1alpha1.HostPort{Enabled: true}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("[reaperRef can't be used with hostPort]"))
+		})
+	})
 	Context(".spec.maintenance[].dc", func() {
 		It("should be required if maintenance request is specified", func() {
 			cc := validCluster.DeepCopy()
@@ -604,4 +644,33 @@ var _ = Describe("cassandracluster validation", func() {
 			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("replication factor (4) is greater than number of replicas (3) for dc dc1"))
 		})
 	})
+	Context("with invalid restoreFrom storage location", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.RestoreFrom = &v1alpha1.RestoreFrom{
+				StorageLocation: "ftp://bucket",
+				SnapshotTag:     "snapshot",
+				SecretName:      "storage-credentials",
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("restoreFrom.storageLocation is invalid: protocol ftp is not supported. Should be one of the following: [s3 minio oracle ceph gcp azure]"))
+		})
+	})
+	Context("with invalid commitlog archiving storage location", func() {
+		It("should fail the validation", func() {
+			cc := validCluster.DeepCopy()
+			cc.Spec.Cassandra = &v1alpha1.Cassandra{
+				CommitLogArchiving: &v1alpha1.CommitLogArchiving{
+					StorageLocation: "ftp://bucket",
+					SecretName:      "storage-credentials",
+				},
+			}
+			markMocksAsReady(cc)
+			err := k8sClient.Create(ctx, cc)
+			Expect(err).To(BeAssignableToTypeOf(&errors.StatusError{}))
+			Expect(err.(*errors.StatusError).ErrStatus.Reason).To(BeEquivalentTo("cassandra.commitLogArchiving.storageLocation is invalid: protocol ftp is not supported. Should be one of the following: [s3 minio oracle ceph gcp azure]"))
+		})
+	})
 })